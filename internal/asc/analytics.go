@@ -77,6 +77,7 @@ type SalesReportParams struct {
 type ReportDownload struct {
 	Body          io.ReadCloser
 	ContentLength int64
+	ContentType   string
 }
 
 // AnalyticsReportRequestAttributes describes analytics report request data.
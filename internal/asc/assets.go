@@ -1,5 +1,10 @@
 package asc
 
+import (
+	"strconv"
+	"strings"
+)
+
 // AppScreenshotSetAttributes describes a screenshot set resource.
 type AppScreenshotSetAttributes struct {
 	ScreenshotDisplayType string `json:"screenshotDisplayType"`
@@ -24,6 +29,20 @@ type ImageAsset struct {
 	Height      int    `json:"height"`
 }
 
+// OriginalURL expands the image template URL at the asset's original resolution.
+// The format is the image file extension (for example "png" or "jpg").
+func (a ImageAsset) OriginalURL(format string) string {
+	if strings.TrimSpace(format) == "" {
+		format = "png"
+	}
+	replacer := strings.NewReplacer(
+		"{w}", strconv.Itoa(a.Width),
+		"{h}", strconv.Itoa(a.Height),
+		"{f}", format,
+	)
+	return replacer.Replace(a.TemplateURL)
+}
+
 // AssetDeliveryState describes the delivery state of an asset.
 type AssetDeliveryState struct {
 	State  string        `json:"state"`
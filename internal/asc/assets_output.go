@@ -1,6 +1,9 @@
 package asc
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // AppScreenshotSetWithScreenshots groups a set with its screenshots.
 type AppScreenshotSetWithScreenshots struct {
@@ -32,6 +35,7 @@ type AssetUploadResultItem struct {
 	FilePath string `json:"filePath"`
 	AssetID  string `json:"assetId"`
 	State    string `json:"state,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
 }
 
 // AppScreenshotUploadResult represents screenshot upload output.
@@ -56,6 +60,80 @@ type AssetDeleteResult struct {
 	Deleted bool   `json:"deleted"`
 }
 
+// AssetValidationFile represents local validation output for a single asset file.
+type AssetValidationFile struct {
+	FileName        string   `json:"fileName"`
+	FilePath        string   `json:"filePath"`
+	Format          string   `json:"format,omitempty"`
+	Width           int      `json:"width,omitempty"`
+	Height          int      `json:"height,omitempty"`
+	DurationSeconds float64  `json:"durationSeconds,omitempty"`
+	FrameRate       float64  `json:"frameRate,omitempty"`
+	Valid           bool     `json:"valid"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+// AssetValidationResult represents local screenshot or preview validation output.
+type AssetValidationResult struct {
+	Path        string                `json:"path"`
+	AssetType   string                `json:"assetType"`
+	DisplayType string                `json:"displayType"`
+	Files       []AssetValidationFile `json:"files"`
+	ErrorCount  int                   `json:"errorCount"`
+	Valid       bool                  `json:"valid"`
+}
+
+// AssetStatusItem describes the delivery state of a single screenshot or preview.
+type AssetStatusItem struct {
+	Locale                string   `json:"locale"`
+	VersionLocalizationID string   `json:"versionLocalizationId"`
+	AssetType             string   `json:"assetType"`
+	DisplayType           string   `json:"displayType"`
+	SetID                 string   `json:"setId"`
+	AssetID               string   `json:"assetId"`
+	FileName              string   `json:"fileName"`
+	State                 string   `json:"state"`
+	Errors                []string `json:"errors,omitempty"`
+}
+
+// AssetStatusResult represents asset delivery status output for a version.
+type AssetStatusResult struct {
+	VersionID string            `json:"versionId"`
+	Total     int               `json:"total"`
+	Complete  int               `json:"complete"`
+	Failed    int               `json:"failed"`
+	Pending   int               `json:"pending"`
+	Assets    []AssetStatusItem `json:"assets"`
+}
+
+// AssetDownloadFile represents a single downloaded screenshot or preview.
+type AssetDownloadFile struct {
+	Locale      string `json:"locale"`
+	AssetType   string `json:"assetType"`
+	DisplayType string `json:"displayType"`
+	AssetID     string `json:"assetId"`
+	Path        string `json:"path"`
+	Bytes       int64  `json:"bytes"`
+}
+
+// AssetDownloadSkipped represents an asset that could not be downloaded.
+type AssetDownloadSkipped struct {
+	Locale  string `json:"locale"`
+	AssetID string `json:"assetId"`
+	Reason  string `json:"reason"`
+}
+
+// AssetDownloadResult represents screenshot and preview download output.
+type AssetDownloadResult struct {
+	VersionID    string                 `json:"versionId"`
+	OutputDir    string                 `json:"outputDir"`
+	ManifestPath string                 `json:"manifestPath"`
+	Screenshots  int                    `json:"screenshots"`
+	Previews     int                    `json:"previews"`
+	Files        []AssetDownloadFile    `json:"files"`
+	Skipped      []AssetDownloadSkipped `json:"skipped,omitempty"`
+}
+
 func appScreenshotSetsRows(resp *AppScreenshotSetsResponse) ([]string, [][]string) {
 	headers := []string{"ID", "Display Type"}
 	rows := make([][]string, 0, len(resp.Data))
@@ -190,3 +268,103 @@ func assetDeleteResultRows(result *AssetDeleteResult) ([]string, [][]string) {
 	rows := [][]string{{result.ID, fmt.Sprintf("%t", result.Deleted)}}
 	return headers, rows
 }
+
+func assetValidationResultMainRows(result *AssetValidationResult) ([]string, [][]string) {
+	headers := []string{"Path", "Asset Type", "Display Type", "Files", "Errors", "Valid"}
+	rows := [][]string{{
+		result.Path,
+		result.AssetType,
+		result.DisplayType,
+		fmt.Sprintf("%d", len(result.Files)),
+		fmt.Sprintf("%d", result.ErrorCount),
+		fmt.Sprintf("%t", result.Valid),
+	}}
+	return headers, rows
+}
+
+func assetValidationFileRows(files []AssetValidationFile) ([]string, [][]string) {
+	headers := []string{"File Name", "Format", "Size", "Duration", "Frame Rate", "Valid", "Errors"}
+	rows := make([][]string, 0, len(files))
+	for _, item := range files {
+		size := ""
+		if item.Width > 0 || item.Height > 0 {
+			size = fmt.Sprintf("%dx%d", item.Width, item.Height)
+		}
+		duration := ""
+		if item.DurationSeconds > 0 {
+			duration = fmt.Sprintf("%.2fs", item.DurationSeconds)
+		}
+		frameRate := ""
+		if item.FrameRate > 0 {
+			frameRate = fmt.Sprintf("%.2f", item.FrameRate)
+		}
+		rows = append(rows, []string{
+			item.FileName,
+			item.Format,
+			size,
+			duration,
+			frameRate,
+			fmt.Sprintf("%t", item.Valid),
+			strings.Join(item.Errors, "; "),
+		})
+	}
+	return headers, rows
+}
+
+func assetStatusResultMainRows(result *AssetStatusResult) ([]string, [][]string) {
+	headers := []string{"Version ID", "Total", "Complete", "Failed", "Pending"}
+	rows := [][]string{{
+		result.VersionID,
+		fmt.Sprintf("%d", result.Total),
+		fmt.Sprintf("%d", result.Complete),
+		fmt.Sprintf("%d", result.Failed),
+		fmt.Sprintf("%d", result.Pending),
+	}}
+	return headers, rows
+}
+
+func assetStatusItemRows(items []AssetStatusItem) ([]string, [][]string) {
+	headers := []string{"Locale", "Type", "Display Type", "Asset ID", "File Name", "State", "Errors"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.Locale,
+			item.AssetType,
+			item.DisplayType,
+			item.AssetID,
+			item.FileName,
+			item.State,
+			strings.Join(item.Errors, "; "),
+		})
+	}
+	return headers, rows
+}
+
+func assetDownloadResultMainRows(result *AssetDownloadResult) ([]string, [][]string) {
+	headers := []string{"Version ID", "Output Dir", "Manifest", "Screenshots", "Previews", "Skipped"}
+	rows := [][]string{{
+		result.VersionID,
+		result.OutputDir,
+		result.ManifestPath,
+		fmt.Sprintf("%d", result.Screenshots),
+		fmt.Sprintf("%d", result.Previews),
+		fmt.Sprintf("%d", len(result.Skipped)),
+	}}
+	return headers, rows
+}
+
+func assetDownloadFileRows(files []AssetDownloadFile) ([]string, [][]string) {
+	headers := []string{"Locale", "Type", "Display Type", "Asset ID", "Path", "Bytes"}
+	rows := make([][]string, 0, len(files))
+	for _, item := range files {
+		rows = append(rows, []string{
+			item.Locale,
+			item.AssetType,
+			item.DisplayType,
+			item.AssetID,
+			item.Path,
+			fmt.Sprintf("%d", item.Bytes),
+		})
+	}
+	return headers, rows
+}
@@ -203,31 +203,20 @@ type AppInfoUpdateCategoriesRequest struct {
 
 // UpdateAppInfoCategories updates the categories for an app info resource.
 func (c *Client) UpdateAppInfoCategories(ctx context.Context, appInfoID string, primaryCategoryID, secondaryCategoryID string) (*AppInfoResponse, error) {
-	relationships := &AppInfoUpdateCategoriesRelationships{}
-
-	if primaryCategoryID != "" {
-		relationships.PrimaryCategory = &Relationship{
-			Data: ResourceData{
-				Type: ResourceTypeAppCategories,
-				ID:   primaryCategoryID,
-			},
-		}
-	}
-
-	if secondaryCategoryID != "" {
-		relationships.SecondaryCategory = &Relationship{
-			Data: ResourceData{
-				Type: ResourceTypeAppCategories,
-				ID:   secondaryCategoryID,
-			},
-		}
-	}
+	return c.UpdateAppInfoCategoryRelationships(ctx, appInfoID, AppInfoUpdateCategoriesRelationships{
+		PrimaryCategory:   AppCategoryRelationship(primaryCategoryID),
+		SecondaryCategory: AppCategoryRelationship(secondaryCategoryID),
+	})
+}
 
+// UpdateAppInfoCategoryRelationships updates categories and subcategories for an app info resource.
+// Nil relationships are left unchanged.
+func (c *Client) UpdateAppInfoCategoryRelationships(ctx context.Context, appInfoID string, relationships AppInfoUpdateCategoriesRelationships) (*AppInfoResponse, error) {
 	request := AppInfoUpdateCategoriesRequest{
 		Data: AppInfoUpdateCategoriesData{
 			Type:          ResourceTypeAppInfos,
 			ID:            appInfoID,
-			Relationships: relationships,
+			Relationships: &relationships,
 		},
 	}
 
@@ -248,3 +237,16 @@ func (c *Client) UpdateAppInfoCategories(ctx context.Context, appInfoID string,
 
 	return &response, nil
 }
+
+// AppCategoryRelationship returns a category relationship, or nil for an empty ID.
+func AppCategoryRelationship(categoryID string) *Relationship {
+	if categoryID == "" {
+		return nil
+	}
+	return &Relationship{
+		Data: ResourceData{
+			Type: ResourceTypeAppCategories,
+			ID:   categoryID,
+		},
+	}
+}
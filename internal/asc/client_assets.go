@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // AppScreenshotSetRelationships describes relationships for screenshot sets.
@@ -474,3 +476,38 @@ func (c *Client) DeleteAppPreview(ctx context.Context, previewID string) error {
 	_, err := c.do(ctx, "DELETE", path, nil)
 	return err
 }
+
+// DownloadAppMediaAsset downloads a screenshot image or preview video from its media URL.
+func (c *Client) DownloadAppMediaAsset(ctx context.Context, downloadURL string) (*ReportDownload, error) {
+	if err := validateAppMediaDownloadURL(downloadURL); err != nil {
+		return nil, fmt.Errorf("media asset download: %w", err)
+	}
+
+	resp, err := c.doStreamNoAuth(ctx, "GET", downloadURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportDownload{Body: resp.Body, ContentLength: resp.ContentLength}, nil
+}
+
+func validateAppMediaDownloadURL(downloadURL string) error {
+	if strings.TrimSpace(downloadURL) == "" {
+		return fmt.Errorf("empty download URL")
+	}
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil {
+		return fmt.Errorf("invalid download URL: %w", err)
+	}
+	if parsedURL.Scheme != "https" {
+		return fmt.Errorf("rejected download URL with insecure scheme %q (expected https)", parsedURL.Scheme)
+	}
+	host := strings.ToLower(parsedURL.Hostname())
+	if host == "" {
+		return fmt.Errorf("rejected media download URL with empty host")
+	}
+	if !isAllowedAnalyticsHost(host) {
+		return fmt.Errorf("rejected media download URL from untrusted host %q", parsedURL.Host)
+	}
+	return nil
+}
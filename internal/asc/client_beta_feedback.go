@@ -114,3 +114,18 @@ func (c *Client) DeleteBetaFeedbackScreenshotSubmission(ctx context.Context, sub
 	_, err := c.do(ctx, "DELETE", path, nil)
 	return err
 }
+
+// DownloadBetaFeedbackScreenshot downloads a signed beta feedback screenshot URL.
+func (c *Client) DownloadBetaFeedbackScreenshot(ctx context.Context, screenshotURL string) (*ReportDownload, error) {
+	screenshotURL = strings.TrimSpace(screenshotURL)
+	if err := validateAppMediaDownloadURL(screenshotURL); err != nil {
+		return nil, fmt.Errorf("beta feedback screenshot: %w", err)
+	}
+
+	resp, err := c.doStreamNoAuth(ctx, "GET", screenshotURL, "image/*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportDownload{Body: resp.Body, ContentLength: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}, nil
+}
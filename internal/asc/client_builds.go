@@ -197,7 +197,7 @@ func (c *Client) GetBuilds(ctx context.Context, appID string, opts ...BuildsOpti
 		values := url.Values{}
 		// Use /v1/builds endpoint when sorting, limiting, or filtering by preReleaseVersion,
 		// since /v1/apps/{id}/builds doesn't support these
-		if query.sort != "" || query.limit > 0 || query.preReleaseVersionID != "" {
+		if query.sort != "" || query.limit > 0 || query.preReleaseVersionID != "" || len(query.include) > 0 {
 			path = "/v1/builds"
 			values.Set("filter[app]", appID)
 			if query.sort != "" {
@@ -209,6 +209,10 @@ func (c *Client) GetBuilds(ctx context.Context, appID string, opts ...BuildsOpti
 			if query.preReleaseVersionID != "" {
 				values.Set("filter[preReleaseVersion]", query.preReleaseVersionID)
 			}
+			addCSV(values, "include", query.include)
+			if query.betaGroupsLimit > 0 {
+				values.Set("limit[betaGroups]", strconv.Itoa(query.betaGroupsLimit))
+			}
 		}
 		if queryString := values.Encode(); queryString != "" {
 			path += "?" + queryString
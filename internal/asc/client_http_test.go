@@ -8848,3 +8848,52 @@ func TestDeletePromotedPurchase_SendsRequest(t *testing.T) {
 		t.Fatalf("DeletePromotedPurchase() error: %v", err)
 	}
 }
+
+func TestDownloadAppMediaAsset_NoAuthHeader(t *testing.T) {
+	downloadURL := "https://is1-ssl.mzstatic.com/image/thumb/Purple/shot.png/1242x2688bb.png"
+	response := rawResponse(http.StatusOK, "png-data")
+	client := newTestClient(t, func(req *http.Request) {
+		if req.URL.String() != downloadURL {
+			t.Fatalf("expected URL %q, got %q", downloadURL, req.URL.String())
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Fatalf("expected no Authorization header")
+		}
+	}, response)
+
+	download, err := client.DownloadAppMediaAsset(context.Background(), downloadURL)
+	if err != nil {
+		t.Fatalf("DownloadAppMediaAsset() error: %v", err)
+	}
+	_ = download.Body.Close()
+}
+
+func TestDownloadAppMediaAsset_UntrustedHost(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	if _, err := client.DownloadAppMediaAsset(context.Background(), "https://images.example.com/shot.png"); err == nil {
+		t.Fatal("expected error for untrusted host")
+	}
+	if _, err := client.DownloadAppMediaAsset(context.Background(), "http://is1-ssl.mzstatic.com/shot.png"); err == nil {
+		t.Fatal("expected error for insecure scheme")
+	}
+}
+
+func TestImageAssetOriginalURL(t *testing.T) {
+	asset := ImageAsset{TemplateURL: "https://is1-ssl.mzstatic.com/image/thumb/a/{w}x{h}bb.{f}", Width: 1242, Height: 2688}
+	if got := asset.OriginalURL("jpg"); got != "https://is1-ssl.mzstatic.com/image/thumb/a/1242x2688bb.jpg" {
+		t.Fatalf("unexpected URL %q", got)
+	}
+	if got := asset.OriginalURL(""); got != "https://is1-ssl.mzstatic.com/image/thumb/a/1242x2688bb.png" {
+		t.Fatalf("expected png default, got %q", got)
+	}
+}
+
+func TestDownloadBetaFeedbackScreenshot_UntrustedHost(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	if _, err := client.DownloadBetaFeedbackScreenshot(context.Background(), "https://images.example.com/shot.png"); err == nil {
+		t.Fatal("expected error for untrusted host")
+	}
+	if _, err := client.DownloadBetaFeedbackScreenshot(context.Background(), "http://is1-ssl.mzstatic.com/shot.png"); err == nil {
+		t.Fatal("expected error for insecure scheme")
+	}
+}
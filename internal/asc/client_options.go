@@ -275,6 +275,9 @@ type LinkagesOption func(*linkagesQuery)
 // PricePointsOption is a functional option for GetAppPricePoints.
 type PricePointsOption func(*pricePointsQuery)
 
+// AppPricesOption is a functional option for app price schedule price lists.
+type AppPricesOption func(*appPricesQuery)
+
 // AccessibilityDeclarationsOption is a functional option for accessibility declarations.
 type AccessibilityDeclarationsOption func(*accessibilityDeclarationsQuery)
 
@@ -400,6 +403,13 @@ func WithFeedbackIncludeScreenshots() FeedbackOption {
 	}
 }
 
+// WithFeedbackInclude includes related resources (e.g. build, tester) in feedback responses.
+func WithFeedbackInclude(include []string) FeedbackOption {
+	return func(q *feedbackQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
 // WithCrashDeviceModels filters crashes by device model(s).
 func WithCrashDeviceModels(models []string) CrashOption {
 	return func(q *crashQuery) {
@@ -1010,6 +1020,13 @@ func WithCrashSort(sort string) CrashOption {
 	}
 }
 
+// WithCrashInclude includes related resources (e.g. build, tester) in crash responses.
+func WithCrashInclude(include []string) CrashOption {
+	return func(q *crashQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
 // WithRating filters reviews by star rating (1-5).
 func WithRating(rating int) ReviewOption {
 	return func(r *reviewQuery) {
@@ -1461,6 +1478,22 @@ func WithBuildsPreReleaseVersion(preReleaseVersionID string) BuildsOption {
 	}
 }
 
+// WithBuildsInclude includes related resources (e.g., preReleaseVersion, appStoreVersion, betaGroups).
+func WithBuildsInclude(include []string) BuildsOption {
+	return func(q *buildsQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
+// WithBuildsBetaGroupsLimit sets the max number of included beta groups per build.
+func WithBuildsBetaGroupsLimit(limit int) BuildsOption {
+	return func(q *buildsQuery) {
+		if limit > 0 {
+			q.betaGroupsLimit = limit
+		}
+	}
+}
+
 // WithBuildBundlesLimit sets the max number of included build bundles to return.
 func WithBuildBundlesLimit(limit int) BuildBundlesOption {
 	return func(q *buildBundlesQuery) {
@@ -2368,6 +2401,13 @@ func WithCertificatesFilterType(certType string) CertificatesOption {
 	}
 }
 
+// WithCertificatesFilterSerialNumbers filters certificates by serial number(s).
+func WithCertificatesFilterSerialNumbers(serialNumbers []string) CertificatesOption {
+	return func(q *certificatesQuery) {
+		q.serialNumbers = normalizeUpperList(serialNumbers)
+	}
+}
+
 // WithProfilesLimit sets the max number of profiles to return.
 func WithProfilesLimit(limit int) ProfilesOption {
 	return func(q *profilesQuery) {
@@ -3148,6 +3188,31 @@ func WithPricePointsTerritory(territory string) PricePointsOption {
 	}
 }
 
+// WithAppPricesLimit sets the max number of app prices to return.
+func WithAppPricesLimit(limit int) AppPricesOption {
+	return func(q *appPricesQuery) {
+		if limit > 0 {
+			q.limit = limit
+		}
+	}
+}
+
+// WithAppPricesNextURL uses a next page URL directly.
+func WithAppPricesNextURL(next string) AppPricesOption {
+	return func(q *appPricesQuery) {
+		if strings.TrimSpace(next) != "" {
+			q.nextURL = strings.TrimSpace(next)
+		}
+	}
+}
+
+// WithAppPricesInclude sets include for app price responses.
+func WithAppPricesInclude(include []string) AppPricesOption {
+	return func(q *appPricesQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
 // WithAppCustomProductPagesLimit sets the max number of custom product pages to return.
 func WithAppCustomProductPagesLimit(limit int) AppCustomProductPagesOption {
 	return func(q *appCustomProductPagesQuery) {
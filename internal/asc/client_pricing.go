@@ -252,9 +252,22 @@ func (c *Client) GetAppPriceScheduleBaseTerritory(ctx context.Context, scheduleI
 }
 
 // GetAppPriceScheduleManualPrices retrieves manual prices for a schedule.
-func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string) (*AppPricesResponse, error) {
+func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string, opts ...AppPricesOption) (*AppPricesResponse, error) {
+	query := &appPricesQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
 	scheduleID = strings.TrimSpace(scheduleID)
 	path := fmt.Sprintf("/v1/appPriceSchedules/%s/manualPrices", scheduleID)
+	if query.nextURL != "" {
+		if err := validateNextURL(query.nextURL); err != nil {
+			return nil, fmt.Errorf("manualPrices: %w", err)
+		}
+		path = query.nextURL
+	} else if queryString := buildAppPricesQuery(query); queryString != "" {
+		path += "?" + queryString
+	}
 
 	data, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
@@ -23,6 +23,7 @@ type feedbackQuery struct {
 	testerIDs                 []string
 	sort                      string
 	includeScreenshots        bool
+	include                   []string
 }
 
 type crashQuery struct {
@@ -35,6 +36,7 @@ type crashQuery struct {
 	buildPreReleaseVersionIDs []string
 	testerIDs                 []string
 	sort                      string
+	include                   []string
 }
 
 type reviewQuery struct {
@@ -111,6 +113,8 @@ type buildsQuery struct {
 	listQuery
 	sort                string
 	preReleaseVersionID string
+	include             []string
+	betaGroupsLimit     int
 }
 
 type buildUploadsQuery struct {
@@ -431,6 +435,7 @@ type passTypeIDCertificatesQuery struct {
 type certificatesQuery struct {
 	listQuery
 	certificateTypes []string
+	serialNumbers    []string
 	include          []string
 }
 
@@ -544,6 +549,11 @@ type pricePointsQuery struct {
 	territory string
 }
 
+type appPricesQuery struct {
+	listQuery
+	include []string
+}
+
 type accessibilityDeclarationsQuery struct {
 	listQuery
 	deviceFamilies []string
@@ -743,6 +753,7 @@ func buildFeedbackQuery(query *feedbackQuery) string {
 	addCSV(values, "filter[build]", query.buildIDs)
 	addCSV(values, "filter[build.preReleaseVersion]", query.buildPreReleaseVersionIDs)
 	addCSV(values, "filter[tester]", query.testerIDs)
+	addCSV(values, "include", query.include)
 	if query.sort != "" {
 		values.Set("sort", query.sort)
 	}
@@ -759,6 +770,7 @@ func buildCrashQuery(query *crashQuery) string {
 	addCSV(values, "filter[build]", query.buildIDs)
 	addCSV(values, "filter[build.preReleaseVersion]", query.buildPreReleaseVersionIDs)
 	addCSV(values, "filter[tester]", query.testerIDs)
+	addCSV(values, "include", query.include)
 	if query.sort != "" {
 		values.Set("sort", query.sort)
 	}
@@ -879,6 +891,7 @@ func buildBundleIDCapabilitiesQuery(_ *bundleIDCapabilitiesQuery) string {
 func buildCertificatesQuery(query *certificatesQuery) string {
 	values := url.Values{}
 	addCSV(values, "filter[certificateType]", query.certificateTypes)
+	addCSV(values, "filter[serialNumber]", query.serialNumbers)
 	addCSV(values, "include", query.include)
 	addLimit(values, query.limit)
 	return values.Encode()
@@ -1530,3 +1543,10 @@ func buildPricePointsQuery(query *pricePointsQuery) string {
 	addLimit(values, query.limit)
 	return values.Encode()
 }
+
+func buildAppPricesQuery(query *appPricesQuery) string {
+	values := url.Values{}
+	addCSV(values, "include", query.include)
+	addLimit(values, query.limit)
+	return values.Encode()
+}
@@ -26,6 +26,7 @@ const (
 	DeviceClassIPod       DeviceClass = "IPOD"
 	DeviceClassAppleTV    DeviceClass = "APPLE_TV"
 	DeviceClassMac        DeviceClass = "MAC"
+	DeviceClassVisionPro  DeviceClass = "APPLE_VISION_PRO"
 )
 
 // DeviceAttributes describes an App Store Connect device.
@@ -0,0 +1,126 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BetaTesterEngagement is one ranked row of a beta tester engagement report.
+type BetaTesterEngagement struct {
+	Rank       int      `json:"rank"`
+	ID         string   `json:"id"`
+	Email      string   `json:"email,omitempty"`
+	FirstName  string   `json:"firstName,omitempty"`
+	LastName   string   `json:"lastName,omitempty"`
+	State      string   `json:"state,omitempty"`
+	InviteType string   `json:"inviteType,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Sessions   int      `json:"sessions"`
+	Crashes    int      `json:"crashes"`
+	Feedback   int      `json:"feedback"`
+}
+
+// BetaTesterEngagementResult represents CLI output for beta tester engagement.
+type BetaTesterEngagementResult struct {
+	AppID    string                 `json:"appId"`
+	Period   string                 `json:"period"`
+	Groups   []string               `json:"groups,omitempty"`
+	Total    int                    `json:"total"`
+	Active   int                    `json:"active"`
+	Inactive int                    `json:"inactive"`
+	Testers  []BetaTesterEngagement `json:"testers"`
+}
+
+// BetaTesterPruneEntry is one tester selected for removal from a beta group.
+type BetaTesterPruneEntry struct {
+	TesterID  string `json:"testerId"`
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	State     string `json:"state,omitempty"`
+	GroupID   string `json:"groupId"`
+	Group     string `json:"group"`
+	Sessions  int    `json:"sessions"`
+	Removed   bool   `json:"removed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BetaTesterPruneResult represents CLI output for beta tester pruning.
+type BetaTesterPruneResult struct {
+	AppID        string                 `json:"appId"`
+	Period       string                 `json:"period"`
+	Groups       []string               `json:"groups"`
+	DryRun       bool                   `json:"dryRun"`
+	Evaluated    int                    `json:"evaluated"`
+	Selected     int                    `json:"selected"`
+	RemovedCount int                    `json:"removedCount"`
+	FailedCount  int                    `json:"failedCount"`
+	CSVPath      string                 `json:"csvPath,omitempty"`
+	Testers      []BetaTesterPruneEntry `json:"testers"`
+}
+
+func betaTesterEngagementResultMainRows(result *BetaTesterEngagementResult) ([]string, [][]string) {
+	headers := []string{"App ID", "Period", "Groups", "Total", "Active", "Inactive"}
+	rows := [][]string{{
+		result.AppID,
+		result.Period,
+		strings.Join(result.Groups, ", "),
+		fmt.Sprintf("%d", result.Total),
+		fmt.Sprintf("%d", result.Active),
+		fmt.Sprintf("%d", result.Inactive),
+	}}
+	return headers, rows
+}
+
+func betaTesterEngagementRows(testers []BetaTesterEngagement) ([]string, [][]string) {
+	headers := []string{"Rank", "ID", "Email", "Name", "State", "Groups", "Sessions", "Crashes", "Feedback"}
+	rows := make([][]string, 0, len(testers))
+	for _, item := range testers {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", item.Rank),
+			item.ID,
+			item.Email,
+			compactWhitespace(formatBetaTesterName(BetaTesterAttributes{FirstName: item.FirstName, LastName: item.LastName})),
+			item.State,
+			compactWhitespace(strings.Join(item.Groups, ", ")),
+			fmt.Sprintf("%d", item.Sessions),
+			fmt.Sprintf("%d", item.Crashes),
+			fmt.Sprintf("%d", item.Feedback),
+		})
+	}
+	return headers, rows
+}
+
+func betaTesterPruneResultMainRows(result *BetaTesterPruneResult) ([]string, [][]string) {
+	headers := []string{"App ID", "Period", "Groups", "Dry Run", "Evaluated", "Selected", "Removed", "Failed", "CSV"}
+	rows := [][]string{{
+		result.AppID,
+		result.Period,
+		strings.Join(result.Groups, ", "),
+		fmt.Sprintf("%t", result.DryRun),
+		fmt.Sprintf("%d", result.Evaluated),
+		fmt.Sprintf("%d", result.Selected),
+		fmt.Sprintf("%d", result.RemovedCount),
+		fmt.Sprintf("%d", result.FailedCount),
+		result.CSVPath,
+	}}
+	return headers, rows
+}
+
+func betaTesterPruneEntryRows(entries []BetaTesterPruneEntry) ([]string, [][]string) {
+	headers := []string{"Tester ID", "Email", "Name", "State", "Group", "Sessions", "Removed", "Error"}
+	rows := make([][]string, 0, len(entries))
+	for _, item := range entries {
+		rows = append(rows, []string{
+			item.TesterID,
+			item.Email,
+			compactWhitespace(formatBetaTesterName(BetaTesterAttributes{FirstName: item.FirstName, LastName: item.LastName})),
+			item.State,
+			compactWhitespace(item.Group),
+			fmt.Sprintf("%d", item.Sessions),
+			fmt.Sprintf("%t", item.Removed),
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
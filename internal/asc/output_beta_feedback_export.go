@@ -0,0 +1,96 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BetaFeedbackExportItem is one exported feedback or crash submission.
+type BetaFeedbackExportItem struct {
+	ID          string   `json:"id"`
+	Kind        string   `json:"kind"`
+	CreatedDate string   `json:"createdDate"`
+	Email       string   `json:"email,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	DeviceModel string   `json:"deviceModel,omitempty"`
+	OSVersion   string   `json:"osVersion,omitempty"`
+	AppPlatform string   `json:"appPlatform,omitempty"`
+	Locale      string   `json:"locale,omitempty"`
+	BuildID     string   `json:"buildId,omitempty"`
+	BuildNumber string   `json:"buildNumber,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Files       []string `json:"files,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// BetaFeedbackExportBuild summarizes exported submissions for one build.
+type BetaFeedbackExportBuild struct {
+	BuildID     string `json:"buildId,omitempty"`
+	Version     string `json:"version,omitempty"`
+	BuildNumber string `json:"buildNumber,omitempty"`
+	Count       int    `json:"count"`
+}
+
+// BetaFeedbackExportResult represents CLI output for feedback and crash exports.
+type BetaFeedbackExportResult struct {
+	AppID      string                    `json:"appId"`
+	Kind       string                    `json:"kind"`
+	Since      string                    `json:"since"`
+	Dir        string                    `json:"dir"`
+	Count      int                       `json:"count"`
+	Downloaded int                       `json:"downloaded"`
+	Skipped    int                       `json:"skipped"`
+	Failed     int                       `json:"failed"`
+	Digest     string                    `json:"digest,omitempty"`
+	Issues     string                    `json:"issues,omitempty"`
+	Manifest   string                    `json:"manifest,omitempty"`
+	Builds     []BetaFeedbackExportBuild `json:"builds"`
+	Items      []BetaFeedbackExportItem  `json:"items"`
+}
+
+func betaFeedbackExportResultMainRows(result *BetaFeedbackExportResult) ([]string, [][]string) {
+	headers := []string{"App ID", "Kind", "Since", "Dir", "Count", "Downloaded", "Skipped", "Failed", "Digest", "Issues"}
+	rows := [][]string{{
+		result.AppID,
+		result.Kind,
+		result.Since,
+		result.Dir,
+		fmt.Sprintf("%d", result.Count),
+		fmt.Sprintf("%d", result.Downloaded),
+		fmt.Sprintf("%d", result.Skipped),
+		fmt.Sprintf("%d", result.Failed),
+		result.Digest,
+		result.Issues,
+	}}
+	return headers, rows
+}
+
+func betaFeedbackExportBuildRows(builds []BetaFeedbackExportBuild) ([]string, [][]string) {
+	headers := []string{"Version", "Build", "Build ID", "Count"}
+	rows := make([][]string, 0, len(builds))
+	for _, item := range builds {
+		rows = append(rows, []string{item.Version, item.BuildNumber, item.BuildID, fmt.Sprintf("%d", item.Count)})
+	}
+	return headers, rows
+}
+
+func betaFeedbackExportItemRows(items []BetaFeedbackExportItem) ([]string, [][]string) {
+	headers := []string{"ID", "Created", "Build", "Email", "Device", "Files", "Comment"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		build := strings.TrimSpace(item.Version + " (" + item.BuildNumber + ")")
+		if item.BuildNumber == "" {
+			build = item.Version
+		}
+		rows = append(rows, []string{
+			item.ID,
+			item.CreatedDate,
+			build,
+			item.Email,
+			item.DeviceModel,
+			fmt.Sprintf("%d", len(item.Files)),
+			compactWhitespace(item.Comment),
+		})
+	}
+	return headers, rows
+}
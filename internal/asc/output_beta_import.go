@@ -0,0 +1,86 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BetaTesterImportRow is the outcome for one row of a tester import file.
+type BetaTesterImportRow struct {
+	Row       int    `json:"row"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email"`
+	TesterID  string `json:"testerId,omitempty"`
+	Status    string `json:"status"`
+	Invited   bool   `json:"invited,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BetaTesterImportResult represents CLI output for beta tester imports.
+type BetaTesterImportResult struct {
+	AppID       string                `json:"appId"`
+	File        string                `json:"file"`
+	Groups      []string              `json:"groups"`
+	DryRun      bool                  `json:"dryRun"`
+	Total       int                   `json:"total"`
+	Created     int                   `json:"created"`
+	Added       int                   `json:"added"`
+	Existing    int                   `json:"existing"`
+	Duplicates  int                   `json:"duplicates"`
+	Invalid     int                   `json:"invalid"`
+	Failed      int                   `json:"failed"`
+	Invited     int                   `json:"invited"`
+	ResultsFile string                `json:"resultsFile,omitempty"`
+	Rows        []BetaTesterImportRow `json:"rows"`
+}
+
+// BetaTesterExportResult represents CLI output for beta tester exports.
+type BetaTesterExportResult struct {
+	AppID   string `json:"appId"`
+	Group   string `json:"group,omitempty"`
+	File    string `json:"file"`
+	Testers int    `json:"testers"`
+}
+
+func betaTesterImportResultMainRows(result *BetaTesterImportResult) ([]string, [][]string) {
+	headers := []string{"App ID", "File", "Groups", "Dry Run", "Total", "Created", "Added", "Existing", "Duplicates", "Invalid", "Failed", "Invited"}
+	rows := [][]string{{
+		result.AppID,
+		result.File,
+		strings.Join(result.Groups, ", "),
+		fmt.Sprintf("%t", result.DryRun),
+		fmt.Sprintf("%d", result.Total),
+		fmt.Sprintf("%d", result.Created),
+		fmt.Sprintf("%d", result.Added),
+		fmt.Sprintf("%d", result.Existing),
+		fmt.Sprintf("%d", result.Duplicates),
+		fmt.Sprintf("%d", result.Invalid),
+		fmt.Sprintf("%d", result.Failed),
+		fmt.Sprintf("%d", result.Invited),
+	}}
+	return headers, rows
+}
+
+func betaTesterImportRows(items []BetaTesterImportRow) ([]string, [][]string) {
+	headers := []string{"Row", "Email", "Name", "Tester ID", "Status", "Invited", "Error"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", item.Row),
+			item.Email,
+			compactWhitespace(formatBetaTesterName(BetaTesterAttributes{FirstName: item.FirstName, LastName: item.LastName})),
+			item.TesterID,
+			item.Status,
+			fmt.Sprintf("%t", item.Invited),
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
+
+func betaTesterExportResultRows(result *BetaTesterExportResult) ([]string, [][]string) {
+	headers := []string{"App ID", "Group", "File", "Testers"}
+	rows := [][]string{{result.AppID, result.Group, result.File, fmt.Sprintf("%d", result.Testers)}}
+	return headers, rows
+}
@@ -66,6 +66,56 @@ type BuildExpireAllResult struct {
 	Failures            []BuildExpireAllFailure `json:"failures,omitempty"`
 }
 
+// BuildRetentionDecision describes why a build would be expired or kept by a retention policy.
+type BuildRetentionDecision struct {
+	ID                string   `json:"id"`
+	BuildNumber       string   `json:"buildNumber"`
+	Version           string   `json:"version,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	UploadedDate      string   `json:"uploadedDate"`
+	AgeDays           int      `json:"ageDays"`
+	Groups            []string `json:"groups,omitempty"`
+	AppStoreVersionID string   `json:"appStoreVersionId,omitempty"`
+	Action            string   `json:"action"`
+	ExpireRule        string   `json:"expireRule"`
+	KeepRule          string   `json:"keepRule,omitempty"`
+	Reason            string   `json:"reason"`
+	Expired           *bool    `json:"expired,omitempty"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// BuildRetentionResult represents CLI output for applying a build retention policy.
+type BuildRetentionResult struct {
+	AppID          string                   `json:"appId"`
+	Policy         string                   `json:"policy"`
+	DryRun         bool                     `json:"dryRun"`
+	Evaluated      int                      `json:"evaluated"`
+	SkippedExpired int                      `json:"skippedExpired"`
+	SelectedCount  int                      `json:"selectedCount"`
+	ProtectedCount int                      `json:"protectedCount"`
+	ExpiredCount   int                      `json:"expiredCount"`
+	FailedCount    int                      `json:"failedCount"`
+	Decisions      []BuildRetentionDecision `json:"decisions"`
+}
+
+// BuildTestNotesLocaleResult represents generated notes written to one locale.
+type BuildTestNotesLocaleResult struct {
+	Locale         string `json:"locale"`
+	LocalizationID string `json:"localizationId,omitempty"`
+}
+
+// BuildTestNotesGenerateResult represents CLI output for notes generated from git history.
+type BuildTestNotesGenerateResult struct {
+	BuildID   string                       `json:"buildId,omitempty"`
+	Range     string                       `json:"range"`
+	Commits   int                          `json:"commits"`
+	Length    int                          `json:"length"`
+	Truncated bool                         `json:"truncated"`
+	DryRun    bool                         `json:"dryRun"`
+	Notes     string                       `json:"notes"`
+	Locales   []BuildTestNotesLocaleResult `json:"locales"`
+}
+
 // formatEncryptionStatus formats the UsesNonExemptEncryption field for display.
 // Returns "required" if true (needs encryption declaration), "exempt" if false,
 // or "n/a" if null (no information available).
@@ -245,3 +295,61 @@ func buildUploadDeleteResultRows(result *BuildUploadDeleteResult) ([]string, [][
 	rows := [][]string{{result.ID, fmt.Sprintf("%t", result.Deleted)}}
 	return headers, rows
 }
+
+func buildTestNotesGenerateResultRows(result *BuildTestNotesGenerateResult) ([]string, [][]string) {
+	headers := []string{"Locale", "Localization ID", "Range", "Commits", "Length", "Truncated", "Notes"}
+	rows := make([][]string, 0, len(result.Locales))
+	for _, item := range result.Locales {
+		rows = append(rows, []string{
+			item.Locale,
+			item.LocalizationID,
+			result.Range,
+			fmt.Sprintf("%d", result.Commits),
+			fmt.Sprintf("%d", result.Length),
+			fmt.Sprintf("%t", result.Truncated),
+			compactWhitespace(result.Notes),
+		})
+	}
+	return headers, rows
+}
+
+func buildRetentionResultMainRows(result *BuildRetentionResult) ([]string, [][]string) {
+	headers := []string{"App ID", "Policy", "Dry Run", "Evaluated", "Selected", "Protected", "Expired", "Failed"}
+	rows := [][]string{{
+		result.AppID,
+		result.Policy,
+		fmt.Sprintf("%t", result.DryRun),
+		fmt.Sprintf("%d", result.Evaluated),
+		fmt.Sprintf("%d", result.SelectedCount),
+		fmt.Sprintf("%d", result.ProtectedCount),
+		fmt.Sprintf("%d", result.ExpiredCount),
+		fmt.Sprintf("%d", result.FailedCount),
+	}}
+	return headers, rows
+}
+
+func buildRetentionDecisionRows(decisions []BuildRetentionDecision) ([]string, [][]string) {
+	headers := []string{"ID", "Version", "Build", "Uploaded", "Age Days", "Action", "Rule", "Reason"}
+	rows := make([][]string, 0, len(decisions))
+	for _, item := range decisions {
+		rule := item.ExpireRule
+		if item.KeepRule != "" {
+			rule = item.KeepRule
+		}
+		reason := item.Reason
+		if item.Error != "" {
+			reason = item.Error
+		}
+		rows = append(rows, []string{
+			item.ID,
+			item.Version,
+			item.BuildNumber,
+			item.UploadedDate,
+			fmt.Sprintf("%d", item.AgeDays),
+			item.Action,
+			rule,
+			compactWhitespace(reason),
+		})
+	}
+	return headers, rows
+}
@@ -0,0 +1,76 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BundleIDCapabilityChange is one capability difference between a
+// capabilities file or entitlements and a bundle ID.
+type BundleIDCapabilityChange struct {
+	CapabilityType string              `json:"capabilityType"`
+	Action         string              `json:"action"`
+	CapabilityID   string              `json:"capabilityId,omitempty"`
+	Sources        []string            `json:"sources,omitempty"`
+	Settings       []CapabilitySetting `json:"settings,omitempty"`
+	LiveSettings   []CapabilitySetting `json:"liveSettings,omitempty"`
+	Status         string              `json:"status,omitempty"`
+	Error          string              `json:"error,omitempty"`
+}
+
+// BundleIDCapabilityPlanResult represents CLI output for capabilities plan and apply.
+type BundleIDCapabilityPlanResult struct {
+	BundleID   string                     `json:"bundleId"`
+	Identifier string                     `json:"identifier,omitempty"`
+	Source     string                     `json:"source"`
+	Applied    bool                       `json:"applied"`
+	Prune      bool                       `json:"prune"`
+	Changes    []BundleIDCapabilityChange `json:"changes"`
+	Notes      []string                   `json:"notes,omitempty"`
+}
+
+func bundleIDCapabilityPlanMainRows(result *BundleIDCapabilityPlanResult) ([]string, [][]string) {
+	counts := map[string]int{}
+	for _, change := range result.Changes {
+		counts[change.Action]++
+	}
+	headers := []string{"Bundle ID", "Identifier", "Source", "Applied", "Add", "Update", "Remove", "Extra", "Unchanged"}
+	rows := [][]string{{
+		result.BundleID,
+		result.Identifier,
+		result.Source,
+		fmt.Sprintf("%t", result.Applied),
+		fmt.Sprintf("%d", counts["add"]),
+		fmt.Sprintf("%d", counts["update"]),
+		fmt.Sprintf("%d", counts["remove"]),
+		fmt.Sprintf("%d", counts["extra"]),
+		fmt.Sprintf("%d", counts["unchanged"]),
+	}}
+	return headers, rows
+}
+
+func bundleIDCapabilityChangeRows(changes []BundleIDCapabilityChange) ([]string, [][]string) {
+	headers := []string{"Capability", "Action", "Capability ID", "Sources", "Settings", "Status", "Error"}
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []string{
+			change.CapabilityType,
+			change.Action,
+			change.CapabilityID,
+			strings.Join(change.Sources, ", "),
+			formatCapabilitySettings(change.Settings),
+			change.Status,
+			compactWhitespace(change.Error),
+		})
+	}
+	return headers, rows
+}
+
+func bundleIDCapabilityPlanNoteRows(notes []string) ([]string, [][]string) {
+	headers := []string{"Note"}
+	rows := make([][]string, 0, len(notes))
+	for _, note := range notes {
+		rows = append(rows, []string{note})
+	}
+	return headers, rows
+}
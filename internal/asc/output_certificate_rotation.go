@@ -0,0 +1,86 @@
+package asc
+
+import "fmt"
+
+// CertificateRotationCertificate describes a certificate in a rotation.
+type CertificateRotationCertificate struct {
+	ID              string `json:"id"`
+	Name            string `json:"name,omitempty"`
+	CertificateType string `json:"certificateType"`
+	SerialNumber    string `json:"serialNumber"`
+	ExpirationDate  string `json:"expirationDate,omitempty"`
+	KeyFile         string `json:"keyFile,omitempty"`
+	CertificateFile string `json:"certificateFile,omitempty"`
+	P12File         string `json:"p12File,omitempty"`
+}
+
+// CertificateRotationProfile is a profile that referenced the old certificate.
+// BundleResourceID, CertificateIDs and DeviceIDs record what the replacement
+// is created with, so a profile deleted mid-migration can be recreated.
+type CertificateRotationProfile struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	ProfileType      string   `json:"profileType"`
+	BundleID         string   `json:"bundleId,omitempty"`
+	BundleResourceID string   `json:"bundleResourceId,omitempty"`
+	CertificateIDs   []string `json:"certificateIds,omitempty"`
+	DeviceIDs        []string `json:"deviceIds,omitempty"`
+	Action           string   `json:"action"`
+	NewProfileID     string   `json:"newProfileId,omitempty"`
+	File             string   `json:"file,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// CertificateRotationResult represents CLI output for certificates rotate.
+type CertificateRotationResult struct {
+	Stage          string                          `json:"stage"`
+	StateFile      string                          `json:"stateFile"`
+	DryRun         bool                            `json:"dryRun"`
+	OldCertificate CertificateRotationCertificate  `json:"oldCertificate"`
+	NewCertificate *CertificateRotationCertificate `json:"newCertificate,omitempty"`
+	Revoked        bool                            `json:"revoked"`
+	Profiles       []CertificateRotationProfile    `json:"profiles"`
+	NextStep       string                          `json:"nextStep,omitempty"`
+}
+
+func certificateRotationResultMainRows(result *CertificateRotationResult) ([]string, [][]string) {
+	headers := []string{"Stage", "Dry Run", "Old Certificate", "Old Serial", "Old Expiration", "New Certificate", "New Serial", "P12 File", "Revoked", "Profiles", "Next Step"}
+	newID, newSerial, p12File := "", "", ""
+	if result.NewCertificate != nil {
+		newID = result.NewCertificate.ID
+		newSerial = result.NewCertificate.SerialNumber
+		p12File = result.NewCertificate.P12File
+	}
+	rows := [][]string{{
+		result.Stage,
+		fmt.Sprintf("%t", result.DryRun),
+		result.OldCertificate.ID,
+		result.OldCertificate.SerialNumber,
+		result.OldCertificate.ExpirationDate,
+		newID,
+		newSerial,
+		p12File,
+		fmt.Sprintf("%t", result.Revoked),
+		fmt.Sprintf("%d", len(result.Profiles)),
+		result.NextStep,
+	}}
+	return headers, rows
+}
+
+func certificateRotationProfileRows(profiles []CertificateRotationProfile) ([]string, [][]string) {
+	headers := []string{"Name", "Type", "Bundle ID", "Action", "Old ID", "New ID", "File", "Error"}
+	rows := make([][]string, 0, len(profiles))
+	for _, item := range profiles {
+		rows = append(rows, []string{
+			compactWhitespace(item.Name),
+			item.ProfileType,
+			item.BundleID,
+			item.Action,
+			item.ID,
+			item.NewProfileID,
+			item.File,
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
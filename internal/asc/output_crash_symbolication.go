@@ -0,0 +1,100 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CrashSymbolicationImage is one binary image listed in a crash log.
+type CrashSymbolicationImage struct {
+	Name        string `json:"name"`
+	Arch        string `json:"arch,omitempty"`
+	UUID        string `json:"uuid"`
+	LoadAddress string `json:"loadAddress"`
+	Path        string `json:"path,omitempty"`
+	DSYM        string `json:"dsym,omitempty"`
+	Status      string `json:"status"`
+	Frames      int    `json:"frames"`
+}
+
+// CrashSymbolicationFrame is one stack frame of a crash log.
+type CrashSymbolicationFrame struct {
+	Thread      string `json:"thread,omitempty"`
+	Index       int    `json:"index"`
+	Image       string `json:"image"`
+	Address     string `json:"address"`
+	Symbol      string `json:"symbol,omitempty"`
+	Offset      uint64 `json:"offset,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	InlinedInto string `json:"inlinedInto,omitempty"`
+}
+
+// CrashSymbolicationResult represents CLI output for crash symbolication.
+type CrashSymbolicationResult struct {
+	Source          string                    `json:"source"`
+	Out             string                    `json:"out,omitempty"`
+	DSYMs           int                       `json:"dsyms"`
+	Matched         int                       `json:"matched"`
+	Unmatched       []string                  `json:"unmatched,omitempty"`
+	Frames          int                       `json:"frames"`
+	Symbolicated    int                       `json:"symbolicated"`
+	Images          []CrashSymbolicationImage `json:"images"`
+	StackFrames     []CrashSymbolicationFrame `json:"stackFrames"`
+	SymbolicatedLog string                    `json:"symbolicatedLog,omitempty"`
+}
+
+func crashSymbolicationResultMainRows(result *CrashSymbolicationResult) ([]string, [][]string) {
+	headers := []string{"Source", "Out", "dSYMs", "Matched Images", "Unmatched Images", "Frames", "Symbolicated"}
+	rows := [][]string{{
+		result.Source,
+		result.Out,
+		fmt.Sprintf("%d", result.DSYMs),
+		fmt.Sprintf("%d", result.Matched),
+		strings.Join(result.Unmatched, ", "),
+		fmt.Sprintf("%d", result.Frames),
+		fmt.Sprintf("%d", result.Symbolicated),
+	}}
+	return headers, rows
+}
+
+func crashSymbolicationImageRows(images []CrashSymbolicationImage) ([]string, [][]string) {
+	headers := []string{"Image", "Arch", "UUID", "Load Address", "Status", "Frames", "dSYM"}
+	rows := make([][]string, 0, len(images))
+	for _, image := range images {
+		rows = append(rows, []string{
+			image.Name,
+			image.Arch,
+			image.UUID,
+			image.LoadAddress,
+			image.Status,
+			fmt.Sprintf("%d", image.Frames),
+			image.DSYM,
+		})
+	}
+	return headers, rows
+}
+
+func crashSymbolicationFrameRows(frames []CrashSymbolicationFrame) ([]string, [][]string) {
+	headers := []string{"Thread", "#", "Image", "Address", "Symbol", "Location"}
+	rows := make([][]string, 0, len(frames))
+	for _, frame := range frames {
+		symbol := frame.Symbol
+		if symbol != "" && frame.InlinedInto != "" {
+			symbol += " (inlined into " + frame.InlinedInto + ")"
+		}
+		location := ""
+		if frame.File != "" {
+			location = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		rows = append(rows, []string{
+			frame.Thread,
+			fmt.Sprintf("%d", frame.Index),
+			frame.Image,
+			frame.Address,
+			compactWhitespace(symbol),
+			location,
+		})
+	}
+	return headers, rows
+}
@@ -0,0 +1,54 @@
+package asc
+
+import "fmt"
+
+// DeviceCollectItem is a device that answered the profile service.
+type DeviceCollectItem struct {
+	UDID        string `json:"udid"`
+	Product     string `json:"product,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Serial      string `json:"serial,omitempty"`
+	DeviceName  string `json:"deviceName,omitempty"`
+	Platform    string `json:"platform"`
+	Status      string `json:"status"`
+	DeviceID    string `json:"deviceId,omitempty"`
+	Error       string `json:"error,omitempty"`
+	CollectedAt string `json:"collectedAt"`
+}
+
+// DeviceCollectResult represents CLI output for device collection.
+type DeviceCollectResult struct {
+	URL      string              `json:"url"`
+	Signed   bool                `json:"signed"`
+	Register bool                `json:"register"`
+	Devices  []DeviceCollectItem `json:"devices"`
+}
+
+func deviceCollectResultMainRows(result *DeviceCollectResult) ([]string, [][]string) {
+	headers := []string{"URL", "Signed", "Register", "Devices"}
+	rows := [][]string{{
+		result.URL,
+		fmt.Sprintf("%t", result.Signed),
+		fmt.Sprintf("%t", result.Register),
+		fmt.Sprintf("%d", len(result.Devices)),
+	}}
+	return headers, rows
+}
+
+func deviceCollectItemRows(items []DeviceCollectItem) ([]string, [][]string) {
+	headers := []string{"UDID", "Product", "Version", "Name", "Platform", "Status", "Device ID", "Error"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.UDID,
+			item.Product,
+			item.Version,
+			compactWhitespace(item.DeviceName),
+			item.Platform,
+			item.Status,
+			item.DeviceID,
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
@@ -0,0 +1,108 @@
+package asc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeviceImportRow is the outcome for one row of a device import file.
+type DeviceImportRow struct {
+	Row      int    `json:"row"`
+	UDID     string `json:"udid"`
+	Name     string `json:"name,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	DeviceID string `json:"deviceId,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DeviceImportPreflight summarizes device limit usage for one platform.
+type DeviceImportPreflight struct {
+	Platform   string   `json:"platform"`
+	Families   []string `json:"families"`
+	Registered int      `json:"registered"`
+	Limit      int      `json:"limit"`
+	Remaining  int      `json:"remaining"`
+	New        int      `json:"new"`
+	Exceeded   bool     `json:"exceeded"`
+}
+
+// DeviceImportResult represents CLI output for device imports.
+type DeviceImportResult struct {
+	File       string                  `json:"file"`
+	Format     string                  `json:"format"`
+	DryRun     bool                    `json:"dryRun"`
+	Total      int                     `json:"total"`
+	Registered int                     `json:"registered"`
+	Existing   int                     `json:"existing"`
+	Duplicates int                     `json:"duplicates"`
+	Invalid    int                     `json:"invalid"`
+	OverLimit  int                     `json:"overLimit"`
+	Failed     int                     `json:"failed"`
+	Preflight  []DeviceImportPreflight `json:"preflight"`
+	Rows       []DeviceImportRow       `json:"rows"`
+}
+
+// DeviceExportResult represents CLI output for device exports.
+type DeviceExportResult struct {
+	File    string `json:"file"`
+	Format  string `json:"format"`
+	Devices int    `json:"devices"`
+}
+
+func deviceImportResultMainRows(result *DeviceImportResult) ([]string, [][]string) {
+	headers := []string{"File", "Format", "Dry Run", "Total", "Registered", "Existing", "Duplicates", "Invalid", "Over Limit", "Failed"}
+	rows := [][]string{{
+		result.File,
+		result.Format,
+		fmt.Sprintf("%t", result.DryRun),
+		fmt.Sprintf("%d", result.Total),
+		fmt.Sprintf("%d", result.Registered),
+		fmt.Sprintf("%d", result.Existing),
+		fmt.Sprintf("%d", result.Duplicates),
+		fmt.Sprintf("%d", result.Invalid),
+		fmt.Sprintf("%d", result.OverLimit),
+		fmt.Sprintf("%d", result.Failed),
+	}}
+	return headers, rows
+}
+
+func deviceImportPreflightRows(items []DeviceImportPreflight) ([]string, [][]string) {
+	headers := []string{"Platform", "Families", "Registered", "Limit", "Remaining", "New", "Exceeded"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.Platform,
+			strings.Join(item.Families, ", "),
+			fmt.Sprintf("%d", item.Registered),
+			fmt.Sprintf("%d", item.Limit),
+			fmt.Sprintf("%d", item.Remaining),
+			fmt.Sprintf("%d", item.New),
+			fmt.Sprintf("%t", item.Exceeded),
+		})
+	}
+	return headers, rows
+}
+
+func deviceImportRows(items []DeviceImportRow) ([]string, [][]string) {
+	headers := []string{"Row", "UDID", "Name", "Platform", "Device ID", "Status", "Error"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", item.Row),
+			item.UDID,
+			compactWhitespace(item.Name),
+			item.Platform,
+			item.DeviceID,
+			item.Status,
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
+
+func deviceExportResultRows(result *DeviceExportResult) ([]string, [][]string) {
+	headers := []string{"File", "Format", "Devices"}
+	rows := [][]string{{result.File, result.Format, fmt.Sprintf("%d", result.Devices)}}
+	return headers, rows
+}
@@ -43,6 +43,13 @@ type LocalizationUploadLocaleResult struct {
 	LocalizationID string `json:"localizationId,omitempty"`
 }
 
+// LocalizationSkippedValue represents an untranslated value skipped on upload.
+type LocalizationSkippedValue struct {
+	Locale string `json:"locale"`
+	Key    string `json:"key"`
+	State  string `json:"state"`
+}
+
 // LocalizationUploadResult represents CLI output for localization uploads.
 type LocalizationUploadResult struct {
 	Type      string                           `json:"type"`
@@ -51,6 +58,95 @@ type LocalizationUploadResult struct {
 	AppInfoID string                           `json:"appInfoId,omitempty"`
 	DryRun    bool                             `json:"dryRun"`
 	Results   []LocalizationUploadLocaleResult `json:"results"`
+	Skipped   []LocalizationSkippedValue       `json:"skipped,omitempty"`
+}
+
+// LocalizationLintIssue represents a single metadata lint finding.
+type LocalizationLintIssue struct {
+	Locale   string `json:"locale"`
+	Field    string `json:"field"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Length   int    `json:"length,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// LocalizationLintResult represents CLI output for localization lint.
+type LocalizationLintResult struct {
+	Source        string                  `json:"source"`
+	PrimaryLocale string                  `json:"primaryLocale,omitempty"`
+	Locales       []string                `json:"locales"`
+	Issues        []LocalizationLintIssue `json:"issues"`
+	ErrorCount    int                     `json:"errorCount"`
+	WarningCount  int                     `json:"warningCount"`
+	Valid         bool                    `json:"valid"`
+}
+
+// LocalizationTranslationItem represents one machine-translated metadata field.
+type LocalizationTranslationItem struct {
+	Locale string `json:"locale"`
+	Field  string `json:"field"`
+	Status string `json:"status"`
+	Text   string `json:"text,omitempty"`
+	Length int    `json:"length,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// LocalizationTranslateResult represents CLI output for localization translation.
+type LocalizationTranslateResult struct {
+	Type         string                           `json:"type"`
+	VersionID    string                           `json:"versionId,omitempty"`
+	AppID        string                           `json:"appId,omitempty"`
+	AppInfoID    string                           `json:"appInfoId,omitempty"`
+	SourceLocale string                           `json:"sourceLocale"`
+	Fields       []string                         `json:"fields"`
+	DryRun       bool                             `json:"dryRun"`
+	Translations []LocalizationTranslationItem    `json:"translations"`
+	Files        []LocalizationFileResult         `json:"files,omitempty"`
+	Results      []LocalizationUploadLocaleResult `json:"results,omitempty"`
+	FailedCount  int                              `json:"failedCount"`
+}
+
+// LocalizationCopyChange represents one field difference between source and destination.
+type LocalizationCopyChange struct {
+	Type   string `json:"type"`
+	Locale string `json:"locale"`
+	Field  string `json:"field"`
+	Action string `json:"action"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to"`
+}
+
+// LocalizationCopyScreenshotSet represents a screenshot set copied between versions.
+type LocalizationCopyScreenshotSet struct {
+	Locale      string `json:"locale"`
+	DisplayType string `json:"displayType"`
+	Count       int    `json:"count"`
+}
+
+// LocalizationCopyAttachment represents a review attachment copied between versions.
+type LocalizationCopyAttachment struct {
+	FileName string `json:"fileName"`
+	Action   string `json:"action"`
+	ID       string `json:"id,omitempty"`
+}
+
+// LocalizationCopyResult represents CLI output for localization copies.
+type LocalizationCopyResult struct {
+	FromVersionID     string                           `json:"fromVersionId"`
+	ToVersionID       string                           `json:"toVersionId"`
+	FromAppInfoID     string                           `json:"fromAppInfoId,omitempty"`
+	ToAppInfoID       string                           `json:"toAppInfoId,omitempty"`
+	DryRun            bool                             `json:"dryRun"`
+	Changes           []LocalizationCopyChange         `json:"changes"`
+	Unchanged         int                              `json:"unchanged"`
+	Results           []LocalizationUploadLocaleResult `json:"results,omitempty"`
+	AppInfoResults    []LocalizationUploadLocaleResult `json:"appInfoResults,omitempty"`
+	Screenshots       []LocalizationCopyScreenshotSet  `json:"screenshots,omitempty"`
+	ReviewDetail      string                           `json:"reviewDetail,omitempty"`
+	ReviewAttachments []LocalizationCopyAttachment     `json:"reviewAttachments,omitempty"`
 }
 
 func appStoreVersionLocalizationsRows(resp *AppStoreVersionLocalizationsResponse) ([]string, [][]string) {
@@ -127,6 +223,92 @@ func localizationUploadResultRows(result *LocalizationUploadResult) ([]string, [
 			item.LocalizationID,
 		})
 	}
+	for _, item := range result.Skipped {
+		rows = append(rows, []string{
+			item.Locale,
+			fmt.Sprintf("skipped %s (%s)", item.Key, item.State),
+			"",
+		})
+	}
+	return headers, rows
+}
+
+func localizationLintResultMainRows(result *LocalizationLintResult) ([]string, [][]string) {
+	headers := []string{"Source", "Primary Locale", "Locales", "Errors", "Warnings", "Valid"}
+	rows := [][]string{{
+		result.Source,
+		result.PrimaryLocale,
+		fmt.Sprintf("%d", len(result.Locales)),
+		fmt.Sprintf("%d", result.ErrorCount),
+		fmt.Sprintf("%d", result.WarningCount),
+		fmt.Sprintf("%t", result.Valid),
+	}}
+	return headers, rows
+}
+
+func localizationLintIssueRows(issues []LocalizationLintIssue) ([]string, [][]string) {
+	headers := []string{"Locale", "Field", "Rule", "Severity", "Message"}
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, []string{issue.Locale, issue.Field, issue.Rule, issue.Severity, issue.Message})
+	}
+	return headers, rows
+}
+
+func localizationTranslationRows(items []LocalizationTranslationItem) ([]string, [][]string) {
+	headers := []string{"Locale", "Field", "Status", "Length", "Text", "Error"}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		length := ""
+		if item.Limit > 0 {
+			length = fmt.Sprintf("%d/%d", item.Length, item.Limit)
+		}
+		rows = append(rows, []string{
+			item.Locale,
+			item.Field,
+			item.Status,
+			length,
+			compactWhitespace(item.Text),
+			item.Error,
+		})
+	}
+	return headers, rows
+}
+
+func localizationCopyChangeRows(result *LocalizationCopyResult) ([]string, [][]string) {
+	headers := []string{"Type", "Locale", "Field", "Action", "From", "To"}
+	rows := make([][]string, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		rows = append(rows, []string{
+			change.Type,
+			change.Locale,
+			change.Field,
+			change.Action,
+			compactWhitespace(change.From),
+			compactWhitespace(change.To),
+		})
+	}
+	return headers, rows
+}
+
+func localizationCopyScreenshotRows(sets []LocalizationCopyScreenshotSet) ([]string, [][]string) {
+	headers := []string{"Locale", "Display Type", "Screenshots"}
+	rows := make([][]string, 0, len(sets))
+	for _, set := range sets {
+		rows = append(rows, []string{set.Locale, set.DisplayType, fmt.Sprintf("%d", set.Count)})
+	}
+	return headers, rows
+}
+
+func localizationCopyReviewRows(result *LocalizationCopyResult) ([]string, [][]string) {
+	headers := []string{"Review Item", "Action", "ID"}
+	rows := make([][]string, 0, len(result.ReviewAttachments)+1)
+	if result.ReviewDetail != "" {
+		rows = append(rows, []string{"review details", result.ReviewDetail, ""})
+	}
+	for _, attachment := range result.ReviewAttachments {
+		rows = append(rows, []string{attachment.FileName, attachment.Action, attachment.ID})
+	}
 	return headers, rows
 }
 
@@ -439,6 +439,136 @@ func init() {
 		return nil
 	})
 	registerRows(buildExpireAllResultRows)
+	registerRows(buildTestNotesGenerateResultRows)
+	registerDirect(func(v *BuildRetentionResult, render func([]string, [][]string)) error {
+		h, r := buildRetentionResultMainRows(v)
+		render(h, r)
+		if len(v.Decisions) > 0 {
+			dh, dr := buildRetentionDecisionRows(v.Decisions)
+			render(dh, dr)
+		}
+		return nil
+	})
+	registerDirect(func(v *BetaTesterEngagementResult, render func([]string, [][]string)) error {
+		h, r := betaTesterEngagementResultMainRows(v)
+		render(h, r)
+		if len(v.Testers) > 0 {
+			th, tr := betaTesterEngagementRows(v.Testers)
+			render(th, tr)
+		}
+		return nil
+	})
+	registerDirect(func(v *BetaFeedbackExportResult, render func([]string, [][]string)) error {
+		h, r := betaFeedbackExportResultMainRows(v)
+		render(h, r)
+		if len(v.Builds) > 0 {
+			bh, br := betaFeedbackExportBuildRows(v.Builds)
+			render(bh, br)
+		}
+		if len(v.Items) > 0 {
+			ih, ir := betaFeedbackExportItemRows(v.Items)
+			render(ih, ir)
+		}
+		return nil
+	})
+	registerDirect(func(v *CrashSymbolicationResult, render func([]string, [][]string)) error {
+		h, r := crashSymbolicationResultMainRows(v)
+		render(h, r)
+		if len(v.Images) > 0 {
+			ih, ir := crashSymbolicationImageRows(v.Images)
+			render(ih, ir)
+		}
+		if len(v.StackFrames) > 0 {
+			fh, fr := crashSymbolicationFrameRows(v.StackFrames)
+			render(fh, fr)
+		}
+		return nil
+	})
+	registerDirect(func(v *ProfileInspectResult, render func([]string, [][]string)) error {
+		for i := range v.Profiles {
+			profile := &v.Profiles[i]
+			h, r := inspectedProfileRows(profile)
+			render(h, r)
+			if len(profile.Certificates) > 0 {
+				ch, cr := inspectedCertificateRows(profile.Certificates)
+				render(ch, cr)
+			}
+			if len(profile.Devices) > 0 {
+				dh, dr := inspectedProfileDeviceRows(profile.Devices)
+				render(dh, dr)
+			}
+			if len(profile.Entitlements) > 0 {
+				eh, er := inspectedEntitlementRows(profile.Entitlements)
+				render(eh, er)
+			}
+		}
+		return nil
+	})
+	registerRows(certificateInspectResultRows)
+	registerDirect(func(v *BundleIDCapabilityPlanResult, render func([]string, [][]string)) error {
+		h, r := bundleIDCapabilityPlanMainRows(v)
+		render(h, r)
+		if len(v.Changes) > 0 {
+			ch, cr := bundleIDCapabilityChangeRows(v.Changes)
+			render(ch, cr)
+		}
+		if len(v.Notes) > 0 {
+			nh, nr := bundleIDCapabilityPlanNoteRows(v.Notes)
+			render(nh, nr)
+		}
+		return nil
+	})
+	registerDirect(func(v *CertificateRotationResult, render func([]string, [][]string)) error {
+		h, r := certificateRotationResultMainRows(v)
+		render(h, r)
+		if len(v.Profiles) > 0 {
+			ph, pr := certificateRotationProfileRows(v.Profiles)
+			render(ph, pr)
+		}
+		return nil
+	})
+	registerDirect(func(v *DeviceImportResult, render func([]string, [][]string)) error {
+		h, r := deviceImportResultMainRows(v)
+		render(h, r)
+		if len(v.Preflight) > 0 {
+			ph, pr := deviceImportPreflightRows(v.Preflight)
+			render(ph, pr)
+		}
+		if len(v.Rows) > 0 {
+			rh, rr := deviceImportRows(v.Rows)
+			render(rh, rr)
+		}
+		return nil
+	})
+	registerRows(deviceExportResultRows)
+	registerDirect(func(v *DeviceCollectResult, render func([]string, [][]string)) error {
+		h, r := deviceCollectResultMainRows(v)
+		render(h, r)
+		if len(v.Devices) > 0 {
+			dh, dr := deviceCollectItemRows(v.Devices)
+			render(dh, dr)
+		}
+		return nil
+	})
+	registerDirect(func(v *BetaTesterImportResult, render func([]string, [][]string)) error {
+		h, r := betaTesterImportResultMainRows(v)
+		render(h, r)
+		if len(v.Rows) > 0 {
+			rh, rr := betaTesterImportRows(v.Rows)
+			render(rh, rr)
+		}
+		return nil
+	})
+	registerRows(betaTesterExportResultRows)
+	registerDirect(func(v *BetaTesterPruneResult, render func([]string, [][]string)) error {
+		h, r := betaTesterPruneResultMainRows(v)
+		render(h, r)
+		if len(v.Testers) > 0 {
+			th, tr := betaTesterPruneEntryRows(v.Testers)
+			render(th, tr)
+		}
+		return nil
+	})
 	registerRows(appScreenshotListResultRows)
 	registerRows(appPreviewListResultRows)
 	registerDirect(func(v *AppScreenshotUploadResult, render func([]string, [][]string)) error {
@@ -459,6 +589,81 @@ func init() {
 		}
 		return nil
 	})
+	registerDirect(func(v *LocalizationCopyResult, render func([]string, [][]string)) error {
+		h, r := localizationCopyChangeRows(v)
+		render(h, r)
+		if len(v.Results) > 0 || len(v.AppInfoResults) > 0 {
+			results := append(append([]LocalizationUploadLocaleResult{}, v.Results...), v.AppInfoResults...)
+			uh, ur := localizationUploadResultRows(&LocalizationUploadResult{Results: results})
+			render(uh, ur)
+		}
+		if len(v.Screenshots) > 0 {
+			sh, sr := localizationCopyScreenshotRows(v.Screenshots)
+			render(sh, sr)
+		}
+		if v.ReviewDetail != "" || len(v.ReviewAttachments) > 0 {
+			rh, rr := localizationCopyReviewRows(v)
+			render(rh, rr)
+		}
+		return nil
+	})
+	registerRows(versionSnapshotResultRows)
+	registerDirect(func(v *VersionDiffResult, render func([]string, [][]string)) error {
+		h, r := versionDiffSideRows(v)
+		render(h, r)
+		ch, cr := versionDiffChangeRows(v.Changes)
+		render(ch, cr)
+		return nil
+	})
+	registerDirect(func(v *LocalizationTranslateResult, render func([]string, [][]string)) error {
+		h, r := localizationTranslationRows(v.Translations)
+		render(h, r)
+		if len(v.Files) > 0 {
+			fh, fr := localizationDownloadResultRows(&LocalizationDownloadResult{Files: v.Files})
+			render(fh, fr)
+		}
+		if len(v.Results) > 0 {
+			uh, ur := localizationUploadResultRows(&LocalizationUploadResult{Results: v.Results})
+			render(uh, ur)
+		}
+		return nil
+	})
+	registerDirect(func(v *LocalizationLintResult, render func([]string, [][]string)) error {
+		h, r := localizationLintResultMainRows(v)
+		render(h, r)
+		if len(v.Issues) > 0 {
+			ih, ir := localizationLintIssueRows(v.Issues)
+			render(ih, ir)
+		}
+		return nil
+	})
+	registerDirect(func(v *AssetValidationResult, render func([]string, [][]string)) error {
+		h, r := assetValidationResultMainRows(v)
+		render(h, r)
+		if len(v.Files) > 0 {
+			fh, fr := assetValidationFileRows(v.Files)
+			render(fh, fr)
+		}
+		return nil
+	})
+	registerDirect(func(v *AssetStatusResult, render func([]string, [][]string)) error {
+		h, r := assetStatusResultMainRows(v)
+		render(h, r)
+		if len(v.Assets) > 0 {
+			ih, ir := assetStatusItemRows(v.Assets)
+			render(ih, ir)
+		}
+		return nil
+	})
+	registerDirect(func(v *AssetDownloadResult, render func([]string, [][]string)) error {
+		h, r := assetDownloadResultMainRows(v)
+		render(h, r)
+		if len(v.Files) > 0 {
+			fh, fr := assetDownloadFileRows(v.Files)
+			render(fh, fr)
+		}
+		return nil
+	})
 	registerRows(appClipAdvancedExperienceImageUploadResultRows)
 	registerRows(appClipHeaderImageUploadResultRows)
 	registerRows(assetDeleteResultRows)
@@ -694,12 +899,16 @@ func init() {
 	registerRows(merchantIDDeleteResultRows)
 	registerRows(passTypeIDDeleteResultRows)
 	registerRows(bundleIDCapabilityDeleteResultRows)
+	registerRows(certificateCreateResultRows)
 	registerRows(certificateRevokeResultRows)
 	registerRows(profileDeleteResultRows)
 	registerRows(endUserLicenseAgreementRows)
 	registerRows(endUserLicenseAgreementDeleteResultRows)
 	registerRows(profileDownloadResultRows)
 	registerRows(signingFetchResultRows)
+	registerRows(signingSyncResultRows)
+	registerRows(signingAuditResultRows)
+	registerRows(profileRefreshResultRows)
 	registerRows(xcodeCloudRunResultRows)
 	registerRows(xcodeCloudStatusResultRows)
 	registerRows(ciProductsRows)
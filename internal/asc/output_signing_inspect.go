@@ -0,0 +1,157 @@
+package asc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InspectedCertificate describes a signing certificate read from a local
+// file, optionally cross-checked against the certificates API.
+type InspectedCertificate struct {
+	File           string `json:"file,omitempty"`
+	CommonName     string `json:"commonName"`
+	TeamID         string `json:"teamId,omitempty"`
+	TeamName       string `json:"teamName,omitempty"`
+	Issuer         string `json:"issuer,omitempty"`
+	SerialNumber   string `json:"serialNumber"`
+	SHA1           string `json:"sha1"`
+	SHA256         string `json:"sha256"`
+	NotBefore      string `json:"notBefore"`
+	NotAfter       string `json:"notAfter"`
+	Expired        bool   `json:"expired"`
+	HasPrivateKey  bool   `json:"hasPrivateKey,omitempty"`
+	CACertificates int    `json:"caCertificates,omitempty"`
+	APIStatus      string `json:"apiStatus,omitempty"`
+	APIID          string `json:"apiId,omitempty"`
+	APIType        string `json:"apiCertificateType,omitempty"`
+}
+
+// InspectedProfileDevice is a device provisioned by a profile.
+type InspectedProfileDevice struct {
+	UDID      string `json:"udid"`
+	Name      string `json:"name,omitempty"`
+	APIStatus string `json:"apiStatus,omitempty"`
+	APIID     string `json:"apiId,omitempty"`
+}
+
+// InspectedProfile describes a provisioning profile read from a local
+// .mobileprovision file.
+type InspectedProfile struct {
+	File                 string                   `json:"file"`
+	Name                 string                   `json:"name"`
+	UUID                 string                   `json:"uuid"`
+	Kind                 string                   `json:"kind"`
+	AppIDName            string                   `json:"appIdName,omitempty"`
+	ApplicationID        string                   `json:"applicationIdentifier,omitempty"`
+	BundleID             string                   `json:"bundleId,omitempty"`
+	TeamID               string                   `json:"teamId,omitempty"`
+	TeamName             string                   `json:"teamName,omitempty"`
+	Platforms            []string                 `json:"platforms,omitempty"`
+	CreationDate         string                   `json:"creationDate,omitempty"`
+	ExpirationDate       string                   `json:"expirationDate"`
+	Expired              bool                     `json:"expired"`
+	ProvisionsAllDevices bool                     `json:"provisionsAllDevices,omitempty"`
+	SignatureValid       bool                     `json:"signatureValid"`
+	Signer               string                   `json:"signer,omitempty"`
+	ChainVerified        bool                     `json:"chainVerified"`
+	ChainError           string                   `json:"chainError,omitempty"`
+	Entitlements         map[string]any           `json:"entitlements,omitempty"`
+	Certificates         []InspectedCertificate   `json:"certificates"`
+	Devices              []InspectedProfileDevice `json:"devices,omitempty"`
+	Problems             []string                 `json:"problems,omitempty"`
+}
+
+// ProfileInspectResult represents CLI output for profiles inspect.
+type ProfileInspectResult struct {
+	Profiles []InspectedProfile `json:"profiles"`
+}
+
+// CertificateInspectResult represents CLI output for certificates inspect.
+type CertificateInspectResult struct {
+	Certificates []InspectedCertificate `json:"certificates"`
+}
+
+func inspectedProfileRows(profile *InspectedProfile) ([]string, [][]string) {
+	headers := []string{"Field", "Value"}
+	rows := [][]string{
+		{"File", profile.File},
+		{"Name", profile.Name},
+		{"UUID", profile.UUID},
+		{"Kind", profile.Kind},
+		{"App ID", profile.ApplicationID},
+		{"Team", strings.TrimSpace(profile.TeamID + " " + profile.TeamName)},
+		{"Platforms", strings.Join(profile.Platforms, ", ")},
+		{"Expires", profile.ExpirationDate},
+		{"Expired", fmt.Sprintf("%t", profile.Expired)},
+		{"Devices", inspectedProfileDeviceCount(profile)},
+		{"Signature Valid", fmt.Sprintf("%t", profile.SignatureValid)},
+		{"Signer", profile.Signer},
+		{"Chain Verified", fmt.Sprintf("%t", profile.ChainVerified)},
+	}
+	if profile.ChainError != "" {
+		rows = append(rows, []string{"Chain Error", profile.ChainError})
+	}
+	if len(profile.Problems) > 0 {
+		rows = append(rows, []string{"Problems", strings.Join(profile.Problems, "; ")})
+	}
+	return headers, rows
+}
+
+func inspectedProfileDeviceCount(profile *InspectedProfile) string {
+	if profile.ProvisionsAllDevices {
+		return "all"
+	}
+	return fmt.Sprintf("%d", len(profile.Devices))
+}
+
+func inspectedCertificateRows(certs []InspectedCertificate) ([]string, [][]string) {
+	headers := []string{"Common Name", "Team ID", "Serial Number", "SHA-1", "Expires", "Expired", "Private Key", "API Status", "API ID"}
+	rows := make([][]string, 0, len(certs))
+	for _, cert := range certs {
+		rows = append(rows, []string{
+			cert.CommonName,
+			cert.TeamID,
+			cert.SerialNumber,
+			cert.SHA1,
+			cert.NotAfter,
+			fmt.Sprintf("%t", cert.Expired),
+			fmt.Sprintf("%t", cert.HasPrivateKey),
+			cert.APIStatus,
+			cert.APIID,
+		})
+	}
+	return headers, rows
+}
+
+func certificateInspectResultRows(result *CertificateInspectResult) ([]string, [][]string) {
+	return inspectedCertificateRows(result.Certificates)
+}
+
+func inspectedProfileDeviceRows(devices []InspectedProfileDevice) ([]string, [][]string) {
+	headers := []string{"UDID", "Name", "API Status", "API ID"}
+	rows := make([][]string, 0, len(devices))
+	for _, device := range devices {
+		rows = append(rows, []string{device.UDID, device.Name, device.APIStatus, device.APIID})
+	}
+	return headers, rows
+}
+
+func inspectedEntitlementRows(entitlements map[string]any) ([]string, [][]string) {
+	headers := []string{"Entitlement", "Value"}
+	keys := make([]string, 0, len(entitlements))
+	for key := range entitlements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		value := fmt.Sprintf("%v", entitlements[key])
+		if encoded, err := json.Marshal(entitlements[key]); err == nil {
+			value = string(encoded)
+		}
+		rows = append(rows, []string{key, value})
+	}
+	return headers, rows
+}
@@ -0,0 +1,142 @@
+package asc
+
+import "fmt"
+
+// VersionSnapshotSchemaVersion is the current schema version of version snapshot files.
+const VersionSnapshotSchemaVersion = 1
+
+// VersionSnapshotScreenshot describes one screenshot in display order.
+type VersionSnapshotScreenshot struct {
+	FileName string `json:"fileName"`
+	Checksum string `json:"checksum,omitempty"`
+	FileSize int64  `json:"fileSize,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+// VersionSnapshotPrice describes one entry of the app price schedule.
+type VersionSnapshotPrice struct {
+	ID            string `json:"id"`
+	Territory     string `json:"territory,omitempty"`
+	CustomerPrice string `json:"customerPrice,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	Manual        bool   `json:"manual"`
+}
+
+// VersionSnapshotPricing describes the app price schedule at snapshot time.
+type VersionSnapshotPricing struct {
+	BaseTerritory string                 `json:"baseTerritory,omitempty"`
+	ManualPrices  []VersionSnapshotPrice `json:"manualPrices,omitempty"`
+}
+
+// VersionSnapshot captures the full App Store state of a version.
+type VersionSnapshot struct {
+	SchemaVersion        int                                               `json:"schemaVersion"`
+	CapturedAt           string                                            `json:"capturedAt"`
+	AppID                string                                            `json:"appId"`
+	VersionID            string                                            `json:"versionId"`
+	VersionString        string                                            `json:"versionString"`
+	Platform             string                                            `json:"platform"`
+	State                string                                            `json:"state,omitempty"`
+	Version              map[string]string                                 `json:"version,omitempty"`
+	Localizations        map[string]map[string]string                      `json:"localizations,omitempty"`
+	AppInfoID            string                                            `json:"appInfoId,omitempty"`
+	AppInfoLocalizations map[string]map[string]string                      `json:"appInfoLocalizations,omitempty"`
+	Categories           map[string]string                                 `json:"categories,omitempty"`
+	AgeRating            map[string]string                                 `json:"ageRating,omitempty"`
+	Screenshots          map[string]map[string][]VersionSnapshotScreenshot `json:"screenshots,omitempty"`
+	Pricing              *VersionSnapshotPricing                           `json:"pricing,omitempty"`
+}
+
+// VersionSnapshotResult represents CLI output for a saved version snapshot.
+type VersionSnapshotResult struct {
+	Path                 string `json:"path"`
+	AppID                string `json:"appId"`
+	VersionID            string `json:"versionId"`
+	VersionString        string `json:"versionString"`
+	Platform             string `json:"platform"`
+	CapturedAt           string `json:"capturedAt"`
+	Localizations        int    `json:"localizations"`
+	AppInfoLocalizations int    `json:"appInfoLocalizations"`
+	ScreenshotSets       int    `json:"screenshotSets"`
+}
+
+// VersionDiffSide identifies one side of a version diff.
+type VersionDiffSide struct {
+	Source        string `json:"source"`
+	VersionID     string `json:"versionId"`
+	VersionString string `json:"versionString"`
+	Platform      string `json:"platform"`
+	CapturedAt    string `json:"capturedAt,omitempty"`
+}
+
+// VersionDiffChange represents one difference between two versions.
+type VersionDiffChange struct {
+	Section string `json:"section"`
+	Locale  string `json:"locale,omitempty"`
+	Field   string `json:"field"`
+	Action  string `json:"action"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+}
+
+// VersionDiffResult represents CLI output for version diffs.
+type VersionDiffResult struct {
+	From      VersionDiffSide     `json:"from"`
+	To        VersionDiffSide     `json:"to"`
+	Sections  []string            `json:"sections"`
+	Changes   []VersionDiffChange `json:"changes"`
+	Identical bool                `json:"identical"`
+}
+
+func versionSnapshotResultRows(result *VersionSnapshotResult) ([]string, [][]string) {
+	headers := []string{"Path", "Version", "Platform", "Version ID", "Captured At", "Localizations", "App Info Localizations", "Screenshot Sets"}
+	rows := [][]string{{
+		result.Path,
+		result.VersionString,
+		result.Platform,
+		result.VersionID,
+		result.CapturedAt,
+		fmt.Sprintf("%d", result.Localizations),
+		fmt.Sprintf("%d", result.AppInfoLocalizations),
+		fmt.Sprintf("%d", result.ScreenshotSets),
+	}}
+	return headers, rows
+}
+
+func versionDiffSideRows(result *VersionDiffResult) ([]string, [][]string) {
+	headers := []string{"Side", "Version", "Platform", "Version ID", "Source", "Captured At"}
+	rows := make([][]string, 0, 2)
+	for _, side := range []struct {
+		name string
+		side VersionDiffSide
+	}{{"from", result.From}, {"to", result.To}} {
+		rows = append(rows, []string{
+			side.name,
+			side.side.VersionString,
+			side.side.Platform,
+			side.side.VersionID,
+			side.side.Source,
+			side.side.CapturedAt,
+		})
+	}
+	return headers, rows
+}
+
+func versionDiffChangeRows(changes []VersionDiffChange) ([]string, [][]string) {
+	headers := []string{"Section", "Locale", "Field", "Change", "From", "To"}
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []string{
+			change.Section,
+			change.Locale,
+			change.Field,
+			change.Action,
+			compactWhitespace(change.From),
+			compactWhitespace(change.To),
+		})
+	}
+	return headers, rows
+}
@@ -93,6 +93,24 @@ type BundleIDsResponse = Response[BundleIDAttributes]
 // BundleIDResponse is the response from bundle ID detail endpoint.
 type BundleIDResponse = SingleResponse[BundleIDAttributes]
 
+// BundleIDCapabilityUpdateAttributes describes attributes for updating a capability.
+type BundleIDCapabilityUpdateAttributes struct {
+	CapabilityType string              `json:"capabilityType"`
+	Settings       []CapabilitySetting `json:"settings,omitempty"`
+}
+
+// BundleIDCapabilityUpdateData is the data portion of a capability update request.
+type BundleIDCapabilityUpdateData struct {
+	Type       ResourceType                       `json:"type"`
+	ID         string                             `json:"id"`
+	Attributes BundleIDCapabilityUpdateAttributes `json:"attributes"`
+}
+
+// BundleIDCapabilityUpdateRequest is a request to update a bundle ID capability.
+type BundleIDCapabilityUpdateRequest struct {
+	Data BundleIDCapabilityUpdateData `json:"data"`
+}
+
 // BundleIDCapabilitiesResponse is the response from bundle ID capabilities endpoint.
 type BundleIDCapabilitiesResponse = Response[BundleIDCapabilityAttributes]
 
@@ -155,7 +173,8 @@ type CertificateResponse = SingleResponse[CertificateAttributes]
 type ProfileState string
 
 const (
-	ProfileStateActive ProfileState = "ACTIVE"
+	ProfileStateActive  ProfileState = "ACTIVE"
+	ProfileStateInvalid ProfileState = "INVALID"
 )
 
 // ProfileAttributes describes a profile resource.
@@ -1,14 +1,80 @@
 package asc
 
-// SigningFetchResult represents CLI output for signing fetch.
+// SigningFetchProfile is the profile signing fetch resolved for one bundle ID.
+type SigningFetchProfile struct {
+	BundleID         string `json:"bundleId"`
+	BundleIDResource string `json:"bundleIdResourceId"`
+	ProfileID        string `json:"profileId"`
+	ProfileName      string `json:"profileName,omitempty"`
+	ProfileUUID      string `json:"profileUuid,omitempty"`
+	ProfileFile      string `json:"profileFile"`
+	Created          bool   `json:"created,omitempty"`
+}
+
+// SigningFetchResult represents CLI output for signing fetch. The top-level
+// bundle and profile fields describe the first bundle ID; Profiles lists every
+// bundle ID when several are fetched at once.
 type SigningFetchResult struct {
-	BundleID         string   `json:"bundleId"`
-	BundleIDResource string   `json:"bundleIdResourceId"`
-	ProfileType      string   `json:"profileType"`
-	ProfileID        string   `json:"profileId"`
-	ProfileFile      string   `json:"profileFile"`
-	CertificateIDs   []string `json:"certificateIds"`
-	CertificateFiles []string `json:"certificateFiles"`
-	OutputPath       string   `json:"outputPath"`
-	Created          bool     `json:"created,omitempty"`
+	BundleID           string                `json:"bundleId"`
+	BundleIDResource   string                `json:"bundleIdResourceId"`
+	ProfileType        string                `json:"profileType"`
+	ProfileID          string                `json:"profileId"`
+	ProfileFile        string                `json:"profileFile"`
+	CertificateIDs     []string              `json:"certificateIds"`
+	CertificateFiles   []string              `json:"certificateFiles"`
+	OutputPath         string                `json:"outputPath"`
+	Created            bool                  `json:"created,omitempty"`
+	Profiles           []SigningFetchProfile `json:"profiles,omitempty"`
+	Method             string                `json:"method,omitempty"`
+	TeamID             string                `json:"teamId,omitempty"`
+	SigningCertificate string                `json:"signingCertificate,omitempty"`
+	ExportOptionsFile  string                `json:"exportOptionsFile,omitempty"`
+	XcconfigFile       string                `json:"xcconfigFile,omitempty"`
+}
+
+// SigningSyncResult represents CLI output for signing sync.
+type SigningSyncResult struct {
+	Repo            string   `json:"repo"`
+	Branch          string   `json:"branch"`
+	BundleID        string   `json:"bundleId"`
+	ProfileType     string   `json:"profileType"`
+	Readonly        bool     `json:"readonly"`
+	CertificateID   string   `json:"certificateId"`
+	CertificateFile string   `json:"certificateFile"`
+	P12File         string   `json:"p12File"`
+	ProfileID       string   `json:"profileId"`
+	ProfileFile     string   `json:"profileFile"`
+	OutputPath      string   `json:"outputPath"`
+	Changes         []string `json:"changes,omitempty"`
+	Commit          string   `json:"commit,omitempty"`
+}
+
+// SigningAuditItem is a signing asset flagged by signing audit.
+type SigningAuditItem struct {
+	Kind           string `json:"kind"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Identifier     string `json:"identifier,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Issue          string `json:"issue"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+	DaysRemaining  *int   `json:"daysRemaining,omitempty"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+// SigningAuditCounts records how many assets signing audit checked.
+type SigningAuditCounts struct {
+	Certificates           int `json:"certificates"`
+	PassTypeIDCertificates int `json:"passTypeIdCertificates"`
+	MerchantIDCertificates int `json:"merchantIdCertificates"`
+	Profiles               int `json:"profiles"`
+	BundleIDs              int `json:"bundleIds"`
+}
+
+// SigningAuditResult represents CLI output for signing audit.
+type SigningAuditResult struct {
+	Within  string             `json:"within"`
+	Cutoff  string             `json:"cutoff"`
+	Checked SigningAuditCounts `json:"checked"`
+	Items   []SigningAuditItem `json:"items"`
 }
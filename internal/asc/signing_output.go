@@ -24,6 +24,19 @@ type CertificateRevokeResult struct {
 	Revoked bool   `json:"revoked"`
 }
 
+// CertificateCreateResult represents CLI output for certificates created from
+// a locally generated key.
+type CertificateCreateResult struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	CertificateType string `json:"certificateType"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	ExpirationDate  string `json:"expirationDate,omitempty"`
+	KeyFile         string `json:"keyFile,omitempty"`
+	CertificateFile string `json:"certificateFile,omitempty"`
+	P12File         string `json:"p12File,omitempty"`
+}
+
 // ProfileDeleteResult represents CLI output for profile deletions.
 type ProfileDeleteResult struct {
 	ID      string `json:"id"`
@@ -37,6 +50,29 @@ type ProfileDownloadResult struct {
 	OutputPath string `json:"outputPath"`
 }
 
+// ProfileRefreshItem describes one profile handled by profiles refresh.
+type ProfileRefreshItem struct {
+	Name           string   `json:"name"`
+	ProfileType    string   `json:"profileType"`
+	BundleID       string   `json:"bundleId,omitempty"`
+	OldProfileID   string   `json:"oldProfileId"`
+	NewProfileID   string   `json:"newProfileId,omitempty"`
+	Action         string   `json:"action"`
+	CertificateIDs []string `json:"certificateIds,omitempty"`
+	DeviceIDs      []string `json:"deviceIds,omitempty"`
+	DeviceCount    int      `json:"deviceCount"`
+	AddedDevices   []string `json:"addedDevices,omitempty"`
+	RemovedDevices []string `json:"removedDevices,omitempty"`
+	File           string   `json:"file,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// ProfileRefreshResult represents CLI output for profiles refresh.
+type ProfileRefreshResult struct {
+	DryRun   bool                 `json:"dryRun"`
+	Profiles []ProfileRefreshItem `json:"profiles"`
+}
+
 func bundleIDsRows(resp *BundleIDsResponse) ([]string, [][]string) {
 	headers := []string{"ID", "Name", "Identifier", "Platform", "Seed ID"}
 	rows := make([][]string, 0, len(resp.Data))
@@ -92,6 +128,21 @@ func certificatesRows(resp *CertificatesResponse) ([]string, [][]string) {
 	return headers, rows
 }
 
+func certificateCreateResultRows(result *CertificateCreateResult) ([]string, [][]string) {
+	headers := []string{"ID", "Name", "Type", "Serial", "Expiration", "Key File", "Certificate File", "P12 File"}
+	rows := [][]string{{
+		result.ID,
+		result.Name,
+		result.CertificateType,
+		result.SerialNumber,
+		result.ExpirationDate,
+		result.KeyFile,
+		result.CertificateFile,
+		result.P12File,
+	}}
+	return headers, rows
+}
+
 func certificateRevokeResultRows(result *CertificateRevokeResult) ([]string, [][]string) {
 	headers := []string{"ID", "Revoked"}
 	rows := [][]string{{result.ID, fmt.Sprintf("%t", result.Revoked)}}
@@ -138,19 +189,91 @@ func joinSigningList(values []string) string {
 
 func signingFetchResultRows(result *SigningFetchResult) ([]string, [][]string) {
 	headers := []string{"Bundle ID", "Bundle ID Resource", "Profile Type", "Profile ID", "Profile File", "Certificate IDs", "Certificate Files", "Created"}
+	profiles := result.Profiles
+	if len(profiles) == 0 {
+		profiles = []SigningFetchProfile{{
+			BundleID:         result.BundleID,
+			BundleIDResource: result.BundleIDResource,
+			ProfileID:        result.ProfileID,
+			ProfileFile:      result.ProfileFile,
+			Created:          result.Created,
+		}}
+	}
+	rows := make([][]string, 0, len(profiles))
+	for _, profile := range profiles {
+		rows = append(rows, []string{
+			profile.BundleID,
+			profile.BundleIDResource,
+			result.ProfileType,
+			profile.ProfileID,
+			profile.ProfileFile,
+			joinSigningList(result.CertificateIDs),
+			joinSigningList(result.CertificateFiles),
+			fmt.Sprintf("%t", profile.Created),
+		})
+	}
+	return headers, rows
+}
+
+func signingSyncResultRows(result *SigningSyncResult) ([]string, [][]string) {
+	headers := []string{"Bundle ID", "Profile Type", "Certificate ID", "P12 File", "Profile ID", "Profile File", "Changes", "Commit"}
 	rows := [][]string{{
 		result.BundleID,
-		result.BundleIDResource,
 		result.ProfileType,
+		result.CertificateID,
+		result.P12File,
 		result.ProfileID,
 		result.ProfileFile,
-		joinSigningList(result.CertificateIDs),
-		joinSigningList(result.CertificateFiles),
-		fmt.Sprintf("%t", result.Created),
+		joinSigningList(result.Changes),
+		result.Commit,
 	}}
 	return headers, rows
 }
 
+func profileRefreshResultRows(result *ProfileRefreshResult) ([]string, [][]string) {
+	headers := []string{"Name", "Type", "Bundle ID", "Action", "Devices", "Added", "Removed", "Old ID", "New ID", "File", "Error"}
+	rows := make([][]string, 0, len(result.Profiles))
+	for _, item := range result.Profiles {
+		rows = append(rows, []string{
+			compactWhitespace(item.Name),
+			item.ProfileType,
+			item.BundleID,
+			item.Action,
+			fmt.Sprintf("%d", item.DeviceCount),
+			joinSigningList(item.AddedDevices),
+			joinSigningList(item.RemovedDevices),
+			item.OldProfileID,
+			item.NewProfileID,
+			item.File,
+			compactWhitespace(item.Error),
+		})
+	}
+	return headers, rows
+}
+
+func signingAuditResultRows(result *SigningAuditResult) ([]string, [][]string) {
+	headers := []string{"Kind", "Issue", "Name", "Identifier", "Type", "Expires", "Days Left", "Detail", "ID"}
+	rows := make([][]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		daysRemaining := ""
+		if item.DaysRemaining != nil {
+			daysRemaining = fmt.Sprintf("%d", *item.DaysRemaining)
+		}
+		rows = append(rows, []string{
+			item.Kind,
+			item.Issue,
+			compactWhitespace(item.Name),
+			item.Identifier,
+			item.Type,
+			item.ExpirationDate,
+			daysRemaining,
+			compactWhitespace(item.Detail),
+			item.ID,
+		})
+	}
+	return headers, rows
+}
+
 func formatCapabilitySettings(settings []CapabilitySetting) string {
 	if len(settings) == 0 {
 		return ""
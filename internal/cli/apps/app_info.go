@@ -218,6 +218,8 @@ func AppInfoSetCommand() *ffcli.Command {
 	marketingURL := fs.String("marketing-url", "", "Marketing URL")
 	promotionalText := fs.String("promotional-text", "", "Promotional text")
 	whatsNew := fs.String("whats-new", "", "What's New text")
+	whatsNewFromGit := fs.String("whats-new-from-git", "", "Generate What's New text from a git revision range (e.g., v1.2.0..HEAD)")
+	gitNotes := shared.BindGitNotesFlags(fs)
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -229,7 +231,8 @@ func AppInfoSetCommand() *ffcli.Command {
 
 Examples:
   asc app-info set --app "APP_ID" --locale "en-US" --whats-new "Bug fixes"
-  asc app-info set --app "APP_ID" --version "1.2.3" --platform IOS --locale "en-US" --description "New release"`,
+  asc app-info set --app "APP_ID" --version "1.2.3" --platform IOS --locale "en-US" --description "New release"
+  asc app-info set --app "APP_ID" --version "1.2.3" --platform IOS --locale "en-US" --whats-new-from-git "v1.2.2..v1.2.3" --git-trailers-only`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -271,6 +274,17 @@ Examples:
 			marketingURLValue := strings.TrimSpace(*marketingURL)
 			promotionalTextValue := strings.TrimSpace(*promotionalText)
 			whatsNewValue := strings.TrimSpace(*whatsNew)
+			if gitRange := strings.TrimSpace(*whatsNewFromGit); gitRange != "" {
+				if whatsNewValue != "" {
+					fmt.Fprintln(os.Stderr, "Error: --whats-new and --whats-new-from-git are mutually exclusive")
+					return flag.ErrHelp
+				}
+				generated, err := shared.GenerateGitNotes(ctx, gitNotes.Options(gitRange))
+				if err != nil {
+					return fmt.Errorf("app-info set: %w", err)
+				}
+				whatsNewValue = generated.Text
+			}
 			if descriptionValue == "" &&
 				keywordsValue == "" &&
 				supportURLValue == "" &&
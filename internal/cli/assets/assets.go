@@ -21,12 +21,18 @@ func AssetsCommand() *ffcli.Command {
 Examples:
   asc assets screenshots list --version-localization "LOC_ID"
   asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65"
-  asc assets previews upload --version-localization "LOC_ID" --path "./previews" --device-type "IPHONE_65"`,
+  asc assets previews upload --version-localization "LOC_ID" --path "./previews" --device-type "IPHONE_65"
+  asc assets validate --path "./screenshots" --device-type "IPHONE_65"
+  asc assets status --version-id "VERSION_ID"
+  asc assets download --version-id "VERSION_ID" --dir "./backup"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			AssetsScreenshotsCommand(),
 			AssetsPreviewsCommand(),
+			AssetsValidateCommand(),
+			AssetsStatusCommand(),
+			AssetsDownloadCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
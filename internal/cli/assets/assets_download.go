@@ -0,0 +1,330 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const assetManifestFileName = "manifest.json"
+
+// assetManifest records what was downloaded so a backup can be verified or re-uploaded.
+type assetManifest struct {
+	VersionID     string                      `json:"versionId"`
+	DownloadedAt  string                      `json:"downloadedAt"`
+	Localizations []assetManifestLocalization `json:"localizations"`
+}
+
+type assetManifestLocalization struct {
+	Locale                string                                    `json:"locale"`
+	VersionLocalizationID string                                    `json:"versionLocalizationId"`
+	Metadata              asc.AppStoreVersionLocalizationAttributes `json:"metadata"`
+	ScreenshotSets        []assetManifestSet                        `json:"screenshotSets,omitempty"`
+	PreviewSets           []assetManifestSet                        `json:"previewSets,omitempty"`
+}
+
+type assetManifestSet struct {
+	SetID       string               `json:"setId"`
+	DisplayType string               `json:"displayType"`
+	Directory   string               `json:"directory"`
+	Assets      []assetManifestAsset `json:"assets"`
+}
+
+// assetManifestAsset describes a downloaded file; Position preserves the set ordering.
+type assetManifestAsset struct {
+	Position           int    `json:"position"`
+	AssetID            string `json:"assetId"`
+	FileName           string `json:"fileName"`
+	Path               string `json:"path"`
+	Width              int    `json:"width,omitempty"`
+	Height             int    `json:"height,omitempty"`
+	Bytes              int64  `json:"bytes"`
+	SHA256             string `json:"sha256"`
+	SourceFileChecksum string `json:"sourceFileChecksum,omitempty"`
+}
+
+// AssetsDownloadCommand returns the assets download subcommand.
+func AssetsDownloadCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("download", flag.ExitOnError)
+
+	versionID := fs.String("version-id", "", "App Store version ID")
+	dir := fs.String("dir", "", "Output directory for downloaded assets")
+	overwrite := fs.Bool("overwrite", false, "Overwrite existing files")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "download",
+		ShortUsage: "asc assets download --version-id \"VERSION_ID\" --dir \"./backup\" [flags]",
+		ShortHelp:  "Download all screenshots, previews, and localized metadata for a version.",
+		LongHelp: `Download every screenshot and app preview for every localization of a version.
+
+Screenshots are fetched at their original resolution. Files are written as
+<dir>/<locale>/<display-type>/<position>_<file-name>, the same layout accepted by
+"asc assets screenshots upload" and "asc assets previews upload" with --path.
+A manifest.json records localized metadata, set ordering, and SHA-256 checksums.
+
+Examples:
+  asc assets download --version-id "VERSION_ID" --dir "./backup"
+  asc assets download --version-id "VERSION_ID" --dir "./backup" --overwrite`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			id := strings.TrimSpace(*versionID)
+			if id == "" {
+				fmt.Fprintln(os.Stderr, "Error: --version-id is required")
+				return flag.ErrHelp
+			}
+			dirValue := strings.TrimSpace(*dir)
+			if dirValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --dir is required")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("assets download: %w", err)
+			}
+
+			requestCtx, cancel := contextWithAssetUploadTimeout(ctx)
+			defer cancel()
+
+			downloader := assetDownloader{client: client, dir: dirValue, overwrite: *overwrite}
+			result, err := downloader.run(requestCtx, id)
+			if err != nil {
+				return fmt.Errorf("assets download: %w", err)
+			}
+
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+type assetDownloader struct {
+	client    *asc.Client
+	dir       string
+	overwrite bool
+}
+
+func (d assetDownloader) run(ctx context.Context, versionID string) (*asc.AssetDownloadResult, error) {
+	localizations, err := d.client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch localizations: %w", err)
+	}
+
+	result := &asc.AssetDownloadResult{
+		VersionID: versionID,
+		OutputDir: d.dir,
+		Files:     []asc.AssetDownloadFile{},
+	}
+	manifest := assetManifest{
+		VersionID:     versionID,
+		DownloadedAt:  time.Now().UTC().Format(time.RFC3339),
+		Localizations: make([]assetManifestLocalization, 0, len(localizations.Data)),
+	}
+
+	for _, loc := range localizations.Data {
+		entry := assetManifestLocalization{
+			Locale:                loc.Attributes.Locale,
+			VersionLocalizationID: loc.ID,
+			Metadata:              loc.Attributes,
+		}
+
+		screenshotSets, err := d.client.GetAppScreenshotSets(ctx, loc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch screenshot sets for %s: %w", entry.Locale, err)
+		}
+		for _, set := range screenshotSets.Data {
+			screenshots, err := d.client.GetAppScreenshots(ctx, set.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch screenshots for set %s: %w", set.ID, err)
+			}
+			manifestSet := d.newSet(entry.Locale, set.ID, set.Attributes.ScreenshotDisplayType)
+			for index, screenshot := range screenshots.Data {
+				attrs := screenshot.Attributes
+				if attrs.ImageAsset == nil || strings.TrimSpace(attrs.ImageAsset.TemplateURL) == "" {
+					result.Skipped = append(result.Skipped, asc.AssetDownloadSkipped{Locale: entry.Locale, AssetID: screenshot.ID, Reason: "screenshot has no image asset (still processing?)"})
+					continue
+				}
+				format := strings.TrimPrefix(strings.ToLower(filepath.Ext(attrs.FileName)), ".")
+				asset, err := d.download(ctx, manifestSet, index, screenshot.ID, attrs.FileName, attrs.ImageAsset.OriginalURL(format))
+				if err != nil {
+					return nil, err
+				}
+				asset.Width = attrs.ImageAsset.Width
+				asset.Height = attrs.ImageAsset.Height
+				asset.SourceFileChecksum = attrs.SourceFileChecksum
+				manifestSet.Assets = append(manifestSet.Assets, asset)
+				result.Screenshots++
+				result.Files = append(result.Files, downloadFileEntry(entry.Locale, assetStatusTypeScreenshot, manifestSet, asset, d.dir))
+			}
+			entry.ScreenshotSets = append(entry.ScreenshotSets, manifestSet)
+		}
+
+		previewSets, err := d.client.GetAppPreviewSets(ctx, loc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preview sets for %s: %w", entry.Locale, err)
+		}
+		for _, set := range previewSets.Data {
+			previews, err := d.client.GetAppPreviews(ctx, set.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch previews for set %s: %w", set.ID, err)
+			}
+			manifestSet := d.newSet(entry.Locale, set.ID, set.Attributes.PreviewType)
+			for index, preview := range previews.Data {
+				attrs := preview.Attributes
+				if strings.TrimSpace(attrs.VideoURL) == "" {
+					result.Skipped = append(result.Skipped, asc.AssetDownloadSkipped{Locale: entry.Locale, AssetID: preview.ID, Reason: "preview has no video URL (still processing?)"})
+					continue
+				}
+				asset, err := d.download(ctx, manifestSet, index, preview.ID, attrs.FileName, attrs.VideoURL)
+				if err != nil {
+					return nil, err
+				}
+				asset.SourceFileChecksum = attrs.SourceFileChecksum
+				manifestSet.Assets = append(manifestSet.Assets, asset)
+				result.Previews++
+				result.Files = append(result.Files, downloadFileEntry(entry.Locale, assetStatusTypePreview, manifestSet, asset, d.dir))
+			}
+			entry.PreviewSets = append(entry.PreviewSets, manifestSet)
+		}
+
+		manifest.Localizations = append(manifest.Localizations, entry)
+	}
+
+	manifestPath := filepath.Join(d.dir, assetManifestFileName)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if _, _, err := writeDownloadedAsset(manifestPath, strings.NewReader(string(data)+"\n"), d.overwrite); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	result.ManifestPath = manifestPath
+
+	return result, nil
+}
+
+func (d assetDownloader) newSet(locale, setID, displayType string) assetManifestSet {
+	return assetManifestSet{
+		SetID:       setID,
+		DisplayType: displayType,
+		Directory:   filepath.ToSlash(filepath.Join(sanitizePathSegment(locale), sanitizePathSegment(displayType))),
+		Assets:      []assetManifestAsset{},
+	}
+}
+
+func (d assetDownloader) download(ctx context.Context, set assetManifestSet, index int, assetID, fileName, downloadURL string) (assetManifestAsset, error) {
+	name := fmt.Sprintf("%02d_%s", index+1, sanitizePathSegment(fileName))
+	relPath := filepath.Join(filepath.FromSlash(set.Directory), name)
+
+	download, err := d.client.DownloadAppMediaAsset(ctx, downloadURL)
+	if err != nil {
+		return assetManifestAsset{}, fmt.Errorf("failed to download %s: %w", assetID, err)
+	}
+	defer download.Body.Close()
+
+	written, checksum, err := writeDownloadedAsset(filepath.Join(d.dir, relPath), download.Body, d.overwrite)
+	if err != nil {
+		return assetManifestAsset{}, fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+
+	return assetManifestAsset{
+		Position: index + 1,
+		AssetID:  assetID,
+		FileName: fileName,
+		Path:     filepath.ToSlash(relPath),
+		Bytes:    written,
+		SHA256:   checksum,
+	}, nil
+}
+
+func downloadFileEntry(locale, assetType string, set assetManifestSet, asset assetManifestAsset, dir string) asc.AssetDownloadFile {
+	return asc.AssetDownloadFile{
+		Locale:      locale,
+		AssetType:   assetType,
+		DisplayType: set.DisplayType,
+		AssetID:     asset.AssetID,
+		Path:        filepath.Join(dir, filepath.FromSlash(asset.Path)),
+		Bytes:       asset.Bytes,
+	}
+}
+
+// writeDownloadedAsset writes reader to path and returns the byte count and SHA-256 checksum.
+// The data is streamed into a temporary file next to path and renamed into
+// place, so an interrupted download never leaves a truncated asset behind.
+func writeDownloadedAsset(path string, reader io.Reader, overwrite bool) (int64, string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, "", err
+	}
+	if info, err := os.Lstat(path); err == nil {
+		if !overwrite {
+			return 0, "", fmt.Errorf("output file already exists (use --overwrite): %s", path)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return 0, "", fmt.Errorf("refusing to overwrite symlink %q", path)
+		}
+		if info.IsDir() {
+			return 0, "", fmt.Errorf("output path %q is a directory", path)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return 0, "", err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return 0, "", err
+	}
+	tempName := file.Name()
+	committed := false
+	defer func() {
+		_ = file.Close()
+		if !committed {
+			_ = os.Remove(tempName)
+		}
+	}()
+
+	hasher := sha256.New()
+	written, err := io.Copy(file, io.TeeReader(reader, hasher))
+	if err != nil {
+		return 0, "", err
+	}
+	if err := file.Sync(); err != nil {
+		return 0, "", err
+	}
+	if err := file.Chmod(0o644); err != nil {
+		return 0, "", err
+	}
+	if err := file.Close(); err != nil {
+		return 0, "", err
+	}
+	if err := os.Rename(tempName, path); err != nil {
+		return 0, "", err
+	}
+	committed = true
+	return written, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// sanitizePathSegment keeps API-provided names from escaping the output directory.
+func sanitizePathSegment(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(value)
+	if value == "" || value == "." || value == ".." {
+		return "_"
+	}
+	return value
+}
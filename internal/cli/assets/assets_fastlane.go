@@ -0,0 +1,177 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// screenshotInferenceOrder decides which display type wins when several accept
+// the same pixel size, matching the types fastlane deliver picks for them.
+var screenshotInferenceOrder = []string{
+	"APP_IPHONE_67",
+	"APP_IPHONE_69",
+	"APP_IPHONE_65",
+	"APP_IPHONE_61",
+	"APP_IPHONE_58",
+	"APP_IPHONE_55",
+	"APP_IPHONE_47",
+	"APP_IPHONE_40",
+	"APP_IPHONE_35",
+	"APP_IPAD_PRO_3GEN_129",
+	"APP_IPAD_PRO_3GEN_11",
+	"APP_IPAD_PRO_129",
+	"APP_IPAD_105",
+	"APP_IPAD_97",
+	"APP_DESKTOP",
+	"APP_WATCH_ULTRA",
+	"APP_WATCH_SERIES_10",
+	"APP_WATCH_SERIES_7",
+	"APP_WATCH_SERIES_4",
+	"APP_WATCH_SERIES_3",
+	"APP_APPLE_TV",
+	"APP_APPLE_VISION_PRO",
+}
+
+// ScreenshotUploadOptions controls UploadScreenshotFiles.
+type ScreenshotUploadOptions struct {
+	// SkipValidation disables local size and format checks.
+	SkipValidation bool
+	// Replace deletes screenshots already in the set before uploading.
+	Replace bool
+}
+
+// IsScreenshotFile reports whether path has a screenshot file extension.
+func IsScreenshotFile(path string) bool {
+	return slices.Contains(screenshotExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// InferScreenshotDisplayType returns the display type for a screenshot file.
+// A display type embedded in the file name (for example "APP_IPHONE_65_01.png")
+// takes precedence; otherwise the type is inferred from the image dimensions.
+func InferScreenshotDisplayType(path string) (string, error) {
+	name := strings.ToUpper(filepath.Base(path))
+	match := ""
+	for displayType := range screenshotSizeSpecs {
+		if strings.Contains(name, displayType) && len(displayType) > len(match) {
+			match = displayType
+		}
+	}
+	if match != "" {
+		if strings.Contains(name, "IMESSAGE_"+match) {
+			return "IMESSAGE_" + match, nil
+		}
+		return match, nil
+	}
+
+	info, err := inspectImageFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, displayType := range screenshotInferenceOrder {
+		if screenshotSizeSpecs[displayType].accepts(info.Width, info.Height) {
+			return displayType, nil
+		}
+	}
+	return "", fmt.Errorf("%s: no display type accepts %dx%d", filepath.Base(path), info.Width, info.Height)
+}
+
+// ContextWithUploadTimeout returns a context using the asset upload timeout.
+func ContextWithUploadTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return contextWithAssetUploadTimeout(ctx)
+}
+
+// UploadScreenshotFiles uploads files into the localization's screenshot set for
+// displayType, creating the set when needed.
+func UploadScreenshotFiles(ctx context.Context, client *asc.Client, localizationID, displayType string, files []string, opts ScreenshotUploadOptions) (*asc.AppScreenshotUploadResult, error) {
+	displayType, err := normalizeScreenshotDisplayType(displayType)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.SkipValidation {
+		if err := validateBeforeUpload(assetKindScreenshots, displayType, files); err != nil {
+			return nil, err
+		}
+	}
+
+	set, err := ensureScreenshotSet(ctx, client, localizationID, displayType)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Replace {
+		existing, err := client.GetAppScreenshots(ctx, set.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch screenshots for set %s: %w", set.ID, err)
+		}
+		for _, screenshot := range existing.Data {
+			if err := client.DeleteAppScreenshot(ctx, screenshot.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete screenshot %s: %w", screenshot.ID, err)
+			}
+		}
+	}
+
+	uploadOpts := assetUploadOptions{Wait: true, PollInterval: assetPollInterval, MaxRetries: assetDefaultMaxRetries}
+	results := make([]asc.AssetUploadResultItem, 0, len(files))
+	for _, filePath := range files {
+		item, err := uploadScreenshotAsset(ctx, client, set.ID, filePath, uploadOpts)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+
+	return &asc.AppScreenshotUploadResult{
+		VersionLocalizationID: localizationID,
+		SetID:                 set.ID,
+		DisplayType:           set.Attributes.ScreenshotDisplayType,
+		Results:               results,
+	}, nil
+}
+
+// DownloadScreenshots writes every screenshot of a localization into dir using
+// "<DISPLAY_TYPE>_<NN>_<file name>" so InferScreenshotDisplayType can restore
+// the display type on upload. It returns the written paths.
+func DownloadScreenshots(ctx context.Context, client *asc.Client, localizationID, dir string, overwrite bool) ([]string, error) {
+	sets, err := client.GetAppScreenshotSets(ctx, localizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch screenshot sets: %w", err)
+	}
+	sort.SliceStable(sets.Data, func(i, j int) bool {
+		return sets.Data[i].Attributes.ScreenshotDisplayType < sets.Data[j].Attributes.ScreenshotDisplayType
+	})
+
+	var paths []string
+	for _, set := range sets.Data {
+		screenshots, err := client.GetAppScreenshots(ctx, set.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch screenshots for set %s: %w", set.ID, err)
+		}
+		for index, screenshot := range screenshots.Data {
+			attrs := screenshot.Attributes
+			if attrs.ImageAsset == nil || strings.TrimSpace(attrs.ImageAsset.TemplateURL) == "" {
+				continue
+			}
+			format := strings.TrimPrefix(strings.ToLower(filepath.Ext(attrs.FileName)), ".")
+			name := fmt.Sprintf("%s_%02d_%s", set.Attributes.ScreenshotDisplayType, index+1, sanitizePathSegment(attrs.FileName))
+			path := filepath.Join(dir, sanitizePathSegment(name))
+
+			download, err := client.DownloadAppMediaAsset(ctx, attrs.ImageAsset.OriginalURL(format))
+			if err != nil {
+				return nil, fmt.Errorf("failed to download %s: %w", screenshot.ID, err)
+			}
+			_, _, err = writeDownloadedAsset(path, download.Body, overwrite)
+			download.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
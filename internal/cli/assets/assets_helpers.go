@@ -10,13 +10,32 @@ import (
 	"time"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 )
 
 const (
 	assetUploadDefaultTimeout = 10 * time.Minute
 	assetPollInterval         = 2 * time.Second
+	assetDefaultMaxRetries    = 2
 )
 
+// assetUploadOptions controls delivery polling and retries for asset uploads.
+type assetUploadOptions struct {
+	Wait         bool
+	PollInterval time.Duration
+	MaxRetries   int
+}
+
+func validateAssetUploadOptions(opts assetUploadOptions) error {
+	if opts.PollInterval <= 0 {
+		return fmt.Errorf("--poll-interval must be greater than 0")
+	}
+	if opts.MaxRetries < 0 {
+		return fmt.Errorf("--max-retries must be 0 or greater")
+	}
+	return nil
+}
+
 func contextWithAssetUploadTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -63,8 +82,11 @@ func collectAssetFiles(path string) ([]string, error) {
 	return []string{path}, nil
 }
 
-func waitForAssetDeliveryState(ctx context.Context, assetID string, fetch func(context.Context) (*asc.AssetDeliveryState, error)) (string, error) {
-	ticker := time.NewTicker(assetPollInterval)
+func waitForAssetDeliveryState(ctx context.Context, assetID string, interval time.Duration, fetch func(context.Context) (*asc.AssetDeliveryState, error)) (string, error) {
+	if interval <= 0 {
+		interval = assetPollInterval
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	var lastState string
@@ -91,6 +113,52 @@ func waitForAssetDeliveryState(ctx context.Context, assetID string, fetch func(c
 	}
 }
 
+// uploadAssetWithRetry uploads an asset and, when waiting, deletes and re-uploads it
+// each time App Store Connect reports a FAILED delivery state, up to opts.MaxRetries times.
+func uploadAssetWithRetry(
+	ctx context.Context,
+	opts assetUploadOptions,
+	upload func(context.Context) (asc.AssetUploadResultItem, error),
+	wait func(context.Context, string) (string, error),
+	remove func(context.Context, string) error,
+) (asc.AssetUploadResultItem, error) {
+	for attempt := 1; ; attempt++ {
+		item, err := upload(ctx)
+		if err != nil {
+			return item, err
+		}
+		item.Attempts = attempt
+		if !opts.Wait {
+			return item, nil
+		}
+
+		state, err := wait(ctx, item.AssetID)
+		if state != "" {
+			item.State = state
+		}
+		if err == nil {
+			return item, nil
+		}
+		if !strings.EqualFold(state, "FAILED") || attempt > opts.MaxRetries {
+			return item, err
+		}
+
+		if shared.ProgressEnabled() {
+			fmt.Fprintf(os.Stderr, "Asset %s (%s) failed processing; re-uploading (retry %d/%d)\n", item.AssetID, item.FileName, attempt, opts.MaxRetries)
+		}
+		if err := remove(ctx, item.AssetID); err != nil {
+			return item, fmt.Errorf("failed to delete failed asset %s: %w", item.AssetID, err)
+		}
+	}
+}
+
+func deliveryStateValue(state *asc.AssetDeliveryState) string {
+	if state == nil {
+		return ""
+	}
+	return state.State
+}
+
 func formatAssetErrors(errors []asc.ErrorDetail) string {
 	if len(errors) == 0 {
 		return "unknown error"
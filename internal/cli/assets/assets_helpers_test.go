@@ -0,0 +1,182 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestUploadAssetWithRetryReuploadsFailedAssets(t *testing.T) {
+	uploads := 0
+	var deleted []string
+	states := []string{"FAILED", "COMPLETE"}
+
+	item, err := uploadAssetWithRetry(context.Background(),
+		assetUploadOptions{Wait: true, PollInterval: time.Millisecond, MaxRetries: 2},
+		func(context.Context) (asc.AssetUploadResultItem, error) {
+			uploads++
+			return asc.AssetUploadResultItem{FileName: "01.png", AssetID: fmt.Sprintf("asset-%d", uploads), State: "UPLOAD_COMPLETE"}, nil
+		},
+		func(_ context.Context, id string) (string, error) {
+			state := states[0]
+			states = states[1:]
+			if state == "FAILED" {
+				return state, errors.New("delivery failed")
+			}
+			return state, nil
+		},
+		func(_ context.Context, id string) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.AssetID != "asset-2" || item.State != "COMPLETE" || item.Attempts != 2 {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+	if len(deleted) != 1 || deleted[0] != "asset-1" {
+		t.Fatalf("expected failed asset-1 to be deleted, got %v", deleted)
+	}
+}
+
+func TestUploadAssetWithRetryStopsAfterMaxRetries(t *testing.T) {
+	uploads := 0
+	_, err := uploadAssetWithRetry(context.Background(),
+		assetUploadOptions{Wait: true, PollInterval: time.Millisecond, MaxRetries: 1},
+		func(context.Context) (asc.AssetUploadResultItem, error) {
+			uploads++
+			return asc.AssetUploadResultItem{AssetID: fmt.Sprintf("asset-%d", uploads)}, nil
+		},
+		func(context.Context, string) (string, error) {
+			return "FAILED", errors.New("delivery failed")
+		},
+		func(context.Context, string) error { return nil },
+	)
+	if err == nil || !strings.Contains(err.Error(), "delivery failed") {
+		t.Fatalf("expected delivery failure, got %v", err)
+	}
+	if uploads != 2 {
+		t.Fatalf("expected 2 upload attempts, got %d", uploads)
+	}
+}
+
+func TestUploadAssetWithRetrySkipsPollingWithNoWait(t *testing.T) {
+	item, err := uploadAssetWithRetry(context.Background(),
+		assetUploadOptions{PollInterval: time.Millisecond, MaxRetries: 2},
+		func(context.Context) (asc.AssetUploadResultItem, error) {
+			return asc.AssetUploadResultItem{AssetID: "asset-1", State: "UPLOAD_COMPLETE"}, nil
+		},
+		func(context.Context, string) (string, error) {
+			t.Fatal("wait should not be called with --no-wait")
+			return "", nil
+		},
+		func(context.Context, string) error {
+			t.Fatal("remove should not be called with --no-wait")
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.State != "UPLOAD_COMPLETE" || item.Attempts != 1 {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+}
+
+func TestSummarizeAssetStatuses(t *testing.T) {
+	items := []asc.AssetStatusItem{
+		{AssetID: "a", State: "COMPLETE"},
+		{AssetID: "b", State: "FAILED", Errors: []string{"IMAGE_INCORRECT_DIMENSIONS"}},
+		{AssetID: "c", State: "UPLOAD_COMPLETE"},
+		{AssetID: "d", State: ""},
+	}
+
+	result := summarizeAssetStatuses("VERSION_ID", items, false)
+	if result.Total != 4 || result.Complete != 1 || result.Failed != 1 || result.Pending != 2 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	if len(result.Assets) != 3 {
+		t.Fatalf("expected completed assets to be filtered, got %+v", result.Assets)
+	}
+
+	result = summarizeAssetStatuses("VERSION_ID", items, true)
+	if len(result.Assets) != 4 {
+		t.Fatalf("expected all assets with includeComplete, got %d", len(result.Assets))
+	}
+}
+
+func TestSanitizePathSegment(t *testing.T) {
+	tests := map[string]string{
+		"en-US":          "en-US",
+		"../../etc":      ".._.._etc",
+		"..":             "_",
+		"":               "_",
+		`shot\name.png`:  "shot_name.png",
+		"APP_IPHONE_65 ": "APP_IPHONE_65",
+	}
+	for input, want := range tests {
+		if got := sanitizePathSegment(input); got != want {
+			t.Errorf("sanitizePathSegment(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestWriteDownloadedAssetRespectsOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "en-US", "APP_IPHONE_65", "01_shot.png")
+
+	written, checksum, err := writeDownloadedAsset(path, strings.NewReader("abc"), false)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if written != 3 || checksum != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Fatalf("unexpected write result: %d %s", written, checksum)
+	}
+	if _, _, err := writeDownloadedAsset(path, strings.NewReader("abc"), false); err == nil {
+		t.Fatal("expected error when file exists without overwrite")
+	}
+	if _, _, err := writeDownloadedAsset(path, strings.NewReader("abcd"), true); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestWriteDownloadedAssetKeepsFileOnFailedDownload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "01_shot.png")
+	if _, _, err := writeDownloadedAsset(path, io.MultiReader(strings.NewReader("partial"), failingReader{}), false); err == nil {
+		t.Fatal("expected interrupted download to fail")
+	}
+	if _, err := os.Lstat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no partial file, got %v", err)
+	}
+
+	if _, _, err := writeDownloadedAsset(path, strings.NewReader("abc"), false); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, _, err := writeDownloadedAsset(path, io.MultiReader(strings.NewReader("partial"), failingReader{}), true); err == nil {
+		t.Fatal("expected interrupted overwrite to fail")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "abc" {
+		t.Fatalf("expected previous file to be kept, got %q (%v)", data, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected only the asset in %s, got %v (%v)", dir, entries, err)
+	}
+}
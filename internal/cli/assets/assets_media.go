@@ -0,0 +1,429 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register JPEG decoder for image.DecodeConfig
+	"io"
+	"math"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	imageFormatPNG  = "png"
+	imageFormatJPEG = "jpeg"
+
+	// maxMovieBoxSize caps how much of a moov box is read into memory.
+	maxMovieBoxSize = int64(64 * 1024 * 1024)
+)
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// imageInfo describes the properties of an image file relevant to App Store validation.
+type imageInfo struct {
+	Format     string
+	Width      int
+	Height     int
+	HasAlpha   bool
+	ColorSpace string
+}
+
+// videoInfo describes the properties of a video file relevant to App Store validation.
+type videoInfo struct {
+	Container string
+	Duration  time.Duration
+	Width     int
+	Height    int
+	FrameRate float64
+}
+
+// inspectImageFile reads the header of a PNG or JPEG file without decoding pixel data.
+func inspectImageFile(path string) (imageInfo, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return imageInfo{}, err
+	}
+	defer file.Close()
+
+	header := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return imageInfo{}, fmt.Errorf("unrecognized image format")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return imageInfo{}, err
+	}
+
+	switch {
+	case bytes.Equal(header, pngSignature):
+		return inspectPNG(file)
+	case header[0] == 0xff && header[1] == 0xd8:
+		return inspectJPEG(file)
+	default:
+		return imageInfo{}, fmt.Errorf("unrecognized image format (expected PNG or JPEG)")
+	}
+}
+
+// inspectPNG walks PNG chunks up to the first IDAT so transparency chunks are detected.
+func inspectPNG(r io.Reader) (imageInfo, error) {
+	if _, err := io.CopyN(io.Discard, r, int64(len(pngSignature))); err != nil {
+		return imageInfo{}, err
+	}
+
+	info := imageInfo{Format: imageFormatPNG}
+	sawHeader := false
+	chunkHeader := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunkHeader); err != nil {
+			if sawHeader && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+				return info, nil
+			}
+			return imageInfo{}, fmt.Errorf("invalid PNG: %w", err)
+		}
+		length := int64(binary.BigEndian.Uint32(chunkHeader[:4]))
+		chunkType := string(chunkHeader[4:8])
+
+		switch chunkType {
+		case "IHDR":
+			if length < 13 {
+				return imageInfo{}, fmt.Errorf("invalid PNG: short IHDR chunk")
+			}
+			data := make([]byte, 13)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return imageInfo{}, fmt.Errorf("invalid PNG: %w", err)
+			}
+			info.Width = int(binary.BigEndian.Uint32(data[0:4]))
+			info.Height = int(binary.BigEndian.Uint32(data[4:8]))
+			colorType := data[9]
+			switch colorType {
+			case 0, 4:
+				info.ColorSpace = "gray"
+			default:
+				info.ColorSpace = "rgb"
+			}
+			// Color types 4 (gray+alpha) and 6 (RGBA) carry an alpha channel.
+			info.HasAlpha = colorType == 4 || colorType == 6
+			sawHeader = true
+			if _, err := io.CopyN(io.Discard, r, length-13+4); err != nil {
+				return imageInfo{}, fmt.Errorf("invalid PNG: %w", err)
+			}
+			continue
+		case "tRNS":
+			info.HasAlpha = true
+		case "IDAT", "IEND":
+			if !sawHeader {
+				return imageInfo{}, fmt.Errorf("invalid PNG: missing IHDR chunk")
+			}
+			return info, nil
+		}
+
+		if _, err := io.CopyN(io.Discard, r, length+4); err != nil {
+			return imageInfo{}, fmt.Errorf("invalid PNG: %w", err)
+		}
+	}
+}
+
+func inspectJPEG(r io.Reader) (imageInfo, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return imageInfo{}, fmt.Errorf("invalid JPEG: %w", err)
+	}
+	if format != imageFormatJPEG {
+		return imageInfo{}, fmt.Errorf("unrecognized image format %q", format)
+	}
+
+	info := imageInfo{
+		Format: imageFormatJPEG,
+		Width:  cfg.Width,
+		Height: cfg.Height,
+	}
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		info.ColorSpace = "gray"
+	case color.CMYKModel:
+		info.ColorSpace = "cmyk"
+	default:
+		info.ColorSpace = "rgb"
+	}
+	return info, nil
+}
+
+// inspectVideoFile parses an MP4/MOV (ISO base media) container and reports
+// duration, display resolution, and average frame rate of the first video track.
+func inspectVideoFile(path string) (videoInfo, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return videoInfo{}, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return videoInfo{}, err
+	}
+
+	info := videoInfo{}
+	var moov []byte
+	offset := int64(0)
+	for offset < stat.Size() {
+		boxType, headerSize, boxSize, err := readBoxHeaderAt(file, offset, stat.Size())
+		if err != nil {
+			return videoInfo{}, err
+		}
+		switch boxType {
+		case "ftyp":
+			brand := make([]byte, 4)
+			if _, err := file.ReadAt(brand, offset+headerSize); err != nil {
+				return videoInfo{}, fmt.Errorf("invalid ftyp box: %w", err)
+			}
+			if string(brand) == "qt  " {
+				info.Container = "mov"
+			} else {
+				info.Container = "mp4"
+			}
+		case "moov":
+			payload := boxSize - headerSize
+			if payload > maxMovieBoxSize {
+				return videoInfo{}, fmt.Errorf("moov box too large (%d bytes)", payload)
+			}
+			moov = make([]byte, payload)
+			if _, err := file.ReadAt(moov, offset+headerSize); err != nil {
+				return videoInfo{}, fmt.Errorf("failed to read moov box: %w", err)
+			}
+		}
+		offset += boxSize
+	}
+
+	if info.Container == "" {
+		return videoInfo{}, fmt.Errorf("unrecognized video container (missing ftyp box)")
+	}
+	if moov == nil {
+		return videoInfo{}, fmt.Errorf("video is missing a moov box")
+	}
+	if err := parseMovieBox(moov, &info); err != nil {
+		return videoInfo{}, err
+	}
+	return info, nil
+}
+
+func readBoxHeaderAt(r io.ReaderAt, offset, limit int64) (string, int64, int64, error) {
+	header := make([]byte, 16)
+	n, err := r.ReadAt(header[:8], offset)
+	if n < 8 {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", 0, 0, fmt.Errorf("invalid box header at offset %d: %w", offset, err)
+	}
+	size := int64(binary.BigEndian.Uint32(header[0:4]))
+	boxType := string(header[4:8])
+	headerSize := int64(8)
+	switch size {
+	case 0:
+		size = limit - offset
+	case 1:
+		if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+			return "", 0, 0, fmt.Errorf("invalid extended box header at offset %d: %w", offset, err)
+		}
+		size = int64(binary.BigEndian.Uint64(header[8:16]))
+		headerSize = 16
+	}
+	if size < headerSize || offset+size > limit {
+		return "", 0, 0, fmt.Errorf("invalid %q box size %d at offset %d", boxType, size, offset)
+	}
+	return boxType, headerSize, size, nil
+}
+
+// eachBox iterates over the child boxes contained in data.
+func eachBox(data []byte, fn func(boxType string, payload []byte) error) error {
+	reader := bytes.NewReader(data)
+	offset := int64(0)
+	limit := int64(len(data))
+	for offset < limit {
+		boxType, headerSize, boxSize, err := readBoxHeaderAt(reader, offset, limit)
+		if err != nil {
+			return err
+		}
+		if err := fn(boxType, data[offset+headerSize:offset+boxSize]); err != nil {
+			return err
+		}
+		offset += boxSize
+	}
+	return nil
+}
+
+func parseMovieBox(moov []byte, info *videoInfo) error {
+	foundVideo := false
+	err := eachBox(moov, func(boxType string, payload []byte) error {
+		switch boxType {
+		case "mvhd":
+			timescale, duration, err := parseTimescaleAndDuration(payload, "mvhd")
+			if err != nil {
+				return err
+			}
+			if timescale > 0 {
+				info.Duration = scaledDuration(duration, timescale)
+			}
+		case "trak":
+			if foundVideo {
+				return nil
+			}
+			track, isVideo, err := parseTrackBox(payload)
+			if err != nil {
+				return err
+			}
+			if isVideo {
+				foundVideo = true
+				info.Width = track.Width
+				info.Height = track.Height
+				info.FrameRate = track.FrameRate
+				if info.Duration == 0 {
+					info.Duration = track.Duration
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !foundVideo {
+		return fmt.Errorf("video has no video track")
+	}
+	return nil
+}
+
+func parseTrackBox(trak []byte) (videoInfo, bool, error) {
+	track := videoInfo{}
+	isVideo := false
+	err := eachBox(trak, func(boxType string, payload []byte) error {
+		switch boxType {
+		case "tkhd":
+			width, height, err := parseTrackHeader(payload)
+			if err != nil {
+				return err
+			}
+			track.Width = width
+			track.Height = height
+		case "mdia":
+			return eachBox(payload, func(boxType string, payload []byte) error {
+				switch boxType {
+				case "hdlr":
+					if len(payload) < 12 {
+						return fmt.Errorf("invalid hdlr box")
+					}
+					isVideo = string(payload[8:12]) == "vide"
+				case "mdhd":
+					timescale, duration, err := parseTimescaleAndDuration(payload, "mdhd")
+					if err != nil {
+						return err
+					}
+					if timescale > 0 {
+						track.Duration = scaledDuration(duration, timescale)
+					}
+				case "minf":
+					return eachBox(payload, func(boxType string, payload []byte) error {
+						if boxType != "stbl" {
+							return nil
+						}
+						return eachBox(payload, func(boxType string, payload []byte) error {
+							if boxType != "stts" {
+								return nil
+							}
+							samples, err := parseSampleCount(payload)
+							if err != nil {
+								return err
+							}
+							if samples > 0 && track.Duration > 0 {
+								track.FrameRate = float64(samples) / track.Duration.Seconds()
+							}
+							return nil
+						})
+					})
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return track, isVideo, err
+}
+
+// parseTimescaleAndDuration reads the timescale and duration fields shared by mvhd and mdhd.
+func parseTimescaleAndDuration(payload []byte, name string) (uint32, uint64, error) {
+	if len(payload) < 4 {
+		return 0, 0, fmt.Errorf("invalid %s box", name)
+	}
+	switch payload[0] {
+	case 0:
+		if len(payload) < 20 {
+			return 0, 0, fmt.Errorf("invalid %s box", name)
+		}
+		return binary.BigEndian.Uint32(payload[12:16]), uint64(binary.BigEndian.Uint32(payload[16:20])), nil
+	case 1:
+		if len(payload) < 32 {
+			return 0, 0, fmt.Errorf("invalid %s box", name)
+		}
+		return binary.BigEndian.Uint32(payload[20:24]), binary.BigEndian.Uint64(payload[24:32]), nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported %s box version %d", name, payload[0])
+	}
+}
+
+// parseTrackHeader returns the display width and height of a track,
+// swapping them when the transformation matrix rotates the track by 90 degrees.
+func parseTrackHeader(payload []byte) (int, int, error) {
+	if len(payload) < 4 {
+		return 0, 0, fmt.Errorf("invalid tkhd box")
+	}
+	var matrixOffset int
+	switch payload[0] {
+	case 0:
+		matrixOffset = 40
+	case 1:
+		matrixOffset = 52
+	default:
+		return 0, 0, fmt.Errorf("unsupported tkhd box version %d", payload[0])
+	}
+	if len(payload) < matrixOffset+44 {
+		return 0, 0, fmt.Errorf("invalid tkhd box")
+	}
+	matrix := payload[matrixOffset : matrixOffset+36]
+	a := int32(binary.BigEndian.Uint32(matrix[0:4]))
+	d := int32(binary.BigEndian.Uint32(matrix[16:20]))
+	width := int(binary.BigEndian.Uint32(payload[matrixOffset+36:matrixOffset+40]) >> 16)
+	height := int(binary.BigEndian.Uint32(payload[matrixOffset+40:matrixOffset+44]) >> 16)
+	if a == 0 && d == 0 {
+		width, height = height, width
+	}
+	return width, height, nil
+}
+
+func parseSampleCount(payload []byte) (uint64, error) {
+	if len(payload) < 8 {
+		return 0, fmt.Errorf("invalid stts box")
+	}
+	entries := binary.BigEndian.Uint32(payload[4:8])
+	if uint64(len(payload)-8) < uint64(entries)*8 {
+		return 0, fmt.Errorf("invalid stts box")
+	}
+	var total uint64
+	for i := uint32(0); i < entries; i++ {
+		start := 8 + i*8
+		total += uint64(binary.BigEndian.Uint32(payload[start : start+4]))
+	}
+	return total, nil
+}
+
+func scaledDuration(value uint64, timescale uint32) time.Duration {
+	seconds := float64(value) / float64(timescale)
+	if seconds > math.MaxInt64/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -108,6 +109,10 @@ func AssetsPreviewsUploadCommand() *ffcli.Command {
 	localizationID := fs.String("version-localization", "", "App Store version localization ID")
 	path := fs.String("path", "", "Path to preview file or directory")
 	deviceType := fs.String("device-type", "", "Device type (e.g., IPHONE_65)")
+	skipValidation := fs.Bool("skip-validation", false, "Skip local file validation before upload")
+	noWait := fs.Bool("no-wait", false, "Return after committing uploads without waiting for delivery processing")
+	pollInterval := fs.Duration("poll-interval", assetPollInterval, "Polling interval for delivery state")
+	maxRetries := fs.Int("max-retries", assetDefaultMaxRetries, "Delete and re-upload assets that fail processing up to N times")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -117,9 +122,15 @@ func AssetsPreviewsUploadCommand() *ffcli.Command {
 		ShortHelp:  "Upload previews for a localization.",
 		LongHelp: `Upload previews for a localization.
 
+Files are validated locally before any upload is reserved. The command then
+polls each preview's delivery state until COMPLETE or FAILED and deletes and
+re-uploads failed previews up to --max-retries times. --no-wait returns as
+soon as the uploads are committed, without checking delivery.
+
 Examples:
   asc assets previews upload --version-localization "LOC_ID" --path "./previews" --device-type "IPHONE_65"
-  asc assets previews upload --version-localization "LOC_ID" --path "./previews/preview.mov" --device-type "IPHONE_65"`,
+  asc assets previews upload --version-localization "LOC_ID" --path "./previews/preview.mov" --device-type "IPHONE_65"
+  asc assets previews upload --version-localization "LOC_ID" --path "./previews" --device-type "IPHONE_65" --no-wait`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -144,10 +155,25 @@ Examples:
 				return fmt.Errorf("assets previews upload: %w", err)
 			}
 
+			opts := assetUploadOptions{
+				Wait:         !*noWait,
+				PollInterval: *pollInterval,
+				MaxRetries:   *maxRetries,
+			}
+			if err := validateAssetUploadOptions(opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+
 			files, err := collectAssetFiles(pathValue)
 			if err != nil {
 				return fmt.Errorf("assets previews upload: %w", err)
 			}
+			if !*skipValidation {
+				if err := validateBeforeUpload(assetKindPreviews, previewType, files); err != nil {
+					return fmt.Errorf("assets previews upload: %w", err)
+				}
+			}
 
 			client, err := shared.GetASCClient()
 			if err != nil {
@@ -164,7 +190,7 @@ Examples:
 
 			results := make([]asc.AssetUploadResultItem, 0, len(files))
 			for _, filePath := range files {
-				item, err := uploadPreviewAsset(requestCtx, client, set.ID, filePath)
+				item, err := uploadPreviewAsset(requestCtx, client, set.ID, filePath, opts)
 				if err != nil {
 					return fmt.Errorf("assets previews upload: %w", err)
 				}
@@ -264,7 +290,20 @@ func ensurePreviewSet(ctx context.Context, client *asc.Client, localizationID, p
 	return created.Data, nil
 }
 
-func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath string) (asc.AssetUploadResultItem, error) {
+func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath string, opts assetUploadOptions) (asc.AssetUploadResultItem, error) {
+	return uploadAssetWithRetry(ctx, opts,
+		func(ctx context.Context) (asc.AssetUploadResultItem, error) {
+			return commitPreviewAsset(ctx, client, setID, filePath)
+		},
+		func(ctx context.Context, id string) (string, error) {
+			return waitForPreviewDelivery(ctx, client, id, opts.PollInterval)
+		},
+		client.DeleteAppPreview,
+	)
+}
+
+// commitPreviewAsset reserves, uploads, and commits a single preview file.
+func commitPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath string) (asc.AssetUploadResultItem, error) {
 	if err := asc.ValidateImageFile(filePath); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
@@ -302,14 +341,11 @@ func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	if _, err := client.UpdateAppPreview(ctx, created.Data.ID, true, checksum.Hash); err != nil {
-		return asc.AssetUploadResultItem{}, err
-	}
-
-	state, err := waitForPreviewDelivery(ctx, client, created.Data.ID)
+	committed, err := client.UpdateAppPreview(ctx, created.Data.ID, true, checksum.Hash)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	state := deliveryStateValue(committed.Data.Attributes.AssetDeliveryState)
 
 	return asc.AssetUploadResultItem{
 		FileName: info.Name(),
@@ -334,8 +370,8 @@ func detectPreviewMimeType(path string) (string, error) {
 	return mimeType, nil
 }
 
-func waitForPreviewDelivery(ctx context.Context, client *asc.Client, previewID string) (string, error) {
-	return waitForAssetDeliveryState(ctx, previewID, func(ctx context.Context) (*asc.AssetDeliveryState, error) {
+func waitForPreviewDelivery(ctx context.Context, client *asc.Client, previewID string, interval time.Duration) (string, error) {
+	return waitForAssetDeliveryState(ctx, previewID, interval, func(ctx context.Context) (*asc.AssetDeliveryState, error) {
 		resp, err := client.GetAppPreview(ctx, previewID)
 		if err != nil {
 			return nil, err
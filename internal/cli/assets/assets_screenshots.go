@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -106,6 +107,10 @@ func AssetsScreenshotsUploadCommand() *ffcli.Command {
 	localizationID := fs.String("version-localization", "", "App Store version localization ID")
 	path := fs.String("path", "", "Path to screenshot file or directory")
 	deviceType := fs.String("device-type", "", "Device type (e.g., IPHONE_65)")
+	skipValidation := fs.Bool("skip-validation", false, "Skip local file validation before upload")
+	noWait := fs.Bool("no-wait", false, "Return after committing uploads without waiting for delivery processing")
+	pollInterval := fs.Duration("poll-interval", assetPollInterval, "Polling interval for delivery state")
+	maxRetries := fs.Int("max-retries", assetDefaultMaxRetries, "Delete and re-upload assets that fail processing up to N times")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -115,9 +120,16 @@ func AssetsScreenshotsUploadCommand() *ffcli.Command {
 		ShortHelp:  "Upload screenshots for a localization.",
 		LongHelp: `Upload screenshots for a localization.
 
+Files are validated locally before any upload is reserved. The command then
+polls each screenshot's delivery state until COMPLETE or FAILED and deletes and
+re-uploads failed screenshots up to --max-retries times. --no-wait returns as
+soon as the uploads are committed, without checking delivery.
+
 Examples:
   asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65"
-  asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots/en-US.png" --device-type "IPHONE_65"`,
+  asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots/en-US.png" --device-type "IPHONE_65"
+  asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65" --max-retries 3
+  asc assets screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65" --no-wait`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -142,10 +154,25 @@ Examples:
 				return fmt.Errorf("assets screenshots upload: %w", err)
 			}
 
+			opts := assetUploadOptions{
+				Wait:         !*noWait,
+				PollInterval: *pollInterval,
+				MaxRetries:   *maxRetries,
+			}
+			if err := validateAssetUploadOptions(opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+
 			files, err := collectAssetFiles(pathValue)
 			if err != nil {
 				return fmt.Errorf("assets screenshots upload: %w", err)
 			}
+			if !*skipValidation {
+				if err := validateBeforeUpload(assetKindScreenshots, displayType, files); err != nil {
+					return fmt.Errorf("assets screenshots upload: %w", err)
+				}
+			}
 
 			client, err := shared.GetASCClient()
 			if err != nil {
@@ -162,7 +189,7 @@ Examples:
 
 			results := make([]asc.AssetUploadResultItem, 0, len(files))
 			for _, filePath := range files {
-				item, err := uploadScreenshotAsset(requestCtx, client, set.ID, filePath)
+				item, err := uploadScreenshotAsset(requestCtx, client, set.ID, filePath, opts)
 				if err != nil {
 					return fmt.Errorf("assets screenshots upload: %w", err)
 				}
@@ -264,7 +291,20 @@ func ensureScreenshotSet(ctx context.Context, client *asc.Client, localizationID
 	return created.Data, nil
 }
 
-func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, filePath string) (asc.AssetUploadResultItem, error) {
+func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, filePath string, opts assetUploadOptions) (asc.AssetUploadResultItem, error) {
+	return uploadAssetWithRetry(ctx, opts,
+		func(ctx context.Context) (asc.AssetUploadResultItem, error) {
+			return commitScreenshotAsset(ctx, client, setID, filePath)
+		},
+		func(ctx context.Context, id string) (string, error) {
+			return waitForScreenshotDelivery(ctx, client, id, opts.PollInterval)
+		},
+		client.DeleteAppScreenshot,
+	)
+}
+
+// commitScreenshotAsset reserves, uploads, and commits a single screenshot file.
+func commitScreenshotAsset(ctx context.Context, client *asc.Client, setID, filePath string) (asc.AssetUploadResultItem, error) {
 	if err := asc.ValidateImageFile(filePath); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
@@ -297,14 +337,11 @@ func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, fileP
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	if _, err := client.UpdateAppScreenshot(ctx, created.Data.ID, true, checksum.Hash); err != nil {
-		return asc.AssetUploadResultItem{}, err
-	}
-
-	state, err := waitForScreenshotDelivery(ctx, client, created.Data.ID)
+	committed, err := client.UpdateAppScreenshot(ctx, created.Data.ID, true, checksum.Hash)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	state := deliveryStateValue(committed.Data.Attributes.AssetDeliveryState)
 
 	return asc.AssetUploadResultItem{
 		FileName: info.Name(),
@@ -314,8 +351,8 @@ func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, fileP
 	}, nil
 }
 
-func waitForScreenshotDelivery(ctx context.Context, client *asc.Client, screenshotID string) (string, error) {
-	return waitForAssetDeliveryState(ctx, screenshotID, func(ctx context.Context) (*asc.AssetDeliveryState, error) {
+func waitForScreenshotDelivery(ctx context.Context, client *asc.Client, screenshotID string, interval time.Duration) (string, error) {
+	return waitForAssetDeliveryState(ctx, screenshotID, interval, func(ctx context.Context) (*asc.AssetDeliveryState, error) {
 		resp, err := client.GetAppScreenshot(ctx, screenshotID)
 		if err != nil {
 			return nil, err
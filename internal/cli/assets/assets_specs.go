@@ -0,0 +1,132 @@
+package assets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// assetDimension is an accepted pixel size in its portrait (or native) orientation.
+type assetDimension struct {
+	Width  int
+	Height int
+}
+
+func (d assetDimension) String() string {
+	return fmt.Sprintf("%dx%d", d.Width, d.Height)
+}
+
+// assetSizeSpec lists the accepted sizes for a display or preview type.
+type assetSizeSpec struct {
+	Sizes []assetDimension
+	// Rotatable reports whether the transposed size (landscape) is also accepted.
+	Rotatable bool
+}
+
+// accepts reports whether width x height matches one of the accepted sizes.
+func (s assetSizeSpec) accepts(width, height int) bool {
+	for _, size := range s.Sizes {
+		if size.Width == width && size.Height == height {
+			return true
+		}
+		if s.Rotatable && size.Width == height && size.Height == width {
+			return true
+		}
+	}
+	return false
+}
+
+func (s assetSizeSpec) describe() string {
+	parts := make([]string, 0, len(s.Sizes)*2)
+	for _, size := range s.Sizes {
+		parts = append(parts, size.String())
+		if s.Rotatable && size.Width != size.Height {
+			parts = append(parts, assetDimension{Width: size.Height, Height: size.Width}.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+const (
+	previewMinDuration   = 15 * time.Second
+	previewMaxDuration   = 30 * time.Second
+	previewMaxFrameRate  = 30.0
+	frameRateTolerance   = 0.5
+	durationTolerance    = 500 * time.Millisecond
+	assetKindScreenshots = "screenshots"
+	assetKindPreviews    = "previews"
+)
+
+var (
+	screenshotExtensions = []string{".png", ".jpg", ".jpeg"}
+	previewExtensions    = []string{".mov", ".m4v", ".mp4"}
+)
+
+// screenshotSizeSpecs maps screenshot display types to the sizes App Store Connect accepts.
+var screenshotSizeSpecs = map[string]assetSizeSpec{
+	"APP_IPHONE_69": {Sizes: []assetDimension{{1320, 2868}, {1290, 2796}, {1260, 2736}}, Rotatable: true},
+	"APP_IPHONE_67": {Sizes: []assetDimension{{1290, 2796}, {1284, 2778}}, Rotatable: true},
+	"APP_IPHONE_65": {Sizes: []assetDimension{{1242, 2688}, {1284, 2778}}, Rotatable: true},
+	"APP_IPHONE_61": {Sizes: []assetDimension{{1179, 2556}, {1170, 2532}, {1206, 2622}}, Rotatable: true},
+	"APP_IPHONE_58": {Sizes: []assetDimension{{1125, 2436}, {1170, 2532}, {1080, 2340}}, Rotatable: true},
+	"APP_IPHONE_55": {Sizes: []assetDimension{{1242, 2208}}, Rotatable: true},
+	"APP_IPHONE_47": {Sizes: []assetDimension{{750, 1334}}, Rotatable: true},
+	"APP_IPHONE_40": {Sizes: []assetDimension{{640, 1136}, {640, 1096}}, Rotatable: true},
+	"APP_IPHONE_35": {Sizes: []assetDimension{{640, 960}, {640, 920}}, Rotatable: true},
+
+	"APP_IPAD_PRO_3GEN_129": {Sizes: []assetDimension{{2064, 2752}, {2048, 2732}}, Rotatable: true},
+	"APP_IPAD_PRO_3GEN_11":  {Sizes: []assetDimension{{1488, 2266}, {1668, 2420}, {1668, 2388}, {1640, 2360}}, Rotatable: true},
+	"APP_IPAD_PRO_129":      {Sizes: []assetDimension{{2048, 2732}}, Rotatable: true},
+	"APP_IPAD_105":          {Sizes: []assetDimension{{1668, 2224}}, Rotatable: true},
+	"APP_IPAD_97":           {Sizes: []assetDimension{{1536, 2048}, {1536, 2008}, {768, 1024}, {768, 1004}}, Rotatable: true},
+
+	"APP_DESKTOP": {Sizes: []assetDimension{{1280, 800}, {1440, 900}, {2560, 1600}, {2880, 1800}}},
+
+	"APP_WATCH_ULTRA":     {Sizes: []assetDimension{{422, 514}, {410, 502}}},
+	"APP_WATCH_SERIES_10": {Sizes: []assetDimension{{416, 496}}},
+	"APP_WATCH_SERIES_7":  {Sizes: []assetDimension{{396, 484}}},
+	"APP_WATCH_SERIES_4":  {Sizes: []assetDimension{{368, 448}}},
+	"APP_WATCH_SERIES_3":  {Sizes: []assetDimension{{312, 390}}},
+
+	"APP_APPLE_TV":         {Sizes: []assetDimension{{1920, 1080}, {3840, 2160}}},
+	"APP_APPLE_VISION_PRO": {Sizes: []assetDimension{{3840, 2160}}},
+}
+
+// previewSizeSpecs maps app preview types to the resolutions App Store Connect accepts.
+var previewSizeSpecs = map[string]assetSizeSpec{
+	"IPHONE_67": {Sizes: []assetDimension{{886, 1920}}, Rotatable: true},
+	"IPHONE_65": {Sizes: []assetDimension{{886, 1920}}, Rotatable: true},
+	"IPHONE_61": {Sizes: []assetDimension{{886, 1920}}, Rotatable: true},
+	"IPHONE_58": {Sizes: []assetDimension{{886, 1920}}, Rotatable: true},
+	"IPHONE_55": {Sizes: []assetDimension{{1080, 1920}}, Rotatable: true},
+	"IPHONE_47": {Sizes: []assetDimension{{750, 1334}}, Rotatable: true},
+	"IPHONE_40": {Sizes: []assetDimension{{1080, 1920}}, Rotatable: true},
+	"IPHONE_35": {Sizes: []assetDimension{{640, 960}}, Rotatable: true},
+
+	"IPAD_PRO_3GEN_129": {Sizes: []assetDimension{{1200, 1600}, {900, 1200}}, Rotatable: true},
+	"IPAD_PRO_3GEN_11":  {Sizes: []assetDimension{{1200, 1600}, {900, 1200}}, Rotatable: true},
+	"IPAD_PRO_129":      {Sizes: []assetDimension{{1200, 1600}, {900, 1200}}, Rotatable: true},
+	"IPAD_105":          {Sizes: []assetDimension{{1200, 1600}, {900, 1200}}, Rotatable: true},
+	"IPAD_97":           {Sizes: []assetDimension{{900, 1200}, {1200, 1600}}, Rotatable: true},
+
+	"DESKTOP":          {Sizes: []assetDimension{{1920, 1080}}},
+	"APPLE_TV":         {Sizes: []assetDimension{{1920, 1080}}},
+	"APPLE_VISION_PRO": {Sizes: []assetDimension{{3840, 2160}}},
+}
+
+// screenshotSizeSpec returns the accepted sizes for a screenshot display type.
+// iMessage display types share the sizes of their app counterparts.
+func screenshotSizeSpec(displayType string) (assetSizeSpec, bool) {
+	value := strings.ToUpper(strings.TrimSpace(displayType))
+	if strings.HasPrefix(value, "IMESSAGE_") {
+		value = strings.TrimPrefix(value, "IMESSAGE_")
+	}
+	spec, ok := screenshotSizeSpecs[value]
+	return spec, ok
+}
+
+// previewSizeSpec returns the accepted resolutions for an app preview type.
+func previewSizeSpec(previewType string) (assetSizeSpec, bool) {
+	spec, ok := previewSizeSpecs[strings.ToUpper(strings.TrimSpace(previewType))]
+	return spec, ok
+}
@@ -0,0 +1,156 @@
+package assets
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	assetStatusTypeScreenshot = "screenshot"
+	assetStatusTypePreview    = "preview"
+)
+
+// AssetsStatusCommand returns the assets status subcommand.
+func AssetsStatusCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+
+	versionID := fs.String("version-id", "", "App Store version ID")
+	all := fs.Bool("all", false, "Include assets that finished processing (default: only failed or pending)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "status",
+		ShortUsage: "asc assets status --version-id \"VERSION_ID\" [flags]",
+		ShortHelp:  "Report screenshots and previews that failed or are stuck in processing.",
+		LongHelp: `Report the delivery state of every screenshot and preview for a version.
+
+By default only assets whose delivery state is FAILED or not yet COMPLETE
+(for example AWAITING_UPLOAD or UPLOAD_COMPLETE) are listed.
+
+Examples:
+  asc assets status --version-id "VERSION_ID"
+  asc assets status --version-id "VERSION_ID" --all --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			id := strings.TrimSpace(*versionID)
+			if id == "" {
+				fmt.Fprintln(os.Stderr, "Error: --version-id is required")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("assets status: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			items, err := collectAssetStatuses(requestCtx, client, id)
+			if err != nil {
+				return fmt.Errorf("assets status: %w", err)
+			}
+
+			return shared.PrintOutput(summarizeAssetStatuses(id, items, *all), *output, *pretty)
+		},
+	}
+}
+
+func collectAssetStatuses(ctx context.Context, client *asc.Client, versionID string) ([]asc.AssetStatusItem, error) {
+	localizations, err := client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch localizations: %w", err)
+	}
+
+	var items []asc.AssetStatusItem
+	for _, loc := range localizations.Data {
+		locale := loc.Attributes.Locale
+
+		screenshotSets, err := client.GetAppScreenshotSets(ctx, loc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch screenshot sets for %s: %w", locale, err)
+		}
+		for _, set := range screenshotSets.Data {
+			screenshots, err := client.GetAppScreenshots(ctx, set.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch screenshots for set %s: %w", set.ID, err)
+			}
+			for _, screenshot := range screenshots.Data {
+				items = append(items, newAssetStatusItem(
+					locale, loc.ID, assetStatusTypeScreenshot, set.Attributes.ScreenshotDisplayType, set.ID,
+					screenshot.ID, screenshot.Attributes.FileName, screenshot.Attributes.AssetDeliveryState,
+				))
+			}
+		}
+
+		previewSets, err := client.GetAppPreviewSets(ctx, loc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preview sets for %s: %w", locale, err)
+		}
+		for _, set := range previewSets.Data {
+			previews, err := client.GetAppPreviews(ctx, set.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch previews for set %s: %w", set.ID, err)
+			}
+			for _, preview := range previews.Data {
+				items = append(items, newAssetStatusItem(
+					locale, loc.ID, assetStatusTypePreview, set.Attributes.PreviewType, set.ID,
+					preview.ID, preview.Attributes.FileName, preview.Attributes.AssetDeliveryState,
+				))
+			}
+		}
+	}
+	return items, nil
+}
+
+func newAssetStatusItem(locale, localizationID, assetType, displayType, setID, assetID, fileName string, state *asc.AssetDeliveryState) asc.AssetStatusItem {
+	item := asc.AssetStatusItem{
+		Locale:                locale,
+		VersionLocalizationID: localizationID,
+		AssetType:             assetType,
+		DisplayType:           displayType,
+		SetID:                 setID,
+		AssetID:               assetID,
+		FileName:              fileName,
+		State:                 deliveryStateValue(state),
+	}
+	if state != nil && len(state.Errors) > 0 {
+		item.Errors = []string{formatAssetErrors(state.Errors)}
+	}
+	return item
+}
+
+// summarizeAssetStatuses counts assets by delivery state and filters out
+// completed assets unless includeComplete is set.
+func summarizeAssetStatuses(versionID string, items []asc.AssetStatusItem, includeComplete bool) *asc.AssetStatusResult {
+	result := &asc.AssetStatusResult{
+		VersionID: versionID,
+		Total:     len(items),
+		Assets:    make([]asc.AssetStatusItem, 0, len(items)),
+	}
+	for _, item := range items {
+		switch strings.ToUpper(item.State) {
+		case "COMPLETE":
+			result.Complete++
+			if !includeComplete {
+				continue
+			}
+		case "FAILED":
+			result.Failed++
+		default:
+			result.Pending++
+		}
+		result.Assets = append(result.Assets, item)
+	}
+	return result
+}
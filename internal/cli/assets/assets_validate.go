@@ -0,0 +1,278 @@
+package assets
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// AssetsValidateCommand returns the assets validate subcommand.
+func AssetsValidateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+
+	path := fs.String("path", "", "Path to asset file or directory")
+	deviceType := fs.String("device-type", "", "Device type (e.g., IPHONE_65)")
+	assetType := fs.String("type", "", "Asset type: screenshots or previews (default: detected from file extensions)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown, junit")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "validate",
+		ShortUsage: "asc assets validate --path \"./screenshots\" --device-type \"IPHONE_65\" [flags]",
+		ShortHelp:  "Validate screenshots or previews locally before upload.",
+		LongHelp: `Validate screenshots or app previews locally without making any API calls.
+
+Screenshots are checked for file type (PNG/JPEG), accepted dimensions and
+orientation for the display type, alpha channels, and color space.
+Previews are checked for file type (MOV/M4V/MP4), resolution, duration
+(15-30 seconds), and frame rate (30 fps max).
+
+The command exits non-zero when any file fails validation.
+
+Examples:
+  asc assets validate --path "./screenshots" --device-type "IPHONE_65"
+  asc assets validate --path "./previews" --device-type "IPHONE_65" --type previews
+  asc assets validate --path "./screenshots" --device-type "IPAD_PRO_3GEN_129" --output junit > report.xml`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pathValue := strings.TrimSpace(*path)
+			if pathValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --path is required")
+				return flag.ErrHelp
+			}
+			deviceValue := strings.TrimSpace(*deviceType)
+			if deviceValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --device-type is required")
+				return flag.ErrHelp
+			}
+			kind := strings.ToLower(strings.TrimSpace(*assetType))
+			if kind != "" && kind != assetKindScreenshots && kind != assetKindPreviews {
+				fmt.Fprintf(os.Stderr, "Error: --type must be %q or %q\n", assetKindScreenshots, assetKindPreviews)
+				return flag.ErrHelp
+			}
+			format := strings.ToLower(strings.TrimSpace(*output))
+			if format == "junit" && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
+
+			files, err := collectAssetFiles(pathValue)
+			if err != nil {
+				return fmt.Errorf("assets validate: %w", err)
+			}
+			if kind == "" {
+				kind = detectAssetKind(files)
+			}
+
+			result, err := validateAssetFiles(kind, deviceValue, files)
+			if err != nil {
+				return fmt.Errorf("assets validate: %w", err)
+			}
+			result.Path = pathValue
+
+			if format == "junit" {
+				if err := assetValidationJUnitReport(result).WriteTo(os.Stdout); err != nil {
+					return fmt.Errorf("assets validate: %w", err)
+				}
+			} else if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+
+			if !result.Valid {
+				return shared.NewReportedError(fmt.Errorf("assets validate: %d file(s) failed validation", invalidFileCount(result)))
+			}
+			return nil
+		},
+	}
+}
+
+// detectAssetKind treats the input as previews only when every file has a video extension.
+func detectAssetKind(files []string) string {
+	for _, file := range files {
+		if !slices.Contains(previewExtensions, strings.ToLower(filepath.Ext(file))) {
+			return assetKindScreenshots
+		}
+	}
+	return assetKindPreviews
+}
+
+// validateAssetFiles validates each file against the accepted specs for the device type.
+func validateAssetFiles(kind, deviceType string, files []string) (*asc.AssetValidationResult, error) {
+	result := &asc.AssetValidationResult{
+		AssetType: kind,
+		Files:     make([]asc.AssetValidationFile, 0, len(files)),
+	}
+
+	switch kind {
+	case assetKindScreenshots:
+		displayType, err := normalizeScreenshotDisplayType(deviceType)
+		if err != nil {
+			return nil, err
+		}
+		spec, ok := screenshotSizeSpec(displayType)
+		if !ok {
+			return nil, fmt.Errorf("no size requirements known for display type %q", displayType)
+		}
+		result.DisplayType = displayType
+		for _, file := range files {
+			result.Files = append(result.Files, validateScreenshotFile(file, spec))
+		}
+	case assetKindPreviews:
+		previewType, err := normalizePreviewType(deviceType)
+		if err != nil {
+			return nil, err
+		}
+		spec, ok := previewSizeSpec(previewType)
+		if !ok {
+			return nil, fmt.Errorf("no size requirements known for preview type %q", previewType)
+		}
+		result.DisplayType = previewType
+		for _, file := range files {
+			result.Files = append(result.Files, validatePreviewFile(file, spec))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported asset type %q", kind)
+	}
+
+	for _, file := range result.Files {
+		result.ErrorCount += len(file.Errors)
+	}
+	result.Valid = result.ErrorCount == 0
+	return result, nil
+}
+
+func validateScreenshotFile(path string, spec assetSizeSpec) asc.AssetValidationFile {
+	item := asc.AssetValidationFile{
+		FileName: filepath.Base(path),
+		FilePath: path,
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if !slices.Contains(screenshotExtensions, ext) {
+		item.Errors = append(item.Errors, fmt.Sprintf("unsupported file type %q (expected %s)", ext, strings.Join(screenshotExtensions, ", ")))
+	}
+
+	info, err := inspectImageFile(path)
+	if err != nil {
+		item.Errors = append(item.Errors, err.Error())
+		return item
+	}
+	item.Format = info.Format
+	item.Width = info.Width
+	item.Height = info.Height
+
+	if !spec.accepts(info.Width, info.Height) {
+		item.Errors = append(item.Errors, fmt.Sprintf("dimensions %dx%d not accepted (expected one of %s)", info.Width, info.Height, spec.describe()))
+	}
+	if info.HasAlpha {
+		item.Errors = append(item.Errors, "image has an alpha channel or transparency")
+	}
+	if info.ColorSpace != "rgb" {
+		item.Errors = append(item.Errors, fmt.Sprintf("unsupported color space %q (expected RGB)", info.ColorSpace))
+	}
+
+	item.Valid = len(item.Errors) == 0
+	return item
+}
+
+func validatePreviewFile(path string, spec assetSizeSpec) asc.AssetValidationFile {
+	item := asc.AssetValidationFile{
+		FileName: filepath.Base(path),
+		FilePath: path,
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if !slices.Contains(previewExtensions, ext) {
+		item.Errors = append(item.Errors, fmt.Sprintf("unsupported file type %q (expected %s)", ext, strings.Join(previewExtensions, ", ")))
+	}
+
+	info, err := inspectVideoFile(path)
+	if err != nil {
+		item.Errors = append(item.Errors, err.Error())
+		return item
+	}
+	item.Format = info.Container
+	item.Width = info.Width
+	item.Height = info.Height
+	item.DurationSeconds = roundTo(info.Duration.Seconds(), 2)
+	item.FrameRate = roundTo(info.FrameRate, 2)
+
+	if !spec.accepts(info.Width, info.Height) {
+		item.Errors = append(item.Errors, fmt.Sprintf("resolution %dx%d not accepted (expected one of %s)", info.Width, info.Height, spec.describe()))
+	}
+	if info.Duration < previewMinDuration-durationTolerance || info.Duration > previewMaxDuration+durationTolerance {
+		item.Errors = append(item.Errors, fmt.Sprintf("duration %.2fs outside accepted range (%s-%s)", info.Duration.Seconds(), previewMinDuration, previewMaxDuration))
+	}
+	if info.FrameRate > previewMaxFrameRate+frameRateTolerance {
+		item.Errors = append(item.Errors, fmt.Sprintf("frame rate %.2f fps exceeds %.0f fps", info.FrameRate, previewMaxFrameRate))
+	}
+
+	item.Valid = len(item.Errors) == 0
+	return item
+}
+
+// validateBeforeUpload fails fast when local files would be rejected after reservation.
+func validateBeforeUpload(kind, deviceType string, files []string) error {
+	result, err := validateAssetFiles(kind, deviceType, files)
+	if err != nil {
+		return err
+	}
+	if result.Valid {
+		return nil
+	}
+	failures := make([]string, 0, len(result.Files))
+	for _, file := range result.Files {
+		if len(file.Errors) == 0 {
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", file.FileName, strings.Join(file.Errors, "; ")))
+	}
+	return fmt.Errorf("validation failed for %d file(s) (use --skip-validation to upload anyway):\n  %s", len(failures), strings.Join(failures, "\n  "))
+}
+
+func assetValidationJUnitReport(result *asc.AssetValidationResult) *shared.JUnitReport {
+	classname := fmt.Sprintf("assets.%s.%s", result.AssetType, result.DisplayType)
+	report := &shared.JUnitReport{
+		Name:      "asc assets validate",
+		Timestamp: time.Now(),
+		Tests:     make([]shared.JUnitTestCase, 0, len(result.Files)),
+	}
+	for _, file := range result.Files {
+		testCase := shared.JUnitTestCase{
+			Name:      file.FilePath,
+			Classname: classname,
+		}
+		if len(file.Errors) > 0 {
+			testCase.Failure = "VALIDATION"
+			testCase.Message = strings.Join(file.Errors, "; ")
+		}
+		report.Tests = append(report.Tests, testCase)
+	}
+	return report
+}
+
+func invalidFileCount(result *asc.AssetValidationResult) int {
+	count := 0
+	for _, file := range result.Files {
+		if !file.Valid {
+			count++
+		}
+	}
+	return count
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,250 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestPNG(t *testing.T, path string, width, height int, opaque bool) {
+	t.Helper()
+	var img image.Image
+	if opaque {
+		rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+		for i := 3; i < len(rgba.Pix); i += 4 {
+			rgba.Pix[i] = 0xff
+		}
+		img = rgba
+	} else {
+		nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+		nrgba.Set(0, 0, color.NRGBA{R: 1, A: 0x10})
+		img = nrgba
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write png: %v", err)
+	}
+}
+
+func writeTestJPEG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write jpeg: %v", err)
+	}
+}
+
+func testBox(boxType string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	out := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(out[0:4], uint32(8+len(body)))
+	copy(out[4:8], boxType)
+	return append(out, body...)
+}
+
+func u32(values ...uint32) []byte {
+	out := make([]byte, 4*len(values))
+	for i, value := range values {
+		binary.BigEndian.PutUint32(out[i*4:], value)
+	}
+	return out
+}
+
+// buildTestMovie assembles a minimal ISO BMFF file with a single video track.
+func buildTestMovie(brand string, width, height int, seconds, fps uint32, rotated bool) []byte {
+	const timescale = 600
+
+	mvhd := testBox("mvhd", u32(0, 0, 0, timescale, seconds*timescale), make([]byte, 80))
+
+	matrix := u32(0x00010000, 0, 0, 0, 0x00010000, 0, 0, 0, 0x40000000)
+	if rotated {
+		matrix = u32(0, 0x00010000, 0, 0xffff0000, 0, 0, 0, 0, 0x40000000)
+	}
+	tkhdHead := u32(0, 0, 0, 1, 0, seconds*timescale, 0, 0, 0, 0)
+	tkhd := testBox("tkhd", tkhdHead, matrix, u32(uint32(width)<<16, uint32(height)<<16))
+
+	mdhd := testBox("mdhd", u32(0, 0, 0, timescale, seconds*timescale, 0))
+	hdlr := testBox("hdlr", u32(0, 0), []byte("vide"), make([]byte, 13))
+	stts := testBox("stts", u32(0, 1, seconds*fps, timescale/fps))
+	minf := testBox("minf", testBox("stbl", stts))
+	mdia := testBox("mdia", mdhd, hdlr, minf)
+	trak := testBox("trak", tkhd, mdia)
+
+	ftyp := testBox("ftyp", []byte(brand), u32(0), []byte(brand))
+	mdat := testBox("mdat", make([]byte, 32))
+	return bytes.Join([][]byte{ftyp, mdat, testBox("moov", mvhd, trak)}, nil)
+}
+
+func TestInspectImageFile(t *testing.T) {
+	dir := t.TempDir()
+
+	opaquePath := filepath.Join(dir, "opaque.png")
+	writeTestPNG(t, opaquePath, 40, 80, true)
+	info, err := inspectImageFile(opaquePath)
+	if err != nil {
+		t.Fatalf("inspect opaque png: %v", err)
+	}
+	if info.Format != imageFormatPNG || info.Width != 40 || info.Height != 80 || info.HasAlpha {
+		t.Fatalf("unexpected opaque png info: %+v", info)
+	}
+
+	alphaPath := filepath.Join(dir, "alpha.png")
+	writeTestPNG(t, alphaPath, 10, 10, false)
+	info, err = inspectImageFile(alphaPath)
+	if err != nil {
+		t.Fatalf("inspect alpha png: %v", err)
+	}
+	if !info.HasAlpha {
+		t.Fatalf("expected alpha to be detected, got %+v", info)
+	}
+
+	jpegPath := filepath.Join(dir, "photo.jpg")
+	writeTestJPEG(t, jpegPath, 64, 32)
+	info, err = inspectImageFile(jpegPath)
+	if err != nil {
+		t.Fatalf("inspect jpeg: %v", err)
+	}
+	if info.Format != imageFormatJPEG || info.Width != 64 || info.Height != 32 || info.ColorSpace != "rgb" {
+		t.Fatalf("unexpected jpeg info: %+v", info)
+	}
+
+	textPath := filepath.Join(dir, "notes.png")
+	if err := os.WriteFile(textPath, []byte("definitely not an image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inspectImageFile(textPath); err == nil {
+		t.Fatal("expected error for non-image file")
+	}
+}
+
+func TestInspectVideoFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "preview.mp4")
+	if err := os.WriteFile(path, buildTestMovie("isom", 886, 1920, 20, 30, false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := inspectVideoFile(path)
+	if err != nil {
+		t.Fatalf("inspect video: %v", err)
+	}
+	if info.Container != "mp4" || info.Width != 886 || info.Height != 1920 {
+		t.Fatalf("unexpected video info: %+v", info)
+	}
+	if info.Duration != 20*time.Second {
+		t.Fatalf("expected 20s duration, got %s", info.Duration)
+	}
+	if math.Abs(info.FrameRate-30) > 0.01 {
+		t.Fatalf("expected 30 fps, got %f", info.FrameRate)
+	}
+
+	rotatedPath := filepath.Join(dir, "rotated.mov")
+	if err := os.WriteFile(rotatedPath, buildTestMovie("qt  ", 1920, 886, 20, 30, true), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err = inspectVideoFile(rotatedPath)
+	if err != nil {
+		t.Fatalf("inspect rotated video: %v", err)
+	}
+	if info.Container != "mov" || info.Width != 886 || info.Height != 1920 {
+		t.Fatalf("expected rotated display size 886x1920 mov, got %+v", info)
+	}
+}
+
+func TestValidateAssetFilesScreenshots(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "01.png")
+	writeTestPNG(t, valid, 1242, 2688, true)
+	landscape := filepath.Join(dir, "02.png")
+	writeTestPNG(t, landscape, 2688, 1242, true)
+	wrongSize := filepath.Join(dir, "03.png")
+	writeTestPNG(t, wrongSize, 1000, 2000, true)
+	alpha := filepath.Join(dir, "04.png")
+	writeTestPNG(t, alpha, 1242, 2688, false)
+
+	result, err := validateAssetFiles(assetKindScreenshots, "IPHONE_65", []string{valid, landscape, wrongSize, alpha})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if result.Valid || result.DisplayType != "APP_IPHONE_65" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !result.Files[0].Valid || !result.Files[1].Valid {
+		t.Fatalf("expected portrait and landscape files to be valid: %+v", result.Files[:2])
+	}
+	if result.Files[2].Valid || !strings.Contains(strings.Join(result.Files[2].Errors, ";"), "dimensions 1000x2000") {
+		t.Fatalf("expected dimension error, got %+v", result.Files[2])
+	}
+	if result.Files[3].Valid || !strings.Contains(strings.Join(result.Files[3].Errors, ";"), "alpha") {
+		t.Fatalf("expected alpha error, got %+v", result.Files[3])
+	}
+	if err := validateBeforeUpload(assetKindScreenshots, "APP_IPHONE_65", []string{valid, wrongSize}); err == nil || !strings.Contains(err.Error(), "03.png") {
+		t.Fatalf("expected upload validation error naming 03.png, got %v", err)
+	}
+}
+
+func TestValidateAssetFilesWatchRejectsLandscape(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watch.png")
+	writeTestPNG(t, path, 496, 416, true)
+
+	result, err := validateAssetFiles(assetKindScreenshots, "WATCH_SERIES_10", []string{path})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if result.Valid {
+		t.Fatalf("expected landscape watch screenshot to be rejected: %+v", result.Files)
+	}
+}
+
+func TestValidateAssetFilesPreviews(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.mp4")
+	if err := os.WriteFile(valid, buildTestMovie("isom", 886, 1920, 20, 30, false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tooLong := filepath.Join(dir, "long.mov")
+	if err := os.WriteFile(tooLong, buildTestMovie("qt  ", 886, 1920, 45, 60, false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if kind := detectAssetKind([]string{valid, tooLong}); kind != assetKindPreviews {
+		t.Fatalf("expected previews kind, got %q", kind)
+	}
+
+	result, err := validateAssetFiles(assetKindPreviews, "IPHONE_65", []string{valid, tooLong})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !result.Files[0].Valid {
+		t.Fatalf("expected valid preview, got %+v", result.Files[0])
+	}
+	errs := strings.Join(result.Files[1].Errors, ";")
+	if !strings.Contains(errs, "duration") || !strings.Contains(errs, "frame rate") {
+		t.Fatalf("expected duration and frame rate errors, got %q", errs)
+	}
+
+	report := assetValidationJUnitReport(result)
+	data, err := report.MarshalXML()
+	if err != nil {
+		t.Fatalf("marshal junit: %v", err)
+	}
+	if !strings.Contains(string(data), `failures="1"`) {
+		t.Fatalf("expected one junit failure, got %s", data)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -28,7 +29,8 @@ Examples:
   asc builds test-notes get --id "LOCALIZATION_ID"
   asc builds test-notes create --build "BUILD_ID" --locale "en-US" --whats-new "Test instructions"
   asc builds test-notes update --id "LOCALIZATION_ID" --whats-new "Updated instructions"
-  asc builds test-notes delete --id "LOCALIZATION_ID" --confirm`,
+  asc builds test-notes delete --id "LOCALIZATION_ID" --confirm
+  asc builds test-notes generate --build "BUILD_ID" --from-git "v1.2.0..HEAD" --locale "en-US"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -37,6 +39,7 @@ Examples:
 			BuildsTestNotesCreateCommand(),
 			BuildsTestNotesUpdateCommand(),
 			BuildsTestNotesDeleteCommand(),
+			BuildsTestNotesGenerateCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -341,3 +344,101 @@ Examples:
 		},
 	}
 }
+
+// BuildsTestNotesGenerateCommand returns the generate subcommand.
+func BuildsTestNotesGenerateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+
+	buildID := fs.String("build", "", "Build ID (required unless --dry-run)")
+	fromGit := fs.String("from-git", "", "Git revision range to collect commits from (e.g., v1.2.0..HEAD)")
+	locale := fs.String("locale", "", "Locale(s), comma-separated (e.g., en-US,de-DE)")
+	gitNotes := shared.BindGitNotesFlags(fs)
+	dryRun := fs.Bool("dry-run", false, "Print the generated notes without writing them")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "generate",
+		ShortUsage: "asc builds test-notes generate [flags]",
+		ShortHelp:  "Generate What to Test notes from git history.",
+		LongHelp: `Generate What to Test notes from git history.
+
+Collects the non-merge commits in --from-git from the local repository. Each
+commit contributes its subject (without the conventional commit prefix), or the
+values of its --git-trailer trailers ("Release-Note: ..." by default). A trailer
+value of "none" leaves the commit out. Notes are rendered with --notes-template
+(a Go text/template receiving .Range and .Notes) and trimmed to
+--notes-max-length by dropping trailing lines.
+
+Examples:
+  asc builds test-notes generate --build "BUILD_ID" --from-git "v1.2.0..HEAD" --locale "en-US"
+  asc builds test-notes generate --build "BUILD_ID" --from-git "v1.2.0..HEAD" --locale "en-US,de-DE" --git-type feat,fix
+  asc builds test-notes generate --from-git "HEAD~20..HEAD" --git-path "App/" --git-trailers-only --locale "en-US" --dry-run
+  asc builds test-notes generate --build "BUILD_ID" --from-git "v1.2.0..v1.3.0" --notes-template "notes.tmpl" --locale "en-US"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			build := strings.TrimSpace(*buildID)
+			if build == "" && !*dryRun {
+				fmt.Fprintln(os.Stderr, "Error: --build is required")
+				return flag.ErrHelp
+			}
+			gitRange := strings.TrimSpace(*fromGit)
+			if gitRange == "" {
+				fmt.Fprintln(os.Stderr, "Error: --from-git is required")
+				return flag.ErrHelp
+			}
+			locales := shared.SplitCSV(*locale)
+			if len(locales) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --locale is required")
+				return flag.ErrHelp
+			}
+			if err := shared.ValidateBuildLocalizationLocales(locales); err != nil {
+				return fmt.Errorf("builds test-notes generate: %w", err)
+			}
+
+			generated, err := shared.GenerateGitNotes(ctx, gitNotes.Options(gitRange))
+			if err != nil {
+				return fmt.Errorf("builds test-notes generate: %w", err)
+			}
+
+			result := &asc.BuildTestNotesGenerateResult{
+				BuildID:   build,
+				Range:     gitRange,
+				Commits:   generated.Commits,
+				Length:    utf8.RuneCountInString(generated.Text),
+				Truncated: generated.Truncated,
+				DryRun:    *dryRun,
+				Notes:     generated.Text,
+				Locales:   make([]asc.BuildTestNotesLocaleResult, 0, len(locales)),
+			}
+			if *dryRun {
+				for _, localeValue := range locales {
+					result.Locales = append(result.Locales, asc.BuildTestNotesLocaleResult{Locale: localeValue})
+				}
+				return shared.PrintOutput(result, *output, *pretty)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("builds test-notes generate: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			for _, localeValue := range locales {
+				resp, err := shared.UpsertBetaBuildLocalization(requestCtx, client, build, localeValue, generated.Text)
+				if err != nil {
+					return fmt.Errorf("builds test-notes generate: %w", err)
+				}
+				result.Locales = append(result.Locales, asc.BuildTestNotesLocaleResult{
+					Locale:         localeValue,
+					LocalizationID: resp.Data.ID,
+				})
+			}
+
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
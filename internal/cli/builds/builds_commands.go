@@ -30,7 +30,9 @@ func BuildsUploadCommand() *ffcli.Command {
 	concurrency := fs.Int("concurrency", 1, "Upload concurrency (default 1)")
 	verifyChecksum := fs.Bool("checksum", false, "Verify upload checksums if provided by API")
 	testNotes := fs.String("test-notes", "", "What to Test notes (requires build processing)")
-	locale := fs.String("locale", "", "Locale for --test-notes (e.g., en-US)")
+	testNotesFromGit := fs.String("test-notes-from-git", "", "Generate What to Test notes from a git revision range (e.g., v1.2.0..HEAD)")
+	gitNotes := shared.BindGitNotesFlags(fs)
+	locale := fs.String("locale", "", "Locale(s) for test notes, comma-separated (e.g., en-US,de-DE)")
 	wait := fs.Bool("wait", false, "Wait for build processing to complete")
 	pollInterval := fs.Duration("poll-interval", shared.PublishDefaultPollInterval, "Polling interval for --wait and --test-notes")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
@@ -53,6 +55,7 @@ Examples:
   asc builds upload --ipa "app.ipa" --version "1.0.0" --build-number "123"
   asc builds upload --app "123456789" --ipa "app.ipa" --dry-run
   asc builds upload --app "123456789" --ipa "app.ipa" --test-notes "Test flow" --locale "en-US" --wait
+  asc builds upload --app "123456789" --ipa "app.ipa" --test-notes-from-git "v1.2.0..HEAD" --locale "en-US" --wait
   asc builds upload --app "123456789" --pkg "path/to/app.pkg" --version "1.0.0" --build-number "123"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -140,22 +143,38 @@ Examples:
 			}
 
 			testNotesValue := strings.TrimSpace(*testNotes)
-			localeValue := strings.TrimSpace(*locale)
-			if testNotesValue != "" && localeValue == "" {
+			gitRange := strings.TrimSpace(*testNotesFromGit)
+			locales := shared.SplitCSV(*locale)
+			if testNotesValue != "" && gitRange != "" {
+				fmt.Fprintln(os.Stderr, "Error: --test-notes and --test-notes-from-git are mutually exclusive")
+				return flag.ErrHelp
+			}
+			if testNotesValue != "" && len(locales) == 0 {
 				fmt.Fprintln(os.Stderr, "Error: --locale is required with --test-notes")
 				return flag.ErrHelp
 			}
-			if testNotesValue == "" && localeValue != "" {
+			if gitRange != "" && len(locales) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --locale is required with --test-notes-from-git")
+				return flag.ErrHelp
+			}
+			if testNotesValue == "" && gitRange == "" && len(locales) > 0 {
 				fmt.Fprintln(os.Stderr, "Error: --test-notes is required with --locale")
 				return flag.ErrHelp
 			}
-			if testNotesValue != "" {
+			if testNotesValue != "" || gitRange != "" {
 				if *dryRun {
 					return fmt.Errorf("builds upload: --test-notes is not supported with --dry-run")
 				}
-				if err := shared.ValidateBuildLocalizationLocale(localeValue); err != nil {
+				if err := shared.ValidateBuildLocalizationLocales(locales); err != nil {
+					return fmt.Errorf("builds upload: %w", err)
+				}
+			}
+			if gitRange != "" {
+				generated, err := shared.GenerateGitNotes(ctx, gitNotes.Options(gitRange))
+				if err != nil {
 					return fmt.Errorf("builds upload: %w", err)
 				}
+				testNotesValue = generated.Text
 			}
 			if (*wait || testNotesValue != "") && *pollInterval <= 0 {
 				return fmt.Errorf("builds upload: --poll-interval must be greater than 0")
@@ -350,8 +369,10 @@ Examples:
 					}
 
 					if testNotesValue != "" {
-						if _, err := shared.UpsertBetaBuildLocalization(requestCtx, client, buildResp.Data.ID, localeValue, testNotesValue); err != nil {
-							return fmt.Errorf("builds upload: %w", err)
+						for _, localeValue := range locales {
+							if _, err := shared.UpsertBetaBuildLocalization(requestCtx, client, buildResp.Data.ID, localeValue, testNotesValue); err != nil {
+								return fmt.Errorf("builds upload: %w", err)
+							}
 						}
 					}
 				}
@@ -383,6 +404,7 @@ Examples:
   asc builds info --build "BUILD_ID"
   asc builds expire --build "BUILD_ID"
   asc builds expire-all --app "123456789" --older-than 90d --dry-run
+  asc builds retention apply --app "123456789" --policy retention.yaml --dry-run
   asc builds upload --app "123456789" --ipa "app.ipa"
   asc builds upload --app "123456789" --pkg "app.pkg" --version "1.0.0" --build-number "1"
   asc builds uploads list --app "123456789"
@@ -405,6 +427,7 @@ Examples:
 			BuildsInfoCommand(),
 			BuildsExpireCommand(),
 			BuildsExpireAllCommand(),
+			BuildsRetentionCommand(),
 			BuildsUploadCommand(),
 			BuildsUploadsCommand(),
 			BuildsTestNotesCommand(),
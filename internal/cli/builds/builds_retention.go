@@ -0,0 +1,619 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	retentionActionKeep    = "keep"
+	retentionActionExpire  = "expire"
+	retentionActionExpired = "expired"
+	retentionActionFailed  = "failed"
+)
+
+// retentionPolicy is the policy file format for builds retention apply.
+type retentionPolicy struct {
+	Rules []retentionRule `yaml:"rules"`
+}
+
+// retentionRule keeps or expires the builds matched by every condition in Match.
+type retentionRule struct {
+	Name   string         `yaml:"name"`
+	Action string         `yaml:"action"`
+	Match  retentionMatch `yaml:"match"`
+}
+
+type retentionMatch struct {
+	OlderThan                 string   `yaml:"olderThan,omitempty"`
+	Latest                    int      `yaml:"latest,omitempty"`
+	LatestPerVersion          int      `yaml:"latestPerVersion,omitempty"`
+	AttachedToAppStoreVersion *bool    `yaml:"attachedToAppStoreVersion,omitempty"`
+	InExternalGroup           *bool    `yaml:"inExternalGroup,omitempty"`
+	InAnyGroup                *bool    `yaml:"inAnyGroup,omitempty"`
+	Groups                    []string `yaml:"groups,omitempty"`
+	Versions                  []string `yaml:"versions,omitempty"`
+
+	olderThan time.Time
+}
+
+type retentionGroup struct {
+	name     string
+	internal bool
+}
+
+type retentionBuild struct {
+	resource          asc.Resource[asc.BuildAttributes]
+	uploadedAt        time.Time
+	ageDays           int
+	version           string
+	platform          string
+	appStoreVersionID string
+	groups            []retentionGroup
+	rank              int
+	rankInVersion     int
+}
+
+// BuildsRetentionCommand returns the builds retention command group.
+func BuildsRetentionCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("retention", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "retention",
+		ShortUsage: "asc builds retention <subcommand> [flags]",
+		ShortHelp:  "Expire TestFlight builds with a retention policy.",
+		LongHelp: `Expire TestFlight builds with a retention policy.
+
+Examples:
+  asc builds retention apply --app "123456789" --policy retention.yaml --dry-run
+  asc builds retention apply --app "123456789" --policy retention.yaml --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			BuildsRetentionApplyCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// BuildsRetentionApplyCommand returns the retention apply subcommand.
+func BuildsRetentionApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("builds retention apply", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (required, or ASC_APP_ID env)")
+	policyPath := fs.String("policy", "", "Retention policy file (YAML or JSON)")
+	dryRun := fs.Bool("dry-run", false, "Report builds that would be expired without expiring")
+	confirm := fs.Bool("confirm", false, "Confirm expiration (required unless --dry-run)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown, junit")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc builds retention apply --policy FILE [flags]",
+		ShortHelp:  "Evaluate a retention policy and expire matching builds.",
+		LongHelp: `Evaluate a retention policy and expire matching builds.
+
+A build is expired when an "expire" rule matches it and no "keep" rule does.
+Each rule matches builds that satisfy all of its conditions:
+
+  olderThan                  uploaded before a duration (60d, 2w, 3m) or date
+  latest                     among the N most recent builds
+  latestPerVersion           among the N most recent builds of its pre-release version
+  attachedToAppStoreVersion  attached (true) or not attached (false) to an App Store version
+  inExternalGroup            in (true) or not in (false) any external beta group
+  inAnyGroup                 in (true) or not in (false) any beta group
+  groups                     in any of the named beta groups
+  versions                   pre-release version string is one of the listed versions
+
+Policy file:
+  rules:
+    - name: keep-latest-5-per-version
+      action: keep
+      match:
+        latestPerVersion: 5
+    - name: keep-app-store-builds
+      action: keep
+      match:
+        attachedToAppStoreVersion: true
+    - name: expire-stale-internal
+      action: expire
+      match:
+        olderThan: 60d
+        inExternalGroup: false
+
+Use --output junit, or the root --report junit --report-file flags, for a CI
+summary with one test case per selected build.
+
+Examples:
+  asc builds retention apply --app "123456789" --policy retention.yaml --dry-run
+  asc builds retention apply --app "123456789" --policy retention.yaml --dry-run --output table
+  asc builds retention apply --app "123456789" --policy retention.yaml --confirm
+  asc --report junit --report-file retention.xml builds retention apply --app "123456789" --policy retention.yaml --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			policyValue := strings.TrimSpace(*policyPath)
+			if policyValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --policy is required")
+				return flag.ErrHelp
+			}
+			if !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required to expire builds")
+				return flag.ErrHelp
+			}
+			outputFormat := strings.ToLower(strings.TrimSpace(*output))
+			if outputFormat == "junit" && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
+
+			now := time.Now().UTC()
+			policy, err := readRetentionPolicy(policyValue, now)
+			if err != nil {
+				return fmt.Errorf("builds retention apply: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("builds retention apply: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			builds, skippedExpired, err := fetchRetentionBuilds(requestCtx, client, resolvedAppID, now)
+			if err != nil {
+				return fmt.Errorf("builds retention apply: %w", err)
+			}
+
+			decisions := evaluateRetentionPolicy(policy, builds)
+			result := &asc.BuildRetentionResult{
+				AppID:          resolvedAppID,
+				Policy:         policyValue,
+				DryRun:         *dryRun,
+				Evaluated:      len(builds),
+				SkippedExpired: skippedExpired,
+				Decisions:      decisions,
+			}
+			for i := range result.Decisions {
+				decision := &result.Decisions[i]
+				if decision.Action == retentionActionKeep {
+					result.ProtectedCount++
+					continue
+				}
+				result.SelectedCount++
+				if *dryRun {
+					continue
+				}
+				expired := true
+				if _, err := client.ExpireBuild(requestCtx, decision.ID); err != nil {
+					expired = false
+					decision.Action = retentionActionFailed
+					decision.Error = err.Error()
+					result.FailedCount++
+				} else {
+					decision.Action = retentionActionExpired
+					result.ExpiredCount++
+				}
+				decision.Expired = &expired
+			}
+
+			report := buildRetentionJUnitReport(result)
+			if shared.ReportFormat() == shared.ReportFormatJUnit {
+				if err := report.Write(shared.ReportFile()); err != nil {
+					return fmt.Errorf("builds retention apply: %w", err)
+				}
+			}
+			if outputFormat == "junit" {
+				if err := report.WriteTo(os.Stdout); err != nil {
+					return fmt.Errorf("builds retention apply: %w", err)
+				}
+			} else if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+
+			if result.FailedCount > 0 {
+				return shared.NewReportedError(fmt.Errorf("builds retention apply: %d builds failed to expire", result.FailedCount))
+			}
+			return nil
+		},
+	}
+}
+
+// readRetentionPolicy parses and validates a policy file. YAML is a superset
+// of JSON, so both formats are accepted.
+func readRetentionPolicy(path string, now time.Time) (*retentionPolicy, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var policy retentionPolicy
+	if err := decoder.Decode(&policy); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("policy %s is empty", path)
+		}
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := validateRetentionPolicy(&policy, now); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+func validateRetentionPolicy(policy *retentionPolicy, now time.Time) error {
+	if len(policy.Rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+	hasExpire := false
+	names := map[string]bool{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		rule.Action = strings.ToLower(strings.TrimSpace(rule.Action))
+		switch rule.Action {
+		case retentionActionKeep:
+		case retentionActionExpire:
+			hasExpire = true
+		default:
+			return fmt.Errorf("rule %q: action must be %q or %q", rule.Name, retentionActionKeep, retentionActionExpire)
+		}
+
+		match := &rule.Match
+		if match.Latest < 0 || match.LatestPerVersion < 0 {
+			return fmt.Errorf("rule %q: latest and latestPerVersion must be greater than 0", rule.Name)
+		}
+		if strings.TrimSpace(match.OlderThan) != "" {
+			threshold, err := parseOlderThanThreshold(match.OlderThan, now)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			match.olderThan = threshold
+		}
+		if match.isEmpty() {
+			return fmt.Errorf("rule %q: match needs at least one condition", rule.Name)
+		}
+	}
+	if !hasExpire {
+		return fmt.Errorf("at least one %q rule is required", retentionActionExpire)
+	}
+	return nil
+}
+
+func (m retentionMatch) isEmpty() bool {
+	return m.olderThan.IsZero() &&
+		m.Latest == 0 &&
+		m.LatestPerVersion == 0 &&
+		m.AttachedToAppStoreVersion == nil &&
+		m.InExternalGroup == nil &&
+		m.InAnyGroup == nil &&
+		len(m.Groups) == 0 &&
+		len(m.Versions) == 0
+}
+
+// matches reports whether a build satisfies every condition, with a
+// description of the conditions that matched.
+func (m retentionMatch) matches(build retentionBuild) (bool, string) {
+	var reasons []string
+	if !m.olderThan.IsZero() {
+		if !build.uploadedAt.Before(m.olderThan) {
+			return false, ""
+		}
+		reasons = append(reasons, fmt.Sprintf("uploaded %d days ago (older than %s)", build.ageDays, m.OlderThan))
+	}
+	if m.Latest > 0 {
+		if build.rank >= m.Latest {
+			return false, ""
+		}
+		reasons = append(reasons, fmt.Sprintf("#%d most recent build", build.rank+1))
+	}
+	if m.LatestPerVersion > 0 {
+		if build.rankInVersion >= m.LatestPerVersion {
+			return false, ""
+		}
+		reasons = append(reasons, fmt.Sprintf("#%d most recent build of %s", build.rankInVersion+1, build.version))
+	}
+	if m.AttachedToAppStoreVersion != nil {
+		attached := build.appStoreVersionID != ""
+		if attached != *m.AttachedToAppStoreVersion {
+			return false, ""
+		}
+		if attached {
+			reasons = append(reasons, "attached to an App Store version")
+		} else {
+			reasons = append(reasons, "not attached to an App Store version")
+		}
+	}
+	if m.InExternalGroup != nil {
+		external := slices.ContainsFunc(build.groups, func(group retentionGroup) bool { return !group.internal })
+		if external != *m.InExternalGroup {
+			return false, ""
+		}
+		if external {
+			reasons = append(reasons, "in an external group")
+		} else {
+			reasons = append(reasons, "not in an external group")
+		}
+	}
+	if m.InAnyGroup != nil {
+		grouped := len(build.groups) > 0
+		if grouped != *m.InAnyGroup {
+			return false, ""
+		}
+		if grouped {
+			reasons = append(reasons, "in a beta group")
+		} else {
+			reasons = append(reasons, "not in any beta group")
+		}
+	}
+	if len(m.Groups) > 0 {
+		matched := ""
+		for _, group := range build.groups {
+			if slices.ContainsFunc(m.Groups, func(name string) bool { return strings.EqualFold(strings.TrimSpace(name), group.name) }) {
+				matched = group.name
+				break
+			}
+		}
+		if matched == "" {
+			return false, ""
+		}
+		reasons = append(reasons, fmt.Sprintf("in group %q", matched))
+	}
+	if len(m.Versions) > 0 {
+		if !slices.Contains(m.Versions, build.version) {
+			return false, ""
+		}
+		reasons = append(reasons, "version "+build.version)
+	}
+	return true, strings.Join(reasons, ", ")
+}
+
+// evaluateRetentionPolicy returns a decision for every build matched by an
+// expire rule: "expire", or "keep" when a keep rule protects it. Rules are
+// applied in file order; the first matching rule of each kind wins.
+func evaluateRetentionPolicy(policy *retentionPolicy, builds []retentionBuild) []asc.BuildRetentionDecision {
+	decisions := make([]asc.BuildRetentionDecision, 0)
+	for _, build := range builds {
+		var expireRule, expireReason string
+		for _, rule := range policy.Rules {
+			if rule.Action != retentionActionExpire {
+				continue
+			}
+			if ok, reason := rule.Match.matches(build); ok {
+				expireRule, expireReason = rule.Name, reason
+				break
+			}
+		}
+		if expireRule == "" {
+			continue
+		}
+
+		decision := asc.BuildRetentionDecision{
+			ID:                build.resource.ID,
+			BuildNumber:       build.resource.Attributes.Version,
+			Version:           build.version,
+			Platform:          build.platform,
+			UploadedDate:      build.resource.Attributes.UploadedDate,
+			AgeDays:           build.ageDays,
+			AppStoreVersionID: build.appStoreVersionID,
+			Action:            retentionActionExpire,
+			ExpireRule:        expireRule,
+			Reason:            expireReason,
+		}
+		for _, group := range build.groups {
+			decision.Groups = append(decision.Groups, group.name)
+		}
+		for _, rule := range policy.Rules {
+			if rule.Action != retentionActionKeep {
+				continue
+			}
+			if ok, reason := rule.Match.matches(build); ok {
+				decision.Action = retentionActionKeep
+				decision.KeepRule = rule.Name
+				decision.Reason = "kept: " + reason
+				break
+			}
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+// fetchRetentionBuilds loads all unexpired builds with their pre-release
+// version, App Store version and beta groups, ranked newest first.
+func fetchRetentionBuilds(ctx context.Context, client *asc.Client, appID string, now time.Time) ([]retentionBuild, int, error) {
+	opts := []asc.BuildsOption{
+		asc.WithBuildsLimit(200),
+		asc.WithBuildsSort("-uploadedDate"),
+		asc.WithBuildsInclude([]string{"preReleaseVersion", "appStoreVersion", "betaGroups"}),
+		asc.WithBuildsBetaGroupsLimit(50),
+	}
+	var builds []retentionBuild
+	skippedExpired := 0
+	page, err := client.GetBuilds(ctx, appID, opts...)
+	for {
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch builds: %w", err)
+		}
+		versions, groups, err := parseRetentionIncluded(page.Included)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, item := range page.Data {
+			if item.Attributes.Expired {
+				skippedExpired++
+				continue
+			}
+			uploadedAt, err := parseBuildTimestamp(item.Attributes.UploadedDate)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: build %s has invalid uploadedDate %q: %v\n", item.ID, item.Attributes.UploadedDate, err)
+				continue
+			}
+			build := retentionBuild{
+				resource:   item,
+				uploadedAt: uploadedAt,
+				ageDays:    max(int(now.Sub(uploadedAt).Hours()/24), 0),
+			}
+			links := parseRetentionRelationships(item.Relationships)
+			if version, ok := versions[links.preReleaseVersion]; ok {
+				build.version = version.Version
+				build.platform = string(version.Platform)
+			}
+			build.appStoreVersionID = links.appStoreVersion
+			for _, groupID := range links.betaGroups {
+				if group, ok := groups[groupID]; ok {
+					build.groups = append(build.groups, group)
+				}
+			}
+			builds = append(builds, build)
+		}
+		if page.Links.Next == "" {
+			break
+		}
+		page, err = client.GetBuilds(ctx, appID, asc.WithBuildsNextURL(page.Links.Next))
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].uploadedAt.After(builds[j].uploadedAt)
+	})
+	perVersion := map[string]int{}
+	for i := range builds {
+		builds[i].rank = i
+		key := builds[i].platform + "/" + builds[i].version
+		builds[i].rankInVersion = perVersion[key]
+		perVersion[key]++
+	}
+	return builds, skippedExpired, nil
+}
+
+type retentionRelationships struct {
+	preReleaseVersion string
+	appStoreVersion   string
+	betaGroups        []string
+}
+
+func parseRetentionRelationships(raw json.RawMessage) retentionRelationships {
+	var links retentionRelationships
+	if len(raw) == 0 {
+		return links
+	}
+	var relationships struct {
+		PreReleaseVersion struct {
+			Data *asc.ResourceData `json:"data"`
+		} `json:"preReleaseVersion"`
+		AppStoreVersion struct {
+			Data *asc.ResourceData `json:"data"`
+		} `json:"appStoreVersion"`
+		BetaGroups struct {
+			Data []asc.ResourceData `json:"data"`
+		} `json:"betaGroups"`
+	}
+	if err := json.Unmarshal(raw, &relationships); err != nil {
+		return links
+	}
+	if relationships.PreReleaseVersion.Data != nil {
+		links.preReleaseVersion = relationships.PreReleaseVersion.Data.ID
+	}
+	if relationships.AppStoreVersion.Data != nil {
+		links.appStoreVersion = relationships.AppStoreVersion.Data.ID
+	}
+	for _, group := range relationships.BetaGroups.Data {
+		links.betaGroups = append(links.betaGroups, group.ID)
+	}
+	return links
+}
+
+func parseRetentionIncluded(raw json.RawMessage) (map[string]asc.PreReleaseVersionAttributes, map[string]retentionGroup, error) {
+	versions := map[string]asc.PreReleaseVersionAttributes{}
+	groups := map[string]retentionGroup{}
+	if len(raw) == 0 {
+		return versions, groups, nil
+	}
+	var included []struct {
+		Type       string          `json:"type"`
+		ID         string          `json:"id"`
+		Attributes json.RawMessage `json:"attributes"`
+	}
+	if err := json.Unmarshal(raw, &included); err != nil {
+		return nil, nil, fmt.Errorf("parse builds included resources: %w", err)
+	}
+	for _, item := range included {
+		switch item.Type {
+		case string(asc.ResourceTypePreReleaseVersions):
+			var attrs asc.PreReleaseVersionAttributes
+			if err := json.Unmarshal(item.Attributes, &attrs); err != nil {
+				return nil, nil, fmt.Errorf("parse pre-release version attributes: %w", err)
+			}
+			versions[item.ID] = attrs
+		case string(asc.ResourceTypeBetaGroups):
+			var attrs asc.BetaGroupAttributes
+			if err := json.Unmarshal(item.Attributes, &attrs); err != nil {
+				return nil, nil, fmt.Errorf("parse beta group attributes: %w", err)
+			}
+			groups[item.ID] = retentionGroup{name: attrs.Name, internal: attrs.IsInternalGroup}
+		}
+	}
+	return versions, groups, nil
+}
+
+// buildRetentionJUnitReport emits one test case per selected or protected build.
+func buildRetentionJUnitReport(result *asc.BuildRetentionResult) *shared.JUnitReport {
+	report := &shared.JUnitReport{
+		Name:      "asc builds retention",
+		Timestamp: time.Now(),
+	}
+	for _, decision := range result.Decisions {
+		rule := decision.ExpireRule
+		if decision.KeepRule != "" {
+			rule = decision.KeepRule
+		}
+		testCase := shared.JUnitTestCase{
+			Name:      fmt.Sprintf("%s (%s %s)", decision.ID, decision.Version, decision.BuildNumber),
+			Classname: "builds.retention." + rule,
+			SystemOut: decision.Action + ": " + decision.Reason,
+		}
+		if decision.Error != "" {
+			testCase.Failure = "EXPIRE_FAILED"
+			testCase.Message = decision.Error
+		}
+		report.Tests = append(report.Tests, testCase)
+	}
+	return report
+}
@@ -0,0 +1,96 @@
+package builds
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func retentionTestBuild(id, version string, ageDays, rankInVersion int, groups ...retentionGroup) retentionBuild {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return retentionBuild{
+		resource:      asc.Resource[asc.BuildAttributes]{ID: id},
+		uploadedAt:    now.AddDate(0, 0, -ageDays),
+		ageDays:       ageDays,
+		version:       version,
+		rankInVersion: rankInVersion,
+		groups:        groups,
+	}
+}
+
+func TestEvaluateRetentionPolicy(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notExternal := false
+	attached := true
+	policy := &retentionPolicy{Rules: []retentionRule{
+		{Name: "keep-latest", Action: "keep", Match: retentionMatch{LatestPerVersion: 2}},
+		{Name: "keep-store", Action: "keep", Match: retentionMatch{AttachedToAppStoreVersion: &attached}},
+		{Name: "keep-vip", Action: "keep", Match: retentionMatch{Groups: []string{"vip"}}},
+		{Name: "expire-old", Action: "expire", Match: retentionMatch{OlderThan: "60d", InExternalGroup: &notExternal}},
+	}}
+	if err := validateRetentionPolicy(policy, now); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	stored := retentionTestBuild("store", "1.0", 120, 5)
+	stored.appStoreVersionID = "asv-1"
+	builds := []retentionBuild{
+		retentionTestBuild("recent", "1.0", 10, 2),
+		retentionTestBuild("latest", "1.0", 90, 0),
+		retentionTestBuild("stale", "1.0", 90, 3, retentionGroup{name: "Team", internal: true}),
+		retentionTestBuild("external", "1.0", 90, 4, retentionGroup{name: "Public"}),
+		retentionTestBuild("vip", "1.0", 90, 6, retentionGroup{name: "VIP", internal: true}),
+		stored,
+	}
+
+	decisions := evaluateRetentionPolicy(policy, builds)
+	got := map[string]string{}
+	for _, decision := range decisions {
+		got[decision.ID] = decision.Action + "/" + decision.KeepRule
+		if decision.ExpireRule != "expire-old" || decision.Reason == "" {
+			t.Fatalf("unexpected decision: %+v", decision)
+		}
+	}
+	want := map[string]string{
+		"latest": "keep/keep-latest",
+		"stale":  "expire/",
+		"vip":    "keep/keep-vip",
+		"store":  "keep/keep-store",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for id, action := range want {
+		if got[id] != action {
+			t.Fatalf("expected %s to be %s, got %q", id, action, got[id])
+		}
+	}
+}
+
+func TestValidateRetentionPolicyErrors(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		rules []retentionRule
+		want  string
+	}{
+		{name: "no rules", want: "at least one rule"},
+		{name: "bad action", rules: []retentionRule{{Action: "delete", Match: retentionMatch{Latest: 1}}}, want: "action must be"},
+		{name: "empty match", rules: []retentionRule{{Action: "expire"}}, want: "at least one condition"},
+		{name: "bad duration", rules: []retentionRule{{Action: "expire", Match: retentionMatch{OlderThan: "soon"}}}, want: "rule \"rule-1\""},
+		{name: "duplicate names", rules: []retentionRule{
+			{Name: "a", Action: "expire", Match: retentionMatch{Latest: 1}},
+			{Name: "a", Action: "keep", Match: retentionMatch{Latest: 1}},
+		}, want: "duplicate rule name"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRetentionPolicy(&retentionPolicy{Rules: test.rules}, now)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
@@ -27,13 +27,17 @@ func BundleIDsCapabilitiesCommand() *ffcli.Command {
 Examples:
   asc bundle-ids capabilities list --bundle "BUNDLE_ID"
   asc bundle-ids capabilities add --bundle "BUNDLE_ID" --capability ICLOUD
-  asc bundle-ids capabilities remove --id "CAPABILITY_ID" --confirm`,
+  asc bundle-ids capabilities remove --id "CAPABILITY_ID" --confirm
+  asc bundle-ids capabilities plan --bundle-id "com.example.app" --entitlements App.entitlements
+  asc bundle-ids capabilities apply --bundle-id "com.example.app" --file capabilities.yaml --confirm`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			BundleIDsCapabilitiesListCommand(),
 			BundleIDsCapabilitiesAddCommand(),
 			BundleIDsCapabilitiesRemoveCommand(),
+			BundleIDsCapabilitiesPlanCommand(),
+			BundleIDsCapabilitiesApplyCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
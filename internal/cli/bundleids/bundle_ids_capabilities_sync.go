@@ -0,0 +1,571 @@
+package bundleids
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	capabilityActionAdd       = "add"
+	capabilityActionUpdate    = "update"
+	capabilityActionRemove    = "remove"
+	capabilityActionExtra     = "extra"
+	capabilityActionUnchanged = "unchanged"
+
+	capabilityStatusApplied = "applied"
+	capabilityStatusFailed  = "failed"
+)
+
+var capabilityTypePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// capabilitiesFile is the file format for capabilities plan and apply.
+type capabilitiesFile struct {
+	Capabilities []capabilitySpec `yaml:"capabilities"`
+}
+
+// capabilitySpec is a capability type, written either as a plain string or
+// as an object with settings.
+type capabilitySpec struct {
+	Type     string                  `yaml:"type"`
+	Settings []asc.CapabilitySetting `yaml:"settings,omitempty"`
+}
+
+func (s *capabilitySpec) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.Type)
+	}
+	// node.Decode does not inherit KnownFields, so check keys here.
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i].Value; key != "type" && key != "settings" {
+				return fmt.Errorf("line %d: unknown capability field %q", node.Content[i].Line, key)
+			}
+		}
+	}
+	type plain capabilitySpec
+	return node.Decode((*plain)(s))
+}
+
+// desiredCapability is a capability the bundle ID should have.
+type desiredCapability struct {
+	capabilityType string
+	settings       []asc.CapabilitySetting
+	sources        []string
+}
+
+// entitlementCapabilities maps entitlement keys to the capability they need.
+var entitlementCapabilities = map[string]string{
+	"aps-environment":                                                          "PUSH_NOTIFICATIONS",
+	"com.apple.developer.aps-environment":                                      "PUSH_NOTIFICATIONS",
+	"com.apple.security.application-groups":                                    "APP_GROUPS",
+	"com.apple.developer.icloud-container-identifiers":                         "ICLOUD",
+	"com.apple.developer.icloud-services":                                      "ICLOUD",
+	"com.apple.developer.ubiquity-container-identifiers":                       "ICLOUD",
+	"com.apple.developer.ubiquity-kvstore-identifier":                          "ICLOUD",
+	"com.apple.developer.associated-domains":                                   "ASSOCIATED_DOMAINS",
+	"com.apple.developer.applesignin":                                          "APPLE_ID_AUTH",
+	"com.apple.developer.in-app-payments":                                      "APPLE_PAY",
+	"com.apple.developer.pass-type-identifiers":                                "WALLET",
+	"com.apple.developer.healthkit":                                            "HEALTHKIT",
+	"com.apple.developer.healthkit.recalibrate-estimates":                      "HEALTHKIT_RECALIBRATE_ESTIMATES",
+	"com.apple.developer.homekit":                                              "HOMEKIT",
+	"com.apple.developer.game-center":                                          "GAME_CENTER",
+	"com.apple.developer.siri":                                                 "SIRIKIT",
+	"com.apple.developer.default-data-protection":                              "DATA_PROTECTION",
+	"com.apple.developer.networking.wifi-info":                                 "ACCESS_WIFI_INFORMATION",
+	"com.apple.developer.networking.networkextension":                          "NETWORK_EXTENSIONS",
+	"com.apple.developer.networking.vpn.api":                                   "PERSONAL_VPN",
+	"com.apple.developer.networking.HotspotConfiguration":                      "HOT_SPOT",
+	"com.apple.developer.networking.multipath":                                 "MULTIPATH",
+	"com.apple.developer.nfc.readersession.formats":                            "NFC_TAG_READING",
+	"com.apple.developer.ClassKit-environment":                                 "CLASSKIT",
+	"com.apple.developer.authentication-services.autofill-credential-provider": "AUTOFILL_CREDENTIAL_PROVIDER",
+	"com.apple.developer.usernotifications.time-sensitive":                     "USER_NOTIFICATIONS_TIME_SENSITIVE",
+	"com.apple.developer.usernotifications.communication":                      "USER_NOTIFICATIONS_COMMUNICATION",
+	"com.apple.developer.group-session":                                        "GROUP_ACTIVITIES",
+	"com.apple.developer.family-controls":                                      "FAMILY_CONTROLS",
+	"com.apple.developer.coremedia.hls.low-latency":                            "COREMEDIA_HLS_LOW_LATENCY",
+	"com.apple.developer.fileprovider.testing-mode":                            "FILEPROVIDER_TESTINGMODE",
+	"com.apple.developer.kernel.extended-virtual-addressing":                   "EXTENDED_VIRTUAL_ADDRESSING",
+	"com.apple.developer.kernel.increased-memory-limit":                        "INCREASED_MEMORY_LIMIT",
+	"com.apple.developer.weatherkit":                                           "WEATHERKIT",
+	"com.apple.external-accessory.wireless-configuration":                      "WIRELESS_ACCESSORY_CONFIGURATION",
+	"inter-app-audio":                                                          "INTER_APP_AUDIO",
+}
+
+// entitlementsWithoutCapability are keys that need no explicit capability.
+var entitlementsWithoutCapability = map[string]bool{
+	"application-identifier":                           true,
+	"com.apple.application-identifier":                 true,
+	"com.apple.developer.team-identifier":              true,
+	"get-task-allow":                                   true,
+	"keychain-access-groups":                           true,
+	"com.apple.developer.icloud-container-environment": true,
+}
+
+// capabilitiesWithoutEntitlement are enabled on bundle IDs without any
+// entitlement, so they are never pruned when planning from entitlements.
+var capabilitiesWithoutEntitlement = map[string]bool{
+	"IN_APP_PURCHASE": true,
+}
+
+// dataProtectionLevels maps NSFileProtection values to the capability option.
+var dataProtectionLevels = map[string]string{
+	"NSFileProtectionComplete":                             "COMPLETE_PROTECTION",
+	"NSFileProtectionCompleteUnlessOpen":                   "PROTECTED_UNLESS_OPEN",
+	"NSFileProtectionCompleteUntilFirstUserAuthentication": "PROTECTED_UNTIL_FIRST_USER_AUTH",
+}
+
+// BundleIDsCapabilitiesPlanCommand returns the bundle IDs capabilities plan subcommand.
+func BundleIDsCapabilitiesPlanCommand() *ffcli.Command {
+	return bundleIDsCapabilitiesSyncCommand("plan")
+}
+
+// BundleIDsCapabilitiesApplyCommand returns the bundle IDs capabilities apply subcommand.
+func BundleIDsCapabilitiesApplyCommand() *ffcli.Command {
+	return bundleIDsCapabilitiesSyncCommand("apply")
+}
+
+func bundleIDsCapabilitiesSyncCommand(name string) *ffcli.Command {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+
+	bundleID := fs.String("bundle", "", "Bundle ID resource ID")
+	identifier := fs.String("bundle-id", "", "Bundle identifier (e.g., com.example.app)")
+	file := fs.String("file", "", "Capabilities file (YAML or JSON)")
+	entitlements := fs.String("entitlements", "", "Xcode .entitlements file")
+	prune := fs.Bool("prune", false, "Remove capabilities that are not in the file or entitlements")
+	var confirm *bool
+	if name == "apply" {
+		confirm = fs.Bool("confirm", false, "Confirm changes")
+	}
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	command := "bundle-ids capabilities " + name
+	shortHelp := "Show capability changes for a bundle ID."
+	if name == "apply" {
+		shortHelp = "Apply capability changes to a bundle ID."
+	}
+	shortUsage := "asc " + command + " --bundle-id IDENTIFIER (--file capabilities.yaml | --entitlements App.entitlements) [--prune]"
+	if name == "apply" {
+		shortUsage += " --confirm"
+	}
+
+	return &ffcli.Command{
+		Name:       name,
+		ShortUsage: shortUsage,
+		ShortHelp:  shortHelp,
+		LongHelp: shortHelp + `
+
+Compares the capabilities a bundle ID should have with the ones enabled in the
+developer portal. The desired set comes from a capabilities file or from an
+Xcode .entitlements file, whose keys map to capability types: push
+notifications, app groups, iCloud, associated domains, Sign in with Apple,
+Apple Pay, HealthKit, data protection and more.
+
+Capabilities missing from the bundle ID are added, and ones whose settings
+differ are updated. Capabilities enabled on the bundle ID but not requested
+are reported as extra, and removed only with --prune (IN_APP_PURCHASE, which
+has no entitlement, is kept when planning from entitlements). Identifiers
+such as app groups, iCloud containers and merchant IDs cannot be assigned
+through the API; they are listed as notes. plan only reports the changes;
+apply makes them.
+
+Capabilities file:
+  capabilities:
+    - PUSH_NOTIFICATIONS
+    - ASSOCIATED_DOMAINS
+    - type: ICLOUD
+      settings:
+        - key: ICLOUD_VERSION
+          options:
+            - key: XCODE_6
+              enabled: true
+
+Examples:
+  asc bundle-ids capabilities plan --bundle-id "com.example.app" --entitlements App/App.entitlements
+  asc bundle-ids capabilities plan --bundle "BUNDLE_ID" --file capabilities.yaml --output table
+  asc bundle-ids capabilities apply --bundle-id "com.example.app" --file capabilities.yaml --prune --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			bundleValue := strings.TrimSpace(*bundleID)
+			identifierValue := strings.TrimSpace(*identifier)
+			if bundleValue == "" && identifierValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --bundle or --bundle-id is required")
+				return flag.ErrHelp
+			}
+			if bundleValue != "" && identifierValue != "" {
+				fmt.Fprintln(os.Stderr, "Error: --bundle and --bundle-id are mutually exclusive")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			entitlementsValue := strings.TrimSpace(*entitlements)
+			if fileValue == "" && entitlementsValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file or --entitlements is required")
+				return flag.ErrHelp
+			}
+			if fileValue != "" && entitlementsValue != "" {
+				fmt.Fprintln(os.Stderr, "Error: --file and --entitlements are mutually exclusive")
+				return flag.ErrHelp
+			}
+			if confirm != nil && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required")
+				return flag.ErrHelp
+			}
+
+			var desired []desiredCapability
+			var notes []string
+			var keep map[string]bool
+			var err error
+			source := fileValue
+			if fileValue != "" {
+				desired, err = readCapabilitiesFile(fileValue)
+			} else {
+				source = entitlementsValue
+				keep = capabilitiesWithoutEntitlement
+				desired, notes, err = readEntitlementsCapabilities(entitlementsValue)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", command, err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("%s: %w", command, err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			result := &asc.BundleIDCapabilityPlanResult{BundleID: bundleValue, Source: source, Prune: *prune, Notes: notes}
+			if identifierValue != "" {
+				bundle, err := shared.FindBundleID(requestCtx, client, identifierValue)
+				if err != nil {
+					return fmt.Errorf("%s: %w", command, err)
+				}
+				result.BundleID, result.Identifier = bundle.ID, identifierValue
+			}
+
+			live, err := listBundleIDCapabilities(requestCtx, client, result.BundleID)
+			if err != nil {
+				return fmt.Errorf("%s: %w", command, err)
+			}
+			result.Changes = planCapabilityChanges(desired, live, *prune, keep)
+
+			if name == "apply" {
+				result.Applied = true
+				failed := applyCapabilityChanges(requestCtx, client, result.BundleID, result.Changes)
+				if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+					return err
+				}
+				if failed > 0 {
+					return shared.NewReportedError(fmt.Errorf("%s: %d changes failed", command, failed))
+				}
+				return nil
+			}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+// readCapabilitiesFile reads and validates a capabilities file.
+func readCapabilitiesFile(path string) ([]desiredCapability, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var parsed capabilitiesFile
+	if err := decoder.Decode(&parsed); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("capabilities file %s is empty", path)
+		}
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	desired := make([]desiredCapability, 0, len(parsed.Capabilities))
+	for _, spec := range parsed.Capabilities {
+		capabilityType := strings.ToUpper(strings.TrimSpace(spec.Type))
+		if !capabilityTypePattern.MatchString(capabilityType) {
+			return nil, fmt.Errorf("invalid capability type %q in %s", spec.Type, path)
+		}
+		if seen[capabilityType] {
+			return nil, fmt.Errorf("duplicate capability %s in %s", capabilityType, path)
+		}
+		seen[capabilityType] = true
+		desired = append(desired, desiredCapability{capabilityType: capabilityType, settings: spec.Settings, sources: []string{path}})
+	}
+	return desired, nil
+}
+
+// readEntitlementsCapabilities maps the keys of an .entitlements plist to
+// capabilities. It also returns notes for identifiers that must be assigned
+// in the developer portal and for keys it does not recognize.
+func readEntitlementsCapabilities(path string) ([]desiredCapability, []string, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entitlements map[string]any
+	if _, err := plist.Unmarshal(data, &entitlements); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	desired, notes := capabilitiesFromEntitlements(entitlements)
+	return desired, notes, nil
+}
+
+func capabilitiesFromEntitlements(entitlements map[string]any) ([]desiredCapability, []string) {
+	keys := make([]string, 0, len(entitlements))
+	for key := range entitlements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	byType := map[string]*desiredCapability{}
+	var order []string
+	var notes []string
+	for _, key := range keys {
+		value := entitlements[key]
+		capabilityType, ok := entitlementCapabilities[key]
+		if !ok {
+			if !entitlementsWithoutCapability[key] && strings.HasPrefix(key, "com.apple.developer.") {
+				notes = append(notes, fmt.Sprintf("%s has no known capability mapping; add it to a capabilities file if it needs one", key))
+			}
+			continue
+		}
+		if !entitlementEnabled(value) {
+			continue
+		}
+		capability := byType[capabilityType]
+		if capability == nil {
+			capability = &desiredCapability{capabilityType: capabilityType}
+			byType[capabilityType] = capability
+			order = append(order, capabilityType)
+		}
+		capability.sources = append(capability.sources, key)
+
+		switch key {
+		case "com.apple.developer.default-data-protection":
+			level, _ := value.(string)
+			if option, ok := dataProtectionLevels[level]; ok {
+				capability.settings = []asc.CapabilitySetting{capabilitySetting("DATA_PROTECTION_PERMISSION_LEVEL", option)}
+			} else {
+				notes = append(notes, fmt.Sprintf("%s has unknown value %q", key, level))
+			}
+		case "com.apple.developer.icloud-services", "com.apple.developer.icloud-container-identifiers":
+			capability.settings = []asc.CapabilitySetting{capabilitySetting("ICLOUD_VERSION", "XCODE_6")}
+		}
+		if ids := entitlementStrings(value); len(ids) > 0 {
+			switch key {
+			case "com.apple.security.application-groups":
+				notes = append(notes, "assign app groups in the developer portal: "+strings.Join(ids, ", "))
+			case "com.apple.developer.icloud-container-identifiers":
+				notes = append(notes, "assign iCloud containers in the developer portal: "+strings.Join(ids, ", "))
+			case "com.apple.developer.in-app-payments":
+				notes = append(notes, "assign merchant IDs in the developer portal: "+strings.Join(ids, ", "))
+			}
+		}
+	}
+
+	desired := make([]desiredCapability, 0, len(order))
+	for _, capabilityType := range order {
+		desired = append(desired, *byType[capabilityType])
+	}
+	return desired, notes
+}
+
+// entitlementEnabled reports whether an entitlement value turns its
+// capability on: true, a non-empty string or a non-empty array.
+func entitlementEnabled(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	default:
+		return value != nil
+	}
+}
+
+func entitlementStrings(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	var result []string
+	for _, item := range values {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func capabilitySetting(key, option string) asc.CapabilitySetting {
+	enabled := true
+	return asc.CapabilitySetting{Key: key, Options: []asc.CapabilityOption{{Key: option, Enabled: &enabled}}}
+}
+
+func listBundleIDCapabilities(ctx context.Context, client *asc.Client, bundleID string) ([]asc.Resource[asc.BundleIDCapabilityAttributes], error) {
+	var all []asc.Resource[asc.BundleIDCapabilityAttributes]
+	next := ""
+	for {
+		resp, err := client.GetBundleIDCapabilities(ctx, bundleID, asc.WithBundleIDCapabilitiesNextURL(next))
+		if err != nil {
+			return nil, fmt.Errorf("fetch capabilities: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// planCapabilityChanges diffs the desired capabilities against the live ones,
+// sorted by capability type. Live capabilities in keep are never removed.
+func planCapabilityChanges(desired []desiredCapability, live []asc.Resource[asc.BundleIDCapabilityAttributes], prune bool, keep map[string]bool) []asc.BundleIDCapabilityChange {
+	liveByType := make(map[string]asc.Resource[asc.BundleIDCapabilityAttributes], len(live))
+	for _, capability := range live {
+		liveByType[strings.ToUpper(capability.Attributes.CapabilityType)] = capability
+	}
+
+	var changes []asc.BundleIDCapabilityChange
+	wanted := map[string]bool{}
+	for _, capability := range desired {
+		wanted[capability.capabilityType] = true
+		change := asc.BundleIDCapabilityChange{
+			CapabilityType: capability.capabilityType,
+			Sources:        capability.sources,
+			Settings:       capability.settings,
+		}
+		current, ok := liveByType[capability.capabilityType]
+		switch {
+		case !ok:
+			change.Action = capabilityActionAdd
+		case !capabilitySettingsMatch(capability.settings, current.Attributes.Settings):
+			change.Action = capabilityActionUpdate
+			change.CapabilityID = current.ID
+			change.LiveSettings = current.Attributes.Settings
+		default:
+			change.Action = capabilityActionUnchanged
+			change.CapabilityID = current.ID
+		}
+		changes = append(changes, change)
+	}
+	for _, capability := range live {
+		capabilityType := strings.ToUpper(capability.Attributes.CapabilityType)
+		if wanted[capabilityType] {
+			continue
+		}
+		action := capabilityActionExtra
+		if prune && !keep[capabilityType] {
+			action = capabilityActionRemove
+		}
+		changes = append(changes, asc.BundleIDCapabilityChange{
+			CapabilityType: capabilityType,
+			Action:         action,
+			CapabilityID:   capability.ID,
+			LiveSettings:   capability.Attributes.Settings,
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].CapabilityType < changes[j].CapabilityType
+	})
+	return changes
+}
+
+// capabilitySettingsMatch reports whether every desired setting has the same
+// enabled options live. Settings that are not desired are ignored.
+func capabilitySettingsMatch(desired, live []asc.CapabilitySetting) bool {
+	for _, setting := range desired {
+		index := slices.IndexFunc(live, func(s asc.CapabilitySetting) bool { return s.Key == setting.Key })
+		if index < 0 || !slices.Equal(enabledCapabilityOptions(setting), enabledCapabilityOptions(live[index])) {
+			return false
+		}
+	}
+	return true
+}
+
+func enabledCapabilityOptions(setting asc.CapabilitySetting) []string {
+	var keys []string
+	for _, option := range setting.Options {
+		if option.Enabled == nil || *option.Enabled {
+			keys = append(keys, option.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyCapabilityChanges makes the add, update and remove changes and
+// returns how many failed.
+func applyCapabilityChanges(ctx context.Context, client *asc.Client, bundleID string, changes []asc.BundleIDCapabilityChange) int {
+	failed := 0
+	for i := range changes {
+		change := &changes[i]
+		var err error
+		switch change.Action {
+		case capabilityActionAdd:
+			var resp *asc.BundleIDCapabilityResponse
+			resp, err = client.CreateBundleIDCapability(ctx, bundleID, asc.BundleIDCapabilityCreateAttributes{
+				CapabilityType: change.CapabilityType,
+				Settings:       change.Settings,
+			})
+			if err == nil {
+				change.CapabilityID = resp.Data.ID
+			}
+		case capabilityActionUpdate:
+			_, err = client.UpdateBundleIDCapability(ctx, change.CapabilityID, asc.BundleIDCapabilityUpdateAttributes{
+				CapabilityType: change.CapabilityType,
+				Settings:       change.Settings,
+			})
+		case capabilityActionRemove:
+			err = client.DeleteBundleIDCapability(ctx, change.CapabilityID)
+		default:
+			continue
+		}
+		if err != nil {
+			change.Status, change.Error = capabilityStatusFailed, err.Error()
+			failed++
+			continue
+		}
+		change.Status = capabilityStatusApplied
+	}
+	return failed
+}
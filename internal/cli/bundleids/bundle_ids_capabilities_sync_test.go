@@ -0,0 +1,134 @@
+package bundleids
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestCapabilitiesFromEntitlements(t *testing.T) {
+	entitlements := map[string]any{
+		"aps-environment":                                  "production",
+		"com.apple.security.application-groups":            []any{"group.com.example.shared"},
+		"com.apple.developer.icloud-container-identifiers": []any{"iCloud.com.example.app"},
+		"com.apple.developer.icloud-services":              []any{"CloudKit"},
+		"com.apple.developer.associated-domains":           []any{"applinks:example.com"},
+		"com.apple.developer.applesignin":                  []any{"Default"},
+		"com.apple.developer.default-data-protection":      "NSFileProtectionComplete",
+		"com.apple.developer.healthkit":                    false,
+		"com.apple.developer.unknown-feature":              true,
+		"keychain-access-groups":                           []any{"$(AppIdentifierPrefix)com.example.app"},
+		"get-task-allow":                                   true,
+	}
+
+	desired, notes := capabilitiesFromEntitlements(entitlements)
+	var types []string
+	for _, capability := range desired {
+		types = append(types, capability.capabilityType)
+	}
+	if got := strings.Join(types, ","); got != "PUSH_NOTIFICATIONS,APPLE_ID_AUTH,ASSOCIATED_DOMAINS,DATA_PROTECTION,ICLOUD,APP_GROUPS" {
+		t.Fatalf("unexpected capabilities %s", got)
+	}
+	for _, capability := range desired {
+		switch capability.capabilityType {
+		case "ICLOUD":
+			if len(capability.sources) != 2 || len(capability.settings) != 1 || capability.settings[0].Key != "ICLOUD_VERSION" {
+				t.Fatalf("unexpected iCloud capability: %+v", capability)
+			}
+		case "DATA_PROTECTION":
+			if len(capability.settings) != 1 || capability.settings[0].Options[0].Key != "COMPLETE_PROTECTION" {
+				t.Fatalf("unexpected data protection capability: %+v", capability)
+			}
+		}
+	}
+
+	joined := strings.Join(notes, "\n")
+	for _, want := range []string{"group.com.example.shared", "iCloud.com.example.app", "com.apple.developer.unknown-feature"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected a note about %s, got:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "keychain-access-groups") {
+		t.Fatalf("unexpected note for keychain-access-groups:\n%s", joined)
+	}
+
+	// macOS apps use the prefixed push entitlement.
+	desired, _ = capabilitiesFromEntitlements(map[string]any{"com.apple.developer.aps-environment": "production"})
+	if len(desired) != 1 || desired[0].capabilityType != "PUSH_NOTIFICATIONS" {
+		t.Fatalf("expected macOS push entitlement to map to PUSH_NOTIFICATIONS, got %+v", desired)
+	}
+}
+
+func TestReadCapabilitiesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capabilities.yaml")
+	content := `capabilities:
+  - push_notifications
+  - type: ICLOUD
+    settings:
+      - key: ICLOUD_VERSION
+        options:
+          - key: XCODE_6
+            enabled: true
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	desired, err := readCapabilitiesFile(path)
+	if err != nil {
+		t.Fatalf("readCapabilitiesFile() error: %v", err)
+	}
+	if len(desired) != 2 || desired[0].capabilityType != "PUSH_NOTIFICATIONS" || desired[1].capabilityType != "ICLOUD" ||
+		len(desired[1].settings) != 1 || desired[1].settings[0].Options[0].Key != "XCODE_6" {
+		t.Fatalf("unexpected capabilities: %+v", desired)
+	}
+
+	for _, invalid := range []string{
+		"capabilities:\n  - PUSH_NOTIFICATIONS\n  - PUSH_NOTIFICATIONS\n",
+		"capabilities:\n  - push notifications\n",
+		"capabilities:\n  - type: ICLOUD\n    setting: []\n",
+	} {
+		if err := os.WriteFile(path, []byte(invalid), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readCapabilitiesFile(path); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestPlanCapabilityChanges(t *testing.T) {
+	enabled := true
+	disabled := false
+	desired := []desiredCapability{
+		{capabilityType: "PUSH_NOTIFICATIONS"},
+		{capabilityType: "ICLOUD", settings: []asc.CapabilitySetting{capabilitySetting("ICLOUD_VERSION", "XCODE_6")}},
+		{capabilityType: "DATA_PROTECTION", settings: []asc.CapabilitySetting{capabilitySetting("DATA_PROTECTION_PERMISSION_LEVEL", "COMPLETE_PROTECTION")}},
+	}
+	live := []asc.Resource[asc.BundleIDCapabilityAttributes]{
+		{ID: "C_ICLOUD", Attributes: asc.BundleIDCapabilityAttributes{CapabilityType: "ICLOUD", Settings: []asc.CapabilitySetting{
+			{Key: "ICLOUD_VERSION", Options: []asc.CapabilityOption{{Key: "XCODE_5", Enabled: &disabled}, {Key: "XCODE_6", Enabled: &enabled}}},
+		}}},
+		{ID: "C_DP", Attributes: asc.BundleIDCapabilityAttributes{CapabilityType: "DATA_PROTECTION", Settings: []asc.CapabilitySetting{
+			{Key: "DATA_PROTECTION_PERMISSION_LEVEL", Options: []asc.CapabilityOption{{Key: "PROTECTED_UNLESS_OPEN", Enabled: &enabled}}},
+		}}},
+		{ID: "C_IAP", Attributes: asc.BundleIDCapabilityAttributes{CapabilityType: "IN_APP_PURCHASE"}},
+		{ID: "C_HK", Attributes: asc.BundleIDCapabilityAttributes{CapabilityType: "HEALTHKIT"}},
+	}
+
+	summarize := func(changes []asc.BundleIDCapabilityChange) string {
+		var parts []string
+		for _, change := range changes {
+			parts = append(parts, change.CapabilityType+"="+change.Action)
+		}
+		return strings.Join(parts, ",")
+	}
+	if got := summarize(planCapabilityChanges(desired, live, false, nil)); got != "DATA_PROTECTION=update,HEALTHKIT=extra,ICLOUD=unchanged,IN_APP_PURCHASE=extra,PUSH_NOTIFICATIONS=add" {
+		t.Fatalf("unexpected plan %s", got)
+	}
+	if got := summarize(planCapabilityChanges(desired, live, true, capabilitiesWithoutEntitlement)); got != "DATA_PROTECTION=update,HEALTHKIT=remove,ICLOUD=unchanged,IN_APP_PURCHASE=extra,PUSH_NOTIFICATIONS=add" {
+		t.Fatalf("unexpected pruned plan %s", got)
+	}
+}
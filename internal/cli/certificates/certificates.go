@@ -31,9 +31,12 @@ Examples:
   asc certificates list --certificate-type IOS_DISTRIBUTION
   asc certificates get --id "CERT_ID" --include passTypeId
   asc certificates create --certificate-type IOS_DISTRIBUTION --csr "./cert.csr"
+  asc certificates create --certificate-type DISTRIBUTION --generate-key --p12 "./dist.p12" --p12-password-env P12_PASS
   asc certificates update --id "CERT_ID" --activated true
   asc certificates update --id "CERT_ID" --activated false
   asc certificates revoke --id "CERT_ID" --confirm
+  asc certificates rotate --stage create --id "CERT_ID" --p12 "./dist.p12" --p12-password-env P12_PASS
+  asc certificates inspect --p12-password-env P12_PASS "./dist.p12"
   asc certificates relationships pass-type-id --id "CERT_ID"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -43,6 +46,8 @@ Examples:
 			CertificatesCreateCommand(),
 			CertificatesUpdateCommand(),
 			CertificatesRevokeCommand(),
+			CertificatesRotateCommand(),
+			CertificatesInspectCommand(),
 			CertificatesRelationshipsCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -188,17 +193,34 @@ func CertificatesCreateCommand() *ffcli.Command {
 
 	certificateType := fs.String("certificate-type", "", "Certificate type (e.g., IOS_DISTRIBUTION)")
 	csrPath := fs.String("csr", "", "CSR file path")
+	generateKey := fs.Bool("generate-key", false, "Generate an RSA key and CSR locally instead of using --csr")
+	keySize := fs.Int("key-size", shared.DefaultSigningKeyBits, "RSA key size in bits for --generate-key")
+	commonName := fs.String("common-name", "asc", "CSR common name for --generate-key")
+	keyOut := fs.String("key-out", "", "Write the generated private key (PEM) to this path")
+	certOut := fs.String("cert-out", "", "Write the issued certificate (DER .cer) to this path")
+	p12Out := fs.String("p12", "", "Write the generated key and certificate as a .p12 to this path")
+	p12PasswordEnv := fs.String("p12-password-env", "", "Environment variable holding the .p12 password")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
 	return &ffcli.Command{
 		Name:       "create",
-		ShortUsage: "asc certificates create --certificate-type TYPE --csr ./cert.csr",
+		ShortUsage: "asc certificates create --certificate-type TYPE (--csr ./cert.csr | --generate-key --p12 ./cert.p12) [flags]",
 		ShortHelp:  "Create a signing certificate.",
 		LongHelp: `Create a signing certificate.
 
+With --generate-key, an RSA key and CSR are generated locally, so no
+Keychain Access is needed. The issued certificate is downloaded and bundled
+with the key into a password-protected .p12 (--p12) ready to import into a CI
+keychain. The private key never leaves this machine; keep the .p12 or
+--key-out file, because a lost key cannot be recovered from Apple. The key is
+saved before the certificate is requested: without --key-out it is written
+next to the .p12 (NAME.key) and removed once the .p12 has been written.
+
 Examples:
-  asc certificates create --certificate-type IOS_DISTRIBUTION --csr "./cert.csr"`,
+  asc certificates create --certificate-type IOS_DISTRIBUTION --csr "./cert.csr"
+  P12_PASS=secret asc certificates create --certificate-type DISTRIBUTION --generate-key --p12 ./dist.p12 --p12-password-env P12_PASS
+  asc certificates create --certificate-type DEVELOPMENT --generate-key --key-out ./dev.key --cert-out ./dev.cer`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -208,10 +230,28 @@ Examples:
 				return flag.ErrHelp
 			}
 			csrValue := strings.TrimSpace(*csrPath)
+			if *generateKey {
+				if csrValue != "" {
+					fmt.Fprintln(os.Stderr, "Error: --csr and --generate-key are mutually exclusive")
+					return flag.ErrHelp
+				}
+				return createWithGeneratedKey(ctx, generatedCertificateOptions{
+					CertificateType: certificateValue,
+					KeyBits:         *keySize,
+					CommonName:      *commonName,
+					KeyOut:          strings.TrimSpace(*keyOut),
+					CertOut:         strings.TrimSpace(*certOut),
+					P12Out:          strings.TrimSpace(*p12Out),
+				}, *p12PasswordEnv, *output, *pretty)
+			}
 			if csrValue == "" {
 				fmt.Fprintln(os.Stderr, "Error: --csr is required")
 				return flag.ErrHelp
 			}
+			if strings.TrimSpace(*keyOut) != "" || strings.TrimSpace(*certOut) != "" || strings.TrimSpace(*p12Out) != "" {
+				fmt.Fprintln(os.Stderr, "Error: --key-out, --cert-out and --p12 require --generate-key")
+				return flag.ErrHelp
+			}
 
 			csrContent, err := readCSRContent(csrValue)
 			if err != nil {
@@ -236,6 +276,35 @@ Examples:
 	}
 }
 
+func createWithGeneratedKey(ctx context.Context, opts generatedCertificateOptions, p12PasswordEnv, output string, pretty bool) error {
+	if opts.P12Out == "" && opts.KeyOut == "" {
+		fmt.Fprintln(os.Stderr, "Error: --p12 or --key-out is required with --generate-key")
+		return flag.ErrHelp
+	}
+	if opts.P12Out != "" {
+		password, err := resolveP12Password(p12PasswordEnv)
+		if err != nil {
+			return fmt.Errorf("certificates create: %w", err)
+		}
+		opts.P12Password = password
+	}
+
+	client, err := shared.GetASCClient()
+	if err != nil {
+		return fmt.Errorf("certificates create: %w", err)
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	result, err := createCertificateWithGeneratedKey(requestCtx, client, opts)
+	if err != nil {
+		return fmt.Errorf("certificates create: %w", err)
+	}
+
+	return shared.PrintOutput(result, output, pretty)
+}
+
 // CertificatesUpdateCommand returns the certificates update subcommand.
 func CertificatesUpdateCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("update", flag.ExitOnError)
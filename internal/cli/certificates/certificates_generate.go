@@ -0,0 +1,170 @@
+package certificates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// generatedCertificateOptions describes a certificate created from a key
+// generated on this machine, and where to write the results.
+type generatedCertificateOptions struct {
+	CertificateType string
+	KeyBits         int
+	CommonName      string
+	KeyOut          string
+	CertOut         string
+	P12Out          string
+	P12Password     string
+}
+
+// keyPath returns where the private key is written before the certificate is
+// requested. Without --key-out the key goes next to the .p12 and is removed
+// once the .p12 has been written.
+func (o generatedCertificateOptions) keyPath() (string, bool) {
+	if o.KeyOut != "" {
+		return o.KeyOut, false
+	}
+	return strings.TrimSuffix(o.P12Out, filepath.Ext(o.P12Out)) + ".key", true
+}
+
+func (o generatedCertificateOptions) outputPaths() []string {
+	keyPath, _ := o.keyPath()
+	var paths []string
+	for _, path := range []string{keyPath, o.CertOut, o.P12Out} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// resolveP12Password reads the .p12 password from the named environment
+// variable. An empty password is allowed when the variable is set.
+func resolveP12Password(envName string) (string, error) {
+	envName = strings.TrimSpace(envName)
+	if envName == "" {
+		return "", fmt.Errorf("--p12-password-env is required with --p12")
+	}
+	password, ok := os.LookupEnv(envName)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", envName)
+	}
+	return password, nil
+}
+
+// createCertificateWithGeneratedKey generates an RSA key and CSR, creates the
+// certificate, and writes the key, certificate and .p12 bundle. The key is
+// written before the API call, so a failure after Apple issues the
+// certificate never loses it; a temporary key file next to the .p12 is only
+// removed once the .p12 exists.
+func createCertificateWithGeneratedKey(ctx context.Context, client *asc.Client, opts generatedCertificateOptions) (*asc.CertificateCreateResult, error) {
+	for _, path := range opts.outputPaths() {
+		if _, err := os.Lstat(path); err == nil {
+			return nil, fmt.Errorf("%s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	key, err := shared.GenerateSigningKey(opts.KeyBits)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := shared.CreateCertificateSigningRequest(key, opts.CommonName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	keyPath, temporaryKey := opts.keyPath()
+	keyPEM, err := shared.EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSecretFile(keyPath, keyPEM); err != nil {
+		return nil, fmt.Errorf("write private key: %w", err)
+	}
+	result := &asc.CertificateCreateResult{CertificateType: opts.CertificateType, KeyFile: keyPath}
+
+	resp, err := client.CreateCertificate(ctx, csr, opts.CertificateType)
+	if err != nil {
+		if temporaryKey {
+			_ = os.Remove(keyPath)
+		}
+		return nil, fmt.Errorf("failed to create: %w", err)
+	}
+	result.ID = resp.Data.ID
+	result.Name = resp.Data.Attributes.Name
+	result.SerialNumber = resp.Data.Attributes.SerialNumber
+	result.ExpirationDate = resp.Data.Attributes.ExpirationDate
+
+	content := resp.Data.Attributes.CertificateContent
+	if strings.TrimSpace(content) == "" {
+		fetched, err := client.GetCertificate(ctx, resp.Data.ID)
+		if err != nil {
+			return result, fmt.Errorf("certificate %s created but download failed (private key saved to %s): %w", resp.Data.ID, keyPath, err)
+		}
+		content = fetched.Data.Attributes.CertificateContent
+	}
+	cert, err := shared.ParseCertificateContent(content)
+	if err != nil {
+		return result, fmt.Errorf("certificate %s created (private key saved to %s) but %w", resp.Data.ID, keyPath, err)
+	}
+
+	if opts.CertOut != "" {
+		if err := writePublicFile(opts.CertOut, cert.Raw); err != nil {
+			return result, fmt.Errorf("write certificate (private key saved to %s): %w", keyPath, err)
+		}
+		result.CertificateFile = opts.CertOut
+	}
+	if opts.P12Out != "" {
+		p12, err := shared.EncodePKCS12(key, cert, cert.Subject.CommonName, opts.P12Password)
+		if err != nil {
+			return result, fmt.Errorf("encode p12 (private key saved to %s): %w", keyPath, err)
+		}
+		if err := writeSecretFile(opts.P12Out, p12); err != nil {
+			return result, fmt.Errorf("write p12 (private key saved to %s): %w", keyPath, err)
+		}
+		result.P12File = opts.P12Out
+	}
+	if temporaryKey {
+		if err := os.Remove(keyPath); err != nil {
+			return result, fmt.Errorf("remove temporary private key: %w", err)
+		}
+		result.KeyFile = ""
+	}
+	return result, nil
+}
+
+func writeSecretFile(path string, data []byte) error {
+	return writeNewFile(path, data, 0o600)
+}
+
+func writePublicFile(path string, data []byte) error {
+	return writeNewFile(path, data, 0o644)
+}
+
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := shared.OpenNewFileNoFollow(path, perm)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("output file already exists: %w", err)
+		}
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,156 @@
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// CertificatesInspectCommand returns the certificates inspect subcommand.
+func CertificatesInspectCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+
+	passwordEnv := fs.String("p12-password-env", "", "Environment variable holding the .p12 password")
+	offline := fs.Bool("offline", false, "Skip the App Store Connect certificate check")
+	strict := fs.Bool("strict", false, "Exit non-zero when a certificate is expired, revoked or missing its key")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "inspect",
+		ShortUsage: "asc certificates inspect [flags] FILE...",
+		ShortHelp:  "Inspect local .cer and .p12 files.",
+		LongHelp: `Inspect local signing certificates.
+
+Accepts DER or PEM .cer files and .p12 bundles. Reports the subject, team,
+serial number, fingerprints and expiry, whether a .p12 private key matches
+its certificate, and (unless --offline is set) whether App Store Connect
+still lists the certificate.
+
+Examples:
+  asc certificates inspect ./distribution.cer
+  asc certificates inspect --p12-password-env P12_PASS ./dist.p12
+  asc certificates inspect --offline --output table ./*.cer`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: at least one certificate file is required")
+				return flag.ErrHelp
+			}
+
+			now := time.Now()
+			result := &asc.CertificateInspectResult{Certificates: make([]asc.InspectedCertificate, 0, len(args))}
+			var problems []string
+			for _, path := range args {
+				inspected, err := inspectCertificateFile(path, *passwordEnv, now)
+				if err != nil {
+					return fmt.Errorf("certificates inspect: %s: %w", path, err)
+				}
+				if isPKCS12Path(path) && !inspected.HasPrivateKey {
+					problems = append(problems, path+": private key does not match certificate")
+				}
+				result.Certificates = append(result.Certificates, inspected)
+			}
+
+			if !*offline {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("certificates inspect: %w", err)
+				}
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				defer cancel()
+
+				if err := shared.CrossCheckCertificates(requestCtx, client, result.Certificates); err != nil {
+					return fmt.Errorf("certificates inspect: %w", err)
+				}
+			}
+
+			for _, cert := range result.Certificates {
+				switch {
+				case cert.Expired:
+					problems = append(problems, cert.File+": expired on "+cert.NotAfter)
+				case cert.APIStatus == shared.CertificateStatusNotFound:
+					problems = append(problems, cert.File+": revoked or not found")
+				}
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if *strict && len(problems) > 0 {
+				return shared.NewReportedError(fmt.Errorf("certificates inspect: %s", strings.Join(problems, "; ")))
+			}
+			return nil
+		},
+	}
+}
+
+func isPKCS12Path(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".p12", ".pfx":
+		return true
+	default:
+		return false
+	}
+}
+
+// inspectCertificateFile reads a .cer (DER or PEM) or .p12 file. For .p12
+// bundles HasPrivateKey reports whether the bundled key matches the
+// certificate.
+func inspectCertificateFile(path, passwordEnv string, now time.Time) (asc.InspectedCertificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return asc.InspectedCertificate{}, err
+	}
+
+	if isPKCS12Path(path) {
+		password := ""
+		if strings.TrimSpace(passwordEnv) != "" {
+			value, ok := os.LookupEnv(strings.TrimSpace(passwordEnv))
+			if !ok {
+				return asc.InspectedCertificate{}, fmt.Errorf("environment variable %s is not set", passwordEnv)
+			}
+			password = value
+		}
+		contents, err := shared.DecodePKCS12(data, password)
+		if errors.Is(err, shared.ErrPKCS12Password) && strings.TrimSpace(passwordEnv) == "" {
+			return asc.InspectedCertificate{}, fmt.Errorf("%w (set --p12-password-env)", err)
+		}
+		if err != nil {
+			return asc.InspectedCertificate{}, err
+		}
+		inspected := shared.InspectCertificate(contents.Certificate, now)
+		inspected.File = path
+		inspected.HasPrivateKey = shared.PrivateKeyMatchesCertificate(contents.PrivateKey, contents.Certificate)
+		inspected.CACertificates = len(contents.CACerts)
+		return inspected, nil
+	}
+
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return asc.InspectedCertificate{}, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		der = block.Bytes
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return asc.InspectedCertificate{}, fmt.Errorf("parse certificate: %w", err)
+	}
+	inspected := shared.InspectCertificate(cert, now)
+	inspected.File = path
+	return inspected, nil
+}
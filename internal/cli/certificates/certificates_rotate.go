@@ -0,0 +1,660 @@
+package certificates
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	rotationStageCreate          = "create"
+	rotationStageMigrateProfiles = "migrate-profiles"
+	rotationStageRevoke          = "revoke"
+
+	rotationActionWouldMigrate = "would-migrate"
+	rotationActionPending      = "pending"
+	rotationActionMigrated     = "migrated"
+	rotationActionFailed       = "failed"
+)
+
+// rotationState is persisted between stages so each stage can be run
+// separately, possibly days apart, and re-run after a partial failure.
+type rotationState struct {
+	OldCertificate     asc.CertificateRotationCertificate  `json:"oldCertificate"`
+	NewCertificate     *asc.CertificateRotationCertificate `json:"newCertificate,omitempty"`
+	CreatedAt          string                              `json:"createdAt,omitempty"`
+	ProfilesMigratedAt string                              `json:"profilesMigratedAt,omitempty"`
+	RevokedAt          string                              `json:"revokedAt,omitempty"`
+	Profiles           []asc.CertificateRotationProfile    `json:"profiles,omitempty"`
+}
+
+// CertificatesRotateCommand returns the certificates rotate subcommand.
+func CertificatesRotateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+
+	stage := fs.String("stage", "", "Rotation stage: create, migrate-profiles, revoke")
+	id := fs.String("id", "", "ID of the certificate being replaced (--stage create)")
+	statePath := fs.String("state", "./certificate-rotation.json", "State file shared by the rotation stages")
+	keySize := fs.Int("key-size", shared.DefaultSigningKeyBits, "RSA key size in bits for the new certificate")
+	commonName := fs.String("common-name", "asc", "CSR common name for the new certificate")
+	keyOut := fs.String("key-out", "", "Write the new private key (PEM) to this path")
+	certOut := fs.String("cert-out", "", "Write the new certificate (DER .cer) to this path")
+	p12Out := fs.String("p12", "", "Write the new key and certificate as a .p12 to this path")
+	p12PasswordEnv := fs.String("p12-password-env", "", "Environment variable holding the .p12 password")
+	outputDir := fs.String("output-dir", "./profiles", "Directory to download migrated profiles into")
+	dryRun := fs.Bool("dry-run", false, "List affected profiles without changing anything")
+	confirm := fs.Bool("confirm", false, "Confirm recreating profiles or revoking the old certificate")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "rotate",
+		ShortUsage: "asc certificates rotate --stage create|migrate-profiles|revoke [flags]",
+		ShortHelp:  "Replace a certificate and migrate its profiles in stages.",
+		LongHelp: `Replace a certificate and migrate its profiles in stages.
+
+Rotation runs in three stages that share a state file (--state):
+
+  create            Create a certificate of the same type from a locally
+                    generated key and write it as a .p12 (--p12) or key
+                    (--key-out). Requires --id of the certificate to replace.
+  migrate-profiles  Recreate every profile that includes the old certificate
+                    with the new one, keeping its name, bundle ID and devices,
+                    and download the new profiles into --output-dir.
+  revoke            Revoke the old certificate once no profile uses it.
+
+Distribute the new .p12 to build machines before revoking the old
+certificate. Each stage can be re-run; profiles already migrated no longer
+reference the old certificate and are skipped. Before a profile is deleted,
+its replacement is recorded in the state file as pending, so a profile that
+was deleted but not recreated is retried by the next migrate-profiles run,
+as is the download of a recreated profile that could not be written; revoke
+refuses while any profile is pending or failed. --dry-run lists the affected
+profiles without changing anything.
+
+Examples:
+  asc certificates rotate --stage create --id "CERT_ID" --dry-run
+  P12_PASS=secret asc certificates rotate --stage create --id "CERT_ID" --p12 ./dist.p12 --p12-password-env P12_PASS
+  asc certificates rotate --stage migrate-profiles --dry-run
+  asc certificates rotate --stage migrate-profiles --confirm --output-dir ./signing
+  asc certificates rotate --stage revoke --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			stageValue := strings.ToLower(strings.TrimSpace(*stage))
+			switch stageValue {
+			case "":
+				fmt.Fprintln(os.Stderr, "Error: --stage is required")
+				return flag.ErrHelp
+			case rotationStageCreate, rotationStageMigrateProfiles, rotationStageRevoke:
+			default:
+				fmt.Fprintln(os.Stderr, "Error: --stage must be one of create, migrate-profiles, revoke")
+				return flag.ErrHelp
+			}
+			stateFile := strings.TrimSpace(*statePath)
+			if stateFile == "" {
+				fmt.Fprintln(os.Stderr, "Error: --state is required")
+				return flag.ErrHelp
+			}
+			idValue := strings.TrimSpace(*id)
+			if stageValue == rotationStageCreate && idValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --id is required with --stage create")
+				return flag.ErrHelp
+			}
+			if stageValue != rotationStageCreate && idValue != "" {
+				fmt.Fprintln(os.Stderr, "Error: --id is only used with --stage create; later stages read it from --state")
+				return flag.ErrHelp
+			}
+
+			opts := generatedCertificateOptions{
+				KeyBits:    *keySize,
+				CommonName: *commonName,
+				KeyOut:     strings.TrimSpace(*keyOut),
+				CertOut:    strings.TrimSpace(*certOut),
+				P12Out:     strings.TrimSpace(*p12Out),
+			}
+			if stageValue == rotationStageCreate && !*dryRun {
+				if opts.P12Out == "" && opts.KeyOut == "" {
+					fmt.Fprintln(os.Stderr, "Error: --p12 or --key-out is required with --stage create")
+					return flag.ErrHelp
+				}
+				if opts.P12Out != "" {
+					password, err := resolveP12Password(*p12PasswordEnv)
+					if err != nil {
+						return fmt.Errorf("certificates rotate: %w", err)
+					}
+					opts.P12Password = password
+				}
+			}
+			if stageValue != rotationStageCreate && !*dryRun && !*confirm {
+				fmt.Fprintf(os.Stderr, "Error: --confirm is required for --stage %s\n", stageValue)
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("certificates rotate: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var (
+				result *asc.CertificateRotationResult
+				failed int
+			)
+			switch stageValue {
+			case rotationStageCreate:
+				result, err = rotateCreate(requestCtx, client, stateFile, idValue, opts, *dryRun)
+			case rotationStageMigrateProfiles:
+				result, failed, err = rotateMigrateProfiles(requestCtx, client, stateFile, strings.TrimSpace(*outputDir), *dryRun)
+			case rotationStageRevoke:
+				result, err = rotateRevoke(requestCtx, client, stateFile, *dryRun)
+			}
+			if err != nil {
+				return fmt.Errorf("certificates rotate: %w", err)
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("certificates rotate: %d profile(s) failed to migrate", failed))
+			}
+			return nil
+		},
+	}
+}
+
+// rotateCreate creates the replacement certificate. The state file is
+// written before the certificate is created so an interrupted run can be
+// resumed, and refuses to start over once a replacement exists.
+func rotateCreate(ctx context.Context, client *asc.Client, stateFile, certificateID string, opts generatedCertificateOptions, dryRun bool) (*asc.CertificateRotationResult, error) {
+	state, err := readRotationState(stateFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		state = nil
+	case err != nil:
+		return nil, err
+	case state.NewCertificate != nil:
+		return nil, fmt.Errorf("%s already records new certificate %s; run --stage %s next", stateFile, state.NewCertificate.ID, rotationStageMigrateProfiles)
+	case state.OldCertificate.ID != certificateID:
+		return nil, fmt.Errorf("%s is rotating certificate %s, not %s", stateFile, state.OldCertificate.ID, certificateID)
+	}
+
+	resp, err := client.GetCertificate(ctx, certificateID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch certificate: %w", err)
+	}
+	old := asc.CertificateRotationCertificate{
+		ID:              resp.Data.ID,
+		Name:            resp.Data.Attributes.Name,
+		CertificateType: resp.Data.Attributes.CertificateType,
+		SerialNumber:    resp.Data.Attributes.SerialNumber,
+		ExpirationDate:  resp.Data.Attributes.ExpirationDate,
+	}
+	if old.CertificateType == "" || old.SerialNumber == "" {
+		return nil, fmt.Errorf("certificate %s has no type or serial number", certificateID)
+	}
+
+	candidates, err := rotationCandidates(ctx, client, old.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+	result := &asc.CertificateRotationResult{
+		Stage:          rotationStageCreate,
+		StateFile:      stateFile,
+		DryRun:         dryRun,
+		OldCertificate: old,
+		Profiles:       rotationCandidateItems(candidates),
+	}
+	if dryRun {
+		result.NextStep = fmt.Sprintf("run --stage %s without --dry-run to create the new certificate", rotationStageCreate)
+		return result, nil
+	}
+
+	if state == nil {
+		state = &rotationState{OldCertificate: old}
+		if err := writeRotationState(stateFile, state); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.CertificateType = old.CertificateType
+	created, err := createCertificateWithGeneratedKey(ctx, client, opts)
+	if created != nil && created.ID != "" {
+		state.NewCertificate = &asc.CertificateRotationCertificate{
+			ID:              created.ID,
+			Name:            created.Name,
+			CertificateType: created.CertificateType,
+			SerialNumber:    created.SerialNumber,
+			ExpirationDate:  created.ExpirationDate,
+			KeyFile:         created.KeyFile,
+			CertificateFile: created.CertificateFile,
+			P12File:         created.P12File,
+		}
+		state.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+		if writeErr := writeRotationState(stateFile, state); writeErr != nil {
+			return nil, fmt.Errorf("certificate %s created but %w", created.ID, writeErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.NewCertificate = state.NewCertificate
+	result.NextStep = fmt.Sprintf("run --stage %s", rotationStageMigrateProfiles)
+	return result, nil
+}
+
+// rotateMigrateProfiles recreates each profile that still includes the old
+// certificate. The state file is updated after every profile.
+func rotateMigrateProfiles(ctx context.Context, client *asc.Client, stateFile, outputDir string, dryRun bool) (*asc.CertificateRotationResult, int, error) {
+	state, err := readRotationState(stateFile)
+	if err != nil {
+		return nil, 0, err
+	}
+	if state.NewCertificate == nil {
+		return nil, 0, fmt.Errorf("%s has no new certificate; run --stage %s first", stateFile, rotationStageCreate)
+	}
+	if state.RevokedAt != "" {
+		return nil, 0, fmt.Errorf("certificate %s was already revoked", state.OldCertificate.ID)
+	}
+
+	candidates, err := rotationCandidates(ctx, client, state.OldCertificate.SerialNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := &asc.CertificateRotationResult{
+		Stage:          rotationStageMigrateProfiles,
+		StateFile:      stateFile,
+		DryRun:         dryRun,
+		OldCertificate: state.OldCertificate,
+		NewCertificate: state.NewCertificate,
+		Profiles:       []asc.CertificateRotationProfile{},
+	}
+	retries := rotationRetries(state.Profiles, candidates)
+	downloads := rotationDownloads(state.Profiles)
+	if dryRun {
+		result.Profiles = rotationCandidateItems(candidates)
+		for _, entry := range retries {
+			entry.Action = rotationActionWouldMigrate
+			result.Profiles = append(result.Profiles, entry)
+		}
+		result.Profiles = append(result.Profiles, downloads...)
+		result.NextStep = fmt.Sprintf("run --stage %s --confirm", rotationStageMigrateProfiles)
+		return result, 0, nil
+	}
+
+	save := func(item asc.CertificateRotationProfile) error {
+		state.Profiles = recordRotationProfile(state.Profiles, item)
+		return writeRotationState(stateFile, state)
+	}
+	failed := 0
+	record := func(item asc.CertificateRotationProfile, err error) error {
+		if err != nil {
+			// A profile that was recreated but not downloaded stays
+			// migrated; the next run downloads it again.
+			if item.Action != rotationActionMigrated {
+				item.Action = rotationActionFailed
+			}
+			item.Error = err.Error()
+			failed++
+		} else {
+			item.Error = ""
+		}
+		result.Profiles = append(result.Profiles, item)
+		return save(item)
+	}
+	for _, entry := range retries {
+		item, err := recreateRotationProfile(ctx, client, entry, outputDir)
+		if err := record(item, err); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, entry := range downloads {
+		item, err := downloadRotationProfile(ctx, client, entry, outputDir)
+		if err := record(item, err); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, candidate := range candidates {
+		item, err := migrateRotationProfile(ctx, client, candidate, state.OldCertificate.ID, state.NewCertificate.ID, outputDir, save)
+		if err := record(item, err); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if failed == 0 {
+		state.ProfilesMigratedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := writeRotationState(stateFile, state); err != nil {
+			return nil, 0, err
+		}
+		result.NextStep = fmt.Sprintf("distribute the new certificate, then run --stage %s --confirm", rotationStageRevoke)
+	} else {
+		result.NextStep = fmt.Sprintf("re-run --stage %s to retry failed profiles", rotationStageMigrateProfiles)
+	}
+	return result, failed, nil
+}
+
+// rotateRevoke revokes the old certificate. Revoking invalidates every
+// profile that includes it, so any profile still referencing it blocks the
+// revocation.
+func rotateRevoke(ctx context.Context, client *asc.Client, stateFile string, dryRun bool) (*asc.CertificateRotationResult, error) {
+	state, err := readRotationState(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	if state.NewCertificate == nil {
+		return nil, fmt.Errorf("%s has no new certificate; run --stage %s first", stateFile, rotationStageCreate)
+	}
+	result := &asc.CertificateRotationResult{
+		Stage:          rotationStageRevoke,
+		StateFile:      stateFile,
+		DryRun:         dryRun,
+		OldCertificate: state.OldCertificate,
+		NewCertificate: state.NewCertificate,
+		Revoked:        state.RevokedAt != "",
+		Profiles:       []asc.CertificateRotationProfile{},
+	}
+	if result.Revoked {
+		return result, nil
+	}
+	for _, entry := range state.Profiles {
+		if entry.Action == rotationActionPending || entry.Action == rotationActionFailed {
+			return nil, fmt.Errorf("profile %s (%s) has not been migrated; re-run --stage %s first", entry.ID, entry.Name, rotationStageMigrateProfiles)
+		}
+	}
+
+	candidates, err := rotationCandidates(ctx, client, state.OldCertificate.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) > 0 {
+		if dryRun {
+			result.Profiles = rotationCandidateItems(candidates)
+			result.NextStep = fmt.Sprintf("run --stage %s before revoking", rotationStageMigrateProfiles)
+			return result, nil
+		}
+		return nil, fmt.Errorf("%d profile(s) still include certificate %s; run --stage %s first", len(candidates), state.OldCertificate.ID, rotationStageMigrateProfiles)
+	}
+	if dryRun {
+		result.NextStep = fmt.Sprintf("run --stage %s --confirm", rotationStageRevoke)
+		return result, nil
+	}
+
+	if err := client.RevokeCertificate(ctx, state.OldCertificate.ID); err != nil {
+		return nil, fmt.Errorf("revoke certificate: %w", err)
+	}
+	result.Revoked = true
+	state.RevokedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := writeRotationState(stateFile, state); err != nil {
+		return nil, fmt.Errorf("certificate %s revoked but %w", state.OldCertificate.ID, err)
+	}
+	return result, nil
+}
+
+// rotationCandidate is a profile that includes the certificate being rotated.
+type rotationCandidate struct {
+	profile          asc.Resource[asc.ProfileAttributes]
+	bundleID         string
+	bundleIdentifier string
+}
+
+// rotationCandidates lists profiles whose embedded certificates include the
+// given serial number. The API has no certificate-to-profiles relationship,
+// so the profile content is inspected instead.
+func rotationCandidates(ctx context.Context, client *asc.Client, serialNumber string) ([]rotationCandidate, error) {
+	serial := normalizeRotationSerial(serialNumber)
+	now := time.Now()
+
+	var candidates []rotationCandidate
+	next := ""
+	for {
+		resp, err := client.GetProfiles(ctx,
+			asc.WithProfilesInclude([]string{"bundleId"}),
+			asc.WithProfilesLimit(200),
+			asc.WithProfilesNextURL(next),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetch profiles: %w", err)
+		}
+		identifiers, err := shared.IncludedBundleIdentifiers(resp.Included)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Data {
+			content := strings.TrimSpace(item.Attributes.ProfileContent)
+			if content == "" {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				return nil, fmt.Errorf("decode profile %s: %w", item.ID, err)
+			}
+			inspected, err := shared.ParseProvisioningProfile(data, now)
+			if err != nil {
+				return nil, fmt.Errorf("parse profile %s: %w", item.ID, err)
+			}
+			for _, cert := range inspected.Certificates {
+				if normalizeRotationSerial(cert.SerialNumber) == serial {
+					bundleID := shared.ProfileBundleIDRelationship(item.Relationships)
+					candidates = append(candidates, rotationCandidate{profile: item, bundleID: bundleID, bundleIdentifier: identifiers[bundleID]})
+					break
+				}
+			}
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return candidates, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func rotationCandidateItems(candidates []rotationCandidate) []asc.CertificateRotationProfile {
+	items := make([]asc.CertificateRotationProfile, 0, len(candidates))
+	for _, candidate := range candidates {
+		items = append(items, rotationProfileItem(candidate, rotationActionWouldMigrate))
+	}
+	return items
+}
+
+func rotationProfileItem(candidate rotationCandidate, action string) asc.CertificateRotationProfile {
+	return asc.CertificateRotationProfile{
+		ID:          candidate.profile.ID,
+		Name:        candidate.profile.Attributes.Name,
+		ProfileType: candidate.profile.Attributes.ProfileType,
+		BundleID:    candidate.bundleIdentifier,
+		Action:      action,
+	}
+}
+
+func normalizeRotationSerial(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	trimmed := strings.TrimLeft(value, "0")
+	if trimmed == "" && value != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// migrateRotationProfile deletes a profile and recreates it with the new
+// certificate in place of the old one. Profile names are unique, so the old
+// profile has to go first; save records the replacement as pending before
+// the delete so a failure in between can be retried from the state file.
+func migrateRotationProfile(ctx context.Context, client *asc.Client, candidate rotationCandidate, oldCertificateID, newCertificateID, outputDir string, save func(asc.CertificateRotationProfile) error) (asc.CertificateRotationProfile, error) {
+	item := rotationProfileItem(candidate, rotationActionFailed)
+	if candidate.bundleID == "" {
+		return item, fmt.Errorf("profile has no bundle ID")
+	}
+
+	currentCertIDs, err := shared.ListProfileLinkageIDs(ctx, candidate.profile.ID, client.GetProfileCertificatesRelationships)
+	if err != nil {
+		return item, fmt.Errorf("fetch profile certificates: %w", err)
+	}
+	deviceIDs, err := shared.ListProfileLinkageIDs(ctx, candidate.profile.ID, client.GetProfileDevicesRelationships)
+	if err != nil {
+		return item, fmt.Errorf("fetch profile devices: %w", err)
+	}
+	item.BundleResourceID = candidate.bundleID
+	item.CertificateIDs = rotationCertificateIDs(currentCertIDs, oldCertificateID, newCertificateID)
+	item.DeviceIDs = deviceIDs
+
+	item.Action = rotationActionPending
+	if err := save(item); err != nil {
+		item.Action = rotationActionFailed
+		return item, err
+	}
+	item.Action = rotationActionFailed
+	if err := client.DeleteProfile(ctx, candidate.profile.ID); err != nil {
+		return item, fmt.Errorf("delete profile: %w", err)
+	}
+	return recreateRotationProfile(ctx, client, item, outputDir)
+}
+
+// recreateRotationProfile creates the replacement recorded in item and
+// downloads it into outputDir.
+func recreateRotationProfile(ctx context.Context, client *asc.Client, item asc.CertificateRotationProfile, outputDir string) (asc.CertificateRotationProfile, error) {
+	created, err := client.CreateProfile(ctx, asc.ProfileCreateAttributes{
+		Name:        item.Name,
+		ProfileType: item.ProfileType,
+	}, item.BundleResourceID, item.CertificateIDs, item.DeviceIDs)
+	if err != nil {
+		return item, fmt.Errorf("deleted profile %s but failed to recreate it (bundle ID %s, certificates %s, devices %s); re-run --stage %s to retry: %w",
+			item.ID, item.BundleResourceID, strings.Join(item.CertificateIDs, ","), strings.Join(item.DeviceIDs, ","), rotationStageMigrateProfiles, err)
+	}
+	item.NewProfileID = created.Data.ID
+	item.Action = rotationActionMigrated
+	return writeRotationProfile(item, created.Data.Attributes.ProfileContent, outputDir)
+}
+
+// downloadRotationProfile fetches a migrated profile whose file could not be
+// written and writes it into outputDir.
+func downloadRotationProfile(ctx context.Context, client *asc.Client, item asc.CertificateRotationProfile, outputDir string) (asc.CertificateRotationProfile, error) {
+	profile, err := client.GetProfile(ctx, item.NewProfileID)
+	if err != nil {
+		return item, fmt.Errorf("fetch profile %s: %w", item.NewProfileID, err)
+	}
+	return writeRotationProfile(item, profile.Data.Attributes.ProfileContent, outputDir)
+}
+
+// writeRotationProfile writes the base64 profile content of a migrated
+// profile into outputDir.
+func writeRotationProfile(item asc.CertificateRotationProfile, profileContent, outputDir string) (asc.CertificateRotationProfile, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(profileContent))
+	if err != nil {
+		return item, fmt.Errorf("decode profile content: %w", err)
+	}
+	path := filepath.Join(outputDir, shared.ProfileFileName(item.Name, item.NewProfileID))
+	if err := shared.ReplaceProfileFile(path, content); err != nil {
+		return item, fmt.Errorf("write profile: %w", err)
+	}
+	item.File = path
+	return item, nil
+}
+
+// rotationRetries returns state entries whose profile was deleted but not
+// recreated: pending or failed after the delete, and no longer listed among
+// the profiles that include the old certificate.
+func rotationRetries(entries []asc.CertificateRotationProfile, candidates []rotationCandidate) []asc.CertificateRotationProfile {
+	listed := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		listed[candidate.profile.ID] = true
+	}
+	var retries []asc.CertificateRotationProfile
+	for _, entry := range entries {
+		if entry.Action != rotationActionPending && entry.Action != rotationActionFailed {
+			continue
+		}
+		if entry.NewProfileID != "" || entry.BundleResourceID == "" || listed[entry.ID] {
+			continue
+		}
+		retries = append(retries, entry)
+	}
+	return retries
+}
+
+// rotationDownloads returns migrated entries whose new profile was created
+// but could not be written to disk.
+func rotationDownloads(entries []asc.CertificateRotationProfile) []asc.CertificateRotationProfile {
+	var downloads []asc.CertificateRotationProfile
+	for _, entry := range entries {
+		if entry.Action == rotationActionMigrated && entry.NewProfileID != "" && entry.File == "" && entry.Error != "" {
+			downloads = append(downloads, entry)
+		}
+	}
+	return downloads
+}
+
+// rotationCertificateIDs swaps the old certificate for the new one, keeping
+// any other certificates the profile includes.
+func rotationCertificateIDs(current []string, oldID, newID string) []string {
+	ids := []string{newID}
+	for _, id := range current {
+		if id != oldID && id != newID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// recordRotationProfile adds or replaces the entry for a profile.
+func recordRotationProfile(items []asc.CertificateRotationProfile, item asc.CertificateRotationProfile) []asc.CertificateRotationProfile {
+	for i := range items {
+		if items[i].ID == item.ID {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+func readRotationState(path string) (*rotationState, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("read state file: %w", err)
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+	var state rotationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", path, err)
+	}
+	if state.OldCertificate.ID == "" || state.OldCertificate.SerialNumber == "" {
+		return nil, fmt.Errorf("state file %s has no old certificate", path)
+	}
+	return &state, nil
+}
+
+// writeRotationState atomically replaces the state file, so an interrupted
+// write never leaves a truncated state file.
+func writeRotationState(path string, state *rotationState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := shared.WriteFileAtomic(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package certificates
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestRotationCertificateIDs(t *testing.T) {
+	got := rotationCertificateIDs([]string{"OLD", "OTHER", "NEW"}, "OLD", "NEW")
+	if strings.Join(got, ",") != "NEW,OTHER" {
+		t.Fatalf("rotationCertificateIDs() = %v", got)
+	}
+}
+
+func TestNormalizeRotationSerial(t *testing.T) {
+	for input, want := range map[string]string{"0a1b": "A1B", "A1B": "A1B", "00": "0", "": ""} {
+		if got := normalizeRotationSerial(input); got != want {
+			t.Fatalf("normalizeRotationSerial(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRotationStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "rotation.json")
+	if _, err := readRotationState(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected missing state to report ErrNotExist, got %v", err)
+	}
+
+	state := &rotationState{
+		OldCertificate: asc.CertificateRotationCertificate{ID: "OLD", CertificateType: "DISTRIBUTION", SerialNumber: "0A"},
+	}
+	state.Profiles = recordRotationProfile(state.Profiles, asc.CertificateRotationProfile{ID: "P1", Action: rotationActionFailed})
+	state.Profiles = recordRotationProfile(state.Profiles, asc.CertificateRotationProfile{ID: "P1", Action: rotationActionMigrated, NewProfileID: "P1N"})
+	if err := writeRotationState(path, state); err != nil {
+		t.Fatalf("writeRotationState() error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected state file mode 0600, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := readRotationState(path)
+	if err != nil {
+		t.Fatalf("readRotationState() error: %v", err)
+	}
+	if loaded.OldCertificate.ID != "OLD" || len(loaded.Profiles) != 1 || loaded.Profiles[0].NewProfileID != "P1N" {
+		t.Fatalf("unexpected state %+v", loaded)
+	}
+}
+
+func TestRotateCreateChecksExistingState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rotation.json")
+	state := &rotationState{
+		OldCertificate: asc.CertificateRotationCertificate{ID: "OLD", SerialNumber: "0A"},
+		NewCertificate: &asc.CertificateRotationCertificate{ID: "NEW", SerialNumber: "0B"},
+	}
+	if err := writeRotationState(path, state); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := rotateCreate(context.Background(), nil, path, "OLD", generatedCertificateOptions{}, false)
+	if err == nil || !strings.Contains(err.Error(), "already records new certificate NEW") {
+		t.Fatalf("expected completed create stage to be refused, got %v", err)
+	}
+
+	state.NewCertificate = nil
+	if err := writeRotationState(path, state); err != nil {
+		t.Fatal(err)
+	}
+	_, err = rotateCreate(context.Background(), nil, path, "OTHER", generatedCertificateOptions{}, false)
+	if err == nil || !strings.Contains(err.Error(), "is rotating certificate OLD") {
+		t.Fatalf("expected a different certificate to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const buildsRetentionResponse = `{
+  "data": [
+    {"type":"builds","id":"b1","attributes":{"version":"14","uploadedDate":"2020-03-01T00:00:00Z"},
+     "relationships":{"preReleaseVersion":{"data":{"type":"preReleaseVersions","id":"pv2"}},"betaGroups":{"data":[{"type":"betaGroups","id":"g-int"}]}}},
+    {"type":"builds","id":"b2","attributes":{"version":"13","uploadedDate":"2020-02-01T00:00:00Z"},
+     "relationships":{"preReleaseVersion":{"data":{"type":"preReleaseVersions","id":"pv2"}},"betaGroups":{"data":[]}}},
+    {"type":"builds","id":"b3","attributes":{"version":"12","uploadedDate":"2020-01-15T00:00:00Z"},
+     "relationships":{"preReleaseVersion":{"data":{"type":"preReleaseVersions","id":"pv1"}},"appStoreVersion":{"data":{"type":"appStoreVersions","id":"asv1"}}}},
+    {"type":"builds","id":"b4","attributes":{"version":"11","uploadedDate":"2020-01-10T00:00:00Z"},
+     "relationships":{"preReleaseVersion":{"data":{"type":"preReleaseVersions","id":"pv1"}},"betaGroups":{"data":[{"type":"betaGroups","id":"g-ext"}]}}},
+    {"type":"builds","id":"b5","attributes":{"version":"10","uploadedDate":"2020-01-01T00:00:00Z","expired":true}}
+  ],
+  "included": [
+    {"type":"preReleaseVersions","id":"pv1","attributes":{"version":"1.0","platform":"IOS"}},
+    {"type":"preReleaseVersions","id":"pv2","attributes":{"version":"1.1","platform":"IOS"}},
+    {"type":"betaGroups","id":"g-int","attributes":{"name":"Team","isInternalGroup":true}},
+    {"type":"betaGroups","id":"g-ext","attributes":{"name":"Public","isInternalGroup":false}}
+  ],
+  "links": {}
+}`
+
+const buildsRetentionPolicy = `rules:
+  - name: keep-latest-per-version
+    action: keep
+    match:
+      latestPerVersion: 1
+  - name: keep-app-store
+    action: keep
+    match:
+      attachedToAppStoreVersion: true
+  - name: expire-stale
+    action: expire
+    match:
+      olderThan: 60d
+      inExternalGroup: false
+`
+
+func TestBuildsRetentionApplyDryRun(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	policyPath := filepath.Join(t.TempDir(), "retention.yaml")
+	if err := os.WriteFile(policyPath, []byte(buildsRetentionPolicy), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected GET in dry run, got %s %s", req.Method, req.URL.Path)
+		}
+		if req.URL.Path != "/v1/builds" {
+			t.Fatalf("expected path /v1/builds, got %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("filter[app]") != "APP_ID" {
+			t.Fatalf("expected filter[app]=APP_ID, got %q", query.Get("filter[app]"))
+		}
+		if query.Get("include") != "preReleaseVersion,appStoreVersion,betaGroups" {
+			t.Fatalf("unexpected include %q", query.Get("include"))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(buildsRetentionResponse)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"builds", "retention", "apply", "--app", "APP_ID", "--policy", policyPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	var result struct {
+		DryRun         bool `json:"dryRun"`
+		Evaluated      int  `json:"evaluated"`
+		SkippedExpired int  `json:"skippedExpired"`
+		SelectedCount  int  `json:"selectedCount"`
+		ProtectedCount int  `json:"protectedCount"`
+		Decisions      []struct {
+			ID       string `json:"id"`
+			Action   string `json:"action"`
+			KeepRule string `json:"keepRule"`
+		} `json:"decisions"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if !result.DryRun || result.Evaluated != 4 || result.SkippedExpired != 1 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	if result.SelectedCount != 1 || result.ProtectedCount != 2 {
+		t.Fatalf("expected 1 selected and 2 protected, got %+v", result)
+	}
+	want := map[string]string{"b1": "keep-latest-per-version", "b2": "", "b3": "keep-latest-per-version"}
+	if len(result.Decisions) != len(want) {
+		t.Fatalf("unexpected decisions: %+v", result.Decisions)
+	}
+	for _, decision := range result.Decisions {
+		keepRule, ok := want[decision.ID]
+		if !ok || decision.KeepRule != keepRule {
+			t.Fatalf("unexpected decision: %+v", decision)
+		}
+	}
+}
+
+func TestBuildsRetentionApplyInvalidPolicy(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "retention.yaml")
+	if err := os.WriteFile(policyPath, []byte("rules:\n  - name: keep\n    action: keep\n    match:\n      latest: 3\n"), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, _ = captureOutput(t, func() {
+		if err := root.Parse([]string{"builds", "retention", "apply", "--app", "APP_ID", "--policy", policyPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if err == nil || !strings.Contains(err.Error(), `at least one "expire" rule is required`) {
+			t.Fatalf("expected missing expire rule error, got %v", err)
+		}
+	})
+}
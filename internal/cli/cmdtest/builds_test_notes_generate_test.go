@@ -0,0 +1,73 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestBuildsTestNotesGenerateDryRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=tester@example.com",
+			"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=tester@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	git("commit", "-q", "--allow-empty", "-m", "feat: offline mode")
+	git("commit", "-q", "--allow-empty", "-m", "chore: lint")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"builds", "test-notes", "generate",
+			"--from-git", "HEAD~2..HEAD",
+			"--git-repo", repo,
+			"--git-type", "feat",
+			"--locale", "en-US,de-DE",
+			"--dry-run",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	var result struct {
+		Notes   string `json:"notes"`
+		Commits int    `json:"commits"`
+		DryRun  bool   `json:"dryRun"`
+		Locales []struct {
+			Locale string `json:"locale"`
+		} `json:"locales"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if result.Notes != "- offline mode" || result.Commits != 1 || !result.DryRun {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Locales) != 2 || result.Locales[1].Locale != "de-DE" {
+		t.Fatalf("expected both locales, got %+v", result.Locales)
+	}
+}
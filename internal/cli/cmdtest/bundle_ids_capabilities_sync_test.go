@@ -0,0 +1,210 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBundleIDsCapabilitiesSyncValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "plan missing bundle",
+			args:    []string{"bundle-ids", "capabilities", "plan", "--file", "capabilities.yaml"},
+			wantErr: "--bundle or --bundle-id is required",
+		},
+		{
+			name:    "plan bundle and bundle-id",
+			args:    []string{"bundle-ids", "capabilities", "plan", "--bundle", "B1", "--bundle-id", "com.example.app", "--file", "capabilities.yaml"},
+			wantErr: "--bundle and --bundle-id are mutually exclusive",
+		},
+		{
+			name:    "plan missing source",
+			args:    []string{"bundle-ids", "capabilities", "plan", "--bundle", "B1"},
+			wantErr: "--file or --entitlements is required",
+		},
+		{
+			name:    "plan file and entitlements",
+			args:    []string{"bundle-ids", "capabilities", "plan", "--bundle", "B1", "--file", "capabilities.yaml", "--entitlements", "App.entitlements"},
+			wantErr: "--file and --entitlements are mutually exclusive",
+		},
+		{
+			name:    "apply missing confirm",
+			args:    []string{"bundle-ids", "capabilities", "apply", "--bundle", "B1", "--file", "capabilities.yaml"},
+			wantErr: "--confirm is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestBundleIDsCapabilitiesApplyFromEntitlements(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/bundleIds":
+			if got := req.URL.Query().Get("filter[identifier]"); got != "com.example.app" {
+				t.Fatalf("unexpected identifier filter %q", got)
+			}
+			return respond(http.StatusOK, `{"data":[{"type":"bundleIds","id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}}],"links":{}}`)
+		case "GET /v1/bundleIds/B1/bundleIdCapabilities":
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"bundleIdCapabilities","id":"C_IAP","attributes":{"capabilityType":"IN_APP_PURCHASE"}},`+
+				`{"type":"bundleIdCapabilities","id":"C_HK","attributes":{"capabilityType":"HEALTHKIT"}},`+
+				`{"type":"bundleIdCapabilities","id":"C_DP","attributes":{"capabilityType":"DATA_PROTECTION","settings":[{"key":"DATA_PROTECTION_PERMISSION_LEVEL","options":[{"key":"PROTECTED_UNLESS_OPEN","enabled":true}]}]}}],"links":{}}`)
+		case "POST /v1/bundleIdCapabilities":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						CapabilityType string `json:"capabilityType"`
+					} `json:"attributes"`
+					Relationships struct {
+						BundleID struct {
+							Data struct {
+								ID string `json:"id"`
+							} `json:"data"`
+						} `json:"bundleId"`
+					} `json:"relationships"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if payload.Data.Relationships.BundleID.Data.ID != "B1" {
+				t.Fatalf("unexpected bundle ID in %+v", payload.Data)
+			}
+			capabilityType := payload.Data.Attributes.CapabilityType
+			requests = append(requests, "add "+capabilityType)
+			return respond(http.StatusCreated, `{"data":{"type":"bundleIdCapabilities","id":"NEW_`+capabilityType+`","attributes":{"capabilityType":"`+capabilityType+`"}}}`)
+		case "PATCH /v1/bundleIdCapabilities/C_DP":
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"COMPLETE_PROTECTION"`) {
+				t.Fatalf("unexpected update body %s", body)
+			}
+			requests = append(requests, "update DATA_PROTECTION")
+			return respond(http.StatusOK, `{"data":{"type":"bundleIdCapabilities","id":"C_DP","attributes":{"capabilityType":"DATA_PROTECTION"}}}`)
+		case "DELETE /v1/bundleIdCapabilities/C_HK":
+			requests = append(requests, "remove HEALTHKIT")
+			return respond(http.StatusNoContent, "")
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	path := filepath.Join(t.TempDir(), "App.entitlements")
+	entitlements := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>aps-environment</key>
+	<string>development</string>
+	<key>com.apple.developer.default-data-protection</key>
+	<string>NSFileProtectionComplete</string>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>group.com.example.shared</string>
+	</array>
+</dict>
+</plist>
+`
+	if err := os.WriteFile(path, []byte(entitlements), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"bundle-ids", "capabilities", "apply", "--bundle-id", "com.example.app", "--entitlements", path, "--prune", "--confirm"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		BundleID string `json:"bundleId"`
+		Applied  bool   `json:"applied"`
+		Changes  []struct {
+			CapabilityType string `json:"capabilityType"`
+			Action         string `json:"action"`
+			CapabilityID   string `json:"capabilityId"`
+			Status         string `json:"status"`
+		} `json:"changes"`
+		Notes []string `json:"notes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	var changes []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.CapabilityType+" "+change.Action+" "+change.CapabilityID+" "+change.Status)
+	}
+	want := []string{
+		"APP_GROUPS add NEW_APP_GROUPS applied",
+		"DATA_PROTECTION update C_DP applied",
+		"HEALTHKIT remove C_HK applied",
+		"IN_APP_PURCHASE extra C_IAP ",
+		"PUSH_NOTIFICATIONS add NEW_PUSH_NOTIFICATIONS applied",
+	}
+	if result.BundleID != "B1" || !result.Applied || strings.Join(changes, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected result %+v\n%s", result, strings.Join(changes, "\n"))
+	}
+	if len(result.Notes) != 1 || !strings.Contains(result.Notes[0], "group.com.example.shared") {
+		t.Fatalf("unexpected notes %v", result.Notes)
+	}
+	sort.Strings(requests)
+	if got := strings.Join(requests, ","); got != "add APP_GROUPS,add PUSH_NOTIFICATIONS,remove HEALTHKIT,update DATA_PROTECTION" {
+		t.Fatalf("unexpected requests %s", got)
+	}
+}
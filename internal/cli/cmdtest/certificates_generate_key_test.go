@@ -0,0 +1,247 @@
+package cmdtest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificatesCreateGenerateKey(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("P12_PASS", "s3cret")
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test WWDR"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || req.URL.Path != "/v1/certificates" {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		var payload struct {
+			Data struct {
+				Attributes struct {
+					CSRContent      string `json:"csrContent"`
+					CertificateType string `json:"certificateType"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if payload.Data.Attributes.CertificateType != "DISTRIBUTION" {
+			t.Fatalf("expected DISTRIBUTION, got %q", payload.Data.Attributes.CertificateType)
+		}
+		der, err := base64.StdEncoding.DecodeString(payload.Data.Attributes.CSRContent)
+		if err != nil {
+			t.Fatalf("decode CSR: %v", err)
+		}
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			t.Fatalf("parse CSR: %v", err)
+		}
+		if err := csr.CheckSignature(); err != nil {
+			t.Fatalf("CSR signature: %v", err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(7),
+			Subject:      pkix.Name{CommonName: "Apple Distribution: Example (TEAM123456)"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, csr.PublicKey, caKey)
+		if err != nil {
+			t.Fatalf("sign certificate: %v", err)
+		}
+		body := `{"data":{"type":"certificates","id":"CERT_NEW","attributes":{"name":"Apple Distribution: Example","certificateType":"DISTRIBUTION","serialNumber":"07","certificateContent":"` +
+			base64.StdEncoding.EncodeToString(certDER) + `"}}}`
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	dir := t.TempDir()
+	p12Path := filepath.Join(dir, "dist.p12")
+	keyPath := filepath.Join(dir, "dist.key")
+	certPath := filepath.Join(dir, "dist.cer")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"certificates", "create", "--certificate-type", "DISTRIBUTION", "--generate-key",
+			"--p12", p12Path, "--p12-password-env", "P12_PASS", "--key-out", keyPath, "--cert-out", certPath,
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		ID              string `json:"id"`
+		KeyFile         string `json:"keyFile"`
+		CertificateFile string `json:"certificateFile"`
+		P12File         string `json:"p12File"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if result.ID != "CERT_NEW" || result.P12File != p12Path || result.KeyFile != keyPath || result.CertificateFile != certPath {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	for _, path := range []string{p12Path, keyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected %s to be 0600, got %v", path, info.Mode().Perm())
+		}
+	}
+
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		t.Fatalf("expected PKCS#8 key, got %q", keyPEM)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certDER, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.(*rsa.PrivateKey).PublicKey.Equal(cert.PublicKey) {
+		t.Fatal("generated key does not match the issued certificate")
+	}
+	if p12, err := os.ReadFile(p12Path); err != nil || len(p12) == 0 {
+		t.Fatalf("expected .p12 bundle, got %d bytes (%v)", len(p12), err)
+	}
+}
+
+func TestCertificatesCreateGenerateKeyRequiresPasswordEnv(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"certificates", "create", "--certificate-type", "DISTRIBUTION", "--generate-key",
+			"--p12", filepath.Join(t.TempDir(), "dist.p12"), "--p12-password-env", "ASC_TEST_UNSET_P12_PASS",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "ASC_TEST_UNSET_P12_PASS is not set") {
+		t.Fatalf("expected unset env error, got %v", runErr)
+	}
+}
+
+func TestCertificatesCreateGenerateKeyKeepsKeyWhenDownloadFails(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("P12_PASS", "s3cret")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /v1/certificates":
+			body := `{"data":{"type":"certificates","id":"CERT_NEW","attributes":{"name":"Apple Distribution: Example","certificateType":"DISTRIBUTION","serialNumber":"07"}}}`
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		case "GET /v1/certificates/CERT_NEW":
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"500","title":"Internal Server Error"}]}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	dir := t.TempDir()
+	p12Path := filepath.Join(dir, "dist.p12")
+	keyPath := filepath.Join(dir, "dist.key")
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"certificates", "create", "--certificate-type", "DISTRIBUTION", "--generate-key",
+			"--p12", p12Path, "--p12-password-env", "P12_PASS",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "private key saved to "+keyPath) {
+		t.Fatalf("expected error naming the saved key, got %v", runErr)
+	}
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatalf("expected private key to be kept: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected %s to be 0600, got %v", keyPath, info.Mode().Perm())
+	}
+	if _, err := os.Stat(p12Path); !os.IsNotExist(err) {
+		t.Fatalf("expected no .p12 after failure, got %v", err)
+	}
+}
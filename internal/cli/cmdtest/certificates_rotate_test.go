@@ -0,0 +1,469 @@
+package cmdtest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func rotationTestCertificate(t *testing.T, serial int64) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Example (TEAM123456)"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func rotationTestProfile(t *testing.T, signer *x509.Certificate, key *rsa.PrivateKey, name string, certs ...*x509.Certificate) string {
+	t.Helper()
+	developerCertificates := make([][]byte, 0, len(certs))
+	for _, cert := range certs {
+		developerCertificates = append(developerCertificates, cert.Raw)
+	}
+	content, err := plist.Marshal(map[string]any{
+		"Name":                  name,
+		"UUID":                  name + "-UUID",
+		"TeamIdentifier":        []string{"TEAM123456"},
+		"ExpirationDate":        time.Now().Add(24 * time.Hour),
+		"DeveloperCertificates": developerCertificates,
+		"Entitlements":          map[string]any{"application-identifier": "TEAM123456.com.example.app"},
+	}, plist.XMLFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := shared.SignData(content, signer, key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(signed)
+}
+
+func TestCertificatesRotateMigrateProfilesAndRevoke(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	oldCert, signingKey := rotationTestCertificate(t, 10)
+	newCert, _ := rotationTestCertificate(t, 11)
+	otherCert, _ := rotationTestCertificate(t, 12)
+	oldProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", oldCert, otherCert)
+	newProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", newCert, otherCert)
+	unrelatedProfile := rotationTestProfile(t, oldCert, signingKey, "Other", otherCert)
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "rotation.json")
+	state := `{"oldCertificate":{"id":"OLD","certificateType":"DISTRIBUTION","serialNumber":"0A"},` +
+		`"newCertificate":{"id":"NEW","certificateType":"DISTRIBUTION","serialNumber":"0B","p12File":"dist.p12"},"createdAt":"2026-10-01T00:00:00Z"}`
+	if err := os.WriteFile(statePath, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	migrated := false
+	revoked := false
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		relationships := `"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}`
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/profiles":
+			first := `{"type":"profiles","id":"P1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"` + oldProfile + `"},` + relationships + `}`
+			if migrated {
+				first = `{"type":"profiles","id":"P1N","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"` + newProfile + `"},` + relationships + `}`
+			}
+			return respond(http.StatusOK, `{"data":[`+first+`,`+
+				`{"type":"profiles","id":"P2","attributes":{"name":"Other","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"`+unrelatedProfile+`"},`+relationships+`}],`+
+				`"included":[{"type":"bundleIds","id":"B1","attributes":{"identifier":"com.example.app"}}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/certificates":
+			return respond(http.StatusOK, `{"data":[{"type":"certificates","id":"OLD"},{"type":"certificates","id":"OTHER"}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/devices":
+			return respond(http.StatusOK, `{"data":[],"links":{}}`)
+		case "DELETE /v1/profiles/P1":
+			return respond(http.StatusNoContent, "")
+		case "POST /v1/profiles":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						Name        string `json:"name"`
+						ProfileType string `json:"profileType"`
+					} `json:"attributes"`
+					Relationships struct {
+						BundleID struct {
+							Data struct {
+								ID string `json:"id"`
+							} `json:"data"`
+						} `json:"bundleId"`
+						Certificates struct {
+							Data []struct {
+								ID string `json:"id"`
+							} `json:"data"`
+						} `json:"certificates"`
+					} `json:"relationships"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			var certIDs []string
+			for _, cert := range payload.Data.Relationships.Certificates.Data {
+				certIDs = append(certIDs, cert.ID)
+			}
+			if payload.Data.Attributes.Name != "App Store" || payload.Data.Relationships.BundleID.Data.ID != "B1" || strings.Join(certIDs, ",") != "NEW,OTHER" {
+				t.Fatalf("unexpected profile create %+v (certificates %v)", payload.Data, certIDs)
+			}
+			migrated = true
+			return respond(http.StatusCreated, `{"data":{"type":"profiles","id":"P1N","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileContent":"`+newProfile+`"}}}`)
+		case "DELETE /v1/certificates/OLD":
+			if !migrated {
+				t.Fatal("expected profiles to be migrated before revoking")
+			}
+			revoked = true
+			return respond(http.StatusNoContent, "")
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse(append([]string{"certificates", "rotate", "--state", statePath}, args...)); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+		return stdout
+	}
+
+	type rotationOutput struct {
+		Stage    string `json:"stage"`
+		DryRun   bool   `json:"dryRun"`
+		Revoked  bool   `json:"revoked"`
+		Profiles []struct {
+			ID           string `json:"id"`
+			BundleID     string `json:"bundleId"`
+			Action       string `json:"action"`
+			NewProfileID string `json:"newProfileId"`
+			File         string `json:"file"`
+		} `json:"profiles"`
+	}
+	parse := func(stdout string) rotationOutput {
+		t.Helper()
+		var result rotationOutput
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("parse output: %v\n%s", err, stdout)
+		}
+		return result
+	}
+
+	dryRun := parse(run("--stage", "migrate-profiles", "--dry-run"))
+	if !dryRun.DryRun || len(dryRun.Profiles) != 1 || dryRun.Profiles[0].ID != "P1" || dryRun.Profiles[0].Action != "would-migrate" || dryRun.Profiles[0].BundleID != "com.example.app" {
+		t.Fatalf("unexpected dry run %+v", dryRun)
+	}
+	if migrated {
+		t.Fatal("expected dry run not to change profiles")
+	}
+
+	outputDir := filepath.Join(dir, "profiles")
+	result := parse(run("--stage", "migrate-profiles", "--confirm", "--output-dir", outputDir))
+	if len(result.Profiles) != 1 || result.Profiles[0].Action != "migrated" || result.Profiles[0].NewProfileID != "P1N" {
+		t.Fatalf("unexpected migration %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "App Store.mobileprovision")); err != nil {
+		t.Fatalf("expected migrated profile file: %v", err)
+	}
+
+	result = parse(run("--stage", "revoke", "--confirm"))
+	if !revoked || !result.Revoked || len(result.Profiles) != 0 {
+		t.Fatalf("unexpected revoke %+v", result)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		ProfilesMigratedAt string `json:"profilesMigratedAt"`
+		RevokedAt          string `json:"revokedAt"`
+		Profiles           []struct {
+			ID           string `json:"id"`
+			NewProfileID string `json:"newProfileId"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("parse state: %v\n%s", err, data)
+	}
+	if saved.ProfilesMigratedAt == "" || saved.RevokedAt == "" || len(saved.Profiles) != 1 || saved.Profiles[0].NewProfileID != "P1N" {
+		t.Fatalf("unexpected state %s", data)
+	}
+}
+
+func TestCertificatesRotateRetriesDeletedProfile(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	oldCert, signingKey := rotationTestCertificate(t, 10)
+	newCert, _ := rotationTestCertificate(t, 11)
+	oldProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", oldCert)
+	newProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", newCert)
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "rotation.json")
+	state := `{"oldCertificate":{"id":"OLD","certificateType":"DISTRIBUTION","serialNumber":"0A"},` +
+		`"newCertificate":{"id":"NEW","certificateType":"DISTRIBUTION","serialNumber":"0B"}}`
+	if err := os.WriteFile(statePath, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	deleted := false
+	createFails := true
+	var creates []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/profiles":
+			if deleted {
+				return respond(http.StatusOK, `{"data":[],"links":{}}`)
+			}
+			return respond(http.StatusOK, `{"data":[{"type":"profiles","id":"P1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"`+oldProfile+`"},`+
+				`"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/certificates":
+			return respond(http.StatusOK, `{"data":[{"type":"certificates","id":"OLD"}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/devices":
+			return respond(http.StatusOK, `{"data":[{"type":"devices","id":"D1"}],"links":{}}`)
+		case "DELETE /v1/profiles/P1":
+			if deleted {
+				t.Fatal("expected the deleted profile not to be deleted again")
+			}
+			deleted = true
+			return respond(http.StatusNoContent, "")
+		case "POST /v1/profiles":
+			body, _ := io.ReadAll(req.Body)
+			creates = append(creates, string(body))
+			if createFails {
+				return respond(http.StatusInternalServerError, `{"errors":[{"status":"500","title":"Internal Server Error"}]}`)
+			}
+			return respond(http.StatusCreated, `{"data":{"type":"profiles","id":"P1N","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileContent":"`+newProfile+`"}}}`)
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	run := func(args ...string) error {
+		t.Helper()
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		var runErr error
+		captureOutput(t, func() {
+			if err := root.Parse(append([]string{"certificates", "rotate", "--state", statePath}, args...)); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		return runErr
+	}
+	readState := func() string {
+		t.Helper()
+		data, err := os.ReadFile(statePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	outputDir := filepath.Join(dir, "profiles")
+	if err := run("--stage", "migrate-profiles", "--confirm", "--output-dir", outputDir); err == nil {
+		t.Fatal("expected the failed recreate to be reported")
+	}
+	saved := readState()
+	for _, want := range []string{`"action": "failed"`, `"bundleResourceId": "B1"`, `"NEW"`, `"D1"`, `"profileType": "IOS_APP_STORE"`} {
+		if !strings.Contains(saved, want) {
+			t.Fatalf("expected state to contain %s, got:\n%s", want, saved)
+		}
+	}
+
+	if err := run("--stage", "revoke", "--confirm"); err == nil || !strings.Contains(err.Error(), "has not been migrated") {
+		t.Fatalf("expected revoke to refuse, got %v", err)
+	}
+
+	createFails = false
+	if err := run("--stage", "migrate-profiles", "--confirm", "--output-dir", outputDir); err != nil {
+		t.Fatalf("retry error: %v", err)
+	}
+	if len(creates) != 2 || creates[0] != creates[1] {
+		t.Fatalf("expected the retry to recreate the same profile, got %v", creates)
+	}
+	if saved := readState(); !strings.Contains(saved, `"newProfileId": "P1N"`) || !strings.Contains(saved, `"profilesMigratedAt"`) {
+		t.Fatalf("unexpected state after retry:\n%s", saved)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "App Store.mobileprovision")); err != nil {
+		t.Fatalf("expected recreated profile file: %v", err)
+	}
+}
+
+func TestCertificatesRotateDownloadsProfileAfterWriteFailure(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	oldCert, signingKey := rotationTestCertificate(t, 10)
+	newCert, _ := rotationTestCertificate(t, 11)
+	oldProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", oldCert)
+	newProfile := rotationTestProfile(t, oldCert, signingKey, "App Store", newCert)
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "rotation.json")
+	state := `{"oldCertificate":{"id":"OLD","certificateType":"DISTRIBUTION","serialNumber":"0A"},` +
+		`"newCertificate":{"id":"NEW","certificateType":"DISTRIBUTION","serialNumber":"0B"}}`
+	if err := os.WriteFile(statePath, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A file in place of the output directory makes the first write fail.
+	outputDir := filepath.Join(dir, "profiles")
+	if err := os.WriteFile(outputDir, []byte("not a directory"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	deleted, creates, downloads := false, 0, 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/profiles":
+			if deleted {
+				return respond(http.StatusOK, `{"data":[],"links":{}}`)
+			}
+			return respond(http.StatusOK, `{"data":[{"type":"profiles","id":"P1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"`+oldProfile+`"},`+
+				`"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/certificates":
+			return respond(http.StatusOK, `{"data":[{"type":"certificates","id":"OLD"}],"links":{}}`)
+		case "GET /v1/profiles/P1/relationships/devices":
+			return respond(http.StatusOK, `{"data":[],"links":{}}`)
+		case "DELETE /v1/profiles/P1":
+			deleted = true
+			return respond(http.StatusNoContent, "")
+		case "POST /v1/profiles":
+			creates++
+			return respond(http.StatusCreated, `{"data":{"type":"profiles","id":"P1N","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileContent":"`+newProfile+`"}}}`)
+		case "GET /v1/profiles/P1N":
+			downloads++
+			return respond(http.StatusOK, `{"data":{"type":"profiles","id":"P1N","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileContent":"`+newProfile+`"}}}`)
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	run := func() error {
+		t.Helper()
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		var runErr error
+		captureOutput(t, func() {
+			if err := root.Parse([]string{"certificates", "rotate", "--state", statePath, "--stage", "migrate-profiles", "--confirm", "--output-dir", outputDir}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		return runErr
+	}
+
+	if err := run(); err == nil {
+		t.Fatal("expected the failed profile write to be reported")
+	}
+	saved, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"action": "migrated"`, `"newProfileId": "P1N"`, `"error": "write profile`} {
+		if !strings.Contains(string(saved), want) {
+			t.Fatalf("expected state to contain %s, got:\n%s", want, saved)
+		}
+	}
+
+	if err := os.Remove(outputDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(); err != nil {
+		t.Fatalf("retry error: %v", err)
+	}
+	if creates != 1 || downloads != 1 {
+		t.Fatalf("expected one create and one download, got %d and %d", creates, downloads)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "App Store.mobileprovision"))
+	if err != nil {
+		t.Fatalf("expected downloaded profile file: %v", err)
+	}
+	if decoded, _ := base64.StdEncoding.DecodeString(newProfile); string(data) != string(decoded) {
+		t.Fatal("unexpected profile file content")
+	}
+	if saved, _ := os.ReadFile(statePath); strings.Contains(string(saved), `"error"`) || !strings.Contains(string(saved), `"profilesMigratedAt"`) {
+		t.Fatalf("unexpected state after retry:\n%s", saved)
+	}
+}
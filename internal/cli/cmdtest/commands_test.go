@@ -367,6 +367,21 @@ func TestBuildsExpireAllValidationErrors(t *testing.T) {
 			args:    []string{"builds", "expire-all", "--app", "APP_ID", "--older-than", "90d"},
 			wantErr: "--confirm is required to expire builds",
 		},
+		{
+			name:    "builds retention apply missing app",
+			args:    []string{"builds", "retention", "apply", "--policy", "retention.yaml", "--dry-run"},
+			wantErr: "Error: --app is required",
+		},
+		{
+			name:    "builds retention apply missing policy",
+			args:    []string{"builds", "retention", "apply", "--app", "APP_ID", "--dry-run"},
+			wantErr: "Error: --policy is required",
+		},
+		{
+			name:    "builds retention apply missing confirm",
+			args:    []string{"builds", "retention", "apply", "--app", "APP_ID", "--policy", "retention.yaml"},
+			wantErr: "--confirm is required to expire builds",
+		},
 	}
 
 	for _, test := range tests {
@@ -726,6 +741,66 @@ func TestBetaManagementValidationErrors(t *testing.T) {
 			args:    []string{"testflight", "beta-testers", "invite", "--app", "APP_ID"},
 			wantErr: "--email is required",
 		},
+		{
+			name:    "beta-testers engagement missing app",
+			args:    []string{"testflight", "beta-testers", "engagement", "--since", "90d"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "beta-testers engagement invalid since",
+			args:    []string{"testflight", "beta-testers", "engagement", "--app", "APP_ID", "--since", "60d"},
+			wantErr: "--since must be one of: 7d, 30d, 90d, 365d",
+		},
+		{
+			name:    "beta-testers prune missing group",
+			args:    []string{"testflight", "beta-testers", "prune", "--app", "APP_ID", "--since", "90d", "--dry-run"},
+			wantErr: "--group is required",
+		},
+		{
+			name:    "beta-testers prune missing since",
+			args:    []string{"testflight", "beta-testers", "prune", "--app", "APP_ID", "--group", "External", "--dry-run"},
+			wantErr: "--since is required",
+		},
+		{
+			name:    "beta-testers prune missing confirm",
+			args:    []string{"testflight", "beta-testers", "prune", "--app", "APP_ID", "--group", "External", "--since", "90d"},
+			wantErr: "--confirm is required to remove testers",
+		},
+		{
+			name:    "beta-testers import missing file",
+			args:    []string{"testflight", "beta-testers", "import", "--app", "APP_ID", "--group", "External"},
+			wantErr: "--file is required",
+		},
+		{
+			name:    "beta-testers import missing group",
+			args:    []string{"testflight", "beta-testers", "import", "--app", "APP_ID", "--file", "testers.csv"},
+			wantErr: "--group is required",
+		},
+		{
+			name:    "beta-testers export missing file",
+			args:    []string{"testflight", "beta-testers", "export", "--app", "APP_ID"},
+			wantErr: "--file is required",
+		},
+		{
+			name:    "feedback export missing dir",
+			args:    []string{"feedback", "export", "--app", "APP_ID"},
+			wantErr: "--dir is required",
+		},
+		{
+			name:    "crashes export missing dir",
+			args:    []string{"crashes", "export", "--app", "APP_ID"},
+			wantErr: "--dir is required",
+		},
+		{
+			name:    "crashes symbolicate missing source",
+			args:    []string{"crashes", "symbolicate", "--dsym", "MyApp.app.dSYM"},
+			wantErr: "--file, --crash-log-id, or --submission-id is required",
+		},
+		{
+			name:    "crashes symbolicate missing dsym",
+			args:    []string{"crashes", "symbolicate", "--file", "MyApp.crash"},
+			wantErr: "--dsym or --dsym-dir is required",
+		},
 		{
 			name:    "beta-testers get missing id",
 			args:    []string{"testflight", "beta-testers", "get"},
@@ -1701,6 +1776,26 @@ func TestDevicesValidationErrors(t *testing.T) {
 			args:    []string{"devices", "register", "--name", "My Device", "--udid", "UDID"},
 			wantErr: "--platform is required",
 		},
+		{
+			name:    "devices collect missing name",
+			args:    []string{"devices", "collect"},
+			wantErr: "--name is required",
+		},
+		{
+			name:    "devices collect invalid port",
+			args:    []string{"devices", "collect", "--name", "Acme", "--port", "70000"},
+			wantErr: "--port must be between 1 and 65535",
+		},
+		{
+			name:    "devices collect tls cert without key",
+			args:    []string{"devices", "collect", "--name", "Acme", "--tls-cert", "cert.pem"},
+			wantErr: "--tls-cert and --tls-key must be used together",
+		},
+		{
+			name:    "devices collect invalid url",
+			args:    []string{"devices", "collect", "--name", "Acme", "--url", "devices.example.com"},
+			wantErr: "--url must start with https:// or http://",
+		},
 	}
 
 	for _, test := range tests {
@@ -1744,6 +1839,56 @@ func TestCertificatesValidationErrors(t *testing.T) {
 			args:    []string{"certificates", "update", "--id", "CERT_ID"},
 			wantErr: "Error: --activated is required",
 		},
+		{
+			name:    "certificates create generate-key missing outputs",
+			args:    []string{"certificates", "create", "--certificate-type", "DISTRIBUTION", "--generate-key"},
+			wantErr: "Error: --p12 or --key-out is required with --generate-key",
+		},
+		{
+			name:    "certificates create cert-out without generate-key",
+			args:    []string{"certificates", "create", "--certificate-type", "DISTRIBUTION", "--csr", "./cert.csr", "--cert-out", "out.cer"},
+			wantErr: "Error: --key-out, --cert-out and --p12 require --generate-key",
+		},
+		{
+			name:    "certificates create csr with generate-key",
+			args:    []string{"certificates", "create", "--certificate-type", "DISTRIBUTION", "--csr", "./cert.csr", "--generate-key", "--p12", "out.p12"},
+			wantErr: "Error: --csr and --generate-key are mutually exclusive",
+		},
+		{
+			name:    "certificates rotate missing stage",
+			args:    []string{"certificates", "rotate"},
+			wantErr: "Error: --stage is required",
+		},
+		{
+			name:    "certificates rotate invalid stage",
+			args:    []string{"certificates", "rotate", "--stage", "renew"},
+			wantErr: "Error: --stage must be one of create, migrate-profiles, revoke",
+		},
+		{
+			name:    "certificates rotate create missing id",
+			args:    []string{"certificates", "rotate", "--stage", "create", "--p12", "out.p12"},
+			wantErr: "Error: --id is required with --stage create",
+		},
+		{
+			name:    "certificates rotate create missing outputs",
+			args:    []string{"certificates", "rotate", "--stage", "create", "--id", "CERT_ID"},
+			wantErr: "Error: --p12 or --key-out is required with --stage create",
+		},
+		{
+			name:    "certificates rotate id with later stage",
+			args:    []string{"certificates", "rotate", "--stage", "revoke", "--id", "CERT_ID", "--confirm"},
+			wantErr: "Error: --id is only used with --stage create",
+		},
+		{
+			name:    "certificates rotate migrate missing confirm",
+			args:    []string{"certificates", "rotate", "--stage", "migrate-profiles"},
+			wantErr: "Error: --confirm is required for --stage migrate-profiles",
+		},
+		{
+			name:    "certificates rotate revoke missing confirm",
+			args:    []string{"certificates", "rotate", "--stage", "revoke"},
+			wantErr: "Error: --confirm is required for --stage revoke",
+		},
 	}
 
 	for _, test := range tests {
@@ -3024,6 +3169,41 @@ func TestLocalizationsValidationErrors(t *testing.T) {
 			args:    []string{"localizations", "upload", "--type", "app-info", "--path", "localizations"},
 			wantErr: "--app is required",
 		},
+		{
+			name:    "localizations lint missing source",
+			args:    []string{"localizations", "lint"},
+			wantErr: "one of --version, --app, --path or --fastlane-dir is required",
+		},
+		{
+			name:    "localizations translate missing from",
+			args:    []string{"localizations", "translate", "--version", "VERSION_ID", "--to", "de-DE", "--translator-cmd", "./translate.sh"},
+			wantErr: "--from is required",
+		},
+		{
+			name:    "localizations translate missing to",
+			args:    []string{"localizations", "translate", "--version", "VERSION_ID", "--from", "en-US", "--translator-cmd", "./translate.sh"},
+			wantErr: "--to is required",
+		},
+		{
+			name:    "localizations translate missing translator",
+			args:    []string{"localizations", "translate", "--version", "VERSION_ID", "--from", "en-US", "--to", "de-DE"},
+			wantErr: "--translator-cmd is required",
+		},
+		{
+			name:    "localizations copy missing source version",
+			args:    []string{"localizations", "copy", "--to-version", "V2"},
+			wantErr: "--from-version is required",
+		},
+		{
+			name:    "localizations copy missing destination version",
+			args:    []string{"localizations", "copy", "--from-version", "V1"},
+			wantErr: "--to-version is required",
+		},
+		{
+			name:    "localizations copy unpaired app",
+			args:    []string{"localizations", "copy", "--from-version", "V1", "--to-version", "V2", "--from-app", "APP"},
+			wantErr: "--from-app and --to-app must be used together",
+		},
 	}
 
 	for _, test := range tests {
@@ -3117,6 +3297,46 @@ func TestAssetsValidationErrors(t *testing.T) {
 			args:    []string{"assets", "previews", "delete", "--id", "PREVIEW_ID"},
 			wantErr: "--confirm is required to delete",
 		},
+		{
+			name:    "assets screenshots upload invalid max retries",
+			args:    []string{"assets", "screenshots", "upload", "--version-localization", "LOC_ID", "--path", "./screenshots", "--device-type", "IPHONE_65", "--max-retries", "-1"},
+			wantErr: "--max-retries must be 0 or greater",
+		},
+		{
+			name:    "assets previews upload invalid poll interval",
+			args:    []string{"assets", "previews", "upload", "--version-localization", "LOC_ID", "--path", "./previews", "--device-type", "IPHONE_65", "--poll-interval", "0s"},
+			wantErr: "--poll-interval must be greater than 0",
+		},
+		{
+			name:    "assets status missing version id",
+			args:    []string{"assets", "status"},
+			wantErr: "--version-id is required",
+		},
+		{
+			name:    "assets download missing version id",
+			args:    []string{"assets", "download", "--dir", "./backup"},
+			wantErr: "--version-id is required",
+		},
+		{
+			name:    "assets download missing dir",
+			args:    []string{"assets", "download", "--version-id", "VERSION_ID"},
+			wantErr: "--dir is required",
+		},
+		{
+			name:    "assets validate missing path",
+			args:    []string{"assets", "validate", "--device-type", "IPHONE_65"},
+			wantErr: "--path is required",
+		},
+		{
+			name:    "assets validate missing device type",
+			args:    []string{"assets", "validate", "--path", "./screenshots"},
+			wantErr: "--device-type is required",
+		},
+		{
+			name:    "assets validate invalid type",
+			args:    []string{"assets", "validate", "--path", "./screenshots", "--device-type", "IPHONE_65", "--type", "icons"},
+			wantErr: "--type must be",
+		},
 	}
 
 	for _, test := range tests {
@@ -3223,6 +3443,21 @@ func TestBuildsTestNotesValidationErrors(t *testing.T) {
 			args:    []string{"builds", "test-notes", "delete", "--id", "LOC_ID"},
 			wantErr: "--confirm is required",
 		},
+		{
+			name:    "builds test-notes generate missing build",
+			args:    []string{"builds", "test-notes", "generate", "--from-git", "HEAD~1..HEAD", "--locale", "en-US"},
+			wantErr: "Error: --build is required",
+		},
+		{
+			name:    "builds test-notes generate missing range",
+			args:    []string{"builds", "test-notes", "generate", "--build", "BUILD_ID", "--locale", "en-US"},
+			wantErr: "Error: --from-git is required",
+		},
+		{
+			name:    "builds test-notes generate missing locale",
+			args:    []string{"builds", "test-notes", "generate", "--build", "BUILD_ID", "--from-git", "HEAD~1..HEAD"},
+			wantErr: "Error: --locale is required",
+		},
 	}
 
 	for _, test := range tests {
@@ -3333,6 +3568,16 @@ func TestPublishValidationErrors(t *testing.T) {
 			args:    []string{"publish", "testflight", "--app", "APP_123", "--ipa", "app.ipa", "--group", "GROUP_ID", "--locale", "en-US"},
 			wantErr: "Error: --test-notes is required with --locale",
 		},
+		{
+			name:    "publish testflight test-notes and git range",
+			args:    []string{"publish", "testflight", "--app", "APP_123", "--ipa", "app.ipa", "--group", "GROUP_ID", "--test-notes", "Notes", "--test-notes-from-git", "HEAD~1..HEAD", "--locale", "en-US"},
+			wantErr: "Error: --test-notes and --test-notes-from-git are mutually exclusive",
+		},
+		{
+			name:    "publish testflight git range missing locale",
+			args:    []string{"publish", "testflight", "--app", "APP_123", "--ipa", "app.ipa", "--group", "GROUP_ID", "--test-notes-from-git", "HEAD~1..HEAD"},
+			wantErr: "Error: --locale is required with --test-notes-from-git",
+		},
 		{
 			name:    "publish appstore missing app",
 			args:    []string{"publish", "appstore", "--ipa", "app.ipa", "--version", "1.0.0"},
@@ -3489,6 +3734,31 @@ func TestVersionsValidationErrors(t *testing.T) {
 			args:    []string{"versions", "release", "--version-id", "VERSION_123"},
 			wantErr: "Error: --confirm is required to release a version",
 		},
+		{
+			name:    "diff missing from",
+			args:    []string{"versions", "diff", "--app", "APP_123", "--to", "4.3"},
+			wantErr: "Error: --from is required",
+		},
+		{
+			name:    "diff missing to",
+			args:    []string{"versions", "diff", "--app", "APP_123", "--from", "4.2"},
+			wantErr: "Error: --to is required",
+		},
+		{
+			name:    "diff invalid section",
+			args:    []string{"versions", "diff", "--app", "APP_123", "--from", "4.2", "--to", "4.3", "--sections", "builds"},
+			wantErr: "Error: --sections must be one of",
+		},
+		{
+			name:    "snapshot missing path",
+			args:    []string{"versions", "snapshot", "--app", "APP_123", "--version", "4.2"},
+			wantErr: "Error: --path is required",
+		},
+		{
+			name:    "snapshot missing version",
+			args:    []string{"versions", "snapshot", "--app", "APP_123", "--path", "snap.json"},
+			wantErr: "Error: --version or --version-id is required",
+		},
 	}
 
 	for _, test := range tests {
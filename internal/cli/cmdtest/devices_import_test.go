@@ -0,0 +1,198 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDevicesImportRegistersNewDevices(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var registered []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/devices":
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"devices","id":"D1","attributes":{"name":"Existing","udid":"00008030-00000000000000AA","platform":"IOS","deviceClass":"IPHONE","status":"DISABLED"}}],"links":{}}`)
+		case "POST /v1/devices":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						Name     string `json:"name"`
+						UDID     string `json:"udid"`
+						Platform string `json:"platform"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			attrs := payload.Data.Attributes
+			registered = append(registered, attrs.Name+" "+attrs.UDID+" "+attrs.Platform)
+			return respond(http.StatusCreated, `{"data":{"type":"devices","id":"NEW`+attrs.Platform+`","attributes":{"name":"`+attrs.Name+`","udid":"`+attrs.UDID+`","platform":"`+attrs.Platform+`"}}}`)
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	path := filepath.Join(t.TempDir(), "devices.txt")
+	content := "Device ID\tDevice Name\tDevice Platform\n" +
+		"00008030-00000000000000aa\tExisting\tios\n" +
+		"00008030-00000000000000BB\tQA iPhone\tios\n" +
+		"A1B2C3D4-E5F6-7890-ABCD-EF1234567890\tBuild Mac\tmac\n" +
+		"00008030-00000000000000BB\tQA iPhone again\tios\n" +
+		"not-a-udid\tBroken\tios\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"devices", "import", "--file", path}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Format     string `json:"format"`
+		Total      int    `json:"total"`
+		Registered int    `json:"registered"`
+		Existing   int    `json:"existing"`
+		Duplicates int    `json:"duplicates"`
+		Invalid    int    `json:"invalid"`
+		Preflight  []struct {
+			Platform   string `json:"platform"`
+			Registered int    `json:"registered"`
+			New        int    `json:"new"`
+		} `json:"preflight"`
+		Rows []struct {
+			Row      int    `json:"row"`
+			DeviceID string `json:"deviceId"`
+			Status   string `json:"status"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if result.Format != "tsv" || result.Total != 5 || result.Registered != 2 || result.Existing != 1 || result.Duplicates != 1 || result.Invalid != 1 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	var statuses []string
+	for _, row := range result.Rows {
+		statuses = append(statuses, row.Status+":"+row.DeviceID)
+	}
+	if got := strings.Join(statuses, ","); got != "exists:D1,registered:NEWIOS,registered:NEWMAC_OS,duplicate:,invalid:" {
+		t.Fatalf("unexpected row statuses %s", got)
+	}
+	if len(result.Preflight) != 2 || result.Preflight[0].Platform != "IOS" || result.Preflight[0].Registered != 1 || result.Preflight[0].New != 1 {
+		t.Fatalf("unexpected preflight: %+v", result.Preflight)
+	}
+	if len(registered) != 2 || registered[1] != "Build Mac A1B2C3D4-E5F6-7890-ABCD-EF1234567890 MAC_OS" {
+		t.Fatalf("unexpected registrations: %v", registered)
+	}
+}
+
+func TestDevicesExportWritesUploadFormat(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/devices" {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		if got := req.URL.Query().Get("filter[status]"); got != "ENABLED" {
+			t.Fatalf("expected status filter, got %q", got)
+		}
+		body := `{"data":[` +
+			`{"type":"devices","id":"D2","attributes":{"name":"Mac mini","udid":"A1B2C3D4-E5F6-7890-ABCD-EF1234567890","platform":"MAC_OS"}},` +
+			`{"type":"devices","id":"D1","attributes":{"name":"iPhone","udid":"00008030-00000000000000AA","platform":"IOS"}}],"links":{}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "devices.txt")
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"devices", "export", "--file", path, "--status", "enabled"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, `"devices":2`) {
+		t.Fatalf("unexpected output %s", stdout)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Device ID\tDevice Name\tDevice Platform\n" +
+		"00008030-00000000000000AA\tiPhone\tios\n" +
+		"A1B2C3D4-E5F6-7890-ABCD-EF1234567890\tMac mini\tmac\n"
+	if string(data) != want {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+}
+
+func TestDevicesImportValidationErrors(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"devices", "import"}, wantErr: "--file is required"},
+		{args: []string{"devices", "import", "--file", "devices.txt", "--format", "xml"}, wantErr: "--format must be one of"},
+		{args: []string{"devices", "import", "--file", "devices.txt", "--max-devices", "0"}, wantErr: "--max-devices must be at least 1"},
+		{args: []string{"devices", "export"}, wantErr: "--file is required"},
+	}
+	for _, test := range tests {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		_, stderr := captureOutput(t, func() {
+			if err := root.Parse(test.args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("%v: expected ErrHelp, got %v", test.args, err)
+			}
+		})
+		if !strings.Contains(stderr, test.wantErr) {
+			t.Fatalf("%v: unexpected stderr %q", test.args, stderr)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedbackExport(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	dir := filepath.Join(t.TempDir(), "feedback")
+	recent := time.Now().UTC().Add(-24 * time.Hour).Format(time.RFC3339)
+	old := time.Now().UTC().AddDate(0, 0, -30).Format(time.RFC3339)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	downloads := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		contentType := "application/json"
+		switch {
+		case req.URL.Path == "/v1/apps/APP_ID/betaFeedbackScreenshotSubmissions":
+			if got := req.URL.Query().Get("include"); got != "build" {
+				t.Fatalf("expected include=build, got %q", got)
+			}
+			if got := req.URL.Query().Get("sort"); got != "-createdDate" {
+				t.Fatalf("expected sort=-createdDate, got %q", got)
+			}
+			body = `{"data":[
+				{"type":"betaFeedbackScreenshotSubmissions","id":"fb-1","attributes":{"createdDate":"` + recent + `","comment":"Button overlaps text","email":"ada@example.com","deviceModel":"iPhone15,3","osVersion":"18.1","screenshots":[{"url":"https://is1-ssl.mzstatic.com/feedback/shot"}]},"relationships":{"build":{"data":{"type":"builds","id":"build-1"}}}},
+				{"type":"betaFeedbackScreenshotSubmissions","id":"fb-old","attributes":{"createdDate":"` + old + `","comment":"Old"},"relationships":{"build":{"data":{"type":"builds","id":"build-1"}}}}
+			],"included":[{"type":"builds","id":"build-1","attributes":{"version":"42"}}],"links":{"next":"https://api.appstoreconnect.apple.com/v1/apps/APP_ID/betaFeedbackScreenshotSubmissions?cursor=2"}}`
+		case req.URL.Path == "/v1/builds/build-1/preReleaseVersion":
+			body = `{"data":{"type":"preReleaseVersions","id":"pre-1","attributes":{"version":"1.2.0"}}}`
+		case req.URL.Host == "is1-ssl.mzstatic.com":
+			if req.Header.Get("Authorization") != "" {
+				t.Fatalf("expected screenshot download without authorization")
+			}
+			downloads++
+			contentType = "image/jpeg"
+			body = "JPEGDATA"
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{contentType}},
+		}, nil
+	})
+
+	run := func() string {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse([]string{"feedback", "export", "--app", "APP_ID", "--since", "7d", "--dir", dir}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+		return stdout
+	}
+
+	stdout := run()
+
+	var result struct {
+		Count      int `json:"count"`
+		Downloaded int `json:"downloaded"`
+		Skipped    int `json:"skipped"`
+		Builds     []struct {
+			Version     string `json:"version"`
+			BuildNumber string `json:"buildNumber"`
+			Count       int    `json:"count"`
+		} `json:"builds"`
+		Items []struct {
+			ID    string   `json:"id"`
+			Files []string `json:"files"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if result.Count != 1 || result.Downloaded != 1 || result.Items[0].ID != "fb-1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Builds) != 1 || result.Builds[0].Version != "1.2.0" || result.Builds[0].BuildNumber != "42" {
+		t.Fatalf("unexpected builds: %+v", result.Builds)
+	}
+
+	shot, err := os.ReadFile(filepath.Join(dir, "1.2.0-42", "fb-1", "screenshot-1.jpg"))
+	if err != nil || string(shot) != "JPEGDATA" {
+		t.Fatalf("expected screenshot to be written, got %q (%v)", shot, err)
+	}
+	digest, err := os.ReadFile(filepath.Join(dir, "digest.md"))
+	if err != nil {
+		t.Fatalf("read digest: %v", err)
+	}
+	for _, want := range []string{"## 1.2.0 (42)", "> Button overlaps text", "![screenshot-1.jpg](1.2.0-42/fb-1/screenshot-1.jpg)"} {
+		if !strings.Contains(string(digest), want) {
+			t.Fatalf("expected digest to contain %q, got:\n%s", want, digest)
+		}
+	}
+	var issues []struct {
+		Title  string   `json:"title"`
+		Body   string   `json:"body"`
+		Labels []string `json:"labels"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "issues.json"))
+	if err != nil {
+		t.Fatalf("read issues: %v", err)
+	}
+	if err := json.Unmarshal(data, &issues); err != nil {
+		t.Fatalf("parse issues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "TestFlight feedback in 1.2.0 (42): Button overlaps text" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+	if strings.Contains(issues[0].Body, "](") || !strings.Contains(issues[0].Body, "`1.2.0-42/fb-1/screenshot-1.jpg`") {
+		t.Fatalf("expected issue body to list files without relative links, got:\n%s", issues[0].Body)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "feedback.json")); err != nil {
+		t.Fatalf("expected manifest: %v", err)
+	}
+
+	stdout = run()
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if downloads != 1 || result.Skipped != 1 || result.Downloaded != 0 {
+		t.Fatalf("expected re-run to skip existing screenshot, downloads=%d result=%+v", downloads, result)
+	}
+}
+
+func TestCrashesExportJira(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	dir := filepath.Join(t.TempDir(), "crashes")
+	recent := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch req.URL.Path {
+		case "/v1/apps/APP_ID/betaFeedbackCrashSubmissions":
+			body = `{"data":[
+				{"type":"betaFeedbackCrashSubmissions","id":"cr-1","attributes":{"createdDate":"` + recent + `","comment":"Crashed on launch"}},
+				{"type":"betaFeedbackCrashSubmissions","id":"cr-2","attributes":{"createdDate":"` + recent + `"}}
+			],"links":{}}`
+		case "/v1/betaFeedbackCrashSubmissions/cr-1/crashLog":
+			body = `{"data":{"type":"betaCrashLogs","id":"log-1","attributes":{"logText":"Thread 0 Crashed"}}}`
+		case "/v1/betaFeedbackCrashSubmissions/cr-2/crashLog":
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not found"}]}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"crashes", "export", "--app", "APP_ID", "--dir", dir, "--digest", "html", "--issues", "jira"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "1 attachments failed to download") {
+		t.Fatalf("expected failed download error, got %v", runErr)
+	}
+	if !strings.Contains(stdout, `"failed":1`) {
+		t.Fatalf("expected output to report the failure, got %q", stdout)
+	}
+
+	log, err := os.ReadFile(filepath.Join(dir, "unknown-build", "cr-1", "crash.log"))
+	if err != nil || string(log) != "Thread 0 Crashed" {
+		t.Fatalf("expected crash log to be written, got %q (%v)", log, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unknown-build", "cr-2", "crash.log")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no crash log for failed download, got %v", err)
+	}
+	digest, err := os.ReadFile(filepath.Join(dir, "digest.html"))
+	if err != nil || !strings.Contains(string(digest), `<a href="unknown-build/cr-1/crash.log">crash.log</a>`) {
+		t.Fatalf("unexpected html digest: %s (%v)", digest, err)
+	}
+	issues, err := os.ReadFile(filepath.Join(dir, "issues.csv"))
+	if err != nil {
+		t.Fatalf("read issues: %v", err)
+	}
+	if !strings.HasPrefix(string(issues), "Summary,Description,Issue Type,Labels,Labels\n") || !strings.Contains(string(issues), "TestFlight crash in Unknown build: Crashed on launch") {
+		t.Fatalf("unexpected jira csv:\n%s", issues)
+	}
+}
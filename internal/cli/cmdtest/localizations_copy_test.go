@@ -0,0 +1,74 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLocalizationsCopyDryRunShowsDiff(t *testing.T) {
+	setupAuth(t)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected only GET requests in dry run, got %s %s", req.Method, req.URL.Path)
+		}
+		var body string
+		switch req.URL.Path {
+		case "/v1/appStoreVersions/version-1/appStoreVersionLocalizations":
+			body = `{"data":[
+				{"type":"appStoreVersionLocalizations","id":"src-en","attributes":{"locale":"en-US","description":"Same","whatsNew":"New things"}},
+				{"type":"appStoreVersionLocalizations","id":"src-de","attributes":{"locale":"de-DE","description":"Beschreibung"}}
+			],"links":{}}`
+		case "/v1/appStoreVersions/version-2/appStoreVersionLocalizations":
+			body = `{"data":[
+				{"type":"appStoreVersionLocalizations","id":"dst-en","attributes":{"locale":"en-US","description":"Same","whatsNew":"Old things"}}
+			],"links":{}}`
+		default:
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"localizations", "copy",
+			"--from-version", "version-1",
+			"--to-version", "version-2",
+			"--dry-run",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	for _, want := range []string{
+		`{"type":"version","locale":"de-DE","field":"description","action":"create","to":"Beschreibung"}`,
+		`{"type":"version","locale":"en-US","field":"whatsNew","action":"update","from":"Old things","to":"New things"}`,
+		`"unchanged":1`,
+		`"dryRun":true`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %s in output, got %q", want, stdout)
+		}
+	}
+}
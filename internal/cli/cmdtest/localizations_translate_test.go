@@ -0,0 +1,178 @@
+package cmdtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLocalizationsTranslateDryRunValidatesTranslations(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("translator stand-in is a shell script")
+	}
+	setupAuth(t)
+
+	scriptPath := filepath.Join(t.TempDir(), "translate.sh")
+	longText := strings.Repeat("x", 171)
+	script := "#!/bin/sh\ncat > /dev/null\necho '{\"strings\":{\"whatsNew\":\"Fehlerbehebungen\",\"promotionalText\":\"" + longText + "\"}}'\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected only GET requests in dry run, got %s %s", req.Method, req.URL.Path)
+		}
+		if req.URL.Path != "/v1/appStoreVersions/version-1/appStoreVersionLocalizations" {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		body := `{"data":[
+			{"type":"appStoreVersionLocalizations","id":"loc-en","attributes":{"locale":"en-US","whatsNew":"Bug fixes","promotionalText":"Now faster"}},
+			{"type":"appStoreVersionLocalizations","id":"loc-de","attributes":{"locale":"de-DE","description":"Beschreibung"}}
+		],"links":{}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"localizations", "translate",
+			"--version", "version-1",
+			"--from", "en-US",
+			"--to", "de-DE,ja",
+			"--fields", "whatsNew,promotionalText",
+			"--translator-cmd", scriptPath,
+			"--dry-run",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+
+	var reported ReportedError
+	if !errors.As(runErr, &reported) {
+		t.Fatalf("expected reported error for invalid translations, got %v", runErr)
+	}
+	for _, want := range []string{
+		`"failedCount":2`,
+		`"text":"Fehlerbehebungen"`,
+		`"error":"exceeds 170 character limit"`,
+		`{"locale":"de-DE","action":"update","localizationId":"loc-de"}`,
+		`{"locale":"ja","action":"create"}`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %s in output, got %q", want, stdout)
+		}
+	}
+}
+
+func TestLocalizationsTranslateWritesXLIFFSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("translator stand-in is a shell script")
+	}
+	setupAuth(t)
+
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "translate.sh")
+	script := "#!/bin/sh\ncat > /dev/null\necho '{\"strings\":{\"whatsNew\":\"Corrections de bugs\"}}'\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/appStoreVersions/version-1/appStoreVersionLocalizations" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		body := `{"data":[{"type":"appStoreVersionLocalizations","id":"loc-en","attributes":{"locale":"en-US","whatsNew":"Bug fixes"}}],"links":{}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	outputPath := filepath.Join(dir, "fr-FR.xliff")
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"localizations", "translate",
+			"--version", "version-1",
+			"--from", "en-US",
+			"--to", "fr-FR",
+			"--fields", "whatsNew",
+			"--translator-cmd", scriptPath,
+			"--path", outputPath,
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read xliff: %v", err)
+	}
+	for _, want := range []string{"<source>Bug fixes</source>", ">Corrections de bugs</target>"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected %q in xliff, got %s", want, data)
+		}
+	}
+}
+
+func TestLocalizationsTranslateRejectsUnsupportedLocale(t *testing.T) {
+	setupAuth(t)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		return nil, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"localizations", "translate",
+			"--version", "version-1",
+			"--from", "en-US",
+			"--to", "de-DE,xx-YY",
+			"--translator-cmd", "./translate.sh",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), `"xx-YY" is not supported`) {
+		t.Fatalf("expected unsupported locale error, got %v", runErr)
+	}
+}
@@ -0,0 +1,303 @@
+package cmdtest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func newInspectTestCertificate(t *testing.T, serial int64, commonName string) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName, OrganizationalUnit: []string{"TEAM123456"}, Organization: []string{"Example Inc"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+// writeTestProvisioningProfile signs a profile plist with openssl in the
+// streamed (indefinite-length BER) form Apple uses.
+func writeTestProvisioningProfile(t *testing.T, dir string, devCerts []*x509.Certificate, udids []string) string {
+	t.Helper()
+	opensslPath, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not available")
+	}
+	signerKey, signerCert := newInspectTestCertificate(t, 99, "Test Profile Signing")
+
+	var certData, deviceData strings.Builder
+	for _, cert := range devCerts {
+		certData.WriteString("<data>" + base64.StdEncoding.EncodeToString(cert.Raw) + "</data>")
+	}
+	for _, udid := range udids {
+		deviceData.WriteString("<string>" + udid + "</string>")
+	}
+	expires := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict>
+<key>AppIDName</key><string>Example</string>
+<key>CreationDate</key><date>2026-01-01T00:00:00Z</date>
+<key>ExpirationDate</key><date>` + expires + `</date>
+<key>Name</key><string>Example Ad Hoc</string>
+<key>Platform</key><array><string>iOS</string></array>
+<key>TeamIdentifier</key><array><string>TEAM123456</string></array>
+<key>TeamName</key><string>Example Inc</string>
+<key>UUID</key><string>11111111-2222-3333-4444-555555555555</string>
+<key>DeveloperCertificates</key><array>` + certData.String() + `</array>
+<key>ProvisionedDevices</key><array>` + deviceData.String() + `</array>
+<key>Entitlements</key><dict>
+<key>application-identifier</key><string>TEAM123456.com.example.app</string>
+<key>get-task-allow</key><false/>
+</dict>
+</dict></plist>`
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"content.plist": []byte(content),
+		"signer.pem":    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signerCert.Raw}),
+		"signer.key":    pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	profilePath := filepath.Join(dir, "example.mobileprovision")
+	cmd := exec.Command(opensslPath, "cms", "-sign", "-binary", "-nodetach", "-stream", "-outform", "DER",
+		"-in", filepath.Join(dir, "content.plist"),
+		"-signer", filepath.Join(dir, "signer.pem"),
+		"-inkey", filepath.Join(dir, "signer.key"),
+		"-out", profilePath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("openssl cms -sign failed: %v\n%s", err, out)
+	}
+	return profilePath
+}
+
+func TestProfilesInspectCrossChecksAPI(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	_, active := newInspectTestCertificate(t, 0x0A, "Apple Distribution: Example (TEAM123456)")
+	_, revoked := newInspectTestCertificate(t, 0x0B, "Apple Distribution: Old (TEAM123456)")
+	profilePath := writeTestProvisioningProfile(t, t.TempDir(), []*x509.Certificate{active, revoked}, []string{"UDID-ENABLED", "UDID-DISABLED"})
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch req.URL.Path {
+		case "/v1/certificates":
+			if got := req.URL.Query().Get("filter[serialNumber]"); got != "A,B" {
+				t.Fatalf("unexpected serial filter %q", got)
+			}
+			body = `{"data":[{"type":"certificates","id":"CERT_A","attributes":{"name":"Example","certificateType":"DISTRIBUTION","serialNumber":"0A"}}],"links":{}}`
+		case "/v1/devices":
+			if got := req.URL.Query().Get("filter[udid]"); got != "UDID-ENABLED,UDID-DISABLED" {
+				t.Fatalf("unexpected udid filter %q", got)
+			}
+			body = `{"data":[` +
+				`{"type":"devices","id":"DEV_1","attributes":{"name":"Phone","udid":"UDID-ENABLED","status":"ENABLED"}},` +
+				`{"type":"devices","id":"DEV_2","attributes":{"name":"Tablet","udid":"UDID-DISABLED","status":"DISABLED"}}],"links":{}}`
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"profiles", "inspect", "--strict", profilePath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	var reported shared.ReportedError
+	if !errors.As(runErr, &reported) {
+		t.Fatalf("expected reported error with --strict, got %v", runErr)
+	}
+
+	var result struct {
+		Profiles []struct {
+			Kind           string `json:"kind"`
+			BundleID       string `json:"bundleId"`
+			TeamID         string `json:"teamId"`
+			SignatureValid bool   `json:"signatureValid"`
+			ChainVerified  bool   `json:"chainVerified"`
+			Certificates   []struct {
+				SerialNumber string `json:"serialNumber"`
+				TeamID       string `json:"teamId"`
+				APIStatus    string `json:"apiStatus"`
+				APIID        string `json:"apiId"`
+			} `json:"certificates"`
+			Devices []struct {
+				UDID      string `json:"udid"`
+				APIStatus string `json:"apiStatus"`
+			} `json:"devices"`
+			Problems []string `json:"problems"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if len(result.Profiles) != 1 {
+		t.Fatalf("expected one profile, got %d", len(result.Profiles))
+	}
+	profile := result.Profiles[0]
+	if profile.Kind != "ad-hoc" || profile.BundleID != "com.example.app" || profile.TeamID != "TEAM123456" {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+	// The test signer is not an Apple certificate.
+	if !profile.SignatureValid || profile.ChainVerified {
+		t.Fatalf("expected valid signature without an Apple chain, got %+v", profile)
+	}
+	if len(profile.Certificates) != 2 ||
+		profile.Certificates[0].APIStatus != "ACTIVE" || profile.Certificates[0].APIID != "CERT_A" || profile.Certificates[0].TeamID != "TEAM123456" ||
+		profile.Certificates[1].APIStatus != "NOT_FOUND" {
+		t.Fatalf("unexpected certificates: %+v", profile.Certificates)
+	}
+	if len(profile.Devices) != 2 || profile.Devices[0].APIStatus != "ENABLED" || profile.Devices[1].APIStatus != "DISABLED" {
+		t.Fatalf("unexpected devices: %+v", profile.Devices)
+	}
+	problems := strings.Join(profile.Problems, "\n")
+	for _, want := range []string{"certificate B revoked or not found", "device UDID-DISABLED is disabled", "signature chain not verified"} {
+		if !strings.Contains(problems, want) {
+			t.Fatalf("expected problem %q, got %q", want, problems)
+		}
+	}
+}
+
+func TestCertificatesInspectOffline(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("P12_PASS", "s3cret")
+
+	key, cert := newInspectTestCertificate(t, 0x1F, "Apple Development: Example (TEAM123456)")
+	dir := t.TempDir()
+	cerPath := filepath.Join(dir, "dev.cer")
+	pemPath := filepath.Join(dir, "dev.pem")
+	p12Path := filepath.Join(dir, "dev.p12")
+	p12, err := shared.EncodePKCS12(key, cert, "Example", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, data := range map[string][]byte{
+		cerPath: cert.Raw,
+		pemPath: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		p12Path: p12,
+	} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"certificates", "inspect", "--offline", "--p12-password-env", "P12_PASS", cerPath, pemPath, p12Path}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Certificates []struct {
+			File          string `json:"file"`
+			SerialNumber  string `json:"serialNumber"`
+			TeamID        string `json:"teamId"`
+			HasPrivateKey bool   `json:"hasPrivateKey"`
+			APIStatus     string `json:"apiStatus"`
+		} `json:"certificates"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if len(result.Certificates) != 3 {
+		t.Fatalf("expected 3 certificates, got %d", len(result.Certificates))
+	}
+	for i, cert := range result.Certificates {
+		if cert.SerialNumber != "1F" || cert.TeamID != "TEAM123456" || cert.APIStatus != "" {
+			t.Fatalf("unexpected certificate: %+v", cert)
+		}
+		if cert.HasPrivateKey != (i == 2) {
+			t.Fatalf("unexpected hasPrivateKey for %s", cert.File)
+		}
+	}
+}
+
+func TestInspectValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "profiles inspect missing files", args: []string{"profiles", "inspect"}, wantErr: "Error: at least one profile file is required"},
+		{name: "certificates inspect missing files", args: []string{"certificates", "inspect", "--offline"}, wantErr: "Error: at least one certificate file is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
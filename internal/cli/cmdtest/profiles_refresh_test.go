@@ -0,0 +1,229 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfilesRefreshRecreatesProfiles(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var deleted []string
+	var created struct {
+		Data struct {
+			Attributes struct {
+				Name        string `json:"name"`
+				ProfileType string `json:"profileType"`
+			} `json:"attributes"`
+			Relationships struct {
+				BundleID struct {
+					Data struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"bundleId"`
+				Certificates struct {
+					Data []struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"certificates"`
+				Devices struct {
+					Data []struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"devices"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+	profileContent := base64.StdEncoding.EncodeToString([]byte("new profile"))
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/bundleIds":
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"bundleIds","id":"B_OTHER","attributes":{"name":"Other","identifier":"com.example.app.widget","platform":"IOS"}},`+
+				`{"type":"bundleIds","id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}}],"links":{}}`)
+		case "GET /v1/bundleIds/B1/profiles":
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"profiles","id":"P_ADHOC","attributes":{"name":"App Ad Hoc","profileType":"IOS_APP_ADHOC","profileState":"ACTIVE","createdDate":"2026-01-01T00:00:00.000+0000"}},`+
+				`{"type":"profiles","id":"P_CURRENT","attributes":{"name":"App Dev","profileType":"IOS_APP_DEVELOPMENT","profileState":"ACTIVE","createdDate":"2026-03-01T00:00:00.000+0000"}},`+
+				`{"type":"profiles","id":"P_STORE","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE"}}],"links":{}}`)
+		case "GET /v1/devices":
+			if got := req.URL.Query().Get("filter[status]"); got != "ENABLED" {
+				t.Fatalf("expected enabled devices filter, got %q", got)
+			}
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"devices","id":"D_OLD","attributes":{"name":"Old Phone","udid":"UDID-OLD","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED","addedDate":"2025-06-01T00:00:00.000+0000"}},`+
+				`{"type":"devices","id":"D_NEW","attributes":{"name":"New Phone","udid":"UDID-NEW","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED","addedDate":"2026-02-01T00:00:00.000+0000"}}],"links":{}}`)
+		case "GET /v1/profiles/P_ADHOC/relationships/certificates", "GET /v1/profiles/P_CURRENT/relationships/certificates":
+			return respond(http.StatusOK, `{"data":[{"type":"certificates","id":"CERT1"}],"links":{}}`)
+		case "GET /v1/profiles/P_ADHOC/relationships/devices":
+			return respond(http.StatusOK, `{"data":[{"type":"devices","id":"D_OLD"}],"links":{}}`)
+		case "GET /v1/profiles/P_CURRENT/relationships/devices":
+			return respond(http.StatusOK, `{"data":[{"type":"devices","id":"D_OLD"},{"type":"devices","id":"D_NEW"}],"links":{}}`)
+		case "DELETE /v1/profiles/P_ADHOC":
+			deleted = append(deleted, "P_ADHOC")
+			return respond(http.StatusNoContent, "")
+		case "POST /v1/profiles":
+			if len(deleted) != 1 {
+				t.Fatal("expected the old profile to be deleted before creating the new one")
+			}
+			if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			return respond(http.StatusCreated, `{"data":{"type":"profiles","id":"P_NEW","attributes":{"name":"App Ad Hoc","profileType":"IOS_APP_ADHOC","profileState":"ACTIVE","profileContent":"`+profileContent+`"}}}`)
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	outputDir := filepath.Join(t.TempDir(), "profiles")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// A file from an earlier download is replaced.
+	if err := os.WriteFile(filepath.Join(outputDir, "App Ad Hoc.mobileprovision"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"profiles", "refresh", "--bundle-id", "com.example.app", "--confirm", "--output-dir", outputDir}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Profiles []struct {
+			OldProfileID string   `json:"oldProfileId"`
+			NewProfileID string   `json:"newProfileId"`
+			Action       string   `json:"action"`
+			AddedDevices []string `json:"addedDevices"`
+			DeviceCount  int      `json:"deviceCount"`
+			File         string   `json:"file"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if len(result.Profiles) != 2 {
+		t.Fatalf("expected two device profiles, got %+v", result.Profiles)
+	}
+	refreshed, current := result.Profiles[0], result.Profiles[1]
+	if refreshed.Action != "refreshed" || refreshed.NewProfileID != "P_NEW" || refreshed.DeviceCount != 2 ||
+		len(refreshed.AddedDevices) != 1 || refreshed.AddedDevices[0] != "New Phone (UDID-NEW)" {
+		t.Fatalf("unexpected refreshed profile: %+v", refreshed)
+	}
+	if current.OldProfileID != "P_CURRENT" || current.Action != "up-to-date" {
+		t.Fatalf("unexpected current profile: %+v", current)
+	}
+
+	attrs, rels := created.Data.Attributes, created.Data.Relationships
+	if attrs.Name != "App Ad Hoc" || attrs.ProfileType != "IOS_APP_ADHOC" || rels.BundleID.Data.ID != "B1" ||
+		len(rels.Certificates.Data) != 1 || rels.Certificates.Data[0].ID != "CERT1" || len(rels.Devices.Data) != 2 {
+		t.Fatalf("unexpected create payload: %+v", created.Data)
+	}
+
+	data, err := os.ReadFile(refreshed.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new profile" || filepath.Base(refreshed.File) != "App Ad Hoc.mobileprovision" {
+		t.Fatalf("unexpected profile file %s: %q", refreshed.File, data)
+	}
+}
+
+func TestProfilesRefreshReportsHowToRecreateDeletedProfile(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/bundleIds":
+			return respond(http.StatusOK, `{"data":[{"type":"bundleIds","id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}}],"links":{}}`)
+		case "GET /v1/bundleIds/B1/profiles":
+			return respond(http.StatusOK, `{"data":[{"type":"profiles","id":"P_ADHOC","attributes":{"name":"App Ad Hoc","profileType":"IOS_APP_ADHOC","profileState":"ACTIVE"}}],"links":{}}`)
+		case "GET /v1/devices":
+			return respond(http.StatusOK, `{"data":[`+
+				`{"type":"devices","id":"D_OLD","attributes":{"name":"Old Phone","udid":"UDID-OLD","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}},`+
+				`{"type":"devices","id":"D_NEW","attributes":{"name":"New Phone","udid":"UDID-NEW","platform":"IOS","deviceClass":"IPHONE","status":"ENABLED"}}],"links":{}}`)
+		case "GET /v1/profiles/P_ADHOC/relationships/certificates":
+			return respond(http.StatusOK, `{"data":[{"type":"certificates","id":"CERT1"}],"links":{}}`)
+		case "GET /v1/profiles/P_ADHOC/relationships/devices":
+			return respond(http.StatusOK, `{"data":[{"type":"devices","id":"D_OLD"}],"links":{}}`)
+		case "DELETE /v1/profiles/P_ADHOC":
+			return respond(http.StatusNoContent, "")
+		case "POST /v1/profiles":
+			return respond(http.StatusInternalServerError, `{"errors":[{"status":"500","title":"Internal Server Error"}]}`)
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"profiles", "refresh", "--bundle-id", "com.example.app", "--confirm"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil {
+		t.Fatal("expected the failed recreate to be reported")
+	}
+
+	var result struct {
+		Profiles []struct {
+			Action    string   `json:"action"`
+			DeviceIDs []string `json:"deviceIds"`
+			Error     string   `json:"error"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if len(result.Profiles) != 1 || result.Profiles[0].Action != "failed" || len(result.Profiles[0].DeviceIDs) != 2 {
+		t.Fatalf("unexpected result: %+v", result.Profiles)
+	}
+	want := `asc profiles create --name "App Ad Hoc" --profile-type IOS_APP_ADHOC --bundle B1 --certificate CERT1 --device D_NEW,D_OLD`
+	if !strings.Contains(result.Profiles[0].Error, want) {
+		t.Fatalf("expected error to contain %q, got %q", want, result.Profiles[0].Error)
+	}
+}
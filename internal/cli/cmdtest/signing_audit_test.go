@@ -0,0 +1,197 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signingAuditTransport(t *testing.T) roundTripFunc {
+	t.Helper()
+	date := func(days int) string {
+		return time.Now().Add(time.Duration(days) * 24 * time.Hour).UTC().Format("2006-01-02T15:04:05.000-0700")
+	}
+	responses := map[string]string{
+		"/v1/certificates": `{"data":[` +
+			`{"type":"certificates","id":"CERT_OK","attributes":{"name":"Distribution","certificateType":"DISTRIBUTION","expirationDate":"` + date(300) + `"}},` +
+			`{"type":"certificates","id":"CERT_SOON","attributes":{"name":"Development","certificateType":"DEVELOPMENT","expirationDate":"` + date(10) + `"}},` +
+			`{"type":"certificates","id":"CERT_OLD","attributes":{"name":"Old Distribution","certificateType":"DISTRIBUTION","expirationDate":"` + date(-3) + `"}}],"links":{}}`,
+		"/v1/passTypeIds": `{"data":[{"type":"passTypeIds","id":"PT1","attributes":{"name":"Pass","identifier":"pass.com.example"}}],"links":{}}`,
+		"/v1/passTypeIds/PT1/certificates": `{"data":[` +
+			`{"type":"certificates","id":"CERT_PASS","attributes":{"name":"Pass Type ID","certificateType":"PASS_TYPE_ID","expirationDate":"` + date(5) + `"}}],"links":{}}`,
+		"/v1/merchantIds": `{"data":[],"links":{}}`,
+		"/v1/devices": `{"data":[` +
+			`{"type":"devices","id":"DEV1","attributes":{"name":"Phone","udid":"UDID1","status":"ENABLED"}},` +
+			`{"type":"devices","id":"DEV2","attributes":{"name":"Tablet","udid":"UDID2","status":"DISABLED"}}],"links":{}}`,
+		"/v1/profiles": `{"data":[` +
+			`{"type":"profiles","id":"P_STORE","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","expirationDate":"` + date(300) + `"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}},` +
+			`{"type":"profiles","id":"P_DEV","attributes":{"name":"Development","profileType":"IOS_APP_DEVELOPMENT","profileState":"ACTIVE","expirationDate":"` + date(200) + `"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}}],` +
+			`"included":[{"type":"bundleIds","id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}}],"links":{}}`,
+		"/v1/profiles/P_STORE/relationships/certificates": `{"data":[{"type":"certificates","id":"CERT_OK"}],"links":{}}`,
+		"/v1/profiles/P_DEV/relationships/certificates":   `{"data":[{"type":"certificates","id":"CERT_REVOKED"}],"links":{}}`,
+		"/v1/profiles/P_DEV/relationships/devices":        `{"data":[{"type":"devices","id":"DEV1"},{"type":"devices","id":"DEV2"}],"links":{}}`,
+		"/v1/bundleIds": `{"data":[` +
+			`{"type":"bundleIds","id":"B1","attributes":{"name":"App","identifier":"com.example.app","platform":"IOS"}},` +
+			`{"type":"bundleIds","id":"B2","attributes":{"name":"Widget","identifier":"com.example.app.widget","platform":"IOS"}},` +
+			`{"type":"bundleIds","id":"B3","attributes":{"name":"Wildcard","identifier":"com.example.*","platform":"IOS"}}],"links":{}}`,
+	}
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		if req.URL.Path == "/v1/profiles" && req.URL.Query().Get("include") != "bundleId" {
+			t.Fatalf("expected profiles to include bundleId, got %q", req.URL.RawQuery)
+		}
+		body, ok := responses[req.URL.Path]
+		if !ok {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+}
+
+func runSigningAudit(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse(append([]string{"signing", "audit"}, args...)); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	return stdout, runErr
+}
+
+func TestSigningAudit(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = signingAuditTransport(t)
+
+	blocksPath := filepath.Join(t.TempDir(), "blocks.json")
+	stdout, err := runSigningAudit(t, "--within", "30d", "--slack-blocks", blocksPath)
+	if err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+
+	var result struct {
+		Checked struct {
+			Certificates           int `json:"certificates"`
+			PassTypeIDCertificates int `json:"passTypeIdCertificates"`
+			Profiles               int `json:"profiles"`
+			BundleIDs              int `json:"bundleIds"`
+		} `json:"checked"`
+		Items []struct {
+			Kind          string `json:"kind"`
+			ID            string `json:"id"`
+			Identifier    string `json:"identifier"`
+			Issue         string `json:"issue"`
+			DaysRemaining *int   `json:"daysRemaining"`
+			Detail        string `json:"detail"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if result.Checked.Certificates != 3 || result.Checked.PassTypeIDCertificates != 1 || result.Checked.Profiles != 2 || result.Checked.BundleIDs != 2 {
+		t.Fatalf("unexpected checked counts: %+v", result.Checked)
+	}
+
+	got := map[string]string{}
+	for _, item := range result.Items {
+		got[item.ID+" "+item.Issue] = item.Kind + " " + item.Identifier + " " + item.Detail
+	}
+	want := map[string]string{
+		"CERT_OLD EXPIRED":             "certificate  ",
+		"CERT_SOON EXPIRING":           "certificate  ",
+		"CERT_PASS EXPIRING":           "passTypeIdCertificate pass.com.example ",
+		"P_DEV REVOKED_CERTIFICATE":    "profile com.example.app certificates CERT_REVOKED",
+		"P_DEV DISABLED_DEVICE":        "profile com.example.app devices Tablet (UDID2)",
+		"B2 MISSING_APP_STORE_PROFILE": "bundleId com.example.app.widget no active App Store profile",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected items: %v", got)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("item %q = %q, want %q (all: %v)", key, got[key], value, got)
+		}
+	}
+
+	data, err := os.ReadFile(blocksPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks []map[string]any
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		t.Fatalf("slack blocks are not a JSON array: %v", err)
+	}
+	if len(blocks) < 3 || blocks[0]["type"] != "header" || !strings.Contains(string(data), "6 issue(s) within 30d") {
+		t.Fatalf("unexpected slack blocks: %s", data)
+	}
+}
+
+func TestSigningAuditJUnitStrict(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = signingAuditTransport(t)
+
+	stdout, err := runSigningAudit(t, "--within", "30d", "--output", "junit", "--strict")
+	if err == nil || !strings.Contains(err.Error(), "6 issue(s) found") {
+		t.Fatalf("expected --strict to fail, got %v", err)
+	}
+
+	var suite struct {
+		Tests     int `xml:"tests,attr"`
+		Failures  int `xml:"failures,attr"`
+		TestCases []struct {
+			Classname string `xml:"classname,attr"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal([]byte(stdout), &suite); err != nil {
+		t.Fatalf("parse junit: %v\n%s", err, stdout)
+	}
+	if suite.Tests != 6 || suite.Failures != 6 || len(suite.TestCases) != 6 {
+		t.Fatalf("unexpected junit suite: %+v", suite)
+	}
+}
+
+func TestSigningAuditValidationErrors(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"signing", "audit", "--within", "soon"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--within must be a duration like 24h, 30d, 2w, or 3m") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
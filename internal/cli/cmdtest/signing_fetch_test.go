@@ -0,0 +1,103 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSigningFetchCreateMissingNamesProfilesPerBundle(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	bundles := map[string]string{"com.example.app": "BUNDLE_APP", "com.example.app.widget": "BUNDLE_WIDGET"}
+	names := map[string]bool{}
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		status := http.StatusOK
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/certificates":
+			body = `{"data":[{"type":"certificates","id":"CERT_1","attributes":{"name":"Apple Distribution","certificateType":"IOS_DISTRIBUTION","serialNumber":"01","certificateContent":"` + base64.StdEncoding.EncodeToString([]byte("cert")) + `"}}]}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			identifier := req.URL.Query().Get("filter[identifier]")
+			body = fmt.Sprintf(`{"data":[{"type":"bundleIds","id":%q,"attributes":{"identifier":%q}}]}`, bundles[identifier], identifier)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			body = `{"data":[]}`
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						Name string `json:"name"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			name := payload.Data.Attributes.Name
+			if names[name] {
+				status = http.StatusConflict
+				body = `{"errors":[{"status":"409","code":"ENTITY_ERROR","title":"A profile with this name already exists"}]}`
+				break
+			}
+			names[name] = true
+			content := base64.StdEncoding.EncodeToString([]byte(name))
+			body = fmt.Sprintf(`{"data":{"type":"profiles","id":"PROFILE_%d","attributes":{"name":%q,"profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":%q}}}`, len(names), name, content)
+			status = http.StatusCreated
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"signing", "fetch",
+			"--bundle-id", "com.example.app,com.example.app.widget",
+			"--profile-type", "IOS_APP_STORE",
+			"--create-missing",
+			"--output", t.TempDir(),
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Profiles []struct {
+			BundleID    string `json:"bundleId"`
+			ProfileName string `json:"profileName"`
+			Created     bool   `json:"created"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, stdout)
+	}
+	if len(result.Profiles) != 2 {
+		t.Fatalf("expected two profiles, got %+v", result.Profiles)
+	}
+	for _, profile := range result.Profiles {
+		if !profile.Created || !strings.HasPrefix(profile.ProfileName, profile.BundleID+" IOS_APP_STORE ") {
+			t.Fatalf("unexpected profile %+v", profile)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package cmdtest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigningSyncLocalBareRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "correct horse battery staple")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	remote := filepath.Join(t.TempDir(), "certificates.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	profileContent := base64.StdEncoding.EncodeToString([]byte("<plist>com.example.app</plist>"))
+	var created []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		status := http.StatusOK
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/certificates":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						CSRContent string `json:"csrContent"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			body = `{"data":{"type":"certificates","id":"CERT_1","attributes":{"name":"Apple Distribution","certificateType":"IOS_DISTRIBUTION","certificateContent":"` + signTestCSR(t, payload.Data.Attributes.CSRContent) + `"}}}`
+			status = http.StatusCreated
+			created = append(created, "certificate")
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/certificates/CERT_1":
+			body = `{"data":{"type":"certificates","id":"CERT_1","attributes":{"name":"Apple Distribution","certificateType":"IOS_DISTRIBUTION"}}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			body = `{"data":[{"type":"bundleIds","id":"BUNDLE_1","attributes":{"identifier":"com.example.app"}}]}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			body = `{"data":[]}`
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			body = `{"data":{"type":"profiles","id":"PROFILE_1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"` + profileContent + `","expirationDate":"2099-01-01T00:00:00.000+0000"}}}`
+			status = http.StatusCreated
+			created = append(created, "profile")
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles/PROFILE_1":
+			body = `{"data":{"type":"profiles","id":"PROFILE_1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE"}}}`
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	type syncResult struct {
+		CertificateID string   `json:"certificateId"`
+		P12File       string   `json:"p12File"`
+		ProfileID     string   `json:"profileId"`
+		ProfileFile   string   `json:"profileFile"`
+		Changes       []string `json:"changes"`
+		Commit        string   `json:"commit"`
+	}
+	run := func(extra ...string) (syncResult, error) {
+		args := append([]string{"signing", "sync", "--repo", remote, "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"}, extra...)
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		var runErr error
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		var result syncResult
+		if runErr == nil {
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("parse output: %v\n%s", err, stdout)
+			}
+		}
+		return result, runErr
+	}
+
+	if _, err := run("--readonly", "--output", t.TempDir()); err == nil || !strings.Contains(err.Error(), "branch main not found") {
+		t.Fatalf("expected readonly sync of empty repo to fail, got %v", err)
+	}
+
+	first, err := run("--output", t.TempDir())
+	if err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	if first.CertificateID != "CERT_1" || first.ProfileID != "PROFILE_1" || first.Commit == "" || len(first.Changes) != 2 {
+		t.Fatalf("unexpected first sync result: %+v", first)
+	}
+	if strings.Join(created, ",") != "certificate,profile" {
+		t.Fatalf("expected certificate and profile to be created, got %v", created)
+	}
+
+	second, err := run("--output", t.TempDir())
+	if err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if second.Commit != "" || len(second.Changes) != 0 || len(created) != 2 {
+		t.Fatalf("expected second sync to reuse stored files, got %+v (created %v)", second, created)
+	}
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("readonly sync should not call the API: %s %s", req.Method, req.URL.String())
+		return nil, errors.New("unexpected request")
+	})
+	outputDir := t.TempDir()
+	readonly, err := run("--readonly", "--output", outputDir)
+	if err != nil {
+		t.Fatalf("readonly sync: %v", err)
+	}
+	profile, err := os.ReadFile(readonly.ProfileFile)
+	if err != nil || string(profile) != "<plist>com.example.app</plist>" {
+		t.Fatalf("expected decrypted profile, got %q (%v)", profile, err)
+	}
+	info, err := os.Stat(readonly.P12File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 || info.Size() == 0 {
+		t.Fatalf("unexpected .p12 file: mode %v size %d", info.Mode().Perm(), info.Size())
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	if out, err := exec.Command("git", "clone", "--quiet", "--branch", "main", remote, clone).CombinedOutput(); err != nil {
+		t.Fatalf("git clone: %v\n%s", err, out)
+	}
+	stored, err := os.ReadFile(filepath.Join(clone, "profiles", "IOS_APP_STORE", "com.example.app.mobileprovision"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(stored), "com.example.app") {
+		t.Fatal("expected profile to be encrypted in the repository")
+	}
+
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "wrong")
+	if _, err := run("--readonly", "--output", t.TempDir()); err == nil || !strings.Contains(err.Error(), "incorrect passphrase") {
+		t.Fatalf("expected incorrect passphrase error, got %v", err)
+	}
+}
+
+func TestSigningSyncPushesCertificateBeforeProfile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "correct horse battery staple")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	remote := filepath.Join(t.TempDir(), "certificates.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		status := http.StatusOK
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/certificates":
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						CSRContent string `json:"csrContent"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			body = `{"data":{"type":"certificates","id":"CERT_1","attributes":{"name":"Apple Distribution","certificateType":"IOS_DISTRIBUTION","certificateContent":"` + signTestCSR(t, payload.Data.Attributes.CSRContent) + `"}}}`
+			status = http.StatusCreated
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			body = `{"data":[{"type":"bundleIds","id":"BUNDLE_1","attributes":{"identifier":"com.example.app"}}]}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			body = `{"data":[]}`
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			body = `{"errors":[{"status":"500","title":"Internal Server Error"}]}`
+			status = http.StatusInternalServerError
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"signing", "sync", "--repo", remote, "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE", "--output", t.TempDir()}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil {
+		t.Fatal("expected profile creation failure")
+	}
+
+	out, err := exec.Command("git", "--git-dir", remote, "log", "--format=%s", "main").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "Add IOS_DISTRIBUTION certificate CERT_1") {
+		t.Fatalf("expected certificate commit to be pushed, got %q", out)
+	}
+}
+
+// signTestCSR issues a certificate for a base64 DER CSR and returns it
+// base64-encoded, like the certificates API.
+func signTestCSR(t *testing.T, content string) string {
+	t.Helper()
+	der, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		t.Fatalf("decode CSR: %v", err)
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("parse CSR: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Example (TEAM123456)"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	signer, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := *template
+	issuer.Subject = pkix.Name{CommonName: "Test WWDR"}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, &issuer, csr.PublicKey, signer)
+	if err != nil {
+		t.Fatalf("sign certificate: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(certDER)
+}
@@ -0,0 +1,182 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBetaTestersImport(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "testers.csv")
+	content := "First Name,Last Name,Email\n" +
+		"Ada,Lovelace,ada@example.com\n" +
+		"Alan,Turing,alan@example.com\n" +
+		"Grace,Hopper,grace@example.com\n" +
+		"Ada,Again,ADA@example.com\n" +
+		"Bad,Row,not-an-email\n"
+	if err := os.WriteFile(csvPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	resultsPath := filepath.Join(dir, "results.csv")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var created, added []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		status := http.StatusOK
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/betaGroups":
+			body = `{"data":[{"type":"betaGroups","id":"g-ext","attributes":{"name":"External"}}],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/betaTesters":
+			body = `{"data":[
+				{"type":"betaTesters","id":"t-alan","attributes":{"email":"alan@example.com"}},
+				{"type":"betaTesters","id":"t-grace","attributes":{"email":"Grace@Example.com"}}
+			],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/betaGroups/g-ext/betaTesters":
+			body = `{"data":[{"type":"betaTesters","id":"t-grace","attributes":{"email":"grace@example.com"}}],"links":{}}`
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/betaTesters":
+			payload, _ := io.ReadAll(req.Body)
+			created = append(created, string(payload))
+			status = http.StatusCreated
+			body = `{"data":{"type":"betaTesters","id":"t-ada","attributes":{"email":"ada@example.com"}}}`
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/betaGroups/g-ext/relationships/betaTesters":
+			payload, _ := io.ReadAll(req.Body)
+			added = append(added, string(payload))
+			status = http.StatusNoContent
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"testflight", "beta-testers", "import",
+			"--app", "APP_ID",
+			"--file", csvPath,
+			"--group", "External",
+			"--results", resultsPath,
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	if len(created) != 1 || !strings.Contains(created[0], `"email":"ada@example.com"`) || !strings.Contains(created[0], `"id":"g-ext"`) {
+		t.Fatalf("expected ada to be created in g-ext, got %v", created)
+	}
+	if len(added) != 1 || !strings.Contains(added[0], `"id":"t-alan"`) || strings.Contains(added[0], "t-grace") {
+		t.Fatalf("expected only alan to be added, got %v", added)
+	}
+
+	var result struct {
+		Total      int `json:"total"`
+		Created    int `json:"created"`
+		Added      int `json:"added"`
+		Existing   int `json:"existing"`
+		Duplicates int `json:"duplicates"`
+		Invalid    int `json:"invalid"`
+		Rows       []struct {
+			Row    int    `json:"row"`
+			Status string `json:"status"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if result.Total != 5 || result.Created != 1 || result.Added != 1 || result.Existing != 1 || result.Duplicates != 1 || result.Invalid != 1 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	if result.Rows[0].Row != 2 || result.Rows[4].Status != "invalid" {
+		t.Fatalf("unexpected rows: %+v", result.Rows)
+	}
+
+	data, err := os.ReadFile(resultsPath)
+	if err != nil {
+		t.Fatalf("read results: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 6 || lines[1] != "2,Ada,Lovelace,ada@example.com,t-ada,created,false," {
+		t.Fatalf("unexpected results file:\n%s", data)
+	}
+}
+
+func TestBetaTestersExport(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch req.URL.Path {
+		case "/v1/apps/APP_ID/betaGroups":
+			body = `{"data":[{"type":"betaGroups","id":"g-ext","attributes":{"name":"External"}}],"links":{}}`
+		case "/v1/betaGroups/g-ext/betaTesters":
+			body = `{"data":[
+				{"type":"betaTesters","id":"t2","attributes":{"email":"zed@example.com","firstName":"Zed"}},
+				{"type":"betaTesters","id":"t1","attributes":{"email":"ada@example.com","firstName":"Ada","lastName":"Lovelace, Countess"}}
+			],"links":{}}`
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	csvPath := filepath.Join(t.TempDir(), "external.csv")
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"testflight", "beta-testers", "export", "--app", "APP_ID", "--group", "External", "--file", csvPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if !strings.Contains(stdout, `"testers":2`) || !strings.Contains(stdout, `"group":"External"`) {
+		t.Fatalf("unexpected output %q", stdout)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := "First Name,Last Name,Email\nAda,\"Lovelace, Countess\",ada@example.com\nZed,,zed@example.com\n"
+	if string(data) != want {
+		t.Fatalf("unexpected csv:\n%s", data)
+	}
+}
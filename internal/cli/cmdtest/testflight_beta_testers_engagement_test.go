@@ -0,0 +1,217 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const betaTesterEngagementUsages = `{"data":[
+	{"dataPoints":[{"values":{"sessionCount":12,"crashCount":1,"feedbackCount":3}}],"dimensions":{"betaTesters":{"data":"t1"}}},
+	{"dataPoints":[{"values":{"sessionCount":2,"crashCount":0,"feedbackCount":0}}],"dimensions":{"betaTesters":{"data":"t3"}}}
+],"links":{}}`
+
+func betaTesterEngagementTransport(t *testing.T, removed *[]string, usages string) roundTripFunc {
+	t.Helper()
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/betaGroups":
+			body = `{"data":[{"type":"betaGroups","id":"g-ext","attributes":{"name":"External"}},{"type":"betaGroups","id":"g-int","attributes":{"name":"Team","isInternalGroup":true}}],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/betaGroups/g-ext/betaTesters":
+			body = `{"data":[
+				{"type":"betaTesters","id":"t1","attributes":{"email":"active@example.com","firstName":"Ada","lastName":"Active"}},
+				{"type":"betaTesters","id":"t2","attributes":{"email":"idle@example.com","firstName":"Ida","lastName":"Idle","state":"INVITED"}}
+			],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/betaGroups/g-int/betaTesters":
+			body = `{"data":[{"type":"betaTesters","id":"t1","attributes":{"email":"active@example.com"}}],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/betaTesters":
+			body = `{"data":[{"type":"betaTesters","id":"t3","attributes":{"email":"solo@example.com"}}],"links":{}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/metrics/betaTesterUsages":
+			if req.URL.Query().Get("period") != "P90D" || req.URL.Query().Get("groupBy") != "betaTesters" {
+				t.Fatalf("unexpected usage query %q", req.URL.RawQuery)
+			}
+			body = usages
+		case req.Method == http.MethodDelete && req.URL.Path == "/v1/betaGroups/g-ext/relationships/betaTesters":
+			payload, _ := io.ReadAll(req.Body)
+			*removed = append(*removed, string(payload))
+			return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}}, nil
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+}
+
+func TestBetaTestersEngagementRanksTesters(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = betaTesterEngagementTransport(t, nil, betaTesterEngagementUsages)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"testflight", "beta-testers", "engagement", "--app", "APP_ID", "--since", "90d"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	var result struct {
+		Period   string `json:"period"`
+		Total    int    `json:"total"`
+		Active   int    `json:"active"`
+		Inactive int    `json:"inactive"`
+		Testers  []struct {
+			Rank     int      `json:"rank"`
+			ID       string   `json:"id"`
+			Groups   []string `json:"groups"`
+			Sessions int      `json:"sessions"`
+			Feedback int      `json:"feedback"`
+		} `json:"testers"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if result.Period != "P90D" || result.Total != 3 || result.Active != 2 || result.Inactive != 1 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+	order := []string{"t1", "t3", "t2"}
+	for i, tester := range result.Testers {
+		if tester.ID != order[i] || tester.Rank != i+1 {
+			t.Fatalf("unexpected ranking: %+v", result.Testers)
+		}
+	}
+	if first := result.Testers[0]; len(first.Groups) != 2 || first.Sessions != 12 || first.Feedback != 3 {
+		t.Fatalf("unexpected top tester: %+v", first)
+	}
+}
+
+func TestBetaTestersPruneRemovesInactiveTesters(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	var removed []string
+	http.DefaultTransport = betaTesterEngagementTransport(t, &removed, betaTesterEngagementUsages)
+
+	csvPath := filepath.Join(t.TempDir(), "removed.csv")
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"testflight", "beta-testers", "prune",
+			"--app", "APP_ID",
+			"--group", "External",
+			"--since", "90d",
+			"--confirm",
+			"--csv", csvPath,
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	if len(removed) != 1 || !strings.Contains(removed[0], `"id":"t2"`) || strings.Contains(removed[0], `"t1"`) {
+		t.Fatalf("expected only t2 removed, got %v", removed)
+	}
+	var result struct {
+		Evaluated    int `json:"evaluated"`
+		Selected     int `json:"selected"`
+		RemovedCount int `json:"removedCount"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v (%q)", err, stdout)
+	}
+	if result.Evaluated != 2 || result.Selected != 1 || result.RemovedCount != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := "First Name,Last Name,Email,Tester ID,Group,Group ID,Sessions,Status\nIda,Idle,idle@example.com,t2,External,g-ext,0,removed\n"
+	if string(data) != want {
+		t.Fatalf("unexpected csv:\n%s", data)
+	}
+}
+
+func TestBetaTestersPruneRefusesUnmatchedUsage(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	tests := []struct {
+		name    string
+		usages  string
+		wantErr string
+	}{
+		{
+			name:    "unparseable dimension",
+			usages:  `{"data":[{"dataPoints":[{"values":{"sessionCount":4}}],"dimensions":{"betaTesters":{"data":42}}}],"links":{}}`,
+			wantErr: "unrecognized betaTesters dimension 42",
+		},
+		{
+			name:    "no matching tester",
+			usages:  `{"data":[{"dataPoints":[{"values":{"sessionCount":4}}],"dimensions":{"betaTesters":{"data":"unknown"}}}],"links":{}}`,
+			wantErr: "none of the 1 usage rows match a tester",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var removed []string
+			http.DefaultTransport = betaTesterEngagementTransport(t, &removed, test.usages)
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			var runErr error
+			captureOutput(t, func() {
+				if err := root.Parse([]string{"testflight", "beta-testers", "prune", "--app", "APP_ID", "--group", "External", "--since", "90d", "--confirm"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				runErr = root.Run(context.Background())
+			})
+			if runErr == nil || !strings.Contains(runErr.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, runErr)
+			}
+			if len(removed) != 0 {
+				t.Fatalf("expected no testers removed, got %v", removed)
+			}
+		})
+	}
+}
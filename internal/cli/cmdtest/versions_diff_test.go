@@ -0,0 +1,111 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVersionsDiffSnapshotAgainstLiveVersion(t *testing.T) {
+	setupAuth(t)
+
+	snapshotPath := filepath.Join(t.TempDir(), "4.2.json")
+	snapshot := `{
+  "schemaVersion": 1,
+  "capturedAt": "2026-01-01T00:00:00Z",
+  "appId": "app-1",
+  "versionId": "version-old",
+  "versionString": "4.2",
+  "platform": "IOS",
+  "version": {"copyright": "2025 Example"},
+  "localizations": {"en-US": {"description": "Same", "whatsNew": "Bug fixes"}},
+  "ageRating": {"gambling": "false"},
+  "screenshots": {"en-US": {"APP_IPHONE_65": [
+    {"fileName": "a.png", "checksum": "aaa"},
+    {"fileName": "b.png", "checksum": "bbb"}
+  ]}}
+}`
+	if err := os.WriteFile(snapshotPath, []byte(snapshot), 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected only GET requests, got %s %s", req.Method, req.URL.Path)
+		}
+		status := http.StatusOK
+		var body string
+		switch req.URL.Path {
+		case "/v1/appStoreVersions/version-new":
+			body = `{"data":{"type":"appStoreVersions","id":"version-new","attributes":{"versionString":"4.3","platform":"IOS","appStoreState":"PREPARE_FOR_SUBMISSION","copyright":"2026 Example"}}}`
+		case "/v1/appStoreVersions/version-new/appStoreVersionLocalizations":
+			body = `{"data":[{"type":"appStoreVersionLocalizations","id":"loc-en","attributes":{"locale":"en-US","description":"Same","whatsNew":"New widgets"}}],"links":{}}`
+		case "/v1/appStoreVersionLocalizations/loc-en/appScreenshotSets":
+			body = `{"data":[{"type":"appScreenshotSets","id":"set-1","attributes":{"screenshotDisplayType":"APP_IPHONE_65"}}],"links":{}}`
+		case "/v1/appScreenshotSets/set-1/appScreenshots":
+			body = `{"data":[
+				{"type":"appScreenshots","id":"shot-2","attributes":{"fileName":"b.png","sourceFileChecksum":"bbb"}},
+				{"type":"appScreenshots","id":"shot-1","attributes":{"fileName":"a.png","sourceFileChecksum":"aaa"}}
+			],"links":{}}`
+		case "/v1/appStoreVersions/version-new/ageRatingDeclaration":
+			body = `{"data":{"type":"ageRatingDeclarations","id":"age-1","attributes":{"gambling":false}}}`
+		case "/v1/apps/app-1/appInfos":
+			body = `{"data":[],"links":{}}`
+		case "/v1/apps/app-1/appPriceSchedule":
+			status = http.StatusNotFound
+			body = `{"errors":[{"code":"NOT_FOUND","title":"Not Found"}]}`
+		default:
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"versions", "diff",
+			"--app", "app-1",
+			"--from", snapshotPath,
+			"--to", "version-new",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	for _, want := range []string{
+		`"from":{"source":"` + snapshotPath,
+		`"to":{"source":"live","versionId":"version-new","versionString":"4.3"`,
+		`{"section":"version","field":"copyright","action":"changed","from":"2025 Example","to":"2026 Example"}`,
+		`{"section":"localizations","locale":"en-US","field":"whatsNew","action":"changed","from":"Bug fixes","to":"New widgets"}`,
+		`{"section":"screenshots","locale":"en-US","field":"APP_IPHONE_65","action":"reordered","from":"a.png, b.png","to":"b.png, a.png"}`,
+		`"identical":false`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %s in output, got %q", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, `"section":"age-rating"`) {
+		t.Fatalf("expected no age rating changes, got %q", stdout)
+	}
+}
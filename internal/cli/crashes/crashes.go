@@ -47,9 +47,15 @@ Examples:
   asc crashes --app "123456789" --device-model "iPhone15,3" --os-version "17.2"
   asc crashes --app "123456789" --sort -createdDate --limit 5
   asc crashes --next "<links.next>"
-  asc crashes --app "123456789" --paginate`,
+  asc crashes --app "123456789" --paginate
+  asc crashes export --app "123456789" --since 7d --dir ./crashes
+  asc crashes symbolicate --file MyApp.crash --dsym MyApp.app.dSYM`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			CrashesExportCommand(),
+			CrashesSymbolicateCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			if *limit != 0 && (*limit < 1 || *limit > 200) {
 				return fmt.Errorf("crashes: --limit must be between 1 and 200")
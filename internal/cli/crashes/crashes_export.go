@@ -0,0 +1,178 @@
+package crashes
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// CrashesExportCommand returns the crashes export subcommand.
+func CrashesExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	since := fs.String("since", "7d", "Export crashes newer than a duration (24h, 7d, 2w, 3m) or date (YYYY-MM-DD)")
+	dir := fs.String("dir", "", "Directory to write crash logs, digest and issue files into")
+	digest := fs.String("digest", shared.BetaFeedbackDigestMarkdown, "Digest format: markdown or html")
+	issues := fs.String("issues", shared.BetaFeedbackIssuesGitHub, "Issue file format: github (issues.json), jira (issues.csv), or none")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc crashes export --app APP_ID --dir DIR [flags]",
+		ShortHelp:  "Export TestFlight crashes with crash logs and a digest.",
+		LongHelp: `Export TestFlight crashes with crash logs and a digest.
+
+Downloads the crash log of every crash submitted since --since into DIR,
+grouped by build (DIR/<version>-<build>/<submission>/crash.log), and writes:
+
+  digest.md or digest.html   crashes grouped by build, linking each log
+  issues.json / issues.csv   one issue per submission (GitHub or Jira import)
+  crashes.json               manifest of everything exported
+
+Crash logs that already exist are skipped, so the command can be re-run
+into the same directory.
+
+Examples:
+  asc crashes export --app "123456789" --dir ./feedback
+  asc crashes export --app "123456789" --since 30d --dir ./feedback --digest html
+  asc crashes export --app "123456789" --since 2026-01-01 --dir ./feedback --issues jira`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*dir) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --dir is required")
+				return flag.ErrHelp
+			}
+			sinceTime, err := shared.ParseTimeOrAge("--since", *since, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+			digestFormat := strings.ToLower(strings.TrimSpace(*digest))
+			issuesFormat := strings.ToLower(strings.TrimSpace(*issues))
+			if err := shared.ValidateBetaFeedbackExportFormats(digestFormat, issuesFormat); err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			if err := os.MkdirAll(*dir, 0o755); err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+
+			result := &asc.BetaFeedbackExportResult{
+				AppID: resolvedAppID,
+				Kind:  "crashes",
+				Since: sinceTime.Format(time.RFC3339),
+				Dir:   *dir,
+			}
+			builds := shared.NewBetaFeedbackBuildResolver(client)
+
+			resp, err := client.GetCrashes(requestCtx, resolvedAppID,
+				asc.WithCrashSort("-createdDate"),
+				asc.WithCrashLimit(200),
+				asc.WithCrashInclude([]string{"build"}),
+			)
+			for {
+				if err != nil {
+					return fmt.Errorf("crashes export: failed to fetch: %w", err)
+				}
+				if err := builds.AddIncluded(requestCtx, resp.Included); err != nil {
+					return fmt.Errorf("crashes export: %w", err)
+				}
+				reachedSince := false
+				for _, submission := range resp.Data {
+					if created, parseErr := time.Parse(time.RFC3339, submission.Attributes.CreatedDate); parseErr == nil && created.Before(sinceTime) {
+						reachedSince = true
+						break
+					}
+					item := exportCrashSubmission(submission)
+					builds.Apply(&item, submission.Relationships)
+					downloadCrashLog(requestCtx, client, result, &item, submission.Attributes.CrashLog)
+					result.Items = append(result.Items, item)
+				}
+				if reachedSince || strings.TrimSpace(resp.Links.Next) == "" {
+					break
+				}
+				resp, err = client.GetCrashes(requestCtx, resolvedAppID, asc.WithCrashNextURL(resp.Links.Next))
+			}
+
+			if err := shared.FinishBetaFeedbackExport(result, digestFormat, issuesFormat); err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if err := shared.BetaFeedbackExportFailure(result); err != nil {
+				return fmt.Errorf("crashes export: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+func exportCrashSubmission(submission asc.Resource[asc.CrashAttributes]) asc.BetaFeedbackExportItem {
+	attrs := submission.Attributes
+	return asc.BetaFeedbackExportItem{
+		ID:          submission.ID,
+		Kind:        "crashes",
+		CreatedDate: attrs.CreatedDate,
+		Email:       attrs.Email,
+		Comment:     attrs.Comment,
+		DeviceModel: attrs.DeviceModel,
+		OSVersion:   attrs.OSVersion,
+		AppPlatform: attrs.AppPlatform,
+		Locale:      attrs.Locale,
+	}
+}
+
+// downloadCrashLog saves the crash log into the item's build directory,
+// fetching it separately when the list response did not embed it. Failures
+// are recorded on the item instead of aborting the export.
+func downloadCrashLog(ctx context.Context, client *asc.Client, result *asc.BetaFeedbackExportResult, item *asc.BetaFeedbackExportItem, embedded string) {
+	relPath := path.Join(shared.BetaFeedbackItemDir(*item), "crash.log")
+	written, err := shared.WriteBetaFeedbackAttachment(result.Dir, relPath, func() (io.ReadCloser, error) {
+		logText := embedded
+		if strings.TrimSpace(logText) == "" {
+			resp, err := client.GetBetaFeedbackCrashSubmissionCrashLog(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+			logText = resp.Data.Attributes.LogText
+		}
+		return io.NopCloser(strings.NewReader(logText)), nil
+	})
+	if err != nil {
+		result.Failed++
+		item.Errors = append(item.Errors, fmt.Sprintf("crash log: %v", err))
+		return
+	}
+	if written {
+		result.Downloaded++
+	} else {
+		result.Skipped++
+	}
+	item.Files = append(item.Files, relPath)
+}
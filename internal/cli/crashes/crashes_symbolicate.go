@@ -0,0 +1,239 @@
+package crashes
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// CrashesSymbolicateCommand returns the crashes symbolicate subcommand.
+func CrashesSymbolicateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("symbolicate", flag.ExitOnError)
+
+	file := fs.String("file", "", "Path to a crash log (.crash or TestFlight crash log text)")
+	crashLogID := fs.String("crash-log-id", "", "TestFlight beta crash log ID to fetch")
+	submissionID := fs.String("submission-id", "", "TestFlight crash submission ID whose crash log to fetch")
+	dsym := fs.String("dsym", "", "dSYM bundle(s) or unstripped Mach-O file(s), comma-separated")
+	dsymDir := fs.String("dsym-dir", "", "Directory(ies) searched recursively for .dSYM bundles, comma-separated")
+	out := fs.String("out", "", "Write the symbolicated crash log to this path")
+	overwrite := fs.Bool("overwrite", false, "Replace an existing --out file")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "symbolicate",
+		ShortUsage: "asc crashes symbolicate (--file PATH | --crash-log-id ID | --submission-id ID) --dsym PATH [flags]",
+		ShortHelp:  "Symbolicate a crash log with local dSYMs.",
+		LongHelp: `Symbolicate a crash log with local dSYMs.
+
+Reads the crash log's Binary Images section, matches each image to a dSYM by
+UUID, and resolves stack frame addresses to function, file and line using the
+dSYM's DWARF debug info (falling back to its symbol table). Universal dSYMs
+are supported; each architecture slice is matched by its own UUID. Works
+without Xcode, so it runs on Linux CI.
+
+Images that appear in a stack trace but have no matching dSYM are reported
+as unmatched. System libraries are not expected to have dSYMs and are not
+reported. Text crash reports are supported; JSON .ips reports are not.
+
+Without --out, the symbolicated log is included in the JSON output.
+
+Examples:
+  asc crashes symbolicate --file MyApp.crash --dsym build/MyApp.app.dSYM
+  asc crashes symbolicate --submission-id "SUBMISSION_ID" --dsym-dir ./dSYMs --out symbolicated.crash
+  asc crashes symbolicate --crash-log-id "CRASH_LOG_ID" --dsym MyApp.app.dSYM,MyKit.framework.dSYM --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			sources := 0
+			for _, value := range []string{*file, *crashLogID, *submissionID} {
+				if strings.TrimSpace(value) != "" {
+					sources++
+				}
+			}
+			if sources == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --file, --crash-log-id, or --submission-id is required")
+				return flag.ErrHelp
+			}
+			if sources > 1 {
+				return fmt.Errorf("crashes symbolicate: --file, --crash-log-id, and --submission-id are mutually exclusive")
+			}
+			dsymPaths := shared.SplitCSV(*dsym)
+			dsymDirs := shared.SplitCSV(*dsymDir)
+			if len(dsymPaths) == 0 && len(dsymDirs) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --dsym or --dsym-dir is required")
+				return flag.ErrHelp
+			}
+			outPath := strings.TrimSpace(*out)
+			if outPath != "" && !*overwrite {
+				if _, err := os.Lstat(outPath); err == nil {
+					return fmt.Errorf("crashes symbolicate: %s already exists (use --overwrite)", outPath)
+				}
+			}
+
+			source, text, err := readCrashLogSource(ctx, strings.TrimSpace(*file), strings.TrimSpace(*crashLogID), strings.TrimSpace(*submissionID))
+			if err != nil {
+				return fmt.Errorf("crashes symbolicate: %w", err)
+			}
+			log, err := parseCrashLog(text)
+			if err != nil {
+				return fmt.Errorf("crashes symbolicate: %w", err)
+			}
+
+			dsyms, err := loadDSYMs(dsymPaths, dsymDirs)
+			if err != nil {
+				return fmt.Errorf("crashes symbolicate: %w", err)
+			}
+			defer dsyms.Close()
+			if len(dsyms.byUUID) == 0 {
+				return fmt.Errorf("crashes symbolicate: no Mach-O files with a UUID found in the given dSYMs")
+			}
+
+			result, symbolicated := symbolicateCrashLog(log, dsyms)
+			result.Source = source
+			if outPath != "" {
+				if *overwrite {
+					if err := os.Remove(outPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+						return fmt.Errorf("crashes symbolicate: %w", err)
+					}
+				}
+				if _, err := shared.WriteStreamToFile(outPath, strings.NewReader(symbolicated)); err != nil {
+					return fmt.Errorf("crashes symbolicate: failed to write %s: %w", outPath, err)
+				}
+				result.Out = outPath
+			} else {
+				result.SymbolicatedLog = symbolicated
+			}
+
+			for _, image := range result.Unmatched {
+				fmt.Fprintf(os.Stderr, "Warning: no dSYM matches %s\n", image)
+			}
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if result.Matched == 0 {
+				return shared.NewReportedError(errors.New("crashes symbolicate: no dSYM matched any binary image in the crash log"))
+			}
+			return nil
+		},
+	}
+}
+
+// readCrashLogSource loads crash log text from a file or the API.
+func readCrashLogSource(ctx context.Context, path, crashLogID, submissionID string) (string, string, error) {
+	if path != "" {
+		file, err := shared.OpenExistingNoFollow(path)
+		if err != nil {
+			return "", "", err
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return "", "", err
+		}
+		return path, string(data), nil
+	}
+
+	client, err := shared.GetASCClient()
+	if err != nil {
+		return "", "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	var resp *asc.BetaCrashLogResponse
+	source := ""
+	if crashLogID != "" {
+		source = "crash log " + crashLogID
+		resp, err = client.GetBetaCrashLog(requestCtx, crashLogID)
+	} else {
+		source = "crash submission " + submissionID
+		resp, err = client.GetBetaFeedbackCrashSubmissionCrashLog(requestCtx, submissionID)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch crash log: %w", err)
+	}
+	if strings.TrimSpace(resp.Data.Attributes.LogText) == "" {
+		return "", "", fmt.Errorf("%s has no log text", source)
+	}
+	return source, resp.Data.Attributes.LogText, nil
+}
+
+// symbolicateCrashLog resolves every frame that belongs to an image with a
+// matching dSYM and returns the report along with the rewritten log.
+func symbolicateCrashLog(log *parsedCrashLog, dsyms *dsymSet) (*asc.CrashSymbolicationResult, string) {
+	result := &asc.CrashSymbolicationResult{
+		DSYMs:  len(dsyms.byUUID),
+		Frames: len(log.Frames),
+	}
+	frameCounts := make([]int, len(log.Images))
+	lines := append([]string(nil), log.Lines...)
+
+	for _, frame := range log.Frames {
+		entry := asc.CrashSymbolicationFrame{
+			Thread:  frame.Thread,
+			Index:   frame.Index,
+			Image:   frame.Image,
+			Address: fmt.Sprintf("0x%016x", frame.Address),
+		}
+		if i, ok := log.imageFor(frame); ok {
+			frameCounts[i]++
+			image := log.Images[i]
+			if binary := dsyms.byUUID[image.UUID]; binary != nil {
+				if loc, ok := binary.lookup(frame.Address - image.Start + binary.textAddr); ok {
+					entry.Symbol = loc.Function
+					entry.Offset = loc.Offset
+					entry.File = loc.File
+					entry.Line = loc.Line
+					entry.InlinedInto = loc.InlinedInto
+					if match := stackFrameRegex.FindStringSubmatch(lines[frame.Line]); match != nil {
+						lines[frame.Line] = strings.Join(match[1:7], "") + formatSymbolLocation(loc)
+					}
+					result.Symbolicated++
+				}
+			}
+		}
+		result.StackFrames = append(result.StackFrames, entry)
+	}
+
+	for i, image := range log.Images {
+		binary := dsyms.byUUID[image.UUID]
+		if binary == nil && frameCounts[i] == 0 {
+			continue
+		}
+		entry := asc.CrashSymbolicationImage{
+			Name:        image.Name,
+			Arch:        image.Arch,
+			UUID:        image.UUID,
+			LoadAddress: fmt.Sprintf("0x%x", image.Start),
+			Path:        image.Path,
+			Frames:      frameCounts[i],
+		}
+		switch {
+		case binary != nil:
+			entry.Status = "matched"
+			entry.DSYM = binary.Path
+			if entry.Arch == "" {
+				entry.Arch = binary.Arch
+			}
+			result.Matched++
+		case image.isSystem():
+			entry.Status = "system"
+		default:
+			entry.Status = "unmatched"
+			result.Unmatched = append(result.Unmatched, fmt.Sprintf("%s <%s>", image.Name, image.UUID))
+		}
+		result.Images = append(result.Images, entry)
+	}
+
+	return result, strings.Join(lines, "\n")
+}
@@ -0,0 +1,484 @@
+package crashes
+
+import (
+	"debug/dwarf"
+	"debug/macho"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lcUUID is the Mach-O LC_UUID load command, which debug/macho exposes only
+// as raw bytes.
+const lcUUID = 0x1b
+
+var (
+	// 0x104000000 - 0x104ffffff MyApp arm64  <8d4e...> /private/var/.../MyApp.app/MyApp
+	binaryImageRegex = regexp.MustCompile(`(?i)^\s*(0x[0-9a-f]+)\s*-\s*(0x[0-9a-f]+|\?\?\?)\s+\+?(.+?)\s+(?:(arm64e|arm64_32|arm64|armv7[ks]?|x86_64h?|i386)\s+)?<([0-9a-f-]+)>\s*(.*)$`)
+	// 0   MyApp   0x0000000104003a2c 0x104000000 + 14892
+	stackFrameRegex = regexp.MustCompile(`^(\d+)(\s+)(.+?)(\s+)(0x[0-9a-fA-F]+)(\s+)(.+)$`)
+	threadRegex     = regexp.MustCompile(`^(Thread \d+(?: Crashed)?|Last Exception Backtrace):\s*$`)
+)
+
+// crashImage is one entry of a crash log's "Binary Images" section.
+type crashImage struct {
+	Name  string
+	Arch  string
+	UUID  string
+	Path  string
+	Start uint64
+	End   uint64
+}
+
+func (img crashImage) contains(addr uint64) bool {
+	if img.End == 0 {
+		return false
+	}
+	return addr >= img.Start && addr <= img.End
+}
+
+// isSystem reports whether the image ships with the OS rather than the app,
+// so a missing dSYM is expected.
+func (img crashImage) isSystem() bool {
+	for _, prefix := range []string{"/System/", "/usr/lib/", "/usr/libexec/", "/Developer/", "/Library/Apple/", "/private/preboot/Cryptexes/"} {
+		if strings.HasPrefix(img.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// crashFrame is one stack frame line of a crash log.
+type crashFrame struct {
+	Line    int
+	Thread  string
+	Index   int
+	Image   string
+	Address uint64
+}
+
+// parsedCrashLog holds the parts of a textual crash report needed to
+// symbolicate it.
+type parsedCrashLog struct {
+	Lines  []string
+	Images []crashImage
+	Frames []crashFrame
+}
+
+func normalizeUUID(value string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "-", ""))
+}
+
+func parseHexAddress(value string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(strings.ToLower(value), "0x"), 16, 64)
+}
+
+// parseCrashLog reads the binary images and stack frames of an Apple crash
+// report (.crash / TestFlight crash log text).
+func parseCrashLog(text string) (*parsedCrashLog, error) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") {
+		return nil, errors.New("crash log is in JSON (.ips) format; export it as a text crash report first")
+	}
+	log := &parsedCrashLog{Lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")}
+	inImages := false
+	thread := ""
+	for i, line := range log.Lines {
+		trimmedLine := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmedLine, "Binary Images:") {
+			inImages = true
+			continue
+		}
+		if inImages {
+			match := binaryImageRegex.FindStringSubmatch(line)
+			if match == nil {
+				if trimmedLine == "" || strings.HasPrefix(trimmedLine, "EOF") {
+					continue
+				}
+				inImages = false
+			} else {
+				start, err := parseHexAddress(match[1])
+				if err != nil {
+					continue
+				}
+				var end uint64
+				if match[2] != "???" {
+					end, _ = parseHexAddress(match[2])
+				}
+				log.Images = append(log.Images, crashImage{
+					Name:  strings.TrimSpace(match[3]),
+					Arch:  strings.ToLower(match[4]),
+					UUID:  normalizeUUID(match[5]),
+					Path:  strings.TrimSpace(match[6]),
+					Start: start,
+					End:   end,
+				})
+				continue
+			}
+		}
+		if match := threadRegex.FindStringSubmatch(trimmedLine); match != nil {
+			thread = match[1]
+			continue
+		}
+		if match := stackFrameRegex.FindStringSubmatch(line); match != nil {
+			index, err := strconv.Atoi(match[1])
+			if err != nil {
+				continue
+			}
+			addr, err := parseHexAddress(match[5])
+			if err != nil {
+				continue
+			}
+			log.Frames = append(log.Frames, crashFrame{
+				Line:    i,
+				Thread:  thread,
+				Index:   index,
+				Image:   strings.TrimSpace(match[3]),
+				Address: addr,
+			})
+		}
+	}
+	if len(log.Images) == 0 {
+		return nil, errors.New("crash log has no Binary Images section")
+	}
+	return log, nil
+}
+
+// imageFor returns the binary image a frame address belongs to.
+func (l *parsedCrashLog) imageFor(frame crashFrame) (int, bool) {
+	for i, img := range l.Images {
+		if img.contains(frame.Address) {
+			return i, true
+		}
+	}
+	for i, img := range l.Images {
+		if img.End == 0 && img.Name == frame.Image && frame.Address >= img.Start {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// dsymBinary is one architecture slice of a dSYM (or unstripped binary).
+type dsymBinary struct {
+	Path     string
+	Arch     string
+	UUID     string
+	file     *macho.File
+	textAddr uint64
+
+	dwarfData *dwarf.Data
+	dwarfErr  error
+	symbols   []macho.Symbol
+}
+
+// dsymSet indexes the Mach-O slices of the given dSYMs by UUID.
+type dsymSet struct {
+	byUUID  map[string]*dsymBinary
+	closers []io.Closer
+}
+
+func (s *dsymSet) Close() error {
+	for _, closer := range s.closers {
+		_ = closer.Close()
+	}
+	return nil
+}
+
+// loadDSYMs opens each dSYM bundle, Mach-O file, or directory of dSYMs.
+func loadDSYMs(paths []string, dirs []string) (*dsymSet, error) {
+	set := &dsymSet{byUUID: map[string]*dsymBinary{}}
+	for _, path := range paths {
+		if err := set.addPath(path); err != nil {
+			set.Close()
+			return nil, err
+		}
+	}
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".dsym") {
+				if err := set.addPath(path); err != nil {
+					return err
+				}
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			set.Close()
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func (s *dsymSet) addPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return s.addFile(path)
+	}
+	dwarfDir := filepath.Join(path, "Contents", "Resources", "DWARF")
+	entries, err := os.ReadDir(dwarfDir)
+	if err != nil {
+		return fmt.Errorf("%s is not a dSYM bundle: %w", path, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if err := s.addFile(filepath.Join(dwarfDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dsymSet) addFile(path string) error {
+	fat, err := macho.OpenFat(path)
+	if err == nil {
+		s.closers = append(s.closers, fat)
+		for _, arch := range fat.Arches {
+			s.addSlice(path, arch.File)
+		}
+		return nil
+	}
+	if !errors.Is(err, macho.ErrNotFat) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	file, err := macho.Open(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	s.closers = append(s.closers, file)
+	s.addSlice(path, file)
+	return nil
+}
+
+func (s *dsymSet) addSlice(path string, file *macho.File) {
+	uuid := machoUUID(file)
+	if uuid == "" {
+		return
+	}
+	binary := &dsymBinary{
+		Path: path,
+		Arch: machoArchName(file.Cpu, file.SubCpu),
+		UUID: uuid,
+		file: file,
+	}
+	if text := file.Segment("__TEXT"); text != nil {
+		binary.textAddr = text.Addr
+	}
+	s.byUUID[uuid] = binary
+}
+
+func machoUUID(file *macho.File) string {
+	for _, load := range file.Loads {
+		raw := load.Raw()
+		if len(raw) >= 24 && file.ByteOrder.Uint32(raw) == lcUUID {
+			return hex.EncodeToString(raw[8:24])
+		}
+	}
+	return ""
+}
+
+func machoArchName(cpu macho.Cpu, subCpu uint32) string {
+	switch cpu {
+	case macho.CpuArm64:
+		if subCpu&0xff == 2 {
+			return "arm64e"
+		}
+		return "arm64"
+	case macho.CpuAmd64:
+		if subCpu&0xff == 8 {
+			return "x86_64h"
+		}
+		return "x86_64"
+	case macho.CpuArm:
+		return "armv7"
+	case macho.Cpu386:
+		return "i386"
+	default:
+		return strings.ToLower(cpu.String())
+	}
+}
+
+// symbolLocation is the resolved symbol of one address.
+type symbolLocation struct {
+	Function    string
+	Offset      uint64
+	File        string
+	Line        int
+	InlinedInto string
+}
+
+// lookup resolves an address in the binary's own address space using DWARF
+// debug info, falling back to the symbol table.
+func (b *dsymBinary) lookup(addr uint64) (symbolLocation, bool) {
+	loc := b.lookupDWARF(addr)
+	if loc.Function == "" {
+		sym, ok := b.lookupSymtab(addr)
+		if !ok {
+			return symbolLocation{}, false
+		}
+		loc.Function, loc.Offset = sym.Function, sym.Offset
+	}
+	return loc, true
+}
+
+func (b *dsymBinary) lookupDWARF(addr uint64) symbolLocation {
+	if b.dwarfData == nil && b.dwarfErr == nil {
+		b.dwarfData, b.dwarfErr = b.file.DWARF()
+	}
+	if b.dwarfErr != nil {
+		return symbolLocation{}
+	}
+	data := b.dwarfData
+	reader := data.Reader()
+	cu, err := reader.SeekPC(addr)
+	if err != nil || cu == nil {
+		return symbolLocation{}
+	}
+
+	// Walk the unit, descending only into entries whose ranges contain addr;
+	// the chain ends at the innermost inlined subroutine.
+	var chain []*dwarf.Entry
+	depth := 1
+	for depth > 0 {
+		entry, err := reader.Next()
+		if err != nil || entry == nil {
+			break
+		}
+		if entry.Tag == 0 {
+			depth--
+			continue
+		}
+		switch entry.Tag {
+		case dwarf.TagSubprogram, dwarf.TagInlinedSubroutine, dwarf.TagLexDwarfBlock:
+			if !entryContains(data, entry, addr) {
+				if entry.Children {
+					reader.SkipChildren()
+				}
+				continue
+			}
+			if entry.Tag != dwarf.TagLexDwarfBlock {
+				chain = append(chain, entry)
+			}
+		}
+		if entry.Children {
+			depth++
+		}
+	}
+
+	loc := symbolLocation{}
+	if len(chain) > 0 {
+		outer := chain[0]
+		inner := chain[len(chain)-1]
+		loc.Function = dwarfEntryName(data, inner)
+		if len(chain) > 1 {
+			loc.InlinedInto = dwarfEntryName(data, outer)
+		}
+		if ranges, err := data.Ranges(outer); err == nil && len(ranges) > 0 {
+			low := ranges[0][0]
+			for _, r := range ranges {
+				low = min(low, r[0])
+			}
+			loc.Offset = addr - low
+		}
+	}
+	if lineReader, err := data.LineReader(cu); err == nil && lineReader != nil {
+		var entry dwarf.LineEntry
+		if err := lineReader.SeekPC(addr, &entry); err == nil && entry.File != nil {
+			loc.File = filepath.Base(entry.File.Name)
+			loc.Line = entry.Line
+		}
+	}
+	return loc
+}
+
+func entryContains(data *dwarf.Data, entry *dwarf.Entry, addr uint64) bool {
+	ranges, err := data.Ranges(entry)
+	if err != nil {
+		return false
+	}
+	for _, r := range ranges {
+		if addr >= r[0] && addr < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// dwarfEntryName returns the name of a subprogram, following abstract origins
+// of inlined and out-of-line instances.
+func dwarfEntryName(data *dwarf.Data, entry *dwarf.Entry) string {
+	for i := 0; i < 4 && entry != nil; i++ {
+		if name, ok := entry.Val(dwarf.AttrName).(string); ok && name != "" {
+			return name
+		}
+		if name, ok := entry.Val(dwarf.AttrLinkageName).(string); ok && name != "" {
+			return name
+		}
+		offset, ok := entry.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+		if !ok {
+			offset, ok = entry.Val(dwarf.AttrSpecification).(dwarf.Offset)
+		}
+		if !ok {
+			break
+		}
+		reader := data.Reader()
+		reader.Seek(offset)
+		entry, _ = reader.Next()
+	}
+	return ""
+}
+
+func (b *dsymBinary) lookupSymtab(addr uint64) (symbolLocation, bool) {
+	if b.symbols == nil {
+		b.symbols = []macho.Symbol{}
+		if b.file.Symtab != nil {
+			for _, sym := range b.file.Symtab.Syms {
+				// Skip debugger (stab) entries and undefined symbols.
+				if sym.Type&0xe0 != 0 || sym.Sect == 0 || sym.Name == "" {
+					continue
+				}
+				b.symbols = append(b.symbols, sym)
+			}
+		}
+		sort.Slice(b.symbols, func(i, j int) bool { return b.symbols[i].Value < b.symbols[j].Value })
+	}
+	i := sort.Search(len(b.symbols), func(i int) bool { return b.symbols[i].Value > addr }) - 1
+	if i < 0 {
+		return symbolLocation{}, false
+	}
+	sym := b.symbols[i]
+	return symbolLocation{
+		Function: strings.TrimPrefix(sym.Name, "_"),
+		Offset:   addr - sym.Value,
+	}, true
+}
+
+// formatSymbolLocation renders a location the way symbolicated crash logs do:
+// "function + offset (file:line)".
+func formatSymbolLocation(loc symbolLocation) string {
+	text := fmt.Sprintf("%s + %d", loc.Function, loc.Offset)
+	if loc.File != "" {
+		text += fmt.Sprintf(" (%s:%d)", loc.File, loc.Line)
+	}
+	if loc.InlinedInto != "" {
+		text += " [inlined into " + loc.InlinedInto + "]"
+	}
+	return text
+}
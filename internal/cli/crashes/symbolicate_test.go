@@ -0,0 +1,199 @@
+package crashes
+
+import (
+	"debug/macho"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleCrashLog = `Incident Identifier: 6156848E-344E-4D9E-84E0-87AFD0D0AE7B
+Hardware Model:      iPhone15,3
+Process:             MyApp [1234]
+
+Thread 0 name:  Dispatch queue: com.apple.main-thread
+Thread 0 Crashed:
+0   MyApp                         	0x0000000104003a2c 0x104000000 + 14892
+1   My Kit                        	0x0000000105001000 0x105000000 + 4096
+2   libsystem_kernel.dylib        	0x00000001e5f2c1a8 __pthread_kill + 8
+
+Thread 1:
+0   MyApp                         	0x0000000104000010 MyApp + 16
+
+Thread 0 crashed with ARM Thread State (64-bit):
+    x0: 0x0000000000000000   x1: 0x0000000000000000
+
+Binary Images:
+0x104000000 - 0x104ffffff MyApp arm64  <8d4e4f3c1b2a3d4e5f60718293a4b5c6> /private/var/containers/Bundle/Application/UUID/MyApp.app/MyApp
+0x105000000 - 0x1050fffff My Kit arm64  <00112233445566778899aabbccddeeff> /private/var/containers/Bundle/Application/UUID/MyApp.app/Frameworks/My Kit.framework/My Kit
+0x1e5f2a000 - 0x1e5f61fff libsystem_kernel.dylib arm64e  <A1B2C3D4-E5F6-0718-293A-4B5C6D7E8F90> /usr/lib/system/libsystem_kernel.dylib
+
+EOF
+`
+
+func TestParseCrashLog(t *testing.T) {
+	log, err := parseCrashLog(sampleCrashLog)
+	if err != nil {
+		t.Fatalf("parseCrashLog() error: %v", err)
+	}
+	if len(log.Images) != 3 {
+		t.Fatalf("expected 3 images, got %+v", log.Images)
+	}
+	kit := log.Images[1]
+	if kit.Name != "My Kit" || kit.Arch != "arm64" || kit.UUID != "00112233445566778899aabbccddeeff" || kit.Start != 0x105000000 || kit.End != 0x1050fffff {
+		t.Fatalf("unexpected image: %+v", kit)
+	}
+	if log.Images[2].UUID != "a1b2c3d4e5f60718293a4b5c6d7e8f90" || !log.Images[2].isSystem() {
+		t.Fatalf("expected normalized system image, got %+v", log.Images[2])
+	}
+	if len(log.Frames) != 4 {
+		t.Fatalf("expected 4 frames, got %+v", log.Frames)
+	}
+	if frame := log.Frames[1]; frame.Thread != "Thread 0 Crashed" || frame.Image != "My Kit" || frame.Address != 0x105001000 {
+		t.Fatalf("unexpected frame: %+v", frame)
+	}
+	if frame := log.Frames[3]; frame.Thread != "Thread 1" || frame.Index != 0 {
+		t.Fatalf("unexpected frame: %+v", frame)
+	}
+	if i, ok := log.imageFor(log.Frames[0]); !ok || i != 0 {
+		t.Fatalf("expected first frame in MyApp, got %d %v", i, ok)
+	}
+}
+
+func TestParseCrashLogRejectsIPS(t *testing.T) {
+	if _, err := parseCrashLog(`{"app_name":"MyApp","bug_type":"309"}` + "\n{}"); err == nil || !strings.Contains(err.Error(), ".ips") {
+		t.Fatalf("expected .ips error, got %v", err)
+	}
+}
+
+// TestSymbolicateCrashLogUniversalDSYM cross-compiles a small darwin program
+// for two architectures, packs them into a universal dSYM bundle, and
+// symbolicates crash logs for each slice.
+func TestSymbolicateCrashLogUniversalDSYM(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping cross-compilation in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	src := t.TempDir()
+	program := "package main\n\n//go:noinline\nfunc crashHere() { panic(\"crash\") }\n\nfunc main() { crashHere() }\n"
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte(program), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "go.mod"), []byte("module crashapp\n\ngo 1.21\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var slices [][]byte
+	for _, arch := range []string{"arm64", "amd64"} {
+		binPath := filepath.Join(src, "app-"+arch)
+		cmd := exec.Command(goBin, "build", "-o", binPath, ".")
+		cmd.Dir = src
+		cmd.Env = append(os.Environ(), "GOOS=darwin", "GOARCH="+arch, "CGO_ENABLED=0", "GOFLAGS=", "GOWORK=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("cross-compiling for darwin/%s failed: %v\n%s", arch, err, out)
+		}
+		data, err := os.ReadFile(binPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		slices = append(slices, data)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "MyApp.app.dSYM")
+	dwarfDir := filepath.Join(bundle, "Contents", "Resources", "DWARF")
+	if err := os.MkdirAll(dwarfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dwarfDir, "MyApp"), universalBinary(t, slices), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dsyms, err := loadDSYMs(nil, []string{filepath.Dir(bundle)})
+	if err != nil {
+		t.Fatalf("loadDSYMs() error: %v", err)
+	}
+	defer dsyms.Close()
+	if len(dsyms.byUUID) != 2 {
+		t.Fatalf("expected 2 architecture slices, got %d", len(dsyms.byUUID))
+	}
+
+	for _, binary := range dsyms.byUUID {
+		t.Run(binary.Arch, func(t *testing.T) {
+			var symbol uint64
+			for _, sym := range binary.file.Symtab.Syms {
+				if strings.TrimPrefix(sym.Name, "_") == "main.crashHere" {
+					symbol = sym.Value
+				}
+			}
+			if symbol == 0 {
+				t.Fatal("main.crashHere not found in symbol table")
+			}
+			if loc := binary.lookupDWARF(symbol + 4); loc.Function != "main.crashHere" || loc.Line != 4 {
+				t.Fatalf("expected DWARF lookup to resolve main.crashHere, got %+v", loc)
+			}
+
+			const loadAddress = 0x104000000
+			frameAddress := loadAddress + symbol - binary.textAddr + 4
+			crashLog := fmt.Sprintf(`Thread 0 Crashed:
+0   MyApp                         	0x%016x 0x%x + %d
+1   MyKit                         	0x0000000105000010 0x105000000 + 16
+
+Binary Images:
+0x%x - 0x%x MyApp %s  <%s> /private/var/containers/Bundle/Application/UUID/MyApp.app/MyApp
+0x105000000 - 0x1050fffff MyKit %s  <00112233445566778899aabbccddeeff> /private/var/containers/Bundle/Application/UUID/MyApp.app/Frameworks/MyKit.framework/MyKit
+`, frameAddress, loadAddress, frameAddress-loadAddress, loadAddress, loadAddress+0xffffff, binary.Arch, binary.UUID, binary.Arch)
+
+			log, err := parseCrashLog(crashLog)
+			if err != nil {
+				t.Fatalf("parseCrashLog() error: %v", err)
+			}
+			result, text := symbolicateCrashLog(log, dsyms)
+			if result.Matched != 1 || result.Symbolicated != 1 {
+				t.Fatalf("expected one matched image and frame, got %+v", result)
+			}
+			frame := result.StackFrames[0]
+			if frame.Symbol != "main.crashHere" || frame.File != "main.go" || frame.Line != 4 || frame.Offset != 4 {
+				t.Fatalf("unexpected frame: %+v", frame)
+			}
+			if !strings.Contains(text, "main.crashHere + 4 (main.go:4)") {
+				t.Fatalf("expected symbolicated frame in log, got:\n%s", text)
+			}
+			if len(result.Unmatched) != 1 || !strings.HasPrefix(result.Unmatched[0], "MyKit <0011") {
+				t.Fatalf("expected MyKit to be unmatched, got %v", result.Unmatched)
+			}
+		})
+	}
+}
+
+// universalBinary packs thin Mach-O files into a fat (universal) file.
+func universalBinary(t *testing.T, slices [][]byte) []byte {
+	t.Helper()
+	const align = 14
+	header := make([]byte, 8+20*len(slices))
+	binary.BigEndian.PutUint32(header[0:], macho.MagicFat)
+	binary.BigEndian.PutUint32(header[4:], uint32(len(slices)))
+	out := append([]byte(nil), header...)
+	for i, slice := range slices {
+		file, err := macho.NewFile(strings.NewReader(string(slice)))
+		if err != nil {
+			t.Fatalf("parse slice: %v", err)
+		}
+		offset := (len(out) + (1<<align - 1)) &^ (1<<align - 1)
+		out = append(out, make([]byte, offset-len(out))...)
+		entry := out[8+20*i:]
+		binary.BigEndian.PutUint32(entry[0:], uint32(file.Cpu))
+		binary.BigEndian.PutUint32(entry[4:], file.SubCpu)
+		binary.BigEndian.PutUint32(entry[8:], uint32(offset))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(slice)))
+		binary.BigEndian.PutUint32(entry[16:], align)
+		out = append(out, slice...)
+	}
+	return out
+}
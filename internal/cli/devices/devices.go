@@ -30,6 +30,9 @@ Examples:
   asc devices get --id "DEVICE_ID"
   asc devices local-udid
   asc devices register --name "iPhone 15" --udid "UDID" --platform IOS
+  asc devices import --file devices.txt --dry-run
+  asc devices export --file devices.txt
+  asc devices collect --name "Acme Devices" --url "https://devices.example.com" --register
   asc devices update --id "DEVICE_ID" --status DISABLED`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -38,6 +41,9 @@ Examples:
 			DevicesGetCommand(),
 			DevicesLocalUDIDCommand(),
 			DevicesRegisterCommand(),
+			DevicesImportCommand(),
+			DevicesExportCommand(),
+			DevicesCollectCommand(),
 			DevicesUpdateCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -0,0 +1,651 @@
+package devices
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	deviceCollectCollected  = "collected"
+	deviceCollectRegistered = "registered"
+	deviceCollectExists     = "exists"
+	deviceCollectFailed     = "failed"
+
+	deviceCollectProfilePath = "/profile.mobileconfig"
+	deviceCollectEnrollPath  = "/enroll"
+	deviceCollectThanksPath  = "/thanks"
+
+	// deviceCollectMaxResponse bounds the signed device response body.
+	deviceCollectMaxResponse = 256 << 10
+	// deviceCollectMaxIssuer bounds a downloaded issuing certificate.
+	deviceCollectMaxIssuer = 64 << 10
+	// deviceCollectMaxChain bounds the certificates walked while completing a
+	// device certificate chain.
+	deviceCollectMaxChain = 8
+)
+
+// profileServicePayload is a Profile Service .mobileconfig. Installing it
+// makes the device post its attributes to PayloadContent.URL.
+type profileServicePayload struct {
+	PayloadContent      profileServiceContent `plist:"PayloadContent"`
+	PayloadDescription  string                `plist:"PayloadDescription"`
+	PayloadDisplayName  string                `plist:"PayloadDisplayName"`
+	PayloadIdentifier   string                `plist:"PayloadIdentifier"`
+	PayloadOrganization string                `plist:"PayloadOrganization"`
+	PayloadType         string                `plist:"PayloadType"`
+	PayloadUUID         string                `plist:"PayloadUUID"`
+	PayloadVersion      int                   `plist:"PayloadVersion"`
+}
+
+type profileServiceContent struct {
+	Challenge        string   `plist:"Challenge"`
+	DeviceAttributes []string `plist:"DeviceAttributes"`
+	URL              string   `plist:"URL"`
+}
+
+// profileServiceResponse is the plist a device signs and posts back.
+type profileServiceResponse struct {
+	UDID       string `plist:"UDID"`
+	Product    string `plist:"PRODUCT"`
+	Version    string `plist:"VERSION"`
+	Serial     string `plist:"SERIAL"`
+	DeviceName string `plist:"DEVICE_NAME"`
+	Challenge  string `plist:"CHALLENGE"`
+}
+
+// DevicesCollectCommand returns the devices collect subcommand.
+func DevicesCollectCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("collect", flag.ExitOnError)
+
+	port := fs.Int("port", 8443, "Port to listen on")
+	host := fs.String("host", "0.0.0.0", "Address to listen on")
+	publicURL := fs.String("url", "", "Public base URL devices use, e.g. a tunnel (default: http(s)://<local address>:<port>)")
+	name := fs.String("name", "", "Name shown on the enrollment page and profile")
+	identifier := fs.String("identifier", "asc.devices.collect", "Profile payload identifier")
+	tlsCert := fs.String("tls-cert", "", "PEM certificate to serve HTTPS")
+	tlsKey := fs.String("tls-key", "", "PEM private key for --tls-cert")
+	signP12 := fs.String("sign-p12", "", "Sign the profile with this .p12 identity")
+	signPasswordEnv := fs.String("sign-p12-password-env", "", "Environment variable holding the --sign-p12 password")
+	register := fs.Bool("register", false, "Register each collected device")
+	count := fs.Int("count", 0, "Stop after this many devices (default: until interrupted)")
+	timeout := fs.Duration("timeout", 0, "Stop after this long (default: until interrupted)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "collect",
+		ShortUsage: "asc devices collect --name NAME [--port 8443] [--url URL] [--register] [flags]",
+		ShortHelp:  "Collect device UDIDs with a profile service.",
+		LongHelp: `Collect device UDIDs with a profile service.
+
+Serves an enrollment page and a Profile Service .mobileconfig. Installing the
+profile makes the device send its UDID, model and OS version back to the
+server, which shows a thank-you page; the profile itself is not kept on the
+device. With --register each new device is registered with its device name,
+or its model and UDID when the name is not shared. Responses must carry the
+random challenge embedded in this run's profile and be signed by a device
+certificate that chains to Apple's device CA; issuers missing from a response
+are downloaded from the apple.com address named in the certificate.
+
+Devices expect an HTTPS URL with a trusted certificate, so serve with
+--tls-cert and --tls-key or put the listener behind a tunnel and pass its
+address as --url. With --sign-p12 the profile is signed and shows as verified
+when the certificate chains to a trusted root.
+
+Runs until interrupted, --count devices have responded or --timeout passes,
+then prints the collected devices.
+
+Examples:
+  asc devices collect --name "Acme Devices" --url "https://devices.example.com"
+  asc devices collect --name "Acme Devices" --port 8443 --tls-cert cert.pem --tls-key key.pem --register
+  asc devices collect --name "Acme Devices" --url "https://devices.example.com" --sign-p12 signing.p12 --sign-p12-password-env P12_PASS --count 5`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			nameValue := strings.TrimSpace(*name)
+			if nameValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --name is required")
+				return flag.ErrHelp
+			}
+			if *port < 1 || *port > 65535 {
+				fmt.Fprintln(os.Stderr, "Error: --port must be between 1 and 65535")
+				return flag.ErrHelp
+			}
+			certValue, keyValue := strings.TrimSpace(*tlsCert), strings.TrimSpace(*tlsKey)
+			if (certValue == "") != (keyValue == "") {
+				fmt.Fprintln(os.Stderr, "Error: --tls-cert and --tls-key must be used together")
+				return flag.ErrHelp
+			}
+			if *count < 0 {
+				fmt.Fprintln(os.Stderr, "Error: --count must not be negative")
+				return flag.ErrHelp
+			}
+			if *timeout < 0 {
+				fmt.Fprintln(os.Stderr, "Error: --timeout must not be negative")
+				return flag.ErrHelp
+			}
+			baseURL := strings.TrimRight(strings.TrimSpace(*publicURL), "/")
+			if baseURL == "" {
+				scheme := "http"
+				if certValue != "" {
+					scheme = "https"
+				}
+				baseURL = fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(localCollectHost(*host), strconv.Itoa(*port)))
+			} else if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+				fmt.Fprintln(os.Stderr, "Error: --url must start with https:// or http://")
+				return flag.ErrHelp
+			}
+
+			var signer *profileSigner
+			if p12 := strings.TrimSpace(*signP12); p12 != "" {
+				loaded, err := loadProfileSigner(p12, *signPasswordEnv)
+				if err != nil {
+					return fmt.Errorf("devices collect: %w", err)
+				}
+				signer = loaded
+			}
+
+			collector, err := newDeviceCollector(nameValue, strings.TrimSpace(*identifier), baseURL, signer, *count)
+			if err != nil {
+				return fmt.Errorf("devices collect: %w", err)
+			}
+			if *register {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("devices collect: %w", err)
+				}
+				collector.register = func(ctx context.Context, item *asc.DeviceCollectItem) {
+					registerCollectedDevice(ctx, client, item)
+				}
+			}
+
+			listener, err := net.Listen("tcp", net.JoinHostPort(*host, strconv.Itoa(*port)))
+			if err != nil {
+				return fmt.Errorf("devices collect: %w", err)
+			}
+			server := &http.Server{Handler: collector.handler(), ReadHeaderTimeout: 10 * time.Second}
+			serveErr := make(chan error, 1)
+			go func() {
+				if certValue != "" {
+					serveErr <- server.ServeTLS(listener, certValue, keyValue)
+				} else {
+					serveErr <- server.Serve(listener)
+				}
+			}()
+
+			runCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+			if *timeout > 0 {
+				var cancel context.CancelFunc
+				runCtx, cancel = context.WithTimeout(runCtx, *timeout)
+				defer cancel()
+			}
+			fmt.Fprintf(os.Stderr, "Open %s/ on each device to share its UDID (Ctrl+C to stop)\n", baseURL)
+
+			select {
+			case <-runCtx.Done():
+			case <-collector.done:
+			case err := <-serveErr:
+				if !errors.Is(err, http.ErrServerClosed) {
+					return fmt.Errorf("devices collect: %w", err)
+				}
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+
+			result := &asc.DeviceCollectResult{URL: baseURL, Signed: signer != nil, Register: *register, Devices: collector.collected()}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+type profileSigner struct {
+	certificate   *x509.Certificate
+	key           crypto.Signer
+	intermediates []*x509.Certificate
+}
+
+func loadProfileSigner(path, passwordEnv string) (*profileSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	password := ""
+	if env := strings.TrimSpace(passwordEnv); env != "" {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", env)
+		}
+		password = value
+	}
+	contents, err := shared.DecodePKCS12(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	key, ok := contents.PrivateKey.(crypto.Signer)
+	if !ok || contents.Certificate == nil || !shared.PrivateKeyMatchesCertificate(contents.PrivateKey, contents.Certificate) {
+		return nil, fmt.Errorf("%s does not contain a signing identity", path)
+	}
+	return &profileSigner{certificate: contents.Certificate, key: key, intermediates: contents.CACerts}, nil
+}
+
+// deviceCollector serves the enrollment page and profile and records the
+// devices that respond.
+type deviceCollector struct {
+	name      string
+	baseURL   string
+	challenge string
+	profile   []byte
+	limit     int
+	register  func(context.Context, *asc.DeviceCollectItem)
+	// verifySigner checks the certificate that signed a device response.
+	verifySigner func(context.Context, *x509.Certificate, []*x509.Certificate) error
+	// fetchIssuer downloads an issuing certificate missing from a response.
+	fetchIssuer func(context.Context, string) (*x509.Certificate, error)
+	done        chan struct{}
+
+	mu      sync.Mutex
+	devices []asc.DeviceCollectItem
+
+	issuersMu sync.Mutex
+	issuers   map[string]*x509.Certificate
+}
+
+func newDeviceCollector(name, identifier, baseURL string, signer *profileSigner, limit int) (*deviceCollector, error) {
+	challenge, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	uuid, err := randomUUID()
+	if err != nil {
+		return nil, err
+	}
+	if identifier == "" {
+		identifier = "asc.devices.collect"
+	}
+	payload := profileServicePayload{
+		PayloadContent: profileServiceContent{
+			Challenge:        challenge,
+			DeviceAttributes: []string{"UDID", "PRODUCT", "VERSION", "SERIAL", "DEVICE_NAME"},
+			URL:              baseURL + deviceCollectEnrollPath,
+		},
+		PayloadDescription:  fmt.Sprintf("Shares this device's UDID with %s so it can be registered for testing.", name),
+		PayloadDisplayName:  name,
+		PayloadIdentifier:   identifier,
+		PayloadOrganization: name,
+		PayloadType:         "Profile Service",
+		PayloadUUID:         uuid,
+		PayloadVersion:      1,
+	}
+	profile, err := plist.MarshalIndent(payload, plist.XMLFormat, "\t")
+	if err != nil {
+		return nil, fmt.Errorf("encode profile: %w", err)
+	}
+	if signer != nil {
+		profile, err = shared.SignData(profile, signer.certificate, signer.key, signer.intermediates)
+		if err != nil {
+			return nil, fmt.Errorf("sign profile: %w", err)
+		}
+	}
+	collector := &deviceCollector{
+		name:        name,
+		baseURL:     baseURL,
+		challenge:   challenge,
+		profile:     profile,
+		limit:       limit,
+		fetchIssuer: fetchAppleIssuer,
+		done:        make(chan struct{}),
+		issuers:     map[string]*x509.Certificate{},
+	}
+	collector.verifySigner = collector.verifyDeviceSigner
+	return collector, nil
+}
+
+func (c *deviceCollector) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		c.renderPage(w, deviceCollectLandingPage)
+	})
+	mux.HandleFunc("GET "+deviceCollectProfilePath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-apple-aspen-config")
+		w.Header().Set("Content-Disposition", `attachment; filename="enroll.mobileconfig"`)
+		_, _ = w.Write(c.profile)
+	})
+	mux.HandleFunc("POST "+deviceCollectEnrollPath, c.enroll)
+	mux.HandleFunc("GET "+deviceCollectThanksPath, func(w http.ResponseWriter, r *http.Request) {
+		c.renderPage(w, deviceCollectThanksPage)
+	})
+	return mux
+}
+
+func (c *deviceCollector) renderPage(w http.ResponseWriter, page *template.Template) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = page.Execute(w, map[string]string{"Name": c.name, "ProfileURL": deviceCollectProfilePath})
+}
+
+// enroll receives the device's signed attributes, records the device and
+// redirects Safari to the thank-you page.
+func (c *deviceCollector) enroll(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, deviceCollectMaxResponse))
+	if err != nil {
+		http.Error(w, "invalid response", http.StatusBadRequest)
+		return
+	}
+	response, err := parseProfileServiceResponse(r.Context(), body, c.verifySigner)
+	if err != nil {
+		http.Error(w, "invalid response", http.StatusBadRequest)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(response.Challenge), []byte(c.challenge)) != 1 {
+		http.Error(w, "unknown enrollment", http.StatusForbidden)
+		return
+	}
+
+	item := asc.DeviceCollectItem{
+		UDID:        response.UDID,
+		Product:     response.Product,
+		Version:     response.Version,
+		Serial:      response.Serial,
+		DeviceName:  response.DeviceName,
+		Platform:    devicePlatformForProduct(response.Product),
+		Status:      deviceCollectCollected,
+		CollectedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if c.record(r.Context(), &item) {
+		fmt.Fprintf(os.Stderr, "Collected %s %s: %s\n", item.Product, item.UDID, item.Status)
+	}
+	http.Redirect(w, r, c.baseURL+deviceCollectThanksPath, http.StatusMovedPermanently)
+}
+
+// record registers and stores a device the first time it responds. It
+// reports whether the device was new. Registration runs without holding the
+// lock so a slow API call does not block other devices.
+func (c *deviceCollector) record(ctx context.Context, item *asc.DeviceCollectItem) bool {
+	c.mu.Lock()
+	for _, existing := range c.devices {
+		if strings.EqualFold(existing.UDID, item.UDID) {
+			c.mu.Unlock()
+			return false
+		}
+	}
+	if c.limit > 0 && len(c.devices) >= c.limit {
+		c.mu.Unlock()
+		return false
+	}
+	c.devices = append(c.devices, *item)
+	index := len(c.devices) - 1
+	c.mu.Unlock()
+
+	if c.register != nil {
+		c.register(ctx, item)
+		c.mu.Lock()
+		c.devices[index] = *item
+		c.mu.Unlock()
+	}
+	if c.limit > 0 && index+1 == c.limit {
+		close(c.done)
+	}
+	return true
+}
+
+func (c *deviceCollector) collected() []asc.DeviceCollectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]asc.DeviceCollectItem{}, c.devices...)
+}
+
+// parseProfileServiceResponse unwraps and decodes a device's signed
+// attribute plist after checking the signature and, with verify, the signing
+// certificate. The challenge ties a response to this run's profile.
+func parseProfileServiceResponse(ctx context.Context, body []byte, verify func(context.Context, *x509.Certificate, []*x509.Certificate) error) (*profileServiceResponse, error) {
+	signed, err := shared.ParseSignedData(body)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := signed.Verify()
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(ctx, signers[0], signed.Certificates); err != nil {
+		return nil, fmt.Errorf("device certificate: %w", err)
+	}
+	var response profileServiceResponse
+	if _, err := plist.Unmarshal(signed.Content, &response); err != nil {
+		return nil, fmt.Errorf("decode device attributes: %w", err)
+	}
+	response.UDID = strings.TrimSpace(response.UDID)
+	if response.UDID == "" {
+		return nil, errors.New("device attributes have no UDID")
+	}
+	if err := validateDeviceUDID(response.UDID, devicePlatformForProduct(response.Product)); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// verifyDeviceSigner checks that a response was signed by a device
+// certificate issued by Apple's device CA.
+func (c *deviceCollector) verifyDeviceSigner(ctx context.Context, leaf *x509.Certificate, certs []*x509.Certificate) error {
+	return shared.VerifyAppleDeviceCertificate(leaf, c.completeChain(ctx, leaf, certs), time.Now())
+}
+
+// completeChain adds issuers missing from a response, downloading them from
+// the issuer URLs named in each certificate. Trust still comes from the
+// pinned Apple roots, not from where a certificate was downloaded.
+func (c *deviceCollector) completeChain(ctx context.Context, leaf *x509.Certificate, certs []*x509.Certificate) []*x509.Certificate {
+	all := append([]*x509.Certificate{leaf}, certs...)
+	for i := 0; i < len(all) && len(all) < deviceCollectMaxChain; i++ {
+		cert := all[i]
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) || hasIssuer(all, cert) {
+			continue
+		}
+		for _, issuerURL := range cert.IssuingCertificateURL {
+			if issuer, err := c.issuer(ctx, issuerURL); err == nil {
+				all = append(all, issuer)
+				break
+			}
+		}
+	}
+	return all
+}
+
+func hasIssuer(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, candidate := range certs {
+		if bytes.Equal(candidate.RawSubject, cert.RawIssuer) {
+			return true
+		}
+	}
+	return false
+}
+
+// issuer returns the certificate at issuerURL, downloading it once per run.
+func (c *deviceCollector) issuer(ctx context.Context, issuerURL string) (*x509.Certificate, error) {
+	c.issuersMu.Lock()
+	cached, ok := c.issuers[issuerURL]
+	c.issuersMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+	cert, err := c.fetchIssuer(ctx, issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	c.issuersMu.Lock()
+	c.issuers[issuerURL] = cert
+	c.issuersMu.Unlock()
+	return cert, nil
+}
+
+// fetchAppleIssuer downloads a DER or PEM certificate from an apple.com URL.
+func fetchAppleIssuer(ctx context.Context, issuerURL string) (*x509.Certificate, error) {
+	parsed, err := url.Parse(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || (host != "apple.com" && !strings.HasSuffix(host, ".apple.com")) {
+		return nil, fmt.Errorf("refusing to download issuer from %s", issuerURL)
+	}
+	requestCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download issuer %s: %s", issuerURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, deviceCollectMaxIssuer))
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	return x509.ParseCertificate(data)
+}
+
+// devicePlatformForProduct maps a product type such as iPhone15,2 to the
+// platform it registers under.
+func devicePlatformForProduct(product string) string {
+	switch {
+	case strings.HasPrefix(product, "AppleTV"):
+		return "TV_OS"
+	case strings.HasPrefix(product, "RealityDevice"):
+		return "VISION_OS"
+	case strings.HasPrefix(product, "Mac"), strings.HasPrefix(product, "iMac"):
+		return string(asc.DevicePlatformMacOS)
+	default:
+		return string(asc.DevicePlatformIOS)
+	}
+}
+
+func registerCollectedDevice(ctx context.Context, client *asc.Client, item *asc.DeviceCollectItem) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	existing, err := client.GetDevices(requestCtx, asc.WithDevicesFilterUDIDs([]string{item.UDID}), asc.WithDevicesLimit(1))
+	if err != nil {
+		item.Status, item.Error = deviceCollectFailed, err.Error()
+		return
+	}
+	if len(existing.Data) > 0 {
+		item.Status, item.DeviceID = deviceCollectExists, existing.Data[0].ID
+		return
+	}
+
+	name := strings.TrimSpace(item.DeviceName)
+	if name == "" {
+		name = fmt.Sprintf("%s %s", item.Product, item.UDID[max(len(item.UDID)-6, 0):])
+	}
+	device, err := client.CreateDevice(requestCtx, asc.DeviceCreateAttributes{
+		Name:     name,
+		UDID:     item.UDID,
+		Platform: asc.DevicePlatform(item.Platform),
+	})
+	if err != nil {
+		item.Status, item.Error = deviceCollectFailed, err.Error()
+		return
+	}
+	item.Status, item.DeviceID = deviceCollectRegistered, device.Data.ID
+}
+
+// localCollectHost returns the host devices on the local network can reach
+// when listening on host.
+func localCollectHost(host string) string {
+	if host != "" && host != "0.0.0.0" && host != "::" {
+		return host
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+				return ipNet.IP.String()
+			}
+		}
+	}
+	return "localhost"
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func randomUUID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	buf[6] = buf[6]&0x0f | 0x40
+	buf[8] = buf[8]&0x3f | 0x80
+	value := strings.ToUpper(hex.EncodeToString(buf))
+	return fmt.Sprintf("%s-%s-%s-%s-%s", value[:8], value[8:12], value[12:16], value[16:20], value[20:]), nil
+}
+
+var deviceCollectLandingPage = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}}</title>
+<style>body{font-family:-apple-system,sans-serif;max-width:32em;margin:3em auto;padding:0 1em;line-height:1.5}a.button{display:inline-block;padding:.75em 1.5em;background:#0071e3;color:#fff;border-radius:.5em;text-decoration:none}</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p>Share this device's identifier so it can be added for testing.</p>
+<p><a class="button" href="{{.ProfileURL}}">Download profile</a></p>
+<p>Open Settings, tap <b>Profile Downloaded</b> and then <b>Install</b>. The profile is only used to read the identifier and is not kept on the device.</p>
+</body>
+</html>
+`))
+
+var deviceCollectThanksPage = template.Must(template.New("thanks").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}}</title>
+<style>body{font-family:-apple-system,sans-serif;max-width:32em;margin:3em auto;padding:0 1em;line-height:1.5}</style>
+</head>
+<body>
+<h1>Thank you</h1>
+<p>Your device was shared with {{.Name}}. You can close this page.</p>
+</body>
+</html>
+`))
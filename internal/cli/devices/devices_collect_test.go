@@ -0,0 +1,250 @@
+package devices
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func testProfileSigner(t *testing.T) *profileSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		Subject:      pkix.Name{CommonName: "Device Identity"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &profileSigner{certificate: cert, key: key}
+}
+
+func TestDeviceCollectorEnrollsDevices(t *testing.T) {
+	signer := testProfileSigner(t)
+	collector, err := newDeviceCollector("Acme <Devices>", "com.example.collect", "https://devices.example.com", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var verified []string
+	collector.verifySigner = func(_ context.Context, leaf *x509.Certificate, _ []*x509.Certificate) error {
+		verified = append(verified, leaf.Subject.CommonName)
+		return nil
+	}
+	var registered []string
+	collector.register = func(_ context.Context, item *asc.DeviceCollectItem) {
+		registered = append(registered, item.UDID)
+		item.Status, item.DeviceID = deviceCollectRegistered, "DEVICE1"
+	}
+	server := httptest.NewServer(collector.handler())
+	defer server.Close()
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := client.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(page), "Acme &lt;Devices&gt;") || !strings.Contains(string(page), deviceCollectProfilePath) {
+		t.Fatalf("unexpected landing page:\n%s", page)
+	}
+
+	resp, err = client.Get(server.URL + deviceCollectProfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "application/x-apple-aspen-config" {
+		t.Fatalf("unexpected content type %q", got)
+	}
+	var profile profileServicePayload
+	if _, err := plist.Unmarshal(data, &profile); err != nil {
+		t.Fatalf("decode profile: %v", err)
+	}
+	if profile.PayloadType != "Profile Service" || profile.PayloadIdentifier != "com.example.collect" ||
+		profile.PayloadContent.URL != "https://devices.example.com/enroll" || profile.PayloadContent.Challenge == "" {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+
+	enroll := func(challenge string, udid ...string) *http.Response {
+		t.Helper()
+		deviceUDID := "00008030-001A2D3C0E41802E"
+		if len(udid) > 0 {
+			deviceUDID = udid[0]
+		}
+		attrs, err := plist.Marshal(map[string]string{
+			"UDID":      deviceUDID,
+			"PRODUCT":   "iPhone15,2",
+			"VERSION":   "22A3354",
+			"CHALLENGE": challenge,
+		}, plist.XMLFormat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := shared.SignData(attrs, signer.certificate, signer.key, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Post(server.URL+deviceCollectEnrollPath, "application/pkcs7-signature", strings.NewReader(string(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := enroll("wrong"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a wrong challenge to be rejected, got %d", resp.StatusCode)
+	}
+	if resp := enroll(profile.PayloadContent.Challenge, "not-a-udid"); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected an invalid UDID to be rejected, got %d", resp.StatusCode)
+	}
+	resp = enroll(profile.PayloadContent.Challenge)
+	if resp.StatusCode != http.StatusMovedPermanently || resp.Header.Get("Location") != "https://devices.example.com/thanks" {
+		t.Fatalf("unexpected enroll response %d %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	// A repeated response for the same device is not recorded twice.
+	enroll(profile.PayloadContent.Challenge)
+
+	select {
+	case <-collector.done:
+	default:
+		t.Fatal("expected the collector to stop after --count devices")
+	}
+	devices := collector.collected()
+	if len(devices) != 1 || len(registered) != 1 || len(verified) == 0 || verified[0] != "Device Identity" {
+		t.Fatalf("unexpected devices %+v, registered %v, verified %v", devices, registered, verified)
+	}
+	if device := devices[0]; device.Product != "iPhone15,2" || device.Platform != "IOS" || device.Status != deviceCollectRegistered || device.DeviceID != "DEVICE1" {
+		t.Fatalf("unexpected device: %+v", device)
+	}
+}
+
+func TestDeviceCollectorRejectsUntrustedSigner(t *testing.T) {
+	signer := testProfileSigner(t)
+	collector, err := newDeviceCollector("Acme", "", "https://devices.example.com", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs, err := plist.Marshal(map[string]string{"UDID": "00008030-001A2D3C0E41802E", "PRODUCT": "iPhone15,2", "CHALLENGE": collector.challenge}, plist.XMLFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := shared.SignData(attrs, signer.certificate, signer.key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseProfileServiceResponse(context.Background(), body, collector.verifySigner); err == nil || !strings.Contains(err.Error(), "device certificate") {
+		t.Fatalf("expected a self-signed device certificate to be rejected, got %v", err)
+	}
+}
+
+func TestDeviceCollectorCompletesChain(t *testing.T) {
+	signer := testProfileSigner(t)
+	issuer := signer.certificate
+	leafKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(8),
+		Subject:               pkix.Name{CommonName: "Device"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IssuingCertificateURL: []string{"http://certs.apple.com/device-ca.der"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &leafKey.PublicKey, signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collector, err := newDeviceCollector("Acme", "", "https://devices.example.com", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fetched []string
+	collector.fetchIssuer = func(_ context.Context, issuerURL string) (*x509.Certificate, error) {
+		fetched = append(fetched, issuerURL)
+		return issuer, nil
+	}
+	for range 2 {
+		chain := collector.completeChain(context.Background(), leaf, nil)
+		if len(chain) != 2 || chain[1] != issuer {
+			t.Fatalf("unexpected chain %v", chain)
+		}
+	}
+	if len(fetched) != 1 {
+		t.Fatalf("expected the issuer to be downloaded once, got %v", fetched)
+	}
+	if _, err := fetchAppleIssuer(context.Background(), "https://example.com/ca.der"); err == nil {
+		t.Fatal("expected a non-Apple issuer URL to be refused")
+	}
+}
+
+func TestDeviceCollectorSignsProfile(t *testing.T) {
+	signer := testProfileSigner(t)
+	collector, err := newDeviceCollector("Acme", "", "https://devices.example.com", signer, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := shared.ParseSignedData(collector.profile)
+	if err != nil {
+		t.Fatalf("expected a signed profile: %v", err)
+	}
+	if _, err := signed.Verify(); err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	var profile profileServicePayload
+	if _, err := plist.Unmarshal(signed.Content, &profile); err != nil {
+		t.Fatal(err)
+	}
+	if profile.PayloadIdentifier != "asc.devices.collect" || profile.PayloadDisplayName != "Acme" {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestDevicePlatformForProduct(t *testing.T) {
+	tests := map[string]string{
+		"iPhone15,2":        "IOS",
+		"iPad13,4":          "IOS",
+		"Watch6,1":          "IOS",
+		"AppleTV14,1":       "TV_OS",
+		"RealityDevice14,1": "VISION_OS",
+		"Mac14,2":           "MAC_OS",
+	}
+	for product, want := range tests {
+		if got := devicePlatformForProduct(product); got != want {
+			t.Fatalf("devicePlatformForProduct(%q) = %q, want %q", product, got, want)
+		}
+	}
+}
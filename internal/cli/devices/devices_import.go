@@ -0,0 +1,635 @@
+package devices
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	deviceImportRegistered    = "registered"
+	deviceImportWouldRegister = "would-register"
+	deviceImportExists        = "exists"
+	deviceImportDuplicate     = "duplicate"
+	deviceImportInvalid       = "invalid"
+	deviceImportOverLimit     = "over-limit"
+	deviceImportFailed        = "failed"
+
+	deviceFileFormatTSV  = "tsv"
+	deviceFileFormatCSV  = "csv"
+	deviceFileFormatJSON = "json"
+
+	// defaultDeviceLimit is the number of devices per family a membership
+	// year allows.
+	defaultDeviceLimit = 100
+)
+
+// deviceFileHeader is the header of Apple's multiple device upload file.
+var deviceFileHeader = []string{"Device ID", "Device Name", "Device Platform"}
+
+var (
+	legacyDeviceUDIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	modernDeviceUDIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{16}$`)
+	macDeviceUDIDPattern    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+type deviceFileRow struct {
+	line     int
+	udid     string
+	name     string
+	platform string
+}
+
+// deviceJSONEntry is one device in the JSON import and export format.
+type deviceJSONEntry struct {
+	UDID     string `json:"udid"`
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+}
+
+// DevicesImportCommand returns the devices import subcommand.
+func DevicesImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+
+	file := fs.String("file", "", "Device file: Apple's tab-separated upload format, CSV or JSON")
+	format := fs.String("format", "", "File format: tsv, csv, json (default: from the file extension, tsv otherwise)")
+	platform := fs.String("platform", "IOS", "Platform for rows without one: "+strings.Join(devicePlatformList(), ", "))
+	maxDevices := fs.Int("max-devices", defaultDeviceLimit, "Devices allowed per family per membership year")
+	dryRun := fs.Bool("dry-run", false, "Validate and preview without registering devices")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "asc devices import --file devices.txt [flags]",
+		ShortHelp:  "Register devices from a file.",
+		LongHelp: `Register devices from a file.
+
+Accepts the tab-separated file the developer website uses for multiple device
+upload (Device ID, Device Name, Device Platform), the same columns as CSV, or
+a JSON array of {"udid", "name", "platform"} objects. The header row is
+optional; rows without a platform use --platform.
+
+UDIDs are validated for their platform, deduplicated within the file and
+matched against devices already registered. Before registering, the devices
+each family already uses are counted against --max-devices. New devices are
+registered in file order up to the room left and the rest are marked
+over-limit. iOS devices are held to the fullest of the iPhone, iPad, iPod and
+Apple Watch families, since the family is only known once a device is
+registered. Each row gets a status:
+registered, exists, duplicate, invalid, over-limit or failed (would-register
+with --dry-run).
+
+Examples:
+  asc devices import --file devices.txt --dry-run
+  asc devices import --file devices.csv --platform IOS
+  asc devices import --file devices.json --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			formatValue, err := resolveDeviceFileFormat(*format, fileValue)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+			defaultPlatform, err := normalizeDevicePlatform(*platform)
+			if err != nil || defaultPlatform == "" {
+				fmt.Fprintf(os.Stderr, "Error: --platform must be one of: %s\n", strings.Join(devicePlatformList(), ", "))
+				return flag.ErrHelp
+			}
+			if *maxDevices < 1 {
+				fmt.Fprintln(os.Stderr, "Error: --max-devices must be at least 1")
+				return flag.ErrHelp
+			}
+
+			rows, err := readDeviceFile(fileValue, formatValue)
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			existing, err := shared.ListAllDevices(requestCtx, client)
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			result := &asc.DeviceImportResult{File: fileValue, Format: formatValue, DryRun: *dryRun}
+			pending := planDeviceImport(result, rows, defaultPlatform, existing)
+			result.Preflight = deviceLimitPreflight(existing, result.Rows, *maxDevices)
+			applyDeviceLimits(result)
+			registerImportedDevices(requestCtx, client, result, pending, *dryRun)
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if result.Failed > 0 || result.OverLimit > 0 {
+				return shared.NewReportedError(fmt.Errorf("devices import: %d rows failed, %d rows over the device limit", result.Failed, result.OverLimit))
+			}
+			return nil
+		},
+	}
+}
+
+// DevicesExportCommand returns the devices export subcommand.
+func DevicesExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	file := fs.String("file", "", "Write devices to this new file")
+	format := fs.String("format", "", "File format: tsv, csv, json (default: from the file extension, tsv otherwise)")
+	platform := fs.String("platform", "", "Filter by platform(s), comma-separated: "+strings.Join(devicePlatformList(), ", "))
+	status := fs.String("status", "", "Filter by status: ENABLED, DISABLED")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc devices export --file devices.txt [flags]",
+		ShortHelp:  "Export devices to a file.",
+		LongHelp: `Export devices to a file.
+
+Writes Device ID, Device Name and Device Platform columns, the format accepted
+by devices import and the developer website's multiple device upload.
+
+Examples:
+  asc devices export --file devices.txt
+  asc devices export --file enabled.csv --status ENABLED
+  asc devices export --file macs.json --platform MAC_OS`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			formatValue, err := resolveDeviceFileFormat(*format, fileValue)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+			platforms, err := normalizeDevicePlatforms(shared.SplitCSV(*platform))
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			statusValue, err := normalizeDeviceStatus(*status)
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			if _, err := os.Lstat(fileValue); err == nil {
+				return fmt.Errorf("devices export: output file already exists: %s", fileValue)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			opts := []asc.DevicesOption{asc.WithDevicesFilterPlatforms(platforms)}
+			if statusValue != "" {
+				opts = append(opts, asc.WithDevicesFilterStatuses([]string{statusValue}))
+			}
+			devices, err := shared.ListAllDevices(requestCtx, client, opts...)
+			if err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+
+			if err := writeDeviceFile(fileValue, formatValue, devices); err != nil {
+				return fmt.Errorf("devices export: %w", err)
+			}
+			result := &asc.DeviceExportResult{File: fileValue, Format: formatValue, Devices: len(devices)}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+// resolveDeviceFileFormat returns the explicit format or infers it from the
+// file extension.
+func resolveDeviceFileFormat(value, path string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			return deviceFileFormatJSON, nil
+		case ".csv":
+			return deviceFileFormatCSV, nil
+		default:
+			return deviceFileFormatTSV, nil
+		}
+	}
+	switch value {
+	case deviceFileFormatTSV, deviceFileFormatCSV, deviceFileFormatJSON:
+		return value, nil
+	case "txt":
+		return deviceFileFormatTSV, nil
+	}
+	return "", fmt.Errorf("--format must be one of: tsv, csv, json")
+}
+
+// readDeviceFile parses a device file in the given format.
+func readDeviceFile(path, format string) ([]deviceFileRow, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows []deviceFileRow
+	if format == deviceFileFormatJSON {
+		rows, err = parseDeviceJSON(file)
+	} else {
+		rows, err = parseDeviceDelimited(file, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no devices found in %s", path)
+	}
+	return rows, nil
+}
+
+// parseDeviceDelimited reads tab- or comma-separated rows with an optional
+// header row.
+func parseDeviceDelimited(r io.Reader, format string) ([]deviceFileRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	if format == deviceFileFormatTSV {
+		reader.Comma = '\t'
+		reader.LazyQuotes = true
+	}
+
+	udidCol, nameCol, platformCol := 0, 1, 2
+	var rows []deviceFileRow
+	for record := 0; ; {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		record++
+		if record == 1 && len(fields) > 0 {
+			fields[0] = strings.TrimPrefix(fields[0], "\ufeff")
+			if udid, name, platform, ok := deviceFileColumns(fields); ok {
+				udidCol, nameCol, platformCol = udid, name, platform
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := deviceFileRow{
+			line:     line,
+			udid:     deviceField(fields, udidCol),
+			name:     deviceField(fields, nameCol),
+			platform: deviceField(fields, platformCol),
+		}
+		if row.udid == "" && row.name == "" && row.platform == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// deviceFileColumns detects a header row and returns the column indexes.
+func deviceFileColumns(fields []string) (int, int, int, bool) {
+	udid, name, platform := -1, -1, -1
+	for i, field := range fields {
+		switch strings.ToLower(strings.Join(strings.Fields(field), " ")) {
+		case "device id", "udid", "device udid", "identifier":
+			udid = i
+		case "device name", "name":
+			name = i
+		case "device platform", "platform":
+			platform = i
+		}
+	}
+	return udid, name, platform, udid >= 0
+}
+
+func deviceField(fields []string, index int) string {
+	if index < 0 || index >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[index])
+}
+
+// parseDeviceJSON reads a JSON array of devices or an object with a devices
+// array.
+func parseDeviceJSON(r io.Reader) ([]deviceFileRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []deviceJSONEntry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Devices []deviceJSONEntry `json:"devices"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, err
+		}
+		entries = wrapper.Devices
+	} else if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	rows := make([]deviceFileRow, 0, len(entries))
+	for i, entry := range entries {
+		rows = append(rows, deviceFileRow{
+			line:     i + 1,
+			udid:     strings.TrimSpace(entry.UDID),
+			name:     strings.TrimSpace(entry.Name),
+			platform: strings.TrimSpace(entry.Platform),
+		})
+	}
+	return rows, nil
+}
+
+// parseDeviceFilePlatform maps the upload file's platform names, and the API
+// values, to an API platform.
+func parseDeviceFilePlatform(value, fallback string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return fallback, nil
+	case "ios", "ipados", "watchos":
+		return string(asc.DevicePlatformIOS), nil
+	case "mac", "macos", "mac_os":
+		return string(asc.DevicePlatformMacOS), nil
+	case "tvos", "tv_os":
+		return "TV_OS", nil
+	case "visionos", "vision_os":
+		return "VISION_OS", nil
+	}
+	return "", fmt.Errorf("unknown platform %q", value)
+}
+
+// deviceFilePlatform returns the upload file's name for an API platform.
+func deviceFilePlatform(platform asc.DevicePlatform) string {
+	switch platform {
+	case asc.DevicePlatformMacOS:
+		return "mac"
+	case "TV_OS":
+		return "tvos"
+	case "VISION_OS":
+		return "visionos"
+	default:
+		return "ios"
+	}
+}
+
+// validateDeviceUDID checks a UDID against the formats its platform uses.
+// iPhone, iPad, Apple Watch, Apple TV and Vision Pro UDIDs are 40 hex digits
+// or 8-16 hex digits; Macs use the 8-16 form or their hardware UUID.
+func validateDeviceUDID(udid, platform string) error {
+	if platform == string(asc.DevicePlatformMacOS) {
+		if modernDeviceUDIDPattern.MatchString(udid) || macDeviceUDIDPattern.MatchString(udid) {
+			return nil
+		}
+		return fmt.Errorf("invalid Mac UDID %q: expected a hardware UUID or 00000000-0000000000000000 form", udid)
+	}
+	if legacyDeviceUDIDPattern.MatchString(udid) || modernDeviceUDIDPattern.MatchString(udid) {
+		return nil
+	}
+	return fmt.Errorf("invalid UDID %q: expected 40 hex digits or 00000000-0000000000000000 form", udid)
+}
+
+// planDeviceImport validates rows and classifies them against the file and the
+// registered devices. It returns the indexes of rows that need registering.
+func planDeviceImport(result *asc.DeviceImportResult, rows []deviceFileRow, defaultPlatform string, existing []asc.Resource[asc.DeviceAttributes]) []int {
+	registered := make(map[string]string, len(existing))
+	for _, device := range existing {
+		registered[strings.ToLower(strings.TrimSpace(device.Attributes.UDID))] = device.ID
+	}
+
+	seen := make(map[string]bool, len(rows))
+	var pending []int
+	for _, row := range rows {
+		item := asc.DeviceImportRow{Row: row.line, UDID: row.udid, Name: row.name}
+		result.Total++
+
+		platform, err := parseDeviceFilePlatform(row.platform, defaultPlatform)
+		switch {
+		case err != nil:
+			item.Status, item.Error = deviceImportInvalid, err.Error()
+		case row.udid == "":
+			item.Status, item.Error = deviceImportInvalid, "missing UDID"
+		case row.name == "":
+			item.Platform = platform
+			item.Status, item.Error = deviceImportInvalid, "missing device name"
+		default:
+			item.Platform = platform
+			if err := validateDeviceUDID(row.udid, platform); err != nil {
+				item.Status, item.Error = deviceImportInvalid, err.Error()
+			}
+		}
+		if item.Status == deviceImportInvalid {
+			result.Invalid++
+			result.Rows = append(result.Rows, item)
+			continue
+		}
+
+		key := strings.ToLower(row.udid)
+		switch {
+		case seen[key]:
+			item.Status = deviceImportDuplicate
+			result.Duplicates++
+		case registered[key] != "":
+			item.Status = deviceImportExists
+			item.DeviceID = registered[key]
+			result.Existing++
+		default:
+			item.Status = deviceImportWouldRegister
+			pending = append(pending, len(result.Rows))
+		}
+		seen[key] = true
+		result.Rows = append(result.Rows, item)
+	}
+	return pending
+}
+
+// deviceLimitFamilies lists the device families each platform registers
+// devices into.
+var deviceLimitFamilies = map[string][]asc.DeviceClass{
+	string(asc.DevicePlatformIOS):   {asc.DeviceClassIPhone, asc.DeviceClassIPad, asc.DeviceClassIPod, asc.DeviceClassAppleWatch},
+	string(asc.DevicePlatformMacOS): {asc.DeviceClassMac},
+	"TV_OS":                         {asc.DeviceClassAppleTV},
+	"VISION_OS":                     {asc.DeviceClassVisionPro},
+}
+
+// deviceLimitFamily returns the family a registered device counts against.
+// Disabled devices still count until the membership year resets.
+func deviceLimitFamily(device asc.DeviceAttributes) asc.DeviceClass {
+	if device.DeviceClass != "" {
+		return device.DeviceClass
+	}
+	switch device.Platform {
+	case asc.DevicePlatformMacOS:
+		return asc.DeviceClassMac
+	case "TV_OS":
+		return asc.DeviceClassAppleTV
+	case "VISION_OS":
+		return asc.DeviceClassVisionPro
+	default:
+		return asc.DeviceClassIPhone
+	}
+}
+
+// deviceLimitPreflight counts registered devices per family and compares the
+// room left with the new devices for each platform. A platform spanning
+// several families (iPhone, iPad, iPod and Apple Watch for IOS) is held to
+// the most constrained one, since a device's family is only known once it is
+// registered.
+func deviceLimitPreflight(existing []asc.Resource[asc.DeviceAttributes], rows []asc.DeviceImportRow, limit int) []asc.DeviceImportPreflight {
+	used := map[asc.DeviceClass]int{}
+	for _, device := range existing {
+		used[deviceLimitFamily(device.Attributes)]++
+	}
+	pending := map[string]int{}
+	for _, row := range rows {
+		if row.Status == deviceImportWouldRegister {
+			pending[row.Platform]++
+		}
+	}
+
+	var preflight []asc.DeviceImportPreflight
+	for _, platform := range devicePlatformList() {
+		item := asc.DeviceImportPreflight{Platform: platform, New: pending[platform], Limit: limit, Remaining: limit}
+		for _, family := range deviceLimitFamilies[platform] {
+			item.Families = append(item.Families, string(family))
+			if remaining := max(limit-used[family], 0); remaining < item.Remaining {
+				item.Registered = used[family]
+				item.Remaining = remaining
+			}
+		}
+		if item.Registered == 0 && item.New == 0 {
+			continue
+		}
+		item.Exceeded = item.New > item.Remaining
+		preflight = append(preflight, item)
+	}
+	return preflight
+}
+
+// applyDeviceLimits registers new rows for each platform, in file order, up
+// to the room left and marks the rest as over-limit.
+func applyDeviceLimits(result *asc.DeviceImportResult) {
+	for _, item := range result.Preflight {
+		if !item.Exceeded {
+			continue
+		}
+		room := item.Remaining
+		for i := range result.Rows {
+			row := &result.Rows[i]
+			if row.Platform != item.Platform || row.Status != deviceImportWouldRegister {
+				continue
+			}
+			if room > 0 {
+				room--
+				continue
+			}
+			row.Status = deviceImportOverLimit
+			row.Error = fmt.Sprintf("%d new %s devices but room for %d", item.New, item.Platform, item.Remaining)
+			result.OverLimit++
+		}
+	}
+}
+
+func registerImportedDevices(ctx context.Context, client *asc.Client, result *asc.DeviceImportResult, pending []int, dryRun bool) {
+	if dryRun {
+		return
+	}
+	for _, index := range pending {
+		row := &result.Rows[index]
+		if row.Status != deviceImportWouldRegister {
+			continue
+		}
+		device, err := client.CreateDevice(ctx, asc.DeviceCreateAttributes{
+			Name:     row.Name,
+			UDID:     row.UDID,
+			Platform: asc.DevicePlatform(row.Platform),
+		})
+		if err != nil {
+			row.Status, row.Error = deviceImportFailed, err.Error()
+			result.Failed++
+			continue
+		}
+		row.Status, row.DeviceID = deviceImportRegistered, device.Data.ID
+		result.Registered++
+	}
+}
+
+// writeDeviceFile writes devices sorted by name to a new file.
+func writeDeviceFile(path, format string, devices []asc.Resource[asc.DeviceAttributes]) error {
+	sorted := append([]asc.Resource[asc.DeviceAttributes](nil), devices...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Attributes.Name) < strings.ToLower(sorted[j].Attributes.Name)
+	})
+
+	var buf bytes.Buffer
+	if format == deviceFileFormatJSON {
+		entries := make([]deviceJSONEntry, 0, len(sorted))
+		for _, device := range sorted {
+			entries = append(entries, deviceJSONEntry{
+				UDID:     device.Attributes.UDID,
+				Name:     device.Attributes.Name,
+				Platform: string(device.Attributes.Platform),
+			})
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(append(data, '\n'))
+	} else {
+		writer := csv.NewWriter(&buf)
+		if format == deviceFileFormatTSV {
+			writer.Comma = '\t'
+		}
+		records := [][]string{deviceFileHeader}
+		for _, device := range sorted {
+			records = append(records, []string{device.Attributes.UDID, device.Attributes.Name, deviceFilePlatform(device.Attributes.Platform)})
+		}
+		if err := writer.WriteAll(records); err != nil {
+			return err
+		}
+	}
+
+	if _, err := shared.WriteStreamToFile(path, &buf); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
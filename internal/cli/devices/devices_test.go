@@ -3,7 +3,10 @@ package devices
 import (
 	"context"
 	"flag"
+	"strings"
 	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 )
 
 func TestDevicesRegisterCommand_MissingName(t *testing.T) {
@@ -77,3 +80,108 @@ func TestDevicesUpdateCommand_MissingStatus(t *testing.T) {
 		t.Fatalf("expected flag.ErrHelp when --status is missing, got %v", err)
 	}
 }
+
+func TestValidateDeviceUDID(t *testing.T) {
+	tests := []struct {
+		udid     string
+		platform string
+		valid    bool
+	}{
+		{udid: "0123456789abcdef0123456789abcdef01234567", platform: "IOS", valid: true},
+		{udid: "00008030-001A2D3C0E41802E", platform: "IOS", valid: true},
+		{udid: "00008030-001A2D3C0E41802E", platform: "VISION_OS", valid: true},
+		{udid: "00008103-001A2D3C0E41802E", platform: "MAC_OS", valid: true},
+		{udid: "A1B2C3D4-E5F6-7890-ABCD-EF1234567890", platform: "MAC_OS", valid: true},
+		{udid: "A1B2C3D4-E5F6-7890-ABCD-EF1234567890", platform: "IOS"},
+		{udid: "0123456789abcdef0123456789abcdef01234567", platform: "MAC_OS"},
+		{udid: "0123456789abcdef0123456789abcdef0123456z", platform: "IOS"},
+		{udid: "00008030-001A2D3C0E41802", platform: "TV_OS"},
+	}
+	for _, test := range tests {
+		err := validateDeviceUDID(test.udid, test.platform)
+		if (err == nil) != test.valid {
+			t.Fatalf("validateDeviceUDID(%q, %s) = %v, want valid %t", test.udid, test.platform, err, test.valid)
+		}
+	}
+}
+
+func TestParseDeviceDelimited(t *testing.T) {
+	input := "\ufeffDevice ID\tDevice Name\tDevice Platform\n" +
+		"00008030-001A2D3C0E41802E\tQA iPhone\tios\n" +
+		"\n" +
+		"A1B2C3D4-E5F6-7890-ABCD-EF1234567890\tBuild Mac\tmac\n" +
+		"00008030-001A2D3C0E41802F\tNo Platform\n"
+	rows, err := parseDeviceDelimited(strings.NewReader(input), deviceFileFormatTSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %+v", rows)
+	}
+	if rows[0].udid != "00008030-001A2D3C0E41802E" || rows[0].name != "QA iPhone" || rows[0].platform != "ios" || rows[0].line != 2 {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[2].platform != "" {
+		t.Fatalf("expected missing platform, got %+v", rows[2])
+	}
+
+	rows, err = parseDeviceDelimited(strings.NewReader("name,udid\nTablet,00008030-001A2D3C0E41802E\n"), deviceFileFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].name != "Tablet" || rows[0].udid != "00008030-001A2D3C0E41802E" {
+		t.Fatalf("unexpected CSV rows: %+v", rows)
+	}
+}
+
+func TestParseDeviceJSON(t *testing.T) {
+	for _, input := range []string{
+		`[{"udid":"00008030-001A2D3C0E41802E","name":"Phone","platform":"IOS"}]`,
+		`{"devices":[{"udid":"00008030-001A2D3C0E41802E","name":"Phone","platform":"IOS"}]}`,
+	} {
+		rows, err := parseDeviceJSON(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("parseDeviceJSON(%s): %v", input, err)
+		}
+		if len(rows) != 1 || rows[0].name != "Phone" || rows[0].platform != "IOS" || rows[0].line != 1 {
+			t.Fatalf("unexpected rows for %s: %+v", input, rows)
+		}
+	}
+}
+
+func TestDeviceLimitPreflight(t *testing.T) {
+	var existing []asc.Resource[asc.DeviceAttributes]
+	for i := 0; i < 99; i++ {
+		existing = append(existing, asc.Resource[asc.DeviceAttributes]{Attributes: asc.DeviceAttributes{Platform: asc.DevicePlatformMacOS, DeviceClass: asc.DeviceClassMac, Status: asc.DeviceStatusDisabled}})
+	}
+	for i := 0; i < 98; i++ {
+		existing = append(existing, asc.Resource[asc.DeviceAttributes]{Attributes: asc.DeviceAttributes{Platform: asc.DevicePlatformIOS, DeviceClass: asc.DeviceClassIPad}})
+	}
+	existing = append(existing, asc.Resource[asc.DeviceAttributes]{Attributes: asc.DeviceAttributes{Platform: asc.DevicePlatformIOS, DeviceClass: asc.DeviceClassIPhone}})
+	rows := []asc.DeviceImportRow{
+		{Platform: "MAC_OS", Status: deviceImportWouldRegister},
+		{Platform: "MAC_OS", Status: deviceImportWouldRegister},
+		{Platform: "IOS", Status: deviceImportWouldRegister},
+		{Platform: "IOS", Status: deviceImportExists},
+		{Platform: "IOS", Status: deviceImportWouldRegister},
+	}
+
+	preflight := deviceLimitPreflight(existing, rows, 100)
+	if len(preflight) != 2 {
+		t.Fatalf("expected IOS and MAC_OS preflight, got %+v", preflight)
+	}
+	ios, mac := preflight[0], preflight[1]
+	if ios.Platform != "IOS" || ios.Registered != 98 || ios.Limit != 100 || ios.Remaining != 2 || ios.New != 2 || ios.Exceeded {
+		t.Fatalf("unexpected IOS preflight: %+v", ios)
+	}
+	if mac.Platform != "MAC_OS" || mac.Registered != 99 || mac.Remaining != 1 || mac.New != 2 || !mac.Exceeded {
+		t.Fatalf("unexpected MAC_OS preflight: %+v", mac)
+	}
+
+	result := &asc.DeviceImportResult{Rows: rows, Preflight: preflight}
+	applyDeviceLimits(result)
+	if result.OverLimit != 1 || result.Rows[0].Status != deviceImportWouldRegister || result.Rows[1].Status != deviceImportOverLimit ||
+		result.Rows[2].Status != deviceImportWouldRegister || result.Rows[4].Status != deviceImportWouldRegister {
+		t.Fatalf("unexpected rows after limits: %+v", result.Rows)
+	}
+}
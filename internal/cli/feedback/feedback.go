@@ -47,9 +47,13 @@ Examples:
   asc feedback --app "123456789" --device-model "iPhone15,3" --os-version "17.2"
   asc feedback --app "123456789" --sort -createdDate --limit 5
   asc feedback --next "<links.next>"
-  asc feedback --app "123456789" --paginate`,
+  asc feedback --app "123456789" --paginate
+  asc feedback export --app "123456789" --since 7d --dir ./feedback`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			FeedbackExportCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			if *limit != 0 && (*limit < 1 || *limit > 200) {
 				return fmt.Errorf("feedback: --limit must be between 1 and 200")
@@ -0,0 +1,179 @@
+package feedback
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// FeedbackExportCommand returns the feedback export subcommand.
+func FeedbackExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	since := fs.String("since", "7d", "Export feedback newer than a duration (24h, 7d, 2w, 3m) or date (YYYY-MM-DD)")
+	dir := fs.String("dir", "", "Directory to write screenshots, digest and issue files into")
+	digest := fs.String("digest", shared.BetaFeedbackDigestMarkdown, "Digest format: markdown or html")
+	issues := fs.String("issues", shared.BetaFeedbackIssuesGitHub, "Issue file format: github (issues.json), jira (issues.csv), or none")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc feedback export --app APP_ID --dir DIR [flags]",
+		ShortHelp:  "Export TestFlight feedback with screenshots and a digest.",
+		LongHelp: `Export TestFlight feedback with screenshots and a digest.
+
+Downloads every screenshot submitted since --since into DIR, grouped by
+build (DIR/<version>-<build>/<submission>/screenshot-N.png), and writes:
+
+  digest.md or digest.html   feedback grouped by build, screenshots inline
+  issues.json / issues.csv   one issue per submission (GitHub or Jira import)
+  feedback.json              manifest of everything exported
+
+Screenshots that already exist are skipped, so the command can be re-run
+into the same directory.
+
+Examples:
+  asc feedback export --app "123456789" --dir ./feedback
+  asc feedback export --app "123456789" --since 30d --dir ./feedback --digest html
+  asc feedback export --app "123456789" --since 2026-01-01 --dir ./feedback --issues jira`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*dir) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --dir is required")
+				return flag.ErrHelp
+			}
+			sinceTime, err := shared.ParseTimeOrAge("--since", *since, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+			digestFormat := strings.ToLower(strings.TrimSpace(*digest))
+			issuesFormat := strings.ToLower(strings.TrimSpace(*issues))
+			if err := shared.ValidateBetaFeedbackExportFormats(digestFormat, issuesFormat); err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			if err := os.MkdirAll(*dir, 0o755); err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+
+			result := &asc.BetaFeedbackExportResult{
+				AppID: resolvedAppID,
+				Kind:  "feedback",
+				Since: sinceTime.Format(time.RFC3339),
+				Dir:   *dir,
+			}
+			builds := shared.NewBetaFeedbackBuildResolver(client)
+
+			resp, err := client.GetFeedback(requestCtx, resolvedAppID,
+				asc.WithFeedbackSort("-createdDate"),
+				asc.WithFeedbackLimit(200),
+				asc.WithFeedbackInclude([]string{"build"}),
+			)
+			for {
+				if err != nil {
+					return fmt.Errorf("feedback export: failed to fetch: %w", err)
+				}
+				if err := builds.AddIncluded(requestCtx, resp.Included); err != nil {
+					return fmt.Errorf("feedback export: %w", err)
+				}
+				reachedSince := false
+				for _, submission := range resp.Data {
+					if created, parseErr := time.Parse(time.RFC3339, submission.Attributes.CreatedDate); parseErr == nil && created.Before(sinceTime) {
+						reachedSince = true
+						break
+					}
+					item := exportFeedbackSubmission(submission)
+					builds.Apply(&item, submission.Relationships)
+					downloadFeedbackScreenshots(requestCtx, client, result, &item, submission.Attributes.Screenshots)
+					result.Items = append(result.Items, item)
+				}
+				if reachedSince || strings.TrimSpace(resp.Links.Next) == "" {
+					break
+				}
+				resp, err = client.GetFeedback(requestCtx, resolvedAppID, asc.WithFeedbackNextURL(resp.Links.Next))
+			}
+
+			if err := shared.FinishBetaFeedbackExport(result, digestFormat, issuesFormat); err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if err := shared.BetaFeedbackExportFailure(result); err != nil {
+				return fmt.Errorf("feedback export: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+func exportFeedbackSubmission(submission asc.Resource[asc.FeedbackAttributes]) asc.BetaFeedbackExportItem {
+	attrs := submission.Attributes
+	return asc.BetaFeedbackExportItem{
+		ID:          submission.ID,
+		Kind:        "feedback",
+		CreatedDate: attrs.CreatedDate,
+		Email:       attrs.Email,
+		Comment:     attrs.Comment,
+		DeviceModel: attrs.DeviceModel,
+		OSVersion:   attrs.OSVersion,
+		AppPlatform: attrs.AppPlatform,
+		Locale:      attrs.Locale,
+	}
+}
+
+// downloadFeedbackScreenshots saves each screenshot into the item's build
+// directory. Failures are recorded on the item instead of aborting the export.
+func downloadFeedbackScreenshots(ctx context.Context, client *asc.Client, result *asc.BetaFeedbackExportResult, item *asc.BetaFeedbackExportItem, screenshots []asc.FeedbackScreenshotImage) {
+	itemDir := shared.BetaFeedbackItemDir(*item)
+	for i, screenshot := range screenshots {
+		if strings.TrimSpace(screenshot.URL) == "" {
+			continue
+		}
+		relBase := path.Join(itemDir, fmt.Sprintf("screenshot-%d", i+1))
+		relPath, written, err := shared.WriteBetaFeedbackScreenshot(result.Dir, relBase, func() (io.ReadCloser, string, error) {
+			download, err := client.DownloadBetaFeedbackScreenshot(ctx, screenshot.URL)
+			if err != nil {
+				return nil, "", err
+			}
+			return download.Body, download.ContentType, nil
+		})
+		if err != nil {
+			result.Failed++
+			item.Errors = append(item.Errors, fmt.Sprintf("screenshot %d: %v", i+1, err))
+			continue
+		}
+		if written {
+			result.Downloaded++
+		} else {
+			result.Skipped++
+		}
+		item.Files = append(item.Files, relPath)
+	}
+}
@@ -30,7 +30,10 @@ Examples:
   asc localizations preview-sets get --id "PREVIEW_SET_ID"
   asc localizations screenshot-sets get --id "SCREENSHOT_SET_ID"
   asc localizations download --version "VERSION_ID" --path "./localizations"
-  asc localizations upload --version "VERSION_ID" --path "./localizations"`,
+  asc localizations upload --version "VERSION_ID" --path "./localizations"
+  asc localizations lint --path "./localizations"
+  asc localizations translate --version "VERSION_ID" --from en-US --to de-DE,ja --translator-cmd ./translate.sh --dry-run
+  asc localizations copy --from-version "V1" --to-version "V2" --dry-run`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -40,6 +43,9 @@ Examples:
 			LocalizationsScreenshotSetsCommand(),
 			LocalizationsDownloadCommand(),
 			LocalizationsUploadCommand(),
+			LocalizationsLintCommand(),
+			LocalizationsTranslateCommand(),
+			LocalizationsCopyCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -203,7 +209,9 @@ func LocalizationsDownloadCommand() *ffcli.Command {
 	appInfoID := fs.String("app-info", "", "App Info ID (optional override)")
 	locType := fs.String("type", shared.LocalizationTypeVersion, "Localization type: version (default) or app-info")
 	locale := fs.String("locale", "", "Filter by locale(s), comma-separated")
-	path := fs.String("path", "localizations", "Output path (directory or .strings file)")
+	path := fs.String("path", "localizations", "Output path (directory or file)")
+	format := fs.String("format", "", "File format: strings, json, xcstrings, xliff (default: inferred from --path, else strings)")
+	sourceLocale := fs.String("source-locale", "", "Source language for xcstrings/xliff (default: en-US when present)")
 	limit := fs.Int("limit", 0, "Maximum results per page (1-200)")
 	next := fs.String("next", "", "Fetch next page using a links.next URL")
 	paginate := fs.Bool("paginate", false, "Automatically fetch all pages (aggregate results)")
@@ -213,14 +221,21 @@ func LocalizationsDownloadCommand() *ffcli.Command {
 	return &ffcli.Command{
 		Name:       "download",
 		ShortUsage: "asc localizations download [flags]",
-		ShortHelp:  "Download localizations to .strings files.",
-		LongHelp: `Download localizations to .strings files.
+		ShortHelp:  "Download localizations to .strings, JSON, String Catalog or XLIFF files.",
+		LongHelp: `Download localizations to .strings, JSON, String Catalog or XLIFF files.
+
+Each metadata field (description, keywords, whatsNew, name, subtitle, ...) is
+written as a key. String Catalogs (.xcstrings) hold every locale in one file;
+the other formats write one file per locale. Existing .xcstrings and .xliff
+files are merged in place and unchanged values keep their translation state.
 
 Examples:
   asc localizations download --version "VERSION_ID" --path "./localizations"
   asc localizations download --app "APP_ID" --type app-info --path "./localizations"
   asc localizations download --version "VERSION_ID" --locale "en-US" --path "en-US.strings"
-  asc localizations download --version "VERSION_ID" --paginate --path "./localizations"`,
+  asc localizations download --version "VERSION_ID" --paginate --path "./localizations"
+  asc localizations download --version "VERSION_ID" --path "./Metadata.xcstrings"
+  asc localizations download --version "VERSION_ID" --format xliff --source-locale "en-US" --path "./xliff"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -230,6 +245,10 @@ Examples:
 			if err := shared.ValidateNextURL(*next); err != nil {
 				return fmt.Errorf("localizations download: %w", err)
 			}
+			fileFormat, err := shared.ResolveLocalizationFormat(*format, *path)
+			if err != nil {
+				return fmt.Errorf("localizations download: %w", err)
+			}
 
 			normalizedType, err := shared.NormalizeLocalizationType(*locType)
 			if err != nil {
@@ -280,7 +299,7 @@ Examples:
 						return fmt.Errorf("localizations download: unexpected pagination response type")
 					}
 
-					files, err := shared.WriteVersionLocalizationStrings(*path, aggregated.Data)
+					files, err := shared.WriteVersionLocalizations(*path, fileFormat, *sourceLocale, aggregated.Data)
 					if err != nil {
 						return fmt.Errorf("localizations download: %w", err)
 					}
@@ -300,7 +319,7 @@ Examples:
 					return fmt.Errorf("localizations download: failed to fetch: %w", err)
 				}
 
-				files, err := shared.WriteVersionLocalizationStrings(*path, resp.Data)
+				files, err := shared.WriteVersionLocalizations(*path, fileFormat, *sourceLocale, resp.Data)
 				if err != nil {
 					return fmt.Errorf("localizations download: %w", err)
 				}
@@ -360,7 +379,7 @@ Examples:
 						return fmt.Errorf("localizations download: unexpected pagination response type")
 					}
 
-					files, err := shared.WriteAppInfoLocalizationStrings(*path, aggregated.Data)
+					files, err := shared.WriteAppInfoLocalizations(*path, fileFormat, *sourceLocale, aggregated.Data)
 					if err != nil {
 						return fmt.Errorf("localizations download: %w", err)
 					}
@@ -381,7 +400,7 @@ Examples:
 					return fmt.Errorf("localizations download: failed to fetch: %w", err)
 				}
 
-				files, err := shared.WriteAppInfoLocalizationStrings(*path, resp.Data)
+				files, err := shared.WriteAppInfoLocalizations(*path, fileFormat, *sourceLocale, resp.Data)
 				if err != nil {
 					return fmt.Errorf("localizations download: %w", err)
 				}
@@ -411,7 +430,8 @@ func LocalizationsUploadCommand() *ffcli.Command {
 	appInfoID := fs.String("app-info", "", "App Info ID (optional override)")
 	locType := fs.String("type", shared.LocalizationTypeVersion, "Localization type: version (default) or app-info")
 	locale := fs.String("locale", "", "Filter by locale(s), comma-separated")
-	path := fs.String("path", "", "Input path (directory or .strings file)")
+	path := fs.String("path", "", "Input path (directory or file)")
+	format := fs.String("format", "", "File format: strings, json, xcstrings, xliff (default: inferred from --path, else strings)")
 	dryRun := fs.Bool("dry-run", false, "Validate file without uploading")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
@@ -419,14 +439,20 @@ func LocalizationsUploadCommand() *ffcli.Command {
 	return &ffcli.Command{
 		Name:       "upload",
 		ShortUsage: "asc localizations upload [flags]",
-		ShortHelp:  "Upload localizations from .strings files.",
-		LongHelp: `Upload localizations from .strings files.
+		ShortHelp:  "Upload localizations from .strings, JSON, String Catalog or XLIFF files.",
+		LongHelp: `Upload localizations from .strings, JSON, String Catalog or XLIFF files.
+
+Entries marked as new (untranslated) or left empty in String Catalogs and XLIFF
+files are skipped and reported under "skipped"; entries that need review are
+uploaded.
 
 Examples:
   asc localizations upload --version "VERSION_ID" --path "./localizations"
   asc localizations upload --app "APP_ID" --type app-info --path "./localizations"
   asc localizations upload --version "VERSION_ID" --locale "en-US" --path "en-US.strings"
-  asc localizations upload --version "VERSION_ID" --path "./localizations" --dry-run`,
+  asc localizations upload --version "VERSION_ID" --path "./localizations" --dry-run
+  asc localizations upload --version "VERSION_ID" --path "./Metadata.xcstrings"
+  asc localizations upload --app "APP_ID" --type app-info --format xliff --path "./xliff"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -434,6 +460,10 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --path is required")
 				return flag.ErrHelp
 			}
+			fileFormat, err := shared.ResolveLocalizationFormat(*format, *path)
+			if err != nil {
+				return fmt.Errorf("localizations upload: %w", err)
+			}
 
 			normalizedType, err := shared.NormalizeLocalizationType(*locType)
 			if err != nil {
@@ -457,7 +487,7 @@ Examples:
 				requestCtx, cancel := shared.ContextWithTimeout(ctx)
 				defer cancel()
 
-				valuesByLocale, err := shared.ReadLocalizationStrings(*path, locales)
+				valuesByLocale, skipped, err := shared.ReadLocalizationValues(*path, fileFormat, locales)
 				if err != nil {
 					return fmt.Errorf("localizations upload: %w", err)
 				}
@@ -472,6 +502,7 @@ Examples:
 					VersionID: strings.TrimSpace(*versionID),
 					DryRun:    *dryRun,
 					Results:   results,
+					Skipped:   skipped,
 				}
 
 				return shared.PrintOutput(&result, *output, *pretty)
@@ -495,7 +526,7 @@ Examples:
 					return fmt.Errorf("localizations upload: %w", err)
 				}
 
-				valuesByLocale, err := shared.ReadLocalizationStrings(*path, locales)
+				valuesByLocale, skipped, err := shared.ReadLocalizationValues(*path, fileFormat, locales)
 				if err != nil {
 					return fmt.Errorf("localizations upload: %w", err)
 				}
@@ -511,6 +542,7 @@ Examples:
 					AppInfoID: appInfo,
 					DryRun:    *dryRun,
 					Results:   results,
+					Skipped:   skipped,
 				}
 
 				return shared.PrintOutput(&result, *output, *pretty)
@@ -520,3 +552,69 @@ Examples:
 		},
 	}
 }
+
+// fetchVersionLocalizationValues returns every localization of a version as
+// locale -> key -> value, following pagination.
+func fetchVersionLocalizationValues(ctx context.Context, client *asc.Client, versionID string, locales []string) (map[string]map[string]string, error) {
+	items, err := fetchVersionLocalizations(ctx, client, versionID, locales)
+	if err != nil {
+		return nil, err
+	}
+	return shared.VersionLocalizationValues(items), nil
+}
+
+// fetchVersionLocalizations returns every localization of a version, following pagination.
+func fetchVersionLocalizations(ctx context.Context, client *asc.Client, versionID string, locales []string) ([]asc.Resource[asc.AppStoreVersionLocalizationAttributes], error) {
+	opts := []asc.AppStoreVersionLocalizationsOption{asc.WithAppStoreVersionLocalizationsLimit(200)}
+	if len(locales) > 0 {
+		opts = append(opts, asc.WithAppStoreVersionLocalizationLocales(locales))
+	}
+	firstPage, err := client.GetAppStoreVersionLocalizations(ctx, versionID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch version localizations: %w", err)
+	}
+	resp, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregated, ok := resp.(*asc.AppStoreVersionLocalizationsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+	return aggregated.Data, nil
+}
+
+// fetchAppInfoLocalizationValues returns every localization of an app info as
+// locale -> key -> value, following pagination.
+func fetchAppInfoLocalizationValues(ctx context.Context, client *asc.Client, appInfoID string, locales []string) (map[string]map[string]string, error) {
+	items, err := fetchAppInfoLocalizations(ctx, client, appInfoID, locales)
+	if err != nil {
+		return nil, err
+	}
+	return shared.AppInfoLocalizationValues(items), nil
+}
+
+// fetchAppInfoLocalizations returns every localization of an app info, following pagination.
+func fetchAppInfoLocalizations(ctx context.Context, client *asc.Client, appInfoID string, locales []string) ([]asc.Resource[asc.AppInfoLocalizationAttributes], error) {
+	opts := []asc.AppInfoLocalizationsOption{asc.WithAppInfoLocalizationsLimit(200)}
+	if len(locales) > 0 {
+		opts = append(opts, asc.WithAppInfoLocalizationLocales(locales))
+	}
+	firstPage, err := client.GetAppInfoLocalizations(ctx, appInfoID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app info localizations: %w", err)
+	}
+	resp, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppInfoLocalizations(ctx, appInfoID, asc.WithAppInfoLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregated, ok := resp.(*asc.AppInfoLocalizationsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+	return aggregated.Data, nil
+}
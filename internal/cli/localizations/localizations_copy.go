@@ -0,0 +1,468 @@
+package localizations
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/assets"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/reviews"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	copyActionCreate   = "create"
+	copyActionUpdate   = "update"
+	copyActionUpload   = "upload"
+	copyActionUploaded = "uploaded"
+	copyActionMissing  = "missing"
+)
+
+// LocalizationsCopyCommand returns the copy localizations subcommand.
+func LocalizationsCopyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("copy", flag.ExitOnError)
+
+	fromVersion := fs.String("from-version", "", "Source App Store version ID")
+	toVersion := fs.String("to-version", "", "Destination App Store version ID")
+	fromApp := fs.String("from-app", "", "Source app ID (copies app info localizations; requires --to-app)")
+	toApp := fs.String("to-app", "", "Destination app ID (copies app info localizations; requires --from-app)")
+	fields := fs.String("fields", "", "Fields to copy, comma-separated (default: all)")
+	locales := fs.String("locales", "", "Locales to copy, comma-separated (default: all source locales)")
+	screenshots := fs.Bool("screenshots", false, "Also copy screenshot sets (replaces destination sets)")
+	reviewDetails := fs.Bool("review-details", false, "Also copy App Review contact, demo account and notes")
+	reviewAttachments := fs.String("review-attachments", "", "Directory with local copies of the source review attachments to upload")
+	dryRun := fs.Bool("dry-run", false, "Show the differences without copying")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "copy",
+		ShortUsage: "asc localizations copy --from-version V1 --to-version V2 [flags]",
+		ShortHelp:  "Copy metadata between versions, platforms and apps.",
+		LongHelp: `Copy metadata between versions, platforms and apps.
+
+Version localizations (description, keywords, whatsNew, ...) are copied from
+--from-version to --to-version; the versions may belong to different apps or
+platforms. With --from-app and --to-app, app info localizations (name,
+subtitle, privacy) are copied as well. Empty source fields never clear the
+destination.
+
+The result lists every field that would be created or changed. Use --dry-run
+to review the differences before copying.
+
+App Store Connect does not allow downloading review attachments, so
+--review-attachments uploads local files whose names match the source
+attachments and reports any that are missing.
+
+Examples:
+  asc localizations copy --from-version "V1" --to-version "V2" --dry-run
+  asc localizations copy --from-version "V1" --to-version "V2" --fields whatsNew,promotionalText --locales en-US,de-DE
+  asc localizations copy --from-version "IOS_VERSION" --to-version "VISIONOS_VERSION" --review-details
+  asc localizations copy --from-version "V1" --to-version "V2" --from-app "APP_A" --to-app "APP_B" --screenshots
+  asc localizations copy --from-version "V1" --to-version "V2" --review-details --review-attachments ./review-docs`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fromVersionValue := strings.TrimSpace(*fromVersion)
+			if fromVersionValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --from-version is required")
+				return flag.ErrHelp
+			}
+			toVersionValue := strings.TrimSpace(*toVersion)
+			if toVersionValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --to-version is required")
+				return flag.ErrHelp
+			}
+			if fromVersionValue == toVersionValue {
+				return fmt.Errorf("localizations copy: --from-version and --to-version must differ")
+			}
+			fromAppValue := strings.TrimSpace(*fromApp)
+			toAppValue := strings.TrimSpace(*toApp)
+			if (fromAppValue == "") != (toAppValue == "") {
+				fmt.Fprintln(os.Stderr, "Error: --from-app and --to-app must be used together")
+				return flag.ErrHelp
+			}
+			if fromAppValue != "" && fromAppValue == toAppValue {
+				return fmt.Errorf("localizations copy: --from-app and --to-app must differ (app info is shared across versions)")
+			}
+
+			versionFields, appInfoFields, err := resolveCopyFields(*fields, fromAppValue != "")
+			if err != nil {
+				return fmt.Errorf("localizations copy: %w", err)
+			}
+			localeFilter := shared.SplitCSV(*locales)
+			attachmentsDir := strings.TrimSpace(*reviewAttachments)
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("localizations copy: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			result := &asc.LocalizationCopyResult{
+				FromVersionID: fromVersionValue,
+				ToVersionID:   toVersionValue,
+				DryRun:        *dryRun,
+				Changes:       []asc.LocalizationCopyChange{},
+			}
+
+			sourceItems, err := fetchVersionLocalizations(requestCtx, client, fromVersionValue, localeFilter)
+			if err != nil {
+				return fmt.Errorf("localizations copy: %w", err)
+			}
+			if len(sourceItems) == 0 {
+				return fmt.Errorf("localizations copy: source version has no matching localizations")
+			}
+			destValues, err := fetchVersionLocalizationValues(requestCtx, client, toVersionValue, localeFilter)
+			if err != nil {
+				return fmt.Errorf("localizations copy: %w", err)
+			}
+
+			versionUpload := diffLocalizationValues(result, shared.LocalizationTypeVersion, shared.VersionLocalizationValues(sourceItems), destValues, versionFields)
+
+			var appInfoUpload map[string]map[string]string
+			if fromAppValue != "" {
+				result.FromAppInfoID, err = shared.ResolveAppInfoID(requestCtx, client, fromAppValue, "")
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+				result.ToAppInfoID, err = shared.ResolveAppInfoID(requestCtx, client, toAppValue, "")
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+				sourceAppInfo, err := fetchAppInfoLocalizationValues(requestCtx, client, result.FromAppInfoID, localeFilter)
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+				destAppInfo, err := fetchAppInfoLocalizationValues(requestCtx, client, result.ToAppInfoID, localeFilter)
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+				appInfoUpload = diffLocalizationValues(result, shared.LocalizationTypeAppInfo, sourceAppInfo, destAppInfo, appInfoFields)
+			}
+
+			if !*dryRun {
+				if len(versionUpload) > 0 {
+					result.Results, err = shared.UploadVersionLocalizations(requestCtx, client, toVersionValue, versionUpload, false)
+					if err != nil {
+						return fmt.Errorf("localizations copy: %w", err)
+					}
+				}
+				if len(appInfoUpload) > 0 {
+					result.AppInfoResults, err = shared.UploadAppInfoLocalizations(requestCtx, client, result.ToAppInfoID, appInfoUpload, false)
+					if err != nil {
+						return fmt.Errorf("localizations copy: %w", err)
+					}
+				}
+			}
+
+			if *screenshots {
+				result.Screenshots, err = copyScreenshotSets(ctx, client, sourceItems, toVersionValue, *dryRun)
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+			}
+
+			var sourceReviewID, destReviewID string
+			if *reviewDetails || attachmentsDir != "" {
+				sourceReviewID, destReviewID, result.ReviewDetail, err = copyReviewDetails(requestCtx, client, fromVersionValue, toVersionValue, *reviewDetails, *dryRun)
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+			}
+			if attachmentsDir != "" {
+				result.ReviewAttachments, err = copyReviewAttachments(ctx, client, sourceReviewID, destReviewID, attachmentsDir, *dryRun)
+				if err != nil {
+					return fmt.Errorf("localizations copy: %w", err)
+				}
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			missing := 0
+			for _, attachment := range result.ReviewAttachments {
+				if attachment.Action == copyActionMissing {
+					missing++
+				}
+			}
+			if missing > 0 {
+				return shared.NewReportedError(fmt.Errorf("localizations copy: %d review attachment(s) not found in %s", missing, attachmentsDir))
+			}
+			return nil
+		},
+	}
+}
+
+// resolveCopyFields splits --fields into version and app info fields. App info
+// fields require app IDs because app info localizations belong to the app.
+func resolveCopyFields(value string, includeAppInfo bool) ([]string, []string, error) {
+	versionKeys := shared.LocalizationKeys(shared.LocalizationTypeVersion)
+	appInfoKeys := shared.LocalizationKeys(shared.LocalizationTypeAppInfo)
+	requested := shared.SplitCSV(value)
+	if len(requested) == 0 {
+		return versionKeys, appInfoKeys, nil
+	}
+
+	var versionFields, appInfoFields []string
+	for _, field := range requested {
+		switch {
+		case slices.Contains(versionKeys, field):
+			versionFields = append(versionFields, field)
+		case slices.Contains(appInfoKeys, field):
+			if !includeAppInfo {
+				return nil, nil, fmt.Errorf("--fields: %q is an app info field; use --from-app and --to-app", field)
+			}
+			appInfoFields = append(appInfoFields, field)
+		default:
+			return nil, nil, fmt.Errorf("--fields: unknown field %q", field)
+		}
+	}
+	return versionFields, appInfoFields, nil
+}
+
+// diffLocalizationValues records source fields that differ from the destination
+// and returns the values to upload, keyed by locale.
+func diffLocalizationValues(result *asc.LocalizationCopyResult, locType string, source, dest map[string]map[string]string, fields []string) map[string]map[string]string {
+	locales := make([]string, 0, len(source))
+	for locale := range source {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	upload := make(map[string]map[string]string)
+	for _, locale := range locales {
+		destFields, exists := dest[locale]
+		for _, field := range fields {
+			value := source[locale][field]
+			if value == "" {
+				continue
+			}
+			if exists && destFields[field] == value {
+				result.Unchanged++
+				continue
+			}
+			action := copyActionUpdate
+			if !exists {
+				action = copyActionCreate
+			}
+			result.Changes = append(result.Changes, asc.LocalizationCopyChange{
+				Type:   locType,
+				Locale: locale,
+				Field:  field,
+				Action: action,
+				From:   destFields[field],
+				To:     value,
+			})
+			if upload[locale] == nil {
+				upload[locale] = make(map[string]string)
+			}
+			upload[locale][field] = value
+		}
+	}
+	return upload
+}
+
+// copyScreenshotSets downloads each source localization's screenshots and
+// uploads them to the destination localization for the same locale, replacing
+// the destination sets.
+func copyScreenshotSets(ctx context.Context, client *asc.Client, sourceItems []asc.Resource[asc.AppStoreVersionLocalizationAttributes], toVersionID string, dryRun bool) ([]asc.LocalizationCopyScreenshotSet, error) {
+	uploadCtx, cancel := assets.ContextWithUploadTimeout(ctx)
+	defer cancel()
+
+	destIDs := make(map[string]string)
+	if !dryRun {
+		destItems, err := fetchVersionLocalizations(uploadCtx, client, toVersionID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range destItems {
+			destIDs[item.Attributes.Locale] = item.ID
+		}
+	}
+
+	sort.Slice(sourceItems, func(i, j int) bool {
+		return sourceItems[i].Attributes.Locale < sourceItems[j].Attributes.Locale
+	})
+
+	var copied []asc.LocalizationCopyScreenshotSet
+	for _, item := range sourceItems {
+		locale := item.Attributes.Locale
+		if dryRun {
+			sets, err := client.GetAppScreenshotSets(uploadCtx, item.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch screenshot sets for %s: %w", locale, err)
+			}
+			for _, set := range sets.Data {
+				screenshots, err := client.GetAppScreenshots(uploadCtx, set.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to fetch screenshots for set %s: %w", set.ID, err)
+				}
+				if len(screenshots.Data) > 0 {
+					copied = append(copied, asc.LocalizationCopyScreenshotSet{Locale: locale, DisplayType: set.Attributes.ScreenshotDisplayType, Count: len(screenshots.Data)})
+				}
+			}
+			continue
+		}
+
+		tempDir, err := os.MkdirTemp("", "asc-screenshots-*")
+		if err != nil {
+			return nil, err
+		}
+		sets, err := copyLocaleScreenshots(uploadCtx, client, item.ID, destIDs[locale], locale, tempDir)
+		_ = os.RemoveAll(tempDir)
+		if err != nil {
+			return nil, err
+		}
+		copied = append(copied, sets...)
+	}
+	return copied, nil
+}
+
+func copyLocaleScreenshots(ctx context.Context, client *asc.Client, sourceLocalizationID, destLocalizationID, locale, dir string) ([]asc.LocalizationCopyScreenshotSet, error) {
+	paths, err := assets.DownloadScreenshots(ctx, client, sourceLocalizationID, dir, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", locale, err)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if destLocalizationID == "" {
+		return nil, fmt.Errorf("destination version has no %s localization for screenshots", locale)
+	}
+
+	byDisplayType := make(map[string][]string)
+	for _, path := range paths {
+		displayType, err := assets.InferScreenshotDisplayType(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", locale, err)
+		}
+		byDisplayType[displayType] = append(byDisplayType[displayType], path)
+	}
+	displayTypes := make([]string, 0, len(byDisplayType))
+	for displayType := range byDisplayType {
+		displayTypes = append(displayTypes, displayType)
+	}
+	sort.Strings(displayTypes)
+
+	sets := make([]asc.LocalizationCopyScreenshotSet, 0, len(displayTypes))
+	for _, displayType := range displayTypes {
+		files := byDisplayType[displayType]
+		sort.Strings(files)
+		if _, err := assets.UploadScreenshotFiles(ctx, client, destLocalizationID, displayType, files, assets.ScreenshotUploadOptions{Replace: true}); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", locale, displayType, err)
+		}
+		sets = append(sets, asc.LocalizationCopyScreenshotSet{Locale: locale, DisplayType: displayType, Count: len(files)})
+	}
+	return sets, nil
+}
+
+// copyReviewDetails returns the source and destination review detail IDs and,
+// when apply is set, copies the source details onto the destination version.
+func copyReviewDetails(ctx context.Context, client *asc.Client, fromVersionID, toVersionID string, apply, dryRun bool) (string, string, string, error) {
+	source, err := client.GetAppStoreReviewDetailForVersion(ctx, fromVersionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return "", "", "", fmt.Errorf("failed to fetch source review details: %w", err)
+	}
+	if err != nil || source.Data.ID == "" {
+		return "", "", "", fmt.Errorf("source version has no review details")
+	}
+
+	destID := ""
+	dest, err := client.GetAppStoreReviewDetailForVersion(ctx, toVersionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return "", "", "", fmt.Errorf("failed to fetch destination review details: %w", err)
+	}
+	if err == nil {
+		destID = dest.Data.ID
+	}
+	if !apply {
+		return source.Data.ID, destID, "", nil
+	}
+
+	action := copyActionUpdate
+	if destID == "" {
+		action = copyActionCreate
+	}
+	if dryRun {
+		return source.Data.ID, destID, action, nil
+	}
+
+	attrs := reviewDetailUpdateAttributes(source.Data.Attributes)
+	if destID != "" {
+		if _, err := client.UpdateAppStoreReviewDetail(ctx, destID, attrs); err != nil {
+			return "", "", "", fmt.Errorf("failed to update review details: %w", err)
+		}
+		return source.Data.ID, destID, action, nil
+	}
+	createAttrs := asc.AppStoreReviewDetailCreateAttributes(attrs)
+	created, err := client.CreateAppStoreReviewDetail(ctx, toVersionID, &createAttrs)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to create review details: %w", err)
+	}
+	return source.Data.ID, created.Data.ID, action, nil
+}
+
+func reviewDetailUpdateAttributes(attrs asc.AppStoreReviewDetailAttributes) asc.AppStoreReviewDetailUpdateAttributes {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	demoRequired := attrs.DemoAccountRequired
+	return asc.AppStoreReviewDetailUpdateAttributes{
+		ContactFirstName:    optional(attrs.ContactFirstName),
+		ContactLastName:     optional(attrs.ContactLastName),
+		ContactPhone:        optional(attrs.ContactPhone),
+		ContactEmail:        optional(attrs.ContactEmail),
+		DemoAccountName:     optional(attrs.DemoAccountName),
+		DemoAccountPassword: optional(attrs.DemoAccountPassword),
+		DemoAccountRequired: &demoRequired,
+		Notes:               optional(attrs.Notes),
+	}
+}
+
+// copyReviewAttachments uploads local files named like the source attachments.
+func copyReviewAttachments(ctx context.Context, client *asc.Client, sourceReviewID, destReviewID, dir string, dryRun bool) ([]asc.LocalizationCopyAttachment, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	attachments, err := client.GetAppStoreReviewAttachmentsForReviewDetail(requestCtx, sourceReviewID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review attachments: %w", err)
+	}
+	if len(attachments.Data) > 0 && destReviewID == "" && !dryRun {
+		return nil, fmt.Errorf("destination version has no review details; add --review-details")
+	}
+
+	results := make([]asc.LocalizationCopyAttachment, 0, len(attachments.Data))
+	for _, attachment := range attachments.Data {
+		name := attachment.Attributes.FileName
+		path := filepath.Join(dir, filepath.Base(name))
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() <= 0 {
+			results = append(results, asc.LocalizationCopyAttachment{FileName: name, Action: copyActionMissing})
+			continue
+		}
+		if dryRun {
+			results = append(results, asc.LocalizationCopyAttachment{FileName: name, Action: copyActionUpload})
+			continue
+		}
+		uploaded, err := reviews.UploadReviewAttachment(ctx, client, destReviewID, path, info.Size())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, asc.LocalizationCopyAttachment{FileName: name, Action: copyActionUploaded, ID: uploaded.Data.ID})
+	}
+	return results, nil
+}
@@ -0,0 +1,587 @@
+package localizations
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/migrate"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+
+	lintFormatYAML = "yaml"
+)
+
+// lintFieldRule describes the App Store Connect constraints for one metadata field.
+type lintFieldRule struct {
+	Limit      int  // maximum length, 0 for none
+	Bytes      bool // limit counts UTF-8 bytes instead of characters
+	SingleLine bool // newlines and tabs are rejected
+	NoEmoji    bool // emoji and pictographs are rejected
+	URL        bool // value must be an absolute http(s) URL
+}
+
+var lintFieldRules = map[string]lintFieldRule{
+	"name":              {Limit: 30, SingleLine: true, NoEmoji: true},
+	"subtitle":          {Limit: 30, SingleLine: true, NoEmoji: true},
+	"keywords":          {Limit: 100, Bytes: true, SingleLine: true, NoEmoji: true},
+	"promotionalText":   {Limit: 170},
+	"description":       {Limit: 4000},
+	"whatsNew":          {Limit: 4000},
+	"privacyPolicyText": {},
+	"marketingUrl":      {URL: true, SingleLine: true},
+	"supportUrl":        {URL: true, SingleLine: true},
+	"privacyPolicyUrl":  {URL: true, SingleLine: true},
+	"privacyChoicesUrl": {URL: true, SingleLine: true},
+}
+
+// appStoreLocales lists the locales App Store Connect accepts for metadata.
+var appStoreLocales = map[string]bool{
+	"ar-SA": true, "bn-BD": true, "ca": true, "cs": true, "da": true, "de-DE": true,
+	"el": true, "en-AU": true, "en-CA": true, "en-GB": true, "en-US": true,
+	"es-ES": true, "es-MX": true, "fi": true, "fr-CA": true, "fr-FR": true,
+	"gu-IN": true, "he": true, "hi": true, "hr": true, "hu": true, "id": true,
+	"it": true, "ja": true, "kn-IN": true, "ko": true, "ml-IN": true, "mr-IN": true,
+	"ms": true, "nl-NL": true, "no": true, "or-IN": true, "pa-IN": true, "pl": true,
+	"pt-BR": true, "pt-PT": true, "ro": true, "ru": true, "sk": true, "sl-SI": true,
+	"sv": true, "ta-IN": true, "te-IN": true, "th": true, "tr": true, "uk": true,
+	"ur-PK": true, "vi": true, "zh-Hans": true, "zh-Hant": true,
+}
+
+// LocalizationsLintCommand returns the lint localizations subcommand.
+func LocalizationsLintCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+
+	versionID := fs.String("version", "", "Lint a live App Store version's localizations")
+	appID := fs.String("app", "", "Lint a live app's app info localizations (name, subtitle, privacy)")
+	appInfoID := fs.String("app-info", "", "App Info ID (optional override)")
+	path := fs.String("path", "", "Lint local files (directory or file)")
+	format := fs.String("format", "", "File format for --path: strings, json, xcstrings, xliff, yaml (default: inferred)")
+	fastlaneDir := fs.String("fastlane-dir", "", "Lint a fastlane directory (reads metadata/)")
+	locale := fs.String("locale", "", "Filter by locale(s), comma-separated")
+	primaryLocale := fs.String("primary-locale", "en-US", "Locale other locales are compared against for missing fields")
+	strict := fs.Bool("strict", false, "Treat warnings as failures")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown, junit")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "lint",
+		ShortUsage: "asc localizations lint [flags]",
+		ShortHelp:  "Lint App Store metadata from live versions, local files or fastlane.",
+		LongHelp: `Lint App Store metadata from live versions, local files or fastlane.
+
+Use exactly one source: --version and/or --app for live metadata, --path for
+.strings, JSON, String Catalog, XLIFF or YAML files, or --fastlane-dir.
+YAML files map locales to fields:
+
+  en-US:
+    name: My App
+    keywords: photo,editor
+
+Checks:
+  - Length: name 30, subtitle 30, keywords 100 bytes, promotionalText 170,
+    description 4000, whatsNew 4000
+  - Keywords repeated within keywords or already in the name/subtitle
+  - Control characters, line breaks in single-line fields, emoji in
+    name/subtitle/keywords
+  - Locales App Store Connect does not support
+  - Marketing, support and privacy URLs that are not absolute http(s) URLs
+  - Fields present in --primary-locale but missing in other locales
+
+The command exits non-zero when any error is found (or any warning with
+--strict). Use --output junit, or the root --report junit --report-file flags
+alongside JSON output, to gate CI.
+
+Examples:
+  asc localizations lint --version "VERSION_ID" --app "APP_ID"
+  asc localizations lint --path "./localizations"
+  asc localizations lint --path "./metadata.yaml" --primary-locale "en-US"
+  asc localizations lint --fastlane-dir "./fastlane" --output junit > lint.xml
+  asc --report junit --report-file lint.xml localizations lint --path "./Metadata.xcstrings"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pathValue := strings.TrimSpace(*path)
+			fastlaneValue := strings.TrimSpace(*fastlaneDir)
+			versionValue := strings.TrimSpace(*versionID)
+			appValue := strings.TrimSpace(*appID)
+
+			sources := 0
+			for _, set := range []bool{pathValue != "", fastlaneValue != "", versionValue != "" || appValue != ""} {
+				if set {
+					sources++
+				}
+			}
+			if sources == 0 {
+				fmt.Fprintln(os.Stderr, "Error: one of --version, --app, --path or --fastlane-dir is required")
+				return flag.ErrHelp
+			}
+			if sources > 1 {
+				return fmt.Errorf("localizations lint: --path, --fastlane-dir and --version/--app are mutually exclusive")
+			}
+			outputFormat := strings.ToLower(strings.TrimSpace(*output))
+			if outputFormat == "junit" && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
+
+			var (
+				values map[string]map[string]string
+				source string
+				err    error
+			)
+			locales := shared.SplitCSV(*locale)
+			switch {
+			case pathValue != "":
+				source = pathValue
+				values, err = readLintFiles(pathValue, *format, locales)
+			case fastlaneValue != "":
+				source = fastlaneValue
+				values, err = migrate.ReadFastlaneLocalizationValues(fastlaneValue)
+			default:
+				values, source, err = fetchLintValues(ctx, versionValue, appValue, strings.TrimSpace(*appInfoID), locales)
+			}
+			if err != nil {
+				return fmt.Errorf("localizations lint: %w", err)
+			}
+			values = filterLintLocales(values, locales)
+			if len(values) == 0 {
+				return fmt.Errorf("localizations lint: no localizations found in %s", source)
+			}
+
+			primaryExplicit := false
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "primary-locale" {
+					primaryExplicit = true
+				}
+			})
+
+			result, err := lintLocalizations(values, strings.TrimSpace(*primaryLocale), primaryExplicit)
+			if err != nil {
+				return fmt.Errorf("localizations lint: %w", err)
+			}
+			result.Source = source
+			if *strict {
+				result.Valid = result.ErrorCount == 0 && result.WarningCount == 0
+			}
+
+			report := localizationLintJUnitReport(result, *strict)
+			if shared.ReportFormat() == shared.ReportFormatJUnit {
+				if err := report.Write(shared.ReportFile()); err != nil {
+					return fmt.Errorf("localizations lint: %w", err)
+				}
+			}
+			if outputFormat == "junit" {
+				if err := report.WriteTo(os.Stdout); err != nil {
+					return fmt.Errorf("localizations lint: %w", err)
+				}
+			} else if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+
+			if !result.Valid {
+				return shared.NewReportedError(fmt.Errorf("localizations lint: %d error(s), %d warning(s)", result.ErrorCount, result.WarningCount))
+			}
+			return nil
+		},
+	}
+}
+
+// readLintFiles reads local metadata files, adding YAML to the upload formats.
+func readLintFiles(path, format string, locales []string) (map[string]map[string]string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+	ext := strings.ToLower(filepath.Ext(path))
+	if normalized == lintFormatYAML || normalized == "yml" || (normalized == "" && (ext == ".yaml" || ext == ".yml")) {
+		return readLintYAML(path)
+	}
+	resolved, err := shared.ResolveLocalizationFormat(format, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w (or %s)", err, lintFormatYAML)
+	}
+	values, _, err := shared.ReadLocalizationValues(path, resolved, locales)
+	return values, err
+}
+
+// readLintYAML reads a YAML file, or every .yaml/.yml file in a directory,
+// mapping locale -> field -> value.
+func readLintYAML(path string) (map[string]map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no .yaml files found in %q", path)
+		}
+	}
+
+	values := make(map[string]map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var parsed map[string]map[string]string
+		if err := yaml.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid YAML in %s (expected locale: {field: value}): %w", file, err)
+		}
+		for locale, fields := range parsed {
+			if _, exists := values[locale]; exists {
+				return nil, fmt.Errorf("duplicate locale %q in %s", locale, file)
+			}
+			if fields == nil {
+				fields = map[string]string{}
+			}
+			values[locale] = fields
+		}
+	}
+	return values, nil
+}
+
+// fetchLintValues reads live version and/or app info localizations.
+func fetchLintValues(ctx context.Context, versionID, appID, appInfoID string, locales []string) (map[string]map[string]string, string, error) {
+	client, err := shared.GetASCClient()
+	if err != nil {
+		return nil, "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	values := make(map[string]map[string]string)
+	merge := func(more map[string]map[string]string) {
+		for locale, fields := range more {
+			if values[locale] == nil {
+				values[locale] = make(map[string]string)
+			}
+			for key, value := range fields {
+				values[locale][key] = value
+			}
+		}
+	}
+
+	var sources []string
+	if versionID != "" {
+		versionValues, err := fetchVersionLocalizationValues(requestCtx, client, versionID, locales)
+		if err != nil {
+			return nil, "", err
+		}
+		merge(versionValues)
+		sources = append(sources, "version:"+versionID)
+	}
+
+	if appID != "" {
+		appInfo, err := shared.ResolveAppInfoID(requestCtx, client, appID, appInfoID)
+		if err != nil {
+			return nil, "", err
+		}
+		appInfoValues, err := fetchAppInfoLocalizationValues(requestCtx, client, appInfo, locales)
+		if err != nil {
+			return nil, "", err
+		}
+		merge(appInfoValues)
+		sources = append(sources, "appInfo:"+appInfo)
+	}
+
+	return values, strings.Join(sources, ","), nil
+}
+
+func filterLintLocales(values map[string]map[string]string, locales []string) map[string]map[string]string {
+	if len(locales) == 0 {
+		return values
+	}
+	filtered := make(map[string]map[string]string, len(locales))
+	for _, locale := range locales {
+		if fields, ok := values[locale]; ok {
+			filtered[locale] = fields
+		}
+	}
+	return filtered
+}
+
+// lintLocalizations checks every locale's fields and compares locales against
+// the primary locale. A missing primary locale is an error only when it was
+// requested explicitly; otherwise missing-field checks are skipped.
+func lintLocalizations(values map[string]map[string]string, primaryLocale string, primaryExplicit bool) (*asc.LocalizationLintResult, error) {
+	locales := make([]string, 0, len(values))
+	for locale := range values {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	if _, ok := values[primaryLocale]; !ok {
+		if primaryExplicit {
+			return nil, fmt.Errorf("primary locale %q not found", primaryLocale)
+		}
+		primaryLocale = ""
+	}
+
+	result := &asc.LocalizationLintResult{
+		PrimaryLocale: primaryLocale,
+		Locales:       locales,
+		Issues:        []asc.LocalizationLintIssue{},
+	}
+	for _, locale := range locales {
+		result.Issues = append(result.Issues, lintLocale(locale, values[locale])...)
+		if primaryLocale != "" && locale != primaryLocale {
+			result.Issues = append(result.Issues, lintMissingFields(locale, values[locale], values[primaryLocale])...)
+		}
+	}
+	for _, issue := range result.Issues {
+		if issue.Severity == lintSeverityError {
+			result.ErrorCount++
+		} else {
+			result.WarningCount++
+		}
+	}
+	result.Valid = result.ErrorCount == 0
+	return result, nil
+}
+
+func lintLocale(locale string, fields map[string]string) []asc.LocalizationLintIssue {
+	var issues []asc.LocalizationLintIssue
+	add := func(field, rule, severity, message string) {
+		issues = append(issues, asc.LocalizationLintIssue{Locale: locale, Field: field, Rule: rule, Severity: severity, Message: message})
+	}
+
+	if !appStoreLocales[locale] {
+		add("locale", "unsupported-locale", lintSeverityError, fmt.Sprintf("%q is not an App Store Connect locale", locale))
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := fields[key]
+		rule, known := lintFieldRules[key]
+		if !known {
+			add(key, "unknown-field", lintSeverityWarning, "not an App Store Connect metadata field")
+			continue
+		}
+
+		if rule.Limit > 0 {
+			length := utf8.RuneCountInString(value)
+			unit := "character"
+			if rule.Bytes {
+				length = len(value)
+				unit = "byte"
+			}
+			if length > rule.Limit {
+				issues = append(issues, asc.LocalizationLintIssue{
+					Locale:   locale,
+					Field:    key,
+					Rule:     "length",
+					Severity: lintSeverityError,
+					Message:  fmt.Sprintf("exceeds %d %s limit", rule.Limit, unit),
+					Length:   length,
+					Limit:    rule.Limit,
+				})
+			}
+		}
+
+		if message := bannedCharacterMessage(value, rule); message != "" {
+			add(key, "banned-character", lintSeverityError, message)
+		}
+
+		if rule.URL && strings.TrimSpace(value) != "" && !isValidMetadataURL(value) {
+			add(key, "invalid-url", lintSeverityError, fmt.Sprintf("%q is not an absolute http(s) URL", value))
+		}
+	}
+
+	issues = append(issues, lintKeywords(locale, fields)...)
+	return issues
+}
+
+// bannedCharacterMessage reports the first character App Store Connect rejects.
+func bannedCharacterMessage(value string, rule lintFieldRule) string {
+	for _, r := range value {
+		switch {
+		case r == utf8.RuneError:
+			return "contains an invalid UTF-8 sequence or replacement character"
+		case r == '\n' || r == '\r' || r == '\t':
+			if rule.SingleLine {
+				return "contains a line break or tab"
+			}
+		case unicode.IsControl(r):
+			return fmt.Sprintf("contains control character %U", r)
+		case rule.NoEmoji && isEmoji(r):
+			return fmt.Sprintf("contains emoji %q", string(r))
+		}
+	}
+	return ""
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0xFE0F || r == 0x200D:
+		return true
+	}
+	return false
+}
+
+func isValidMetadataURL(value string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return false
+	}
+	return parsed.Host != ""
+}
+
+// lintKeywords flags duplicate or empty keywords and keywords that repeat
+// words already indexed from the name or subtitle.
+func lintKeywords(locale string, fields map[string]string) []asc.LocalizationLintIssue {
+	keywords := fields["keywords"]
+	if strings.TrimSpace(keywords) == "" {
+		return nil
+	}
+
+	indexed := make(map[string]string)
+	for _, field := range []string{"name", "subtitle"} {
+		for _, word := range strings.FieldsFunc(strings.ToLower(fields[field]), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}) {
+			if _, ok := indexed[word]; !ok {
+				indexed[word] = field
+			}
+		}
+	}
+
+	var issues []asc.LocalizationLintIssue
+	add := func(rule, message string) {
+		issues = append(issues, asc.LocalizationLintIssue{Locale: locale, Field: "keywords", Rule: rule, Severity: lintSeverityWarning, Message: message})
+	}
+
+	seen := make(map[string]bool)
+	emptyReported := false
+	for _, keyword := range strings.Split(keywords, ",") {
+		normalized := strings.ToLower(strings.TrimSpace(keyword))
+		if normalized == "" {
+			if !emptyReported {
+				add("keyword-empty", "contains an empty keyword")
+				emptyReported = true
+			}
+			continue
+		}
+		if seen[normalized] {
+			add("keyword-duplicate", fmt.Sprintf("keyword %q is repeated", normalized))
+			continue
+		}
+		seen[normalized] = true
+		if field, ok := indexed[normalized]; ok {
+			add("keyword-in-"+field, fmt.Sprintf("keyword %q is already indexed from the %s", normalized, field))
+		}
+	}
+	return issues
+}
+
+// lintMissingFields reports fields set in the primary locale but not in locale.
+func lintMissingFields(locale string, fields, primary map[string]string) []asc.LocalizationLintIssue {
+	keys := make([]string, 0, len(primary))
+	for key, value := range primary {
+		if strings.TrimSpace(value) != "" && strings.TrimSpace(fields[key]) == "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	issues := make([]asc.LocalizationLintIssue, 0, len(keys))
+	for _, key := range keys {
+		issues = append(issues, asc.LocalizationLintIssue{
+			Locale:   locale,
+			Field:    key,
+			Rule:     "missing-field",
+			Severity: lintSeverityWarning,
+			Message:  "set in primary locale but missing",
+		})
+	}
+	return issues
+}
+
+// localizationLintJUnitReport emits one test case per locale and field.
+// Warnings fail the test case only in strict mode.
+func localizationLintJUnitReport(result *asc.LocalizationLintResult, strict bool) *shared.JUnitReport {
+	report := &shared.JUnitReport{
+		Name:      "asc localizations lint",
+		Timestamp: time.Now(),
+	}
+
+	byLocale := make(map[string]map[string][]asc.LocalizationLintIssue)
+	for _, issue := range result.Issues {
+		if byLocale[issue.Locale] == nil {
+			byLocale[issue.Locale] = make(map[string][]asc.LocalizationLintIssue)
+		}
+		byLocale[issue.Locale][issue.Field] = append(byLocale[issue.Locale][issue.Field], issue)
+	}
+
+	for _, locale := range result.Locales {
+		fields := make([]string, 0, len(byLocale[locale]))
+		for field := range byLocale[locale] {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		if len(fields) == 0 {
+			report.Tests = append(report.Tests, shared.JUnitTestCase{Name: locale, Classname: "localizations.lint"})
+			continue
+		}
+		for _, field := range fields {
+			testCase := shared.JUnitTestCase{
+				Name:      locale + "/" + field,
+				Classname: "localizations.lint",
+			}
+			var failures, warnings []string
+			for _, issue := range byLocale[locale][field] {
+				message := fmt.Sprintf("%s: %s", issue.Rule, issue.Message)
+				if issue.Severity == lintSeverityError || strict {
+					failures = append(failures, message)
+				} else {
+					warnings = append(warnings, message)
+				}
+			}
+			if len(failures) > 0 {
+				testCase.Failure = "LINT"
+				testCase.Message = strings.Join(failures, "; ")
+			}
+			if len(warnings) > 0 {
+				testCase.SystemOut = strings.Join(warnings, "\n")
+			}
+			report.Tests = append(report.Tests, testCase)
+		}
+	}
+	return report
+}
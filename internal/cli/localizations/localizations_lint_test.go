@@ -0,0 +1,156 @@
+package localizations
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func findLintIssue(issues []asc.LocalizationLintIssue, locale, field, rule string) *asc.LocalizationLintIssue {
+	for i := range issues {
+		if issues[i].Locale == locale && issues[i].Field == field && issues[i].Rule == rule {
+			return &issues[i]
+		}
+	}
+	return nil
+}
+
+func TestLintLocalizations_FieldLimits(t *testing.T) {
+	values := map[string]map[string]string{
+		"en-US": {
+			"name":            strings.Repeat("a", 31),
+			"subtitle":        strings.Repeat("b", 30),
+			"keywords":        strings.Repeat("é", 51),
+			"promotionalText": strings.Repeat("p", 171),
+			"description":     strings.Repeat("d", 4001),
+			"whatsNew":        strings.Repeat("w", 4001),
+		},
+	}
+	result, err := lintLocalizations(values, "en-US", false)
+	if err != nil {
+		t.Fatalf("lintLocalizations() error: %v", err)
+	}
+	for _, field := range []string{"name", "keywords", "promotionalText", "description", "whatsNew"} {
+		if findLintIssue(result.Issues, "en-US", field, "length") == nil {
+			t.Errorf("expected length issue for %s", field)
+		}
+	}
+	if findLintIssue(result.Issues, "en-US", "subtitle", "length") != nil {
+		t.Error("expected subtitle at the limit to pass")
+	}
+	issue := findLintIssue(result.Issues, "en-US", "keywords", "length")
+	if issue.Length != 102 || issue.Limit != 100 {
+		t.Errorf("expected keywords to be measured in bytes, got %+v", issue)
+	}
+	if result.Valid || result.ErrorCount != 5 {
+		t.Errorf("expected 5 errors, got %d (valid=%t)", result.ErrorCount, result.Valid)
+	}
+}
+
+func TestLintLocalizations_ContentRules(t *testing.T) {
+	values := map[string]map[string]string{
+		"en-US": {
+			"name":         "Photo Studio",
+			"subtitle":     "Edit 📷",
+			"keywords":     "photo,filters, Filters,,camera",
+			"description":  "Great app",
+			"supportUrl":   "example.com/support",
+			"marketingUrl": "https://example.com",
+		},
+		"de-DE": {
+			"name":        "Foto Studio\n",
+			"description": "Tolle App",
+		},
+		"xx-YY": {
+			"description": "?",
+		},
+	}
+	result, err := lintLocalizations(values, "en-US", false)
+	if err != nil {
+		t.Fatalf("lintLocalizations() error: %v", err)
+	}
+
+	checks := []struct{ locale, field, rule string }{
+		{"en-US", "subtitle", "banned-character"},
+		{"en-US", "keywords", "keyword-in-name"},
+		{"en-US", "keywords", "keyword-duplicate"},
+		{"en-US", "keywords", "keyword-empty"},
+		{"en-US", "supportUrl", "invalid-url"},
+		{"de-DE", "name", "banned-character"},
+		{"de-DE", "keywords", "missing-field"},
+		{"de-DE", "supportUrl", "missing-field"},
+		{"xx-YY", "locale", "unsupported-locale"},
+	}
+	for _, check := range checks {
+		if findLintIssue(result.Issues, check.locale, check.field, check.rule) == nil {
+			t.Errorf("expected %s issue for %s/%s, got %+v", check.rule, check.locale, check.field, result.Issues)
+		}
+	}
+	if findLintIssue(result.Issues, "en-US", "marketingUrl", "invalid-url") != nil {
+		t.Error("expected https marketing URL to pass")
+	}
+	if findLintIssue(result.Issues, "de-DE", "description", "missing-field") != nil {
+		t.Error("expected present description not to be reported missing")
+	}
+}
+
+func TestLintLocalizations_PrimaryLocale(t *testing.T) {
+	values := map[string]map[string]string{"fr-FR": {"description": "Bonjour"}}
+	result, err := lintLocalizations(values, "en-US", false)
+	if err != nil {
+		t.Fatalf("lintLocalizations() error: %v", err)
+	}
+	if result.PrimaryLocale != "" || !result.Valid {
+		t.Fatalf("expected missing default primary locale to be ignored, got %+v", result)
+	}
+	if _, err := lintLocalizations(values, "en-US", true); err == nil {
+		t.Fatal("expected error for explicit missing primary locale")
+	}
+}
+
+func TestReadLintFiles_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	content := "en-US:\n  name: My App\n  keywords: photo,editor\nde-DE:\n  name: Meine App\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write yaml: %v", err)
+	}
+	values, err := readLintFiles(path, "", nil)
+	if err != nil {
+		t.Fatalf("readLintFiles() error: %v", err)
+	}
+	if values["en-US"]["keywords"] != "photo,editor" || values["de-DE"]["name"] != "Meine App" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+}
+
+func TestLocalizationLintJUnitReport(t *testing.T) {
+	result := &asc.LocalizationLintResult{
+		Locales: []string{"de-DE", "en-US"},
+		Issues: []asc.LocalizationLintIssue{
+			{Locale: "de-DE", Field: "name", Rule: "length", Severity: lintSeverityError, Message: "exceeds 30 character limit"},
+			{Locale: "de-DE", Field: "keywords", Rule: "missing-field", Severity: lintSeverityWarning, Message: "set in primary locale but missing"},
+		},
+	}
+
+	data, err := localizationLintJUnitReport(result, false).MarshalXML()
+	if err != nil {
+		t.Fatalf("MarshalXML() error: %v", err)
+	}
+	xml := string(data)
+	for _, want := range []string{`tests="3"`, `failures="1"`, `name="de-DE/name"`, `name="en-US"`} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("expected %q in report, got %s", want, xml)
+		}
+	}
+
+	strictData, err := localizationLintJUnitReport(result, true).MarshalXML()
+	if err != nil {
+		t.Fatalf("MarshalXML() error: %v", err)
+	}
+	if !strings.Contains(string(strictData), `failures="2"`) {
+		t.Errorf("expected warnings to fail in strict mode, got %s", strictData)
+	}
+}
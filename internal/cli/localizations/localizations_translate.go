@@ -0,0 +1,362 @@
+package localizations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	translationStatusTranslated = "translated"
+	translationStatusInvalid    = "invalid"
+	translationStatusFailed     = "failed"
+)
+
+// translatableFields lists the text fields that may be sent to a translator, per
+// localization type. URL fields are never translated.
+var translatableFields = map[string][]string{
+	shared.LocalizationTypeVersion: {"description", "keywords", "promotionalText", "whatsNew"},
+	shared.LocalizationTypeAppInfo: {"name", "subtitle", "privacyPolicyText"},
+}
+
+// translatorRequest is written to the translator command's stdin as JSON.
+type translatorRequest struct {
+	SourceLocale string            `json:"sourceLocale"`
+	TargetLocale string            `json:"targetLocale"`
+	Strings      map[string]string `json:"strings"`
+	Limits       map[string]int    `json:"limits,omitempty"`
+}
+
+// translatorResponse is read from the translator command's stdout as JSON.
+type translatorResponse struct {
+	Strings map[string]string `json:"strings"`
+}
+
+// LocalizationsTranslateCommand returns the translate localizations subcommand.
+func LocalizationsTranslateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("translate", flag.ExitOnError)
+
+	versionID := fs.String("version", "", "App Store version ID")
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	appInfoID := fs.String("app-info", "", "App Info ID (optional override)")
+	locType := fs.String("type", shared.LocalizationTypeVersion, "Localization type: version (default) or app-info")
+	from := fs.String("from", "", "Source locale (e.g., en-US)")
+	to := fs.String("to", "", "Target locale(s), comma-separated")
+	fields := fs.String("fields", "", "Fields to translate, comma-separated (default: all text fields for --type)")
+	translatorCmd := fs.String("translator-cmd", "", "Translator command (JSON on stdin, JSON on stdout)")
+	missingOnly := fs.Bool("missing-only", false, "Only translate fields that are empty in the target locale")
+	path := fs.String("path", "", "Write translations to files instead of uploading (directory or file)")
+	format := fs.String("format", "", "File format for --path: strings, json, xcstrings, xliff (default: inferred from --path, else strings)")
+	dryRun := fs.Bool("dry-run", false, "Translate and validate without uploading or writing files")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "translate",
+		ShortUsage: "asc localizations translate --from LOCALE --to LOCALES --translator-cmd CMD [flags]",
+		ShortHelp:  "Machine-translate metadata into other locales with an external command.",
+		LongHelp: `Machine-translate metadata into other locales with an external command.
+
+The source locale is read from App Store Connect and, for each target locale,
+the translator command is run once. The command line is split on spaces; it
+receives a JSON request on stdin and must print a JSON response on stdout:
+
+  stdin:  {"sourceLocale": "en-US", "targetLocale": "de-DE",
+           "strings": {"whatsNew": "Bug fixes"}, "limits": {"whatsNew": 4000}}
+  stdout: {"strings": {"whatsNew": "Fehlerbehebungen"}}
+
+Limits are characters, except keywords which are bytes. Translations that are
+missing, empty, over the field limit or contain banned characters are reported
+as invalid and are not uploaded. Results are uploaded to App Store Connect
+unless --path is set, in which case they are written to files.
+
+Examples:
+  asc localizations translate --version "VERSION_ID" --from en-US --to de-DE,ja --fields whatsNew,promotionalText --translator-cmd ./translate.sh --dry-run
+  asc localizations translate --version "VERSION_ID" --from en-US --to de-DE,ja --fields whatsNew --translator-cmd ./translate.sh
+  asc localizations translate --app "APP_ID" --type app-info --from en-US --to fr-FR --fields subtitle --translator-cmd ./translate.sh --path ./translations
+  asc localizations translate --version "VERSION_ID" --from en-US --to de-DE,fr-FR,ja --missing-only --translator-cmd "python3 translate.py"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			sourceLocale := strings.TrimSpace(*from)
+			if sourceLocale == "" {
+				fmt.Fprintln(os.Stderr, "Error: --from is required")
+				return flag.ErrHelp
+			}
+			targets := shared.SplitCSV(*to)
+			if len(targets) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --to is required")
+				return flag.ErrHelp
+			}
+			command := strings.Fields(*translatorCmd)
+			if len(command) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --translator-cmd is required")
+				return flag.ErrHelp
+			}
+			if slices.Contains(targets, sourceLocale) {
+				return fmt.Errorf("localizations translate: --to must not include the source locale %q", sourceLocale)
+			}
+			for _, target := range targets {
+				if !appStoreLocales[target] {
+					return fmt.Errorf("localizations translate: --to locale %q is not supported by App Store Connect", target)
+				}
+			}
+
+			normalizedType, err := shared.NormalizeLocalizationType(*locType)
+			if err != nil {
+				return fmt.Errorf("localizations translate: %w", err)
+			}
+			selectedFields, err := resolveTranslateFields(normalizedType, *fields)
+			if err != nil {
+				return fmt.Errorf("localizations translate: %w", err)
+			}
+			outputPath := strings.TrimSpace(*path)
+			fileFormat := ""
+			if outputPath != "" {
+				fileFormat, err = shared.ResolveLocalizationFormat(*format, outputPath)
+				if err != nil {
+					return fmt.Errorf("localizations translate: %w", err)
+				}
+			}
+
+			result := &asc.LocalizationTranslateResult{
+				Type:         normalizedType,
+				SourceLocale: sourceLocale,
+				Fields:       selectedFields,
+				DryRun:       *dryRun,
+			}
+
+			var resolvedAppInfoID string
+			switch normalizedType {
+			case shared.LocalizationTypeVersion:
+				if strings.TrimSpace(*versionID) == "" {
+					fmt.Fprintln(os.Stderr, "Error: --version is required for version localizations")
+					return flag.ErrHelp
+				}
+				result.VersionID = strings.TrimSpace(*versionID)
+			case shared.LocalizationTypeAppInfo:
+				resolvedAppID := shared.ResolveAppID(*appID)
+				if resolvedAppID == "" {
+					fmt.Fprintln(os.Stderr, "Error: --app is required for app-info localizations")
+					return flag.ErrHelp
+				}
+				result.AppID = resolvedAppID
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("localizations translate: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var existing map[string]map[string]string
+			if normalizedType == shared.LocalizationTypeVersion {
+				existing, err = fetchVersionLocalizationValues(requestCtx, client, result.VersionID, nil)
+			} else {
+				resolvedAppInfoID, err = shared.ResolveAppInfoID(requestCtx, client, result.AppID, strings.TrimSpace(*appInfoID))
+				if err == nil {
+					result.AppInfoID = resolvedAppInfoID
+					existing, err = fetchAppInfoLocalizationValues(requestCtx, client, resolvedAppInfoID, nil)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("localizations translate: %w", err)
+			}
+
+			source, ok := existing[sourceLocale]
+			if !ok {
+				return fmt.Errorf("localizations translate: source locale %q not found", sourceLocale)
+			}
+			sourceStrings := make(map[string]string)
+			for _, field := range selectedFields {
+				if strings.TrimSpace(source[field]) != "" {
+					sourceStrings[field] = source[field]
+				}
+			}
+			if len(sourceStrings) == 0 {
+				return fmt.Errorf("localizations translate: source locale %q has no values for %s", sourceLocale, strings.Join(selectedFields, ", "))
+			}
+
+			translated := make(map[string]map[string]string)
+			for _, target := range targets {
+				request := translatorRequest{
+					SourceLocale: sourceLocale,
+					TargetLocale: target,
+					Strings:      make(map[string]string),
+					Limits:       make(map[string]int),
+				}
+				for field, text := range sourceStrings {
+					if *missingOnly && strings.TrimSpace(existing[target][field]) != "" {
+						continue
+					}
+					request.Strings[field] = text
+					if limit := lintFieldRules[field].Limit; limit > 0 {
+						request.Limits[field] = limit
+					}
+				}
+				if len(request.Strings) == 0 {
+					continue
+				}
+
+				items, values := translateLocale(ctx, command, request)
+				result.Translations = append(result.Translations, items...)
+				if len(values) > 0 {
+					translated[target] = values
+				}
+			}
+			for _, item := range result.Translations {
+				if item.Status != translationStatusTranslated {
+					result.FailedCount++
+				}
+			}
+
+			if len(translated) > 0 {
+				// Translators can be slow; give the upload its own request timeout.
+				uploadCtx, uploadCancel := shared.ContextWithTimeout(ctx)
+				defer uploadCancel()
+
+				switch {
+				case outputPath != "":
+					if !*dryRun {
+						result.Files, err = shared.WriteLocalizationValues(outputPath, fileFormat, normalizedType, sourceLocale, sourceStrings, translated)
+					}
+				case normalizedType == shared.LocalizationTypeVersion:
+					result.Results, err = shared.UploadVersionLocalizations(uploadCtx, client, result.VersionID, translated, *dryRun)
+				default:
+					result.Results, err = shared.UploadAppInfoLocalizations(uploadCtx, client, resolvedAppInfoID, translated, *dryRun)
+				}
+				if err != nil {
+					return fmt.Errorf("localizations translate: %w", err)
+				}
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if result.FailedCount > 0 {
+				return shared.NewReportedError(fmt.Errorf("localizations translate: %d translation(s) failed validation", result.FailedCount))
+			}
+			return nil
+		},
+	}
+}
+
+// resolveTranslateFields validates --fields against the translatable fields for locType.
+func resolveTranslateFields(locType, value string) ([]string, error) {
+	allowed := translatableFields[locType]
+	requested := shared.SplitCSV(value)
+	if len(requested) == 0 {
+		return slices.Clone(allowed), nil
+	}
+	for _, field := range requested {
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("--fields: %q cannot be translated for %s localizations (allowed: %s)", field, locType, strings.Join(allowed, ", "))
+		}
+	}
+	return requested, nil
+}
+
+// translateLocale runs the translator for one target locale and validates each
+// returned string. Only valid translations are returned in values.
+func translateLocale(ctx context.Context, command []string, request translatorRequest) ([]asc.LocalizationTranslationItem, map[string]string) {
+	fields := make([]string, 0, len(request.Strings))
+	for field := range request.Strings {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	items := make([]asc.LocalizationTranslationItem, 0, len(fields))
+	response, err := runTranslator(ctx, command, request)
+	if err != nil {
+		for _, field := range fields {
+			items = append(items, asc.LocalizationTranslationItem{
+				Locale: request.TargetLocale,
+				Field:  field,
+				Status: translationStatusFailed,
+				Error:  err.Error(),
+			})
+		}
+		return items, nil
+	}
+
+	values := make(map[string]string)
+	for _, field := range fields {
+		text := response.Strings[field]
+		item := asc.LocalizationTranslationItem{
+			Locale: request.TargetLocale,
+			Field:  field,
+			Status: translationStatusTranslated,
+			Text:   text,
+		}
+		if problem := validateTranslation(field, text, &item); problem != "" {
+			item.Status = translationStatusInvalid
+			item.Error = problem
+		} else {
+			values[field] = text
+		}
+		items = append(items, item)
+	}
+	return items, values
+}
+
+// validateTranslation checks a translated value against the field rules used
+// by localizations lint and records its length and limit on item.
+func validateTranslation(field, text string, item *asc.LocalizationTranslationItem) string {
+	if strings.TrimSpace(text) == "" {
+		return "translator returned no text"
+	}
+	rule := lintFieldRules[field]
+	if rule.Limit > 0 {
+		item.Limit = rule.Limit
+		item.Length = utf8.RuneCountInString(text)
+		unit := "character"
+		if rule.Bytes {
+			item.Length = len(text)
+			unit = "byte"
+		}
+		if item.Length > rule.Limit {
+			return fmt.Sprintf("exceeds %d %s limit", rule.Limit, unit)
+		}
+	}
+	return bannedCharacterMessage(text, rule)
+}
+
+func runTranslator(ctx context.Context, command []string, request translatorRequest) (*translatorResponse, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("translator failed: %w: %s", err, message)
+		}
+		return nil, fmt.Errorf("translator failed: %w", err)
+	}
+
+	var response translatorResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("translator returned invalid JSON: %w", err)
+	}
+	return &response, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/peterbourgon/ff/v3/ffcli"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/assets"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 )
 
@@ -50,6 +51,8 @@ func MigrateImportCommand() *ffcli.Command {
 	versionID := fs.String("version-id", "", "App Store version ID (required)")
 	fastlaneDir := fs.String("fastlane-dir", "", "Path to fastlane directory (required)")
 	dryRun := fs.Bool("dry-run", false, "Preview changes without uploading")
+	skipScreenshots := fs.Bool("skip-screenshots", false, "Skip uploading screenshots from the screenshots directory")
+	overwriteScreenshots := fs.Bool("overwrite-screenshots", false, "Delete existing screenshots in each display type before uploading")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -59,26 +62,44 @@ func MigrateImportCommand() *ffcli.Command {
 		ShortHelp:  "Import metadata from fastlane directory structure.",
 		LongHelp: `Import metadata from fastlane directory structure.
 
-Reads from the standard fastlane structure:
+Reads from the standard fastlane deliver structure:
   fastlane/
   ├── metadata/
+  │   ├── copyright.txt                  (Version)
+  │   ├── primary_category.txt           (App Info, also *_sub_category.txt)
+  │   ├── secondary_category.txt         (App Info)
+  │   ├── app_rating_config.json         (App Info age rating)
+  │   ├── review_information/            (App Review contact and demo account)
+  │   ├── default/                       (fallback for every locale)
   │   ├── en-US/
-  │   │   ├── name.txt           (App Info)
-  │   │   ├── subtitle.txt       (App Info)
-  │   │   ├── description.txt    (Version)
-  │   │   ├── keywords.txt       (Version)
-  │   │   ├── release_notes.txt  (Version)
-  │   │   ├── promotional_text.txt (Version)
-  │   │   ├── support_url.txt    (Version)
-  │   │   └── marketing_url.txt  (Version)
+  │   │   ├── name.txt                   (App Info)
+  │   │   ├── subtitle.txt               (App Info)
+  │   │   ├── privacy_url.txt            (App Info)
+  │   │   ├── apple_tv_privacy_policy.txt (App Info)
+  │   │   ├── description.txt            (Version)
+  │   │   ├── keywords.txt               (Version)
+  │   │   ├── release_notes.txt          (Version)
+  │   │   ├── promotional_text.txt       (Version)
+  │   │   ├── support_url.txt            (Version)
+  │   │   └── marketing_url.txt          (Version)
   │   └── de-DE/
   │       └── ...
+  └── screenshots/
+      └── en-US/*.png                    (display type from name or dimensions)
 
-Note: privacy_url.txt is not supported (app-level, not localized).
+app_rating_config.json may use deliver's keys ("CARTOON_FANTASY_VIOLENCE": 1)
+or App Store Connect attribute names (the format written by "asc migrate
+export"). Screenshots are uploaded through the same
+path as "asc assets screenshots upload", including local validation.
+
+Not supported by the App Store Connect API, and reported when present:
+trade_representative_contact_information. Price tiers are managed with
+"asc pricing".
 
 Examples:
   asc migrate import --app "APP_ID" --version-id "VERSION_ID" --fastlane-dir ./fastlane
-  asc migrate import --app "APP_ID" --version-id "VERSION_ID" --fastlane-dir ./fastlane --dry-run`,
+  asc migrate import --app "APP_ID" --version-id "VERSION_ID" --fastlane-dir ./fastlane --dry-run
+  asc migrate import --app "APP_ID" --version-id "VERSION_ID" --fastlane-dir ./fastlane --overwrite-screenshots`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -117,12 +138,29 @@ Examples:
 				return fmt.Errorf("migrate import: %w", err)
 			}
 
+			appInfoLocs = applyFastlaneDefaults(metadataDir, localizations, appInfoLocs)
+
+			appMeta, err := readFastlaneAppMetadata(*fastlaneDir, metadataDir)
+			if err != nil {
+				return fmt.Errorf("migrate import: %w", err)
+			}
+
+			var screenshots []FastlaneScreenshotSet
+			if !*skipScreenshots {
+				screenshots, err = readFastlaneScreenshots(filepath.Join(*fastlaneDir, fastlaneScreenshotsDir))
+				if err != nil {
+					return fmt.Errorf("migrate import: %w", err)
+				}
+			}
+
 			if *dryRun {
 				result := &MigrateImportResult{
 					DryRun:               true,
 					VersionID:            strings.TrimSpace(*versionID),
 					Localizations:        localizations,
 					AppInfoLocalizations: appInfoLocs,
+					AppMetadata:          appMeta,
+					Screenshots:          screenshots,
 				}
 				return printMigrateOutput(result, *output, *pretty)
 			}
@@ -169,10 +207,11 @@ Examples:
 					}
 				} else {
 					// Create new localization
-					_, err := client.CreateAppStoreVersionLocalization(requestCtx, strings.TrimSpace(*versionID), attrs)
+					created, err := client.CreateAppStoreVersionLocalization(requestCtx, strings.TrimSpace(*versionID), attrs)
 					if err != nil {
 						return fmt.Errorf("migrate import: failed to create %s: %w", loc.Locale, err)
 					}
+					localeToID[loc.Locale] = created.Data.ID
 				}
 
 				uploaded = append(uploaded, LocalizationUploadItem{
@@ -181,10 +220,9 @@ Examples:
 				})
 			}
 
-			// Upload App Info localizations (name, subtitle)
-			appInfoUploaded := make([]LocalizationUploadItem, 0, len(appInfoLocs))
-			if len(appInfoLocs) > 0 {
-				// Get AppInfo ID for the app
+			// Resolve the AppInfo ID when app-level metadata is present
+			appInfoID := ""
+			if len(appInfoLocs) > 0 || appMeta.Categories != nil || appMeta.AgeRating != nil {
 				appInfos, err := client.GetAppInfos(requestCtx, resolvedAppID)
 				if err != nil {
 					return fmt.Errorf("migrate import: failed to get app info: %w", err)
@@ -192,10 +230,15 @@ Examples:
 				if len(appInfos.Data) == 0 {
 					return fmt.Errorf("migrate import: no app info found for app")
 				}
-				appInfoID := selectBestAppInfoID(appInfos)
+				appInfoID = selectBestAppInfoID(appInfos)
 				if strings.TrimSpace(appInfoID) == "" {
 					return fmt.Errorf("migrate import: failed to select app info for app")
 				}
+			}
+
+			// Upload App Info localizations (name, subtitle, privacy policy)
+			appInfoUploaded := make([]LocalizationUploadItem, 0, len(appInfoLocs))
+			if len(appInfoLocs) > 0 {
 
 				// Get existing App Info localizations
 				existingAppInfoLocs, err := client.GetAppInfoLocalizations(requestCtx, appInfoID)
@@ -212,9 +255,11 @@ Examples:
 				// Upload each App Info localization
 				for _, loc := range appInfoLocs {
 					attrs := asc.AppInfoLocalizationAttributes{
-						Locale:   loc.Locale,
-						Name:     loc.Name,
-						Subtitle: loc.Subtitle,
+						Locale:            loc.Locale,
+						Name:              loc.Name,
+						Subtitle:          loc.Subtitle,
+						PrivacyPolicyURL:  loc.PrivacyURL,
+						PrivacyPolicyText: loc.PrivacyPolicyText,
 					}
 
 					if existingID, exists := appInfoLocaleToID[loc.Locale]; exists {
@@ -229,16 +274,41 @@ Examples:
 						}
 					}
 
-					fields := 0
-					if loc.Name != "" {
-						fields++
-					}
-					if loc.Subtitle != "" {
-						fields++
-					}
 					appInfoUploaded = append(appInfoUploaded, LocalizationUploadItem{
 						Locale: loc.Locale,
-						Fields: fields,
+						Fields: countAppInfoFields(loc),
+					})
+				}
+			}
+
+			// Upload copyright, review information, categories and age rating
+			applied, err := applyFastlaneAppMetadata(requestCtx, client, strings.TrimSpace(*versionID), appInfoID, appMeta)
+			if err != nil {
+				return fmt.Errorf("migrate import: %w", err)
+			}
+
+			// Upload screenshots through the asset upload path
+			screenshotsUploaded := make([]ScreenshotUploadItem, 0, len(screenshots))
+			if len(screenshots) > 0 {
+				uploadCtx, uploadCancel := assets.ContextWithUploadTimeout(ctx)
+				defer uploadCancel()
+
+				for _, set := range screenshots {
+					locID, ok := localeToID[set.Locale]
+					if !ok {
+						return fmt.Errorf("migrate import: screenshots/%s has no matching version localization (add metadata/%s)", set.Locale, set.Locale)
+					}
+					uploadResult, err := assets.UploadScreenshotFiles(uploadCtx, client, locID, set.DisplayType, set.Files, assets.ScreenshotUploadOptions{
+						Replace: *overwriteScreenshots,
+					})
+					if err != nil {
+						return fmt.Errorf("migrate import: screenshots %s %s: %w", set.Locale, set.DisplayType, err)
+					}
+					screenshotsUploaded = append(screenshotsUploaded, ScreenshotUploadItem{
+						Locale:      set.Locale,
+						DisplayType: uploadResult.DisplayType,
+						SetID:       uploadResult.SetID,
+						Files:       len(uploadResult.Results),
 					})
 				}
 			}
@@ -248,8 +318,12 @@ Examples:
 				VersionID:            strings.TrimSpace(*versionID),
 				Localizations:        localizations,
 				AppInfoLocalizations: appInfoLocs,
+				AppMetadata:          appMeta,
+				Screenshots:          screenshots,
 				Uploaded:             uploaded,
 				AppInfoUploaded:      appInfoUploaded,
+				Applied:              applied,
+				ScreenshotsUploaded:  screenshotsUploaded,
 			}
 
 			return printMigrateOutput(result, *output, *pretty)
@@ -264,6 +338,7 @@ func MigrateExportCommand() *ffcli.Command {
 	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
 	versionID := fs.String("version-id", "", "App Store version ID (required)")
 	outputDir := fs.String("output-dir", "", "Output directory for fastlane structure (required)")
+	skipScreenshots := fs.Bool("skip-screenshots", false, "Skip downloading screenshots")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -273,10 +348,16 @@ func MigrateExportCommand() *ffcli.Command {
 		ShortHelp:  "Export metadata to fastlane directory structure.",
 		LongHelp: `Export current App Store metadata to fastlane directory structure.
 
-Creates the standard fastlane structure with all localizations.
+Creates the standard fastlane deliver structure with all localizations,
+copyright, categories, review information, app_rating_config.json and
+screenshots, so the result can be re-imported with "asc migrate import".
+
+Screenshots are written to screenshots/<locale>/ with the display type in the
+file name (for example APP_IPHONE_65_01_home.png).
 
 Examples:
-  asc migrate export --app "APP_ID" --version-id "VERSION_ID" --output-dir ./fastlane`,
+  asc migrate export --app "APP_ID" --version-id "VERSION_ID" --output-dir ./fastlane
+  asc migrate export --app "APP_ID" --version-id "VERSION_ID" --output-dir ./fastlane --skip-screenshots`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -336,10 +417,11 @@ Examples:
 				exported = append(exported, locale)
 			}
 
-			// Export App Info localizations (name, subtitle)
+			// Export App Info localizations (name, subtitle, privacy policy)
+			appInfoID := ""
 			appInfos, err := client.GetAppInfos(requestCtx, resolvedAppID)
 			if err == nil && len(appInfos.Data) > 0 {
-				appInfoID := selectBestAppInfoID(appInfos)
+				appInfoID = selectBestAppInfoID(appInfos)
 				if strings.TrimSpace(appInfoID) == "" {
 					return fmt.Errorf("migrate export: failed to select app info for app")
 				}
@@ -352,16 +434,42 @@ Examples:
 						if err := os.MkdirAll(localeDir, 0o755); err == nil {
 							totalFiles += writeAndCount(filepath.Join(localeDir, "name.txt"), loc.Attributes.Name)
 							totalFiles += writeAndCount(filepath.Join(localeDir, "subtitle.txt"), loc.Attributes.Subtitle)
+							totalFiles += writeAndCount(filepath.Join(localeDir, "privacy_url.txt"), loc.Attributes.PrivacyPolicyURL)
+							totalFiles += writeAndCount(filepath.Join(localeDir, "apple_tv_privacy_policy.txt"), loc.Attributes.PrivacyPolicyText)
 						}
 					}
 				}
 			}
 
+			// Export copyright, categories, review information and age rating
+			appFiles, err := exportFastlaneAppMetadata(requestCtx, client, strings.TrimSpace(*versionID), appInfoID, metadataDir)
+			if err != nil {
+				return fmt.Errorf("migrate export: %w", err)
+			}
+			totalFiles += appFiles
+
+			// Export screenshots
+			screenshotCount := 0
+			if !*skipScreenshots {
+				downloadCtx, downloadCancel := assets.ContextWithUploadTimeout(ctx)
+				defer downloadCancel()
+
+				for _, loc := range resp.Data {
+					localeDir := filepath.Join(*outputDir, fastlaneScreenshotsDir, loc.Attributes.Locale)
+					paths, err := assets.DownloadScreenshots(downloadCtx, client, loc.ID, localeDir, true)
+					if err != nil {
+						return fmt.Errorf("migrate export: screenshots %s: %w", loc.Attributes.Locale, err)
+					}
+					screenshotCount += len(paths)
+				}
+			}
+
 			result := &MigrateExportResult{
-				VersionID:  strings.TrimSpace(*versionID),
-				OutputDir:  *outputDir,
-				Locales:    exported,
-				TotalFiles: totalFiles,
+				VersionID:   strings.TrimSpace(*versionID),
+				OutputDir:   *outputDir,
+				Locales:     exported,
+				TotalFiles:  totalFiles,
+				Screenshots: screenshotCount,
 			}
 
 			return printMigrateOutput(result, *output, *pretty)
@@ -380,11 +488,13 @@ type FastlaneLocalization struct {
 	MarketingURL    string `json:"marketingUrl,omitempty"`
 }
 
-// AppInfoFastlaneLocalization holds app-level metadata (name, subtitle) from fastlane.
+// AppInfoFastlaneLocalization holds app-level metadata (name, subtitle, privacy policy) from fastlane.
 type AppInfoFastlaneLocalization struct {
-	Locale   string `json:"locale"`
-	Name     string `json:"name,omitempty"`
-	Subtitle string `json:"subtitle,omitempty"`
+	Locale            string `json:"locale"`
+	Name              string `json:"name,omitempty"`
+	Subtitle          string `json:"subtitle,omitempty"`
+	PrivacyURL        string `json:"privacyUrl,omitempty"`
+	PrivacyPolicyText string `json:"privacyPolicyText,omitempty"`
 }
 
 // LocalizationUploadItem represents an uploaded localization.
@@ -401,14 +511,19 @@ type MigrateImportResult struct {
 	AppInfoLocalizations []AppInfoFastlaneLocalization `json:"appInfoLocalizations,omitempty"`
 	Uploaded             []LocalizationUploadItem      `json:"uploaded,omitempty"`
 	AppInfoUploaded      []LocalizationUploadItem      `json:"appInfoUploaded,omitempty"`
+	AppMetadata          *FastlaneAppMetadata          `json:"appMetadata,omitempty"`
+	Screenshots          []FastlaneScreenshotSet       `json:"screenshots,omitempty"`
+	Applied              []string                      `json:"applied,omitempty"`
+	ScreenshotsUploaded  []ScreenshotUploadItem        `json:"screenshotsUploaded,omitempty"`
 }
 
 // MigrateExportResult is the result of a migrate export operation.
 type MigrateExportResult struct {
-	VersionID  string   `json:"versionId"`
-	OutputDir  string   `json:"outputDir"`
-	Locales    []string `json:"locales"`
-	TotalFiles int      `json:"totalFiles"`
+	VersionID   string   `json:"versionId"`
+	OutputDir   string   `json:"outputDir"`
+	Locales     []string `json:"locales"`
+	TotalFiles  int      `json:"totalFiles"`
+	Screenshots int      `json:"screenshots,omitempty"`
 }
 
 // readFastlaneMetadata reads metadata from a fastlane metadata directory.
@@ -425,28 +540,30 @@ func readFastlaneMetadata(metadataDir string) ([]FastlaneLocalization, error) {
 		}
 
 		locale := entry.Name()
-		if locale == "review_information" || locale == "default" {
+		if isFastlaneSpecialDir(locale) {
 			continue // Skip special directories
 		}
 
-		localeDir := filepath.Join(metadataDir, locale)
-		loc := FastlaneLocalization{Locale: locale}
-
-		// Read each metadata file (version-level localization fields only)
-		loc.Description = readFileIfExists(filepath.Join(localeDir, "description.txt"))
-		loc.Keywords = readFileIfExists(filepath.Join(localeDir, "keywords.txt"))
-		loc.WhatsNew = readFileIfExists(filepath.Join(localeDir, "release_notes.txt"))
-		loc.PromotionalText = readFileIfExists(filepath.Join(localeDir, "promotional_text.txt"))
-		loc.SupportURL = readFileIfExists(filepath.Join(localeDir, "support_url.txt"))
-		loc.MarketingURL = readFileIfExists(filepath.Join(localeDir, "marketing_url.txt"))
-
-		localizations = append(localizations, loc)
+		localizations = append(localizations, readFastlaneLocalizationDir(filepath.Join(metadataDir, locale), locale))
 	}
 
 	return localizations, nil
 }
 
-// readFastlaneAppInfoMetadata reads app-level metadata (name, subtitle) from fastlane structure.
+// readFastlaneLocalizationDir reads version-level localization fields from a locale directory.
+func readFastlaneLocalizationDir(localeDir, locale string) FastlaneLocalization {
+	return FastlaneLocalization{
+		Locale:          locale,
+		Description:     readFileIfExists(filepath.Join(localeDir, "description.txt")),
+		Keywords:        readFileIfExists(filepath.Join(localeDir, "keywords.txt")),
+		WhatsNew:        readFileIfExists(filepath.Join(localeDir, "release_notes.txt")),
+		PromotionalText: readFileIfExists(filepath.Join(localeDir, "promotional_text.txt")),
+		SupportURL:      readFileIfExists(filepath.Join(localeDir, "support_url.txt")),
+		MarketingURL:    readFileIfExists(filepath.Join(localeDir, "marketing_url.txt")),
+	}
+}
+
+// readFastlaneAppInfoMetadata reads app-level metadata (name, subtitle, privacy policy) from fastlane structure.
 func readFastlaneAppInfoMetadata(metadataDir string) ([]AppInfoFastlaneLocalization, error) {
 	entries, err := os.ReadDir(metadataDir)
 	if err != nil {
@@ -460,27 +577,43 @@ func readFastlaneAppInfoMetadata(metadataDir string) ([]AppInfoFastlaneLocalizat
 		}
 
 		locale := entry.Name()
-		if locale == "review_information" || locale == "default" {
+		if isFastlaneSpecialDir(locale) {
 			continue
 		}
 
-		localeDir := filepath.Join(metadataDir, locale)
-		name := readFileIfExists(filepath.Join(localeDir, "name.txt"))
-		subtitle := readFileIfExists(filepath.Join(localeDir, "subtitle.txt"))
+		loc := readFastlaneAppInfoDir(filepath.Join(metadataDir, locale), locale)
 
 		// Only include if at least one field has content
-		if name != "" || subtitle != "" {
-			localizations = append(localizations, AppInfoFastlaneLocalization{
-				Locale:   locale,
-				Name:     name,
-				Subtitle: subtitle,
-			})
+		if countAppInfoFields(loc) > 0 {
+			localizations = append(localizations, loc)
 		}
 	}
 
 	return localizations, nil
 }
 
+// readFastlaneAppInfoDir reads app info localization fields from a locale directory.
+func readFastlaneAppInfoDir(localeDir, locale string) AppInfoFastlaneLocalization {
+	return AppInfoFastlaneLocalization{
+		Locale:            locale,
+		Name:              readFileIfExists(filepath.Join(localeDir, "name.txt")),
+		Subtitle:          readFileIfExists(filepath.Join(localeDir, "subtitle.txt")),
+		PrivacyURL:        readFileIfExists(filepath.Join(localeDir, "privacy_url.txt")),
+		PrivacyPolicyText: readFileIfExists(filepath.Join(localeDir, "apple_tv_privacy_policy.txt")),
+	}
+}
+
+// countAppInfoFields counts the number of non-empty fields in an app info localization.
+func countAppInfoFields(loc AppInfoFastlaneLocalization) int {
+	count := 0
+	for _, value := range []string{loc.Name, loc.Subtitle, loc.PrivacyURL, loc.PrivacyPolicyText} {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
+
 // readFileIfExists reads a file's contents if it exists, returning empty string otherwise.
 func readFileIfExists(path string) string {
 	data, err := os.ReadFile(path)
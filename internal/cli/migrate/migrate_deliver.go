@@ -0,0 +1,541 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/assets"
+)
+
+const (
+	fastlaneReviewInformationDir = "review_information"
+	fastlaneTradeRepresentDir    = "trade_representative_contact_information"
+	fastlaneDefaultDir           = "default"
+	fastlaneCopyrightFile        = "copyright.txt"
+	fastlaneAppRatingConfigFile  = "app_rating_config.json"
+	fastlaneScreenshotsDir       = "screenshots"
+)
+
+// fastlaneCategoryFiles lists deliver category files; subcategories name their parent.
+var fastlaneCategoryFiles = []struct {
+	File   string
+	Parent string
+	Set    func(*FastlaneCategories, string)
+}{
+	{"primary_category.txt", "", func(c *FastlaneCategories, v string) { c.Primary = v }},
+	{"primary_first_sub_category.txt", "primary", func(c *FastlaneCategories, v string) { c.PrimarySubcategoryOne = v }},
+	{"primary_second_sub_category.txt", "primary", func(c *FastlaneCategories, v string) { c.PrimarySubcategoryTwo = v }},
+	{"secondary_category.txt", "", func(c *FastlaneCategories, v string) { c.Secondary = v }},
+	{"secondary_first_sub_category.txt", "secondary", func(c *FastlaneCategories, v string) { c.SecondarySubcategoryOne = v }},
+	{"secondary_second_sub_category.txt", "secondary", func(c *FastlaneCategories, v string) { c.SecondarySubcategoryTwo = v }},
+}
+
+// FastlaneCategories holds App Store category IDs from metadata/*category*.txt.
+type FastlaneCategories struct {
+	Primary                 string `json:"primary,omitempty"`
+	PrimarySubcategoryOne   string `json:"primarySubcategoryOne,omitempty"`
+	PrimarySubcategoryTwo   string `json:"primarySubcategoryTwo,omitempty"`
+	Secondary               string `json:"secondary,omitempty"`
+	SecondarySubcategoryOne string `json:"secondarySubcategoryOne,omitempty"`
+	SecondarySubcategoryTwo string `json:"secondarySubcategoryTwo,omitempty"`
+}
+
+// FastlaneReviewInformation holds App Review contact details from metadata/review_information.
+type FastlaneReviewInformation struct {
+	FirstName       string `json:"firstName,omitempty"`
+	LastName        string `json:"lastName,omitempty"`
+	PhoneNumber     string `json:"phoneNumber,omitempty"`
+	EmailAddress    string `json:"emailAddress,omitempty"`
+	DemoUser        string `json:"demoUser,omitempty"`
+	DemoPassword    string `json:"-"`
+	DemoPasswordSet bool   `json:"demoPasswordSet,omitempty"`
+	Notes           string `json:"notes,omitempty"`
+}
+
+// FastlaneAppMetadata holds deliver metadata stored outside the locale folders.
+type FastlaneAppMetadata struct {
+	Copyright         string                              `json:"copyright,omitempty"`
+	Categories        *FastlaneCategories                 `json:"categories,omitempty"`
+	ReviewInformation *FastlaneReviewInformation          `json:"reviewInformation,omitempty"`
+	AgeRating         *asc.AgeRatingDeclarationAttributes `json:"ageRating,omitempty"`
+	// Unsupported lists deliver files that App Store Connect's API cannot apply.
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+// FastlaneScreenshotSet groups screenshot files for one locale and display type.
+type FastlaneScreenshotSet struct {
+	Locale      string   `json:"locale"`
+	DisplayType string   `json:"displayType"`
+	Files       []string `json:"files"`
+}
+
+// ScreenshotUploadItem summarizes screenshots uploaded for a locale and display type.
+type ScreenshotUploadItem struct {
+	Locale      string `json:"locale"`
+	DisplayType string `json:"displayType"`
+	SetID       string `json:"setId"`
+	Files       int    `json:"files"`
+}
+
+// isFastlaneSpecialDir reports whether a metadata subdirectory is not a locale.
+func isFastlaneSpecialDir(name string) bool {
+	switch name {
+	case fastlaneReviewInformationDir, fastlaneTradeRepresentDir, fastlaneDefaultDir:
+		return true
+	}
+	return false
+}
+
+// readFastlaneAppMetadata reads copyright, categories, review information and
+// the age rating config. The rating config may live in metadata/ or next to it.
+func readFastlaneAppMetadata(fastlaneDir, metadataDir string) (*FastlaneAppMetadata, error) {
+	meta := &FastlaneAppMetadata{
+		Copyright: readFileIfExists(filepath.Join(metadataDir, fastlaneCopyrightFile)),
+	}
+
+	var categories FastlaneCategories
+	for _, entry := range fastlaneCategoryFiles {
+		value := normalizeFastlaneCategory(readFileIfExists(filepath.Join(metadataDir, entry.File)))
+		if value == "" {
+			continue
+		}
+		switch entry.Parent {
+		case "primary":
+			value = qualifySubcategory(categories.Primary, value)
+		case "secondary":
+			value = qualifySubcategory(categories.Secondary, value)
+		}
+		entry.Set(&categories, value)
+	}
+	if categories != (FastlaneCategories{}) {
+		meta.Categories = &categories
+	}
+
+	reviewDir := filepath.Join(metadataDir, fastlaneReviewInformationDir)
+	review := FastlaneReviewInformation{
+		FirstName:    readFileIfExists(filepath.Join(reviewDir, "first_name.txt")),
+		LastName:     readFileIfExists(filepath.Join(reviewDir, "last_name.txt")),
+		PhoneNumber:  readFileIfExists(filepath.Join(reviewDir, "phone_number.txt")),
+		EmailAddress: readFileIfExists(filepath.Join(reviewDir, "email_address.txt")),
+		DemoUser:     readFileIfExists(filepath.Join(reviewDir, "demo_user.txt")),
+		DemoPassword: readFileIfExists(filepath.Join(reviewDir, "demo_password.txt")),
+		Notes:        readFileIfExists(filepath.Join(reviewDir, "notes.txt")),
+	}
+	review.DemoPasswordSet = review.DemoPassword != ""
+	if review != (FastlaneReviewInformation{}) {
+		meta.ReviewInformation = &review
+	}
+
+	for _, path := range []string{
+		filepath.Join(metadataDir, fastlaneAppRatingConfigFile),
+		filepath.Join(fastlaneDir, fastlaneAppRatingConfigFile),
+	} {
+		rating, err := readAppRatingConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		if rating != nil {
+			meta.AgeRating = rating
+			break
+		}
+	}
+
+	if dirHasFiles(filepath.Join(metadataDir, fastlaneTradeRepresentDir)) {
+		meta.Unsupported = append(meta.Unsupported, filepath.Join("metadata", fastlaneTradeRepresentDir))
+	}
+
+	return meta, nil
+}
+
+// deliverAgeRatingKeys maps the legacy deliver app_rating_config.json keys to
+// App Store Connect age rating attribute names.
+var deliverAgeRatingKeys = map[string]string{
+	"CARTOON_FANTASY_VIOLENCE":                      "violenceCartoonOrFantasy",
+	"REALISTIC_VIOLENCE":                            "violenceRealistic",
+	"PROLONGED_GRAPHIC_SADISTIC_REALISTIC_VIOLENCE": "violenceRealisticProlongedGraphicOrSadistic",
+	"PROFANITY_CRUDE_HUMOR":                         "profanityOrCrudeHumor",
+	"MATURE_SUGGESTIVE":                             "matureOrSuggestiveThemes",
+	"HORROR":                                        "horrorOrFearThemes",
+	"MEDICAL_TREATMENT_INFO":                        "medicalOrTreatmentInformation",
+	"ALCOHOL_TOBACCO_DRUGS":                         "alcoholTobaccoOrDrugUseOrReferences",
+	"GAMBLING":                                      "gamblingSimulated",
+	"SEXUAL_CONTENT_NUDITY":                         "sexualContentOrNudity",
+	"GRAPHIC_SEXUAL_CONTENT_NUDITY":                 "sexualContentGraphicAndNudity",
+	"GAMBLING_CONTESTS":                             "contests",
+	"UNRESTRICTED_WEB_ACCESS":                       "unrestrictedWebAccess",
+}
+
+// deliverAgeRatingLevels maps legacy deliver rating levels to App Store
+// Connect values.
+var deliverAgeRatingLevels = []string{"NONE", "INFREQUENT_OR_MILD", "FREQUENT_OR_INTENSE"}
+
+// readAppRatingConfig parses an age rating config. It accepts both the legacy
+// deliver format ("CARTOON_FANTASY_VIOLENCE": 1) and App Store Connect
+// attribute names. It returns nil when the file does not exist.
+func readAppRatingConfig(path string) (*asc.AgeRatingDeclarationAttributes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	normalized := make(map[string]any, len(raw))
+	for key, value := range raw {
+		field, ok := deliverAgeRatingKeys[key]
+		if !ok {
+			normalized[key] = value
+			continue
+		}
+		var level int
+		if err := json.Unmarshal(value, &level); err != nil || level < 0 || level >= len(deliverAgeRatingLevels) {
+			return nil, fmt.Errorf("invalid %s: %s must be 0, 1 or 2", path, key)
+		}
+		if field == "unrestrictedWebAccess" {
+			if level > 1 {
+				return nil, fmt.Errorf("invalid %s: %s must be 0 or 1", path, key)
+			}
+			normalized[field] = level == 1
+			continue
+		}
+		normalized[field] = deliverAgeRatingLevels[level]
+	}
+
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	var attrs asc.AgeRatingDeclarationAttributes
+	if err := decoder.Decode(&attrs); err != nil {
+		return nil, fmt.Errorf("invalid %s (expected deliver keys such as \"CARTOON_FANTASY_VIOLENCE\" or App Store Connect age rating attributes such as \"violenceCartoonOrFantasy\"): %w", path, err)
+	}
+	return &attrs, nil
+}
+
+// normalizeFastlaneCategory converts deliver category values, including the
+// legacy "MZGenre.Business" form, to App Store Connect category IDs.
+func normalizeFastlaneCategory(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "MZGenre.")
+	value = strings.ReplaceAll(value, " ", "_")
+	return strings.ToUpper(value)
+}
+
+// qualifySubcategory prefixes subcategory IDs with their parent (ACTION -> GAMES_ACTION).
+func qualifySubcategory(parent, value string) string {
+	if parent == "" || strings.HasPrefix(value, parent+"_") {
+		return value
+	}
+	return parent + "_" + value
+}
+
+// applyFastlaneDefaults fills empty locale fields from metadata/default and
+// returns the app info localizations, adding locales that only have defaults.
+func applyFastlaneDefaults(metadataDir string, versionLocs []FastlaneLocalization, appInfoLocs []AppInfoFastlaneLocalization) []AppInfoFastlaneLocalization {
+	defaultDir := filepath.Join(metadataDir, fastlaneDefaultDir)
+	defaults := readFastlaneLocalizationDir(defaultDir, "")
+	appInfoDefaults := readFastlaneAppInfoDir(defaultDir, "")
+
+	fill := func(target *string, value string) {
+		if *target == "" {
+			*target = value
+		}
+	}
+	for i := range versionLocs {
+		loc := &versionLocs[i]
+		fill(&loc.Description, defaults.Description)
+		fill(&loc.Keywords, defaults.Keywords)
+		fill(&loc.WhatsNew, defaults.WhatsNew)
+		fill(&loc.PromotionalText, defaults.PromotionalText)
+		fill(&loc.SupportURL, defaults.SupportURL)
+		fill(&loc.MarketingURL, defaults.MarketingURL)
+	}
+
+	if countAppInfoFields(appInfoDefaults) == 0 {
+		return appInfoLocs
+	}
+	seen := make(map[string]bool, len(appInfoLocs))
+	for _, loc := range appInfoLocs {
+		seen[loc.Locale] = true
+	}
+	for _, loc := range versionLocs {
+		if !seen[loc.Locale] {
+			appInfoLocs = append(appInfoLocs, AppInfoFastlaneLocalization{Locale: loc.Locale})
+		}
+	}
+	for i := range appInfoLocs {
+		loc := &appInfoLocs[i]
+		fill(&loc.Name, appInfoDefaults.Name)
+		fill(&loc.Subtitle, appInfoDefaults.Subtitle)
+		fill(&loc.PrivacyURL, appInfoDefaults.PrivacyURL)
+		fill(&loc.PrivacyPolicyText, appInfoDefaults.PrivacyPolicyText)
+	}
+	return appInfoLocs
+}
+
+// readFastlaneScreenshots groups screenshots/<locale>/* by inferred display type,
+// keeping files in name order. A missing screenshots directory yields no sets.
+func readFastlaneScreenshots(screenshotsDir string) ([]FastlaneScreenshotSet, error) {
+	entries, err := os.ReadDir(screenshotsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read screenshots directory: %w", err)
+	}
+
+	var sets []FastlaneScreenshotSet
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		locale := entry.Name()
+		files, err := os.ReadDir(filepath.Join(screenshotsDir, locale))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read screenshots for %s: %w", locale, err)
+		}
+
+		index := make(map[string]int)
+		for _, file := range files {
+			if !file.Type().IsRegular() || strings.HasPrefix(file.Name(), ".") || !assets.IsScreenshotFile(file.Name()) {
+				continue
+			}
+			path := filepath.Join(screenshotsDir, locale, file.Name())
+			displayType, err := assets.InferScreenshotDisplayType(path)
+			if err != nil {
+				return nil, fmt.Errorf("screenshots/%s: %w", locale, err)
+			}
+			i, ok := index[displayType]
+			if !ok {
+				i = len(sets)
+				index[displayType] = i
+				sets = append(sets, FastlaneScreenshotSet{Locale: locale, DisplayType: displayType})
+			}
+			sets[i].Files = append(sets[i].Files, path)
+		}
+	}
+	return sets, nil
+}
+
+func dirHasFiles(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && readFileIfExists(filepath.Join(dir, entry.Name())) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFastlaneAppMetadata uploads copyright, review information, categories and
+// age rating. appInfoID may be empty when only version-level fields are present.
+func applyFastlaneAppMetadata(ctx context.Context, client *asc.Client, versionID, appInfoID string, meta *FastlaneAppMetadata) ([]string, error) {
+	var applied []string
+
+	if meta.Copyright != "" {
+		copyright := meta.Copyright
+		if _, err := client.UpdateAppStoreVersion(ctx, versionID, asc.AppStoreVersionUpdateAttributes{Copyright: &copyright}); err != nil {
+			return applied, fmt.Errorf("failed to update copyright: %w", err)
+		}
+		applied = append(applied, "copyright")
+	}
+
+	if meta.ReviewInformation != nil {
+		if err := applyFastlaneReviewInformation(ctx, client, versionID, meta.ReviewInformation); err != nil {
+			return applied, err
+		}
+		applied = append(applied, "reviewInformation")
+	}
+
+	if meta.Categories != nil {
+		c := meta.Categories
+		_, err := client.UpdateAppInfoCategoryRelationships(ctx, appInfoID, asc.AppInfoUpdateCategoriesRelationships{
+			PrimaryCategory:         asc.AppCategoryRelationship(c.Primary),
+			PrimarySubcategoryOne:   asc.AppCategoryRelationship(c.PrimarySubcategoryOne),
+			PrimarySubcategoryTwo:   asc.AppCategoryRelationship(c.PrimarySubcategoryTwo),
+			SecondaryCategory:       asc.AppCategoryRelationship(c.Secondary),
+			SecondarySubcategoryOne: asc.AppCategoryRelationship(c.SecondarySubcategoryOne),
+			SecondarySubcategoryTwo: asc.AppCategoryRelationship(c.SecondarySubcategoryTwo),
+		})
+		if err != nil {
+			return applied, fmt.Errorf("failed to update categories: %w", err)
+		}
+		applied = append(applied, "categories")
+	}
+
+	if meta.AgeRating != nil {
+		declaration, err := client.GetAgeRatingDeclarationForAppInfo(ctx, appInfoID)
+		if err != nil {
+			return applied, fmt.Errorf("failed to fetch age rating declaration: %w", err)
+		}
+		if _, err := client.UpdateAgeRatingDeclaration(ctx, declaration.Data.ID, *meta.AgeRating); err != nil {
+			return applied, fmt.Errorf("failed to update age rating declaration: %w", err)
+		}
+		applied = append(applied, "ageRating")
+	}
+
+	return applied, nil
+}
+
+func applyFastlaneReviewInformation(ctx context.Context, client *asc.Client, versionID string, info *FastlaneReviewInformation) error {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	demoRequired := info.DemoUser != "" || info.DemoPassword != ""
+	attrs := asc.AppStoreReviewDetailUpdateAttributes{
+		ContactFirstName:    optional(info.FirstName),
+		ContactLastName:     optional(info.LastName),
+		ContactPhone:        optional(info.PhoneNumber),
+		ContactEmail:        optional(info.EmailAddress),
+		DemoAccountName:     optional(info.DemoUser),
+		DemoAccountPassword: optional(info.DemoPassword),
+		DemoAccountRequired: &demoRequired,
+		Notes:               optional(info.Notes),
+	}
+
+	existing, err := client.GetAppStoreReviewDetailForVersion(ctx, versionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return fmt.Errorf("failed to fetch review details: %w", err)
+	}
+	if err == nil && existing.Data.ID != "" {
+		if _, err := client.UpdateAppStoreReviewDetail(ctx, existing.Data.ID, attrs); err != nil {
+			return fmt.Errorf("failed to update review details: %w", err)
+		}
+		return nil
+	}
+
+	createAttrs := asc.AppStoreReviewDetailCreateAttributes(attrs)
+	if _, err := client.CreateAppStoreReviewDetail(ctx, versionID, &createAttrs); err != nil {
+		return fmt.Errorf("failed to create review details: %w", err)
+	}
+	return nil
+}
+
+// exportFastlaneAppMetadata writes copyright, categories, review information and
+// the age rating config. It returns the number of files written.
+func exportFastlaneAppMetadata(ctx context.Context, client *asc.Client, versionID, appInfoID, metadataDir string) (int, error) {
+	files := 0
+
+	version, err := client.GetAppStoreVersion(ctx, versionID)
+	if err != nil {
+		return files, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	files += writeAndCount(filepath.Join(metadataDir, fastlaneCopyrightFile), version.Data.Attributes.Copyright)
+
+	review, err := client.GetAppStoreReviewDetailForVersion(ctx, versionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return files, fmt.Errorf("failed to fetch review details: %w", err)
+	}
+	if err == nil && review.Data.ID != "" {
+		reviewDir := filepath.Join(metadataDir, fastlaneReviewInformationDir)
+		if err := os.MkdirAll(reviewDir, 0o755); err != nil {
+			return files, fmt.Errorf("failed to create directory: %w", err)
+		}
+		attrs := review.Data.Attributes
+		files += writeAndCount(filepath.Join(reviewDir, "first_name.txt"), attrs.ContactFirstName)
+		files += writeAndCount(filepath.Join(reviewDir, "last_name.txt"), attrs.ContactLastName)
+		files += writeAndCount(filepath.Join(reviewDir, "phone_number.txt"), attrs.ContactPhone)
+		files += writeAndCount(filepath.Join(reviewDir, "email_address.txt"), attrs.ContactEmail)
+		files += writeAndCount(filepath.Join(reviewDir, "demo_user.txt"), attrs.DemoAccountName)
+		files += writeAndCount(filepath.Join(reviewDir, "demo_password.txt"), attrs.DemoAccountPassword)
+		files += writeAndCount(filepath.Join(reviewDir, "notes.txt"), attrs.Notes)
+	}
+
+	if appInfoID == "" {
+		return files, nil
+	}
+
+	fetchers := map[string]func(context.Context, string) (*asc.AppCategoryResponse, error){
+		"primary_category.txt":              client.GetAppInfoPrimaryCategory,
+		"primary_first_sub_category.txt":    client.GetAppInfoPrimarySubcategoryOne,
+		"primary_second_sub_category.txt":   client.GetAppInfoPrimarySubcategoryTwo,
+		"secondary_category.txt":            client.GetAppInfoSecondaryCategory,
+		"secondary_first_sub_category.txt":  client.GetAppInfoSecondarySubcategoryOne,
+		"secondary_second_sub_category.txt": client.GetAppInfoSecondarySubcategoryTwo,
+	}
+	for _, entry := range fastlaneCategoryFiles {
+		category, err := fetchers[entry.File](ctx, appInfoID)
+		if err != nil {
+			if asc.IsNotFound(err) {
+				continue
+			}
+			return files, fmt.Errorf("failed to fetch %s: %w", strings.TrimSuffix(entry.File, ".txt"), err)
+		}
+		files += writeAndCount(filepath.Join(metadataDir, entry.File), category.Data.ID)
+	}
+
+	declaration, err := client.GetAgeRatingDeclarationForAppInfo(ctx, appInfoID)
+	if err != nil && !asc.IsNotFound(err) {
+		return files, fmt.Errorf("failed to fetch age rating declaration: %w", err)
+	}
+	if err == nil && declaration.Data.Attributes != (asc.AgeRatingDeclarationAttributes{}) {
+		data, err := json.MarshalIndent(declaration.Data.Attributes, "", "  ")
+		if err != nil {
+			return files, fmt.Errorf("failed to encode age rating: %w", err)
+		}
+		files += writeAndCount(filepath.Join(metadataDir, fastlaneAppRatingConfigFile), string(data))
+	}
+
+	return files, nil
+}
+
+// ReadFastlaneLocalizationValues reads fastlane metadata (including
+// metadata/default) keyed by locale and App Store Connect field name, as used
+// by `asc localizations lint`.
+func ReadFastlaneLocalizationValues(fastlaneDir string) (map[string]map[string]string, error) {
+	metadataDir := filepath.Join(fastlaneDir, "metadata")
+	versionLocs, err := readFastlaneMetadata(metadataDir)
+	if err != nil {
+		return nil, err
+	}
+	appInfoLocs, err := readFastlaneAppInfoMetadata(metadataDir)
+	if err != nil {
+		return nil, err
+	}
+	appInfoLocs = applyFastlaneDefaults(metadataDir, versionLocs, appInfoLocs)
+
+	values := make(map[string]map[string]string)
+	set := func(locale, key, value string) {
+		if values[locale] == nil {
+			values[locale] = make(map[string]string)
+		}
+		if strings.TrimSpace(value) != "" {
+			values[locale][key] = value
+		}
+	}
+	for _, loc := range versionLocs {
+		set(loc.Locale, "description", loc.Description)
+		set(loc.Locale, "keywords", loc.Keywords)
+		set(loc.Locale, "whatsNew", loc.WhatsNew)
+		set(loc.Locale, "promotionalText", loc.PromotionalText)
+		set(loc.Locale, "supportUrl", loc.SupportURL)
+		set(loc.Locale, "marketingUrl", loc.MarketingURL)
+	}
+	for _, loc := range appInfoLocs {
+		set(loc.Locale, "name", loc.Name)
+		set(loc.Locale, "subtitle", loc.Subtitle)
+		set(loc.Locale, "privacyPolicyUrl", loc.PrivacyURL)
+		set(loc.Locale, "privacyPolicyText", loc.PrivacyPolicyText)
+	}
+	return values, nil
+}
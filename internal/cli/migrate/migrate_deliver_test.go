@@ -0,0 +1,216 @@
+package migrate
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFastlaneFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func writeFastlanePNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0xff
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	writeFastlaneFile(t, path, buf.String())
+}
+
+func TestReadFastlaneAppMetadata_FullTree(t *testing.T) {
+	fastlaneDir := t.TempDir()
+	metadataDir := filepath.Join(fastlaneDir, "metadata")
+
+	writeFastlaneFile(t, filepath.Join(metadataDir, "copyright.txt"), "2026 Example Inc.\n")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "primary_category.txt"), "MZGenre.Games")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "primary_first_sub_category.txt"), "MZGenre.Action")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "secondary_category.txt"), "PRODUCTIVITY")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "review_information", "first_name.txt"), "Jane")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "review_information", "email_address.txt"), "jane@example.com")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "review_information", "demo_password.txt"), "secret")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "trade_representative_contact_information", "city.txt"), "Berlin")
+	writeFastlaneFile(t, filepath.Join(fastlaneDir, "app_rating_config.json"), `{"violenceCartoonOrFantasy":"INFREQUENT_OR_MILD","gambling":false}`)
+
+	meta, err := readFastlaneAppMetadata(fastlaneDir, metadataDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.Copyright != "2026 Example Inc." {
+		t.Errorf("unexpected copyright %q", meta.Copyright)
+	}
+	if meta.Categories == nil || meta.Categories.Primary != "GAMES" || meta.Categories.PrimarySubcategoryOne != "GAMES_ACTION" || meta.Categories.Secondary != "PRODUCTIVITY" {
+		t.Errorf("unexpected categories %+v", meta.Categories)
+	}
+	if meta.ReviewInformation == nil || meta.ReviewInformation.FirstName != "Jane" || meta.ReviewInformation.DemoPassword != "secret" || !meta.ReviewInformation.DemoPasswordSet {
+		t.Errorf("unexpected review information %+v", meta.ReviewInformation)
+	}
+	if meta.AgeRating == nil || meta.AgeRating.ViolenceCartoonOrFantasy == nil || *meta.AgeRating.ViolenceCartoonOrFantasy != "INFREQUENT_OR_MILD" {
+		t.Errorf("unexpected age rating %+v", meta.AgeRating)
+	}
+	if len(meta.Unsupported) != 1 || !strings.Contains(meta.Unsupported[0], "trade_representative_contact_information") {
+		t.Errorf("expected trade representative info to be reported as unsupported, got %v", meta.Unsupported)
+	}
+}
+
+func TestReadFastlaneAppMetadata_Empty(t *testing.T) {
+	fastlaneDir := t.TempDir()
+	meta, err := readFastlaneAppMetadata(fastlaneDir, filepath.Join(fastlaneDir, "metadata"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Copyright != "" || meta.Categories != nil || meta.ReviewInformation != nil || meta.AgeRating != nil {
+		t.Errorf("expected empty metadata, got %+v", meta)
+	}
+}
+
+func TestReadAppRatingConfig_MapsDeliverKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app_rating_config.json")
+	writeFastlaneFile(t, path, `{"CARTOON_FANTASY_VIOLENCE": 1, "HORROR": 2, "GAMBLING": 0, "UNRESTRICTED_WEB_ACCESS": 1, "kidsAgeBand": "NINE_TO_ELEVEN"}`)
+
+	attrs, err := readAppRatingConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *attrs.ViolenceCartoonOrFantasy != "INFREQUENT_OR_MILD" || *attrs.HorrorOrFearThemes != "FREQUENT_OR_INTENSE" ||
+		*attrs.GamblingSimulated != "NONE" || !*attrs.UnrestrictedWebAccess || *attrs.KidsAgeBand != "NINE_TO_ELEVEN" {
+		t.Fatalf("unexpected attributes %+v", attrs)
+	}
+}
+
+func TestReadAppRatingConfig_RejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app_rating_config.json")
+	writeFastlaneFile(t, path, `{"NOT_A_RATING": 1}`)
+
+	if _, err := readAppRatingConfig(path); err == nil || !strings.Contains(err.Error(), "age rating attributes") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestReadFastlaneMetadata_SkipsTradeRepresentativeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFastlaneFile(t, filepath.Join(dir, "trade_representative_contact_information", "city.txt"), "Berlin")
+	writeFastlaneFile(t, filepath.Join(dir, "en-US", "description.txt"), "English description")
+
+	locs, err := readFastlaneMetadata(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(locs) != 1 || locs[0].Locale != "en-US" {
+		t.Fatalf("expected only en-US, got %+v", locs)
+	}
+}
+
+func TestReadFastlaneAppInfoMetadata_PrivacyFields(t *testing.T) {
+	dir := t.TempDir()
+	writeFastlaneFile(t, filepath.Join(dir, "en-US", "privacy_url.txt"), "https://example.com/privacy")
+	writeFastlaneFile(t, filepath.Join(dir, "en-US", "apple_tv_privacy_policy.txt"), "Policy text")
+
+	locs, err := readFastlaneAppInfoMetadata(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(locs) != 1 || locs[0].PrivacyURL != "https://example.com/privacy" || locs[0].PrivacyPolicyText != "Policy text" {
+		t.Fatalf("unexpected app info localizations %+v", locs)
+	}
+	if countAppInfoFields(locs[0]) != 2 {
+		t.Errorf("expected 2 fields, got %d", countAppInfoFields(locs[0]))
+	}
+}
+
+func TestApplyFastlaneDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeFastlaneFile(t, filepath.Join(dir, "default", "description.txt"), "Default description")
+	writeFastlaneFile(t, filepath.Join(dir, "default", "privacy_url.txt"), "https://example.com/privacy")
+
+	versionLocs := []FastlaneLocalization{
+		{Locale: "en-US", Description: "English description"},
+		{Locale: "de-DE"},
+	}
+	appInfoLocs := applyFastlaneDefaults(dir, versionLocs, []AppInfoFastlaneLocalization{{Locale: "en-US", Name: "App"}})
+
+	if versionLocs[0].Description != "English description" || versionLocs[1].Description != "Default description" {
+		t.Errorf("unexpected version localizations %+v", versionLocs)
+	}
+	if len(appInfoLocs) != 2 {
+		t.Fatalf("expected de-DE to gain an app info localization, got %+v", appInfoLocs)
+	}
+	for _, loc := range appInfoLocs {
+		if loc.PrivacyURL != "https://example.com/privacy" {
+			t.Errorf("expected default privacy URL for %s, got %q", loc.Locale, loc.PrivacyURL)
+		}
+	}
+}
+
+func TestReadFastlaneScreenshots_GroupsByDisplayType(t *testing.T) {
+	dir := t.TempDir()
+	writeFastlanePNG(t, filepath.Join(dir, "en-US", "01_home.png"), 1242, 2688)
+	writeFastlanePNG(t, filepath.Join(dir, "en-US", "02_detail.png"), 2688, 1242)
+	writeFastlanePNG(t, filepath.Join(dir, "en-US", "APP_IPAD_PRO_129_01_home.png"), 2048, 2732)
+	writeFastlaneFile(t, filepath.Join(dir, "en-US", "notes.txt"), "ignored")
+
+	sets, err := readFastlaneScreenshots(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 sets, got %+v", sets)
+	}
+	if sets[0].DisplayType != "APP_IPHONE_65" || len(sets[0].Files) != 2 {
+		t.Errorf("unexpected iPhone set %+v", sets[0])
+	}
+	if sets[1].DisplayType != "APP_IPAD_PRO_129" || len(sets[1].Files) != 1 {
+		t.Errorf("expected display type from file name, got %+v", sets[1])
+	}
+}
+
+func TestReadFastlaneScreenshots_MissingDirectory(t *testing.T) {
+	sets, err := readFastlaneScreenshots(filepath.Join(t.TempDir(), "screenshots"))
+	if err != nil || sets != nil {
+		t.Fatalf("expected no sets and no error, got %+v, %v", sets, err)
+	}
+}
+
+func TestReadFastlaneLocalizationValues(t *testing.T) {
+	fastlaneDir := t.TempDir()
+	metadataDir := filepath.Join(fastlaneDir, "metadata")
+
+	writeFastlaneFile(t, filepath.Join(metadataDir, "en-US", "description.txt"), "Hello")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "en-US", "release_notes.txt"), "Fixes")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "en-US", "name.txt"), "App")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "default", "keywords.txt"), "one,two")
+	writeFastlaneFile(t, filepath.Join(metadataDir, "de-DE", "privacy_url.txt"), "https://example.com/privacy")
+
+	values, err := ReadFastlaneLocalizationValues(fastlaneDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values["en-US"]["description"] != "Hello" || values["en-US"]["whatsNew"] != "Fixes" || values["en-US"]["name"] != "App" {
+		t.Errorf("unexpected en-US values %+v", values["en-US"])
+	}
+	if values["en-US"]["keywords"] != "one,two" {
+		t.Errorf("expected default keywords to apply, got %+v", values["en-US"])
+	}
+	if values["de-DE"]["privacyPolicyUrl"] != "https://example.com/privacy" {
+		t.Errorf("unexpected de-DE values %+v", values["de-DE"])
+	}
+	if _, ok := values["default"]; ok {
+		t.Error("expected default directory not to be reported as a locale")
+	}
+}
@@ -2,6 +2,7 @@ package migrate
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 )
@@ -64,6 +65,20 @@ func printMigrateImportResultMarkdown(result *MigrateImportResult) error {
 		}
 	}
 
+	if rows := migrateAppMetadataRows(result); len(rows) > 0 {
+		fmt.Println()
+		fmt.Println("### App Metadata")
+		fmt.Println()
+		asc.RenderMarkdown([]string{"Item", "Value", "Status"}, rows)
+	}
+
+	if len(result.Screenshots) > 0 {
+		fmt.Println()
+		fmt.Println("### Screenshots")
+		fmt.Println()
+		asc.RenderMarkdown([]string{"Locale", "Display Type", "Files", "Status"}, migrateScreenshotRows(result))
+	}
+
 	return nil
 }
 
@@ -119,9 +134,80 @@ func printMigrateImportResultTable(result *MigrateImportResult) error {
 		asc.RenderTable(headers, rows)
 	}
 
+	if rows := migrateAppMetadataRows(result); len(rows) > 0 {
+		fmt.Println()
+		fmt.Println("App Metadata:")
+		asc.RenderTable([]string{"Item", "Value", "Status"}, rows)
+	}
+
+	if len(result.Screenshots) > 0 {
+		fmt.Println()
+		fmt.Println("Screenshots:")
+		asc.RenderTable([]string{"Locale", "Display Type", "Files", "Status"}, migrateScreenshotRows(result))
+	}
+
 	return nil
 }
 
+// migrateAppMetadataRows lists app-level deliver metadata with its upload status.
+func migrateAppMetadataRows(result *MigrateImportResult) [][]string {
+	meta := result.AppMetadata
+	if meta == nil {
+		return nil
+	}
+	status := func(key string) string {
+		for _, applied := range result.Applied {
+			if applied == key {
+				return "uploaded"
+			}
+		}
+		return "found"
+	}
+
+	var rows [][]string
+	if meta.Copyright != "" {
+		rows = append(rows, []string{"copyright", meta.Copyright, status("copyright")})
+	}
+	if meta.Categories != nil {
+		value := meta.Categories.Primary
+		if meta.Categories.Secondary != "" {
+			value += ", " + meta.Categories.Secondary
+		}
+		rows = append(rows, []string{"categories", value, status("categories")})
+	}
+	if meta.ReviewInformation != nil {
+		info := meta.ReviewInformation
+		value := strings.TrimSpace(info.FirstName + " " + info.LastName)
+		if info.EmailAddress != "" {
+			value = strings.TrimSpace(value + " <" + info.EmailAddress + ">")
+		}
+		rows = append(rows, []string{"review information", value, status("reviewInformation")})
+	}
+	if meta.AgeRating != nil {
+		rows = append(rows, []string{"age rating", "app_rating_config.json", status("ageRating")})
+	}
+	for _, path := range meta.Unsupported {
+		rows = append(rows, []string{"unsupported", path, "skipped"})
+	}
+	return rows
+}
+
+// migrateScreenshotRows lists screenshot sets found locally with their upload status.
+func migrateScreenshotRows(result *MigrateImportResult) [][]string {
+	rows := make([][]string, 0, len(result.Screenshots))
+	for _, set := range result.Screenshots {
+		status := "found"
+		for _, uploaded := range result.ScreenshotsUploaded {
+			if uploaded.Locale == set.Locale && uploaded.DisplayType == set.DisplayType {
+				status = "uploaded"
+				break
+			}
+		}
+		rows = append(rows, []string{set.Locale, set.DisplayType, fmt.Sprintf("%d", len(set.Files)), status})
+	}
+	return rows
+}
+
 func printMigrateExportResultMarkdown(result *MigrateExportResult) error {
 	fmt.Printf("**Version ID:** %s\n\n", result.VersionID)
 	fmt.Printf("**Output Directory:** %s\n\n", result.OutputDir)
@@ -131,6 +217,9 @@ func printMigrateExportResultMarkdown(result *MigrateExportResult) error {
 		fmt.Printf("- %s\n", locale)
 	}
 	fmt.Printf("\n**Total Files:** %d\n", result.TotalFiles)
+	if result.Screenshots > 0 {
+		fmt.Printf("**Screenshots:** %d\n", result.Screenshots)
+	}
 	return nil
 }
 
@@ -144,6 +233,9 @@ func printMigrateExportResultTable(result *MigrateExportResult) error {
 	}
 	asc.RenderTable(headers, rows)
 	fmt.Printf("\nTotal Files: %d\n", result.TotalFiles)
+	if result.Screenshots > 0 {
+		fmt.Printf("Screenshots: %d\n", result.Screenshots)
+	}
 	return nil
 }
 
@@ -32,6 +32,8 @@ Examples:
   asc profiles create --name "Profile" --profile-type IOS_APP_DEVELOPMENT --bundle "BUNDLE_ID" --certificate "CERT_ID"
   asc profiles delete --id "PROFILE_ID" --confirm
   asc profiles download --id "PROFILE_ID" --output "./profile.mobileprovision"
+  asc profiles inspect "./profile.mobileprovision"
+  asc profiles refresh --bundle-id com.example.app --type IOS_APP_ADHOC --confirm
   asc profiles relationships bundle-id --id "PROFILE_ID"
   asc profiles relationships certificates --id "PROFILE_ID"
   asc profiles relationships devices --id "PROFILE_ID"`,
@@ -44,6 +46,8 @@ Examples:
 			ProfilesCreateCommand(),
 			ProfilesDeleteCommand(),
 			ProfilesDownloadCommand(),
+			ProfilesInspectCommand(),
+			ProfilesRefreshCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -0,0 +1,101 @@
+package profiles
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// ProfilesInspectCommand returns the profiles inspect subcommand.
+func ProfilesInspectCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+
+	offline := fs.Bool("offline", false, "Skip the App Store Connect certificate and device checks")
+	strict := fs.Bool("strict", false, "Exit non-zero when a profile has problems")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "inspect",
+		ShortUsage: "asc profiles inspect [flags] FILE...",
+		ShortHelp:  "Inspect local provisioning profiles.",
+		LongHelp: `Inspect local .mobileprovision files.
+
+Parses the signed envelope, verifies the signature chain against Apple's
+root certificates, and reports the app ID, team, entitlements, devices,
+expiry and embedded certificate fingerprints. Unless --offline is set, the
+embedded certificates and devices are checked against App Store Connect.
+
+Examples:
+  asc profiles inspect ./profile.mobileprovision
+  asc profiles inspect --output table ~/Library/MobileDevice/Provisioning\ Profiles/*.mobileprovision
+  asc profiles inspect --offline --strict ./profile.mobileprovision`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: at least one profile file is required")
+				return flag.ErrHelp
+			}
+
+			now := time.Now()
+			result := &asc.ProfileInspectResult{Profiles: make([]asc.InspectedProfile, 0, len(args))}
+			for _, path := range args {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("profiles inspect: %w", err)
+				}
+				profile, err := shared.ParseProvisioningProfile(data, now)
+				if err != nil {
+					return fmt.Errorf("profiles inspect: %s: %w", path, err)
+				}
+				profile.File = path
+				result.Profiles = append(result.Profiles, *profile)
+			}
+
+			if !*offline {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("profiles inspect: %w", err)
+				}
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				defer cancel()
+
+				for i := range result.Profiles {
+					profile := &result.Profiles[i]
+					if err := shared.CrossCheckCertificates(requestCtx, client, profile.Certificates); err != nil {
+						return fmt.Errorf("profiles inspect: %w", err)
+					}
+					if err := shared.CrossCheckProfileDevices(requestCtx, client, profile.Devices); err != nil {
+						return fmt.Errorf("profiles inspect: %w", err)
+					}
+				}
+			}
+
+			var withProblems []string
+			for i := range result.Profiles {
+				profile := &result.Profiles[i]
+				profile.Problems = shared.ProfileProblems(profile)
+				if len(profile.Problems) > 0 {
+					withProblems = append(withProblems, profile.File)
+				}
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if *strict && len(withProblems) > 0 {
+				return shared.NewReportedError(fmt.Errorf("profiles inspect: problems found in %s", strings.Join(withProblems, ", ")))
+			}
+			return nil
+		},
+	}
+}
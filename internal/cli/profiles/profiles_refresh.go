@@ -0,0 +1,354 @@
+package profiles
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	refreshActionRefreshed    = "refreshed"
+	refreshActionWouldRefresh = "would-refresh"
+	refreshActionUpToDate     = "up-to-date"
+	refreshActionFailed       = "failed"
+)
+
+// refreshDeviceFamilies maps profile types that carry devices to the family
+// of devices they can include.
+var refreshDeviceFamilies = map[string]string{
+	"IOS_APP_DEVELOPMENT":          "ios",
+	"IOS_APP_ADHOC":                "ios",
+	"TVOS_APP_DEVELOPMENT":         "tvos",
+	"TVOS_APP_ADHOC":               "tvos",
+	"MAC_APP_DEVELOPMENT":          "mac",
+	"MAC_CATALYST_APP_DEVELOPMENT": "mac",
+}
+
+// ProfilesRefreshCommand returns the profiles refresh subcommand.
+func ProfilesRefreshCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("refresh", flag.ExitOnError)
+
+	bundleID := fs.String("bundle-id", "", "Bundle identifier whose profiles to refresh (e.g. com.example.app)")
+	profileType := fs.String("type", "", "Profile type(s) to refresh, comma-separated (default: all development and ad hoc types)")
+	all := fs.Bool("all", false, "Refresh every development and ad hoc profile that is missing an enabled device")
+	allDevices := fs.Bool("all-devices", false, "Also include enabled devices of other classes on the profile's platform (e.g. Apple TVs in iOS profiles)")
+	outputDir := fs.String("output-dir", "./profiles", "Directory to download refreshed profiles into")
+	dryRun := fs.Bool("dry-run", false, "Report profiles that would be refreshed without changing them")
+	confirm := fs.Bool("confirm", false, "Confirm deleting and recreating profiles (required unless --dry-run)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "refresh",
+		ShortUsage: "asc profiles refresh (--bundle-id ID | --all) [flags]",
+		ShortHelp:  "Recreate development and ad hoc profiles with current devices.",
+		LongHelp: `Recreate development and ad hoc profiles with current devices.
+
+Profiles cannot be edited, so each affected profile is deleted and recreated
+with the same name, bundle ID and certificates, and the new file is downloaded
+into --output-dir. The new profile keeps its enabled devices and adds every
+enabled device of the profile's kind (iPhone, iPad and Apple Watch for iOS,
+Apple TV for tvOS, Mac for macOS); --all-devices also adds enabled devices of
+other classes on the same platform. Disabled devices are dropped.
+Profiles whose device set would not change are left alone. If a profile is
+deleted but cannot be recreated, its error names the asc profiles create
+command that rebuilds it.
+
+Examples:
+  asc profiles refresh --bundle-id com.example.app --type IOS_APP_ADHOC --dry-run
+  asc profiles refresh --bundle-id com.example.app --type IOS_APP_ADHOC --confirm
+  asc profiles refresh --bundle-id com.example.app --all-devices --confirm --output-dir ./signing
+  asc profiles refresh --all --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			bundleValue := strings.TrimSpace(*bundleID)
+			if bundleValue == "" && !*all {
+				fmt.Fprintln(os.Stderr, "Error: --bundle-id or --all is required")
+				return flag.ErrHelp
+			}
+			if bundleValue != "" && *all {
+				fmt.Fprintln(os.Stderr, "Error: --bundle-id and --all are mutually exclusive")
+				return flag.ErrHelp
+			}
+			types := shared.SplitCSV(strings.ToUpper(*profileType))
+			for _, value := range types {
+				if _, ok := refreshDeviceFamilies[value]; !ok {
+					fmt.Fprintf(os.Stderr, "Error: --type %s does not include devices\n", value)
+					return flag.ErrHelp
+				}
+			}
+			if len(types) == 0 {
+				for value := range refreshDeviceFamilies {
+					types = append(types, value)
+				}
+				sort.Strings(types)
+			}
+			if !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required to recreate profiles")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("profiles refresh: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			candidates, err := refreshCandidates(requestCtx, client, bundleValue, types)
+			if err != nil {
+				return fmt.Errorf("profiles refresh: %w", err)
+			}
+			devices, err := shared.ListAllDevices(requestCtx, client, asc.WithDevicesFilterStatuses([]string{string(asc.DeviceStatusEnabled)}))
+			if err != nil {
+				return fmt.Errorf("profiles refresh: %w", err)
+			}
+
+			result := &asc.ProfileRefreshResult{DryRun: *dryRun, Profiles: []asc.ProfileRefreshItem{}}
+			failed := 0
+			for _, candidate := range candidates {
+				item, err := refreshProfile(requestCtx, client, candidate, devices, *allDevices, *dryRun, strings.TrimSpace(*outputDir))
+				if err != nil {
+					item.Action = refreshActionFailed
+					item.Error = err.Error()
+					failed++
+				}
+				result.Profiles = append(result.Profiles, item)
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("profiles refresh: %d profile(s) failed to refresh", failed))
+			}
+			return nil
+		},
+	}
+}
+
+// refreshCandidate is a profile that may need refreshing, with its bundle ID.
+type refreshCandidate struct {
+	profile          asc.Resource[asc.ProfileAttributes]
+	bundleID         string
+	bundleIdentifier string
+}
+
+func refreshCandidates(ctx context.Context, client *asc.Client, bundleIdentifier string, types []string) ([]refreshCandidate, error) {
+	wanted := map[string]bool{}
+	for _, value := range types {
+		wanted[value] = true
+	}
+
+	var candidates []refreshCandidate
+	if bundleIdentifier != "" {
+		bundle, err := shared.FindBundleID(ctx, client, bundleIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		next := ""
+		for {
+			resp, err := client.GetBundleIDProfiles(ctx, bundle.ID,
+				asc.WithBundleIDProfilesLimit(200),
+				asc.WithBundleIDProfilesNextURL(next),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("fetch profiles: %w", err)
+			}
+			for _, item := range resp.Data {
+				if wanted[item.Attributes.ProfileType] {
+					candidates = append(candidates, refreshCandidate{profile: item, bundleID: bundle.ID, bundleIdentifier: bundle.Attributes.Identifier})
+				}
+			}
+			if strings.TrimSpace(resp.Links.Next) == "" {
+				return candidates, nil
+			}
+			next = resp.Links.Next
+		}
+	}
+
+	next := ""
+	for {
+		resp, err := client.GetProfiles(ctx,
+			asc.WithProfilesTypes(types),
+			asc.WithProfilesInclude([]string{"bundleId"}),
+			asc.WithProfilesLimit(200),
+			asc.WithProfilesNextURL(next),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetch profiles: %w", err)
+		}
+		identifiers, err := shared.IncludedBundleIdentifiers(resp.Included)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Data {
+			if !wanted[item.Attributes.ProfileType] {
+				continue
+			}
+			bundleID := shared.ProfileBundleIDRelationship(item.Relationships)
+			if bundleID == "" {
+				continue
+			}
+			candidates = append(candidates, refreshCandidate{profile: item, bundleID: bundleID, bundleIdentifier: identifiers[bundleID]})
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return candidates, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// deviceMatchesPlatform reports whether device can be added to profiles of
+// family regardless of its device class. tvOS profiles only take Apple TVs.
+func deviceMatchesPlatform(device asc.DeviceAttributes, family string) bool {
+	switch family {
+	case "tvos":
+		return device.DeviceClass == asc.DeviceClassAppleTV
+	case "mac":
+		return device.Platform == asc.DevicePlatformMacOS
+	default:
+		return device.Platform == asc.DevicePlatformIOS
+	}
+}
+
+func deviceMatchesFamily(device asc.DeviceAttributes, family string) bool {
+	switch family {
+	case "tvos":
+		return device.DeviceClass == asc.DeviceClassAppleTV
+	case "mac":
+		return device.Platform == asc.DevicePlatformMacOS
+	default:
+		return device.Platform == asc.DevicePlatformIOS && device.DeviceClass != asc.DeviceClassAppleTV
+	}
+}
+
+// refreshDeviceIDs returns the device IDs the refreshed profile should
+// include: its current enabled devices plus every enabled device of the
+// profile's family. With allDevices, enabled devices of any class on the
+// profile's platform are included too.
+func refreshDeviceIDs(profile asc.ProfileAttributes, currentIDs []string, enabled []asc.Resource[asc.DeviceAttributes], allDevices bool) []string {
+	family := refreshDeviceFamilies[profile.ProfileType]
+	current := map[string]bool{}
+	for _, id := range currentIDs {
+		current[id] = true
+	}
+
+	var ids []string
+	for _, device := range enabled {
+		include := current[device.ID] || deviceMatchesFamily(device.Attributes, family)
+		if !include && allDevices {
+			include = deviceMatchesPlatform(device.Attributes, family)
+		}
+		if include {
+			ids = append(ids, device.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// refreshProfile recreates one profile if its device set changes. Profile
+// names are unique, so the old profile is deleted before the new one is
+// created; a failure in between is reported with the profiles create command
+// that rebuilds it.
+func refreshProfile(ctx context.Context, client *asc.Client, candidate refreshCandidate, enabled []asc.Resource[asc.DeviceAttributes], allDevices, dryRun bool, outputDir string) (asc.ProfileRefreshItem, error) {
+	attrs := candidate.profile.Attributes
+	item := asc.ProfileRefreshItem{
+		Name:         attrs.Name,
+		ProfileType:  attrs.ProfileType,
+		BundleID:     candidate.bundleIdentifier,
+		OldProfileID: candidate.profile.ID,
+	}
+
+	certIDs, err := shared.ListProfileLinkageIDs(ctx, candidate.profile.ID, client.GetProfileCertificatesRelationships)
+	if err != nil {
+		return item, fmt.Errorf("fetch profile certificates: %w", err)
+	}
+	item.CertificateIDs = certIDs
+	currentIDs, err := shared.ListProfileLinkageIDs(ctx, candidate.profile.ID, client.GetProfileDevicesRelationships)
+	if err != nil {
+		return item, fmt.Errorf("fetch profile devices: %w", err)
+	}
+	deviceIDs := refreshDeviceIDs(attrs, currentIDs, enabled, allDevices)
+	item.DeviceIDs = deviceIDs
+	item.DeviceCount = len(deviceIDs)
+
+	labels := map[string]string{}
+	for _, device := range enabled {
+		labels[device.ID] = fmt.Sprintf("%s (%s)", device.Attributes.Name, device.Attributes.UDID)
+	}
+	label := func(id string) string {
+		if value, ok := labels[id]; ok {
+			return value
+		}
+		return id
+	}
+	current := map[string]bool{}
+	for _, id := range currentIDs {
+		current[id] = true
+	}
+	next := map[string]bool{}
+	for _, id := range deviceIDs {
+		next[id] = true
+		if !current[id] {
+			item.AddedDevices = append(item.AddedDevices, label(id))
+		}
+	}
+	for _, id := range currentIDs {
+		if !next[id] {
+			item.RemovedDevices = append(item.RemovedDevices, label(id))
+		}
+	}
+
+	if len(item.AddedDevices) == 0 && len(item.RemovedDevices) == 0 && attrs.ProfileState != asc.ProfileStateInvalid {
+		item.Action = refreshActionUpToDate
+		return item, nil
+	}
+	if len(certIDs) == 0 {
+		return item, fmt.Errorf("profile has no valid certificates")
+	}
+	if len(deviceIDs) == 0 {
+		return item, fmt.Errorf("no enabled devices for %s", attrs.ProfileType)
+	}
+	if dryRun {
+		item.Action = refreshActionWouldRefresh
+		return item, nil
+	}
+
+	if err := client.DeleteProfile(ctx, candidate.profile.ID); err != nil {
+		return item, fmt.Errorf("delete profile: %w", err)
+	}
+	created, err := client.CreateProfile(ctx, asc.ProfileCreateAttributes{
+		Name:        attrs.Name,
+		ProfileType: attrs.ProfileType,
+	}, candidate.bundleID, certIDs, deviceIDs)
+	if err != nil {
+		return item, fmt.Errorf("deleted profile %s but failed to recreate it; recreate it with: asc profiles create --name %q --profile-type %s --bundle %s --certificate %s --device %s: %w",
+			candidate.profile.ID, attrs.Name, attrs.ProfileType, candidate.bundleID, strings.Join(certIDs, ","), strings.Join(deviceIDs, ","), err)
+	}
+	item.NewProfileID = created.Data.ID
+	item.Action = refreshActionRefreshed
+
+	content, err := decodeProfileContent(created.Data.Attributes.ProfileContent)
+	if err != nil {
+		return item, fmt.Errorf("decode profile content: %w", err)
+	}
+	path := filepath.Join(outputDir, shared.ProfileFileName(attrs.Name, created.Data.ID))
+	if err := shared.ReplaceProfileFile(path, content); err != nil {
+		return item, fmt.Errorf("write profile: %w", err)
+	}
+	item.File = path
+	return item, nil
+}
@@ -3,7 +3,10 @@ package profiles
 import (
 	"context"
 	"flag"
+	"strings"
 	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 )
 
 func TestProfilesGetCommand_MissingID(t *testing.T) {
@@ -167,3 +170,58 @@ func TestExtractProfileIDFromNextURL_Invalid(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestProfilesRefreshCommand_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing bundle id and all", args: []string{"--confirm"}},
+		{name: "bundle id with all", args: []string{"--bundle-id", "com.example.app", "--all", "--confirm"}},
+		{name: "type without devices", args: []string{"--bundle-id", "com.example.app", "--type", "IOS_APP_STORE", "--confirm"}},
+		{name: "missing confirm", args: []string{"--bundle-id", "com.example.app"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := ProfilesRefreshCommand()
+			if err := cmd.FlagSet.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			if err := cmd.Exec(context.Background(), []string{}); err != flag.ErrHelp {
+				t.Fatalf("expected flag.ErrHelp, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRefreshDeviceIDs(t *testing.T) {
+	device := func(id string, class asc.DeviceClass, added string) asc.Resource[asc.DeviceAttributes] {
+		platform := asc.DevicePlatformIOS
+		if class == asc.DeviceClassMac {
+			platform = asc.DevicePlatformMacOS
+		}
+		return asc.Resource[asc.DeviceAttributes]{ID: id, Attributes: asc.DeviceAttributes{Platform: platform, DeviceClass: class, AddedDate: added}}
+	}
+	enabled := []asc.Resource[asc.DeviceAttributes]{
+		device("OLD_IN_PROFILE", asc.DeviceClassIPhone, "2025-01-01T00:00:00.000+0000"),
+		device("OLD_EXCLUDED", asc.DeviceClassIPad, "2025-01-01T00:00:00.000+0000"),
+		device("NEW_PHONE", asc.DeviceClassIPhone, "2026-02-01T00:00:00.000+0000"),
+		device("NEW_TV", asc.DeviceClassAppleTV, "2026-02-01T00:00:00.000+0000"),
+		device("NEW_MAC", asc.DeviceClassMac, "2026-02-01T00:00:00.000+0000"),
+	}
+	profile := asc.ProfileAttributes{ProfileType: "IOS_APP_ADHOC", CreatedDate: "2026-01-01T00:00:00.000+0000"}
+	current := []string{"OLD_IN_PROFILE", "DISABLED"}
+
+	got := strings.Join(refreshDeviceIDs(profile, current, enabled, false), ",")
+	if got != "NEW_PHONE,OLD_EXCLUDED,OLD_IN_PROFILE" {
+		t.Fatalf("refreshDeviceIDs() = %s", got)
+	}
+	got = strings.Join(refreshDeviceIDs(profile, current, enabled, true), ",")
+	if got != "NEW_PHONE,NEW_TV,OLD_EXCLUDED,OLD_IN_PROFILE" {
+		t.Fatalf("refreshDeviceIDs(allDevices) = %s", got)
+	}
+	profile.ProfileType = "TVOS_APP_DEVELOPMENT"
+	if got := strings.Join(refreshDeviceIDs(profile, nil, enabled, true), ","); got != "NEW_TV" {
+		t.Fatalf("refreshDeviceIDs(tvOS) = %s", got)
+	}
+}
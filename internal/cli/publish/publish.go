@@ -57,7 +57,9 @@ func PublishTestFlightCommand() *ffcli.Command {
 	pollInterval := fs.Duration("poll-interval", shared.PublishDefaultPollInterval, "Polling interval for --wait and build discovery")
 	timeout := fs.Duration("timeout", 0, "Override upload + processing timeout (e.g., 30m)")
 	testNotes := fs.String("test-notes", "", "What to Test notes for the build")
-	locale := fs.String("locale", "", "Locale for --test-notes (e.g., en-US)")
+	testNotesFromGit := fs.String("test-notes-from-git", "", "Generate What to Test notes from a git revision range (e.g., v1.2.0..HEAD)")
+	gitNotes := shared.BindGitNotesFlags(fs)
+	locale := fs.String("locale", "", "Locale(s) for test notes, comma-separated (e.g., en-US,de-DE)")
 	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -77,7 +79,8 @@ Examples:
   asc publish testflight --app "123" --ipa app.ipa --group "GROUP_ID"
   asc publish testflight --app "123" --ipa app.ipa --group "External Testers"
   asc publish testflight --app "123" --ipa app.ipa --group "G1,G2" --wait --notify
-  asc publish testflight --app "123" --ipa app.ipa --group "GROUP_ID" --test-notes "Test instructions" --locale "en-US" --wait`,
+  asc publish testflight --app "123" --ipa app.ipa --group "GROUP_ID" --test-notes "Test instructions" --locale "en-US" --wait
+  asc publish testflight --app "123" --ipa app.ipa --group "GROUP_ID" --test-notes-from-git "v1.2.0..HEAD" --git-type feat,fix --locale "en-US,de-DE"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -98,20 +101,36 @@ Examples:
 			}
 
 			testNotesValue := strings.TrimSpace(*testNotes)
-			localeValue := strings.TrimSpace(*locale)
-			if testNotesValue != "" && localeValue == "" {
+			gitRange := strings.TrimSpace(*testNotesFromGit)
+			locales := shared.SplitCSV(*locale)
+			if testNotesValue != "" && gitRange != "" {
+				fmt.Fprintln(os.Stderr, "Error: --test-notes and --test-notes-from-git are mutually exclusive")
+				return flag.ErrHelp
+			}
+			if testNotesValue != "" && len(locales) == 0 {
 				fmt.Fprintln(os.Stderr, "Error: --locale is required with --test-notes")
 				return flag.ErrHelp
 			}
-			if testNotesValue == "" && localeValue != "" {
+			if gitRange != "" && len(locales) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --locale is required with --test-notes-from-git")
+				return flag.ErrHelp
+			}
+			if testNotesValue == "" && gitRange == "" && len(locales) > 0 {
 				fmt.Fprintln(os.Stderr, "Error: --test-notes is required with --locale")
 				return flag.ErrHelp
 			}
-			if testNotesValue != "" {
-				if err := shared.ValidateBuildLocalizationLocale(localeValue); err != nil {
+			if len(locales) > 0 {
+				if err := shared.ValidateBuildLocalizationLocales(locales); err != nil {
 					return fmt.Errorf("publish testflight: %w", err)
 				}
 			}
+			if gitRange != "" {
+				generated, err := shared.GenerateGitNotes(ctx, gitNotes.Options(gitRange))
+				if err != nil {
+					return fmt.Errorf("publish testflight: %w", err)
+				}
+				testNotesValue = generated.Text
+			}
 
 			if *pollInterval <= 0 {
 				return fmt.Errorf("publish testflight: --poll-interval must be greater than 0")
@@ -165,8 +184,10 @@ Examples:
 			}
 
 			if testNotesValue != "" {
-				if _, err := shared.UpsertBetaBuildLocalization(requestCtx, client, buildResp.Data.ID, localeValue, testNotesValue); err != nil {
-					return fmt.Errorf("publish testflight: %w", err)
+				for _, localeValue := range locales {
+					if _, err := shared.UpsertBetaBuildLocalization(requestCtx, client, buildResp.Data.ID, localeValue, testNotesValue); err != nil {
+						return fmt.Errorf("publish testflight: %w", err)
+					}
 				}
 			}
 
@@ -225,40 +225,9 @@ Examples:
 				return fmt.Errorf("review attachments-upload: %w", err)
 			}
 
-			requestCtx, cancel := shared.ContextWithTimeout(ctx)
-			defer cancel()
-
-			resp, err := client.CreateAppStoreReviewAttachment(requestCtx, reviewDetailValue, filepath.Base(pathValue), info.Size())
-			if err != nil {
-				return fmt.Errorf("review attachments-upload: failed to create: %w", err)
-			}
-			if resp == nil || len(resp.Data.Attributes.UploadOperations) == 0 {
-				return fmt.Errorf("review attachments-upload: no upload operations returned")
-			}
-
-			uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
-			err = asc.ExecuteUploadOperations(uploadCtx, pathValue, resp.Data.Attributes.UploadOperations)
-			uploadCancel()
-			if err != nil {
-				return fmt.Errorf("review attachments-upload: upload failed: %w", err)
-			}
-
-			checksum, err := asc.ComputeFileChecksum(pathValue, asc.ChecksumAlgorithmMD5)
-			if err != nil {
-				return fmt.Errorf("review attachments-upload: checksum failed: %w", err)
-			}
-
-			uploaded := true
-			updateAttrs := asc.AppStoreReviewAttachmentUpdateAttributes{
-				SourceFileChecksum: &checksum.Hash,
-				Uploaded:           &uploaded,
-			}
-
-			commitCtx, commitCancel := shared.ContextWithUploadTimeout(ctx)
-			commitResp, err := client.UpdateAppStoreReviewAttachment(commitCtx, resp.Data.ID, updateAttrs)
-			commitCancel()
+			commitResp, err := UploadReviewAttachment(ctx, client, reviewDetailValue, pathValue, info.Size())
 			if err != nil {
-				return fmt.Errorf("review attachments-upload: failed to commit upload: %w", err)
+				return fmt.Errorf("review attachments-upload: %w", err)
 			}
 
 			return shared.PrintOutput(commitResp, *output, *pretty)
@@ -398,3 +367,43 @@ func reviewDetailFieldList() []string {
 		"appStoreReviewAttachments",
 	}
 }
+
+// UploadReviewAttachment creates an attachment on a review detail, uploads the
+// file and commits it.
+func UploadReviewAttachment(ctx context.Context, client *asc.Client, reviewDetailID, path string, size int64) (*asc.AppStoreReviewAttachmentResponse, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	resp, err := client.CreateAppStoreReviewAttachment(requestCtx, reviewDetailID, filepath.Base(path), size)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create: %w", err)
+	}
+	if resp == nil || len(resp.Data.Attributes.UploadOperations) == 0 {
+		return nil, fmt.Errorf("no upload operations returned")
+	}
+
+	uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
+	err = asc.ExecuteUploadOperations(uploadCtx, path, resp.Data.Attributes.UploadOperations)
+	uploadCancel()
+	if err != nil {
+		return nil, fmt.Errorf("upload failed: %w", err)
+	}
+
+	checksum, err := asc.ComputeFileChecksum(path, asc.ChecksumAlgorithmMD5)
+	if err != nil {
+		return nil, fmt.Errorf("checksum failed: %w", err)
+	}
+
+	uploaded := true
+	updateAttrs := asc.AppStoreReviewAttachmentUpdateAttributes{
+		SourceFileChecksum: &checksum.Hash,
+		Uploaded:           &uploaded,
+	}
+
+	commitCtx, commitCancel := shared.ContextWithUploadTimeout(ctx)
+	commitResp, err := client.UpdateAppStoreReviewAttachment(commitCtx, resp.Data.ID, updateAttrs)
+	commitCancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit upload: %w", err)
+	}
+	return commitResp, nil
+}
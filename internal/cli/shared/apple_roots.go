@@ -0,0 +1,77 @@
+package shared
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// appleRootFingerprints pins Apple's root certificate authorities by the
+// SHA-256 fingerprint of their DER encoding. Provisioning profiles embed the
+// full chain up to the root, so pinning avoids shipping the certificates.
+var appleRootFingerprints = map[string]string{
+	"b0b1730ecbc7ff4505142c49f1295e6eda6bcaed7e2c68c5be91b5a11001f024": "Apple Root CA",
+	"c2b9b042dd57830e7d117dac55ac8ae19407d38e41d88f3215bc3a890444a050": "Apple Root CA - G2",
+	"63343abfb89a6a03ebb57e9b3f5fa7be7c4f5c756f3017b3a8c488c3653e9179": "Apple Root CA - G3",
+}
+
+// CertificateFingerprints returns the hex SHA-1 and SHA-256 fingerprints of
+// a certificate, uppercase and unseparated as shown by Keychain Access.
+func CertificateFingerprints(cert *x509.Certificate) (string, string) {
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	return strings.ToUpper(hex.EncodeToString(sha1Sum[:])), strings.ToUpper(hex.EncodeToString(sha256Sum[:]))
+}
+
+// VerifyAppleCertificateChain verifies leaf up to a pinned Apple root using
+// candidates (for example the certificates embedded in a CMS envelope) as
+// intermediates and roots. It returns the verified chain, leaf first.
+func VerifyAppleCertificateChain(leaf *x509.Certificate, candidates []*x509.Certificate, now time.Time) ([]*x509.Certificate, error) {
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	hasRoot := false
+	for _, cert := range candidates {
+		_, fingerprint := CertificateFingerprints(cert)
+		if _, ok := appleRootFingerprints[strings.ToLower(fingerprint)]; ok {
+			roots.AddCert(cert)
+			hasRoot = true
+			continue
+		}
+		if cert != leaf {
+			intermediates.AddCert(cert)
+		}
+	}
+	if !hasRoot {
+		return nil, errors.New("no Apple root certificate found")
+	}
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify certificate chain: %w", err)
+	}
+	return chains[0], nil
+}
+
+// VerifyAppleDeviceCertificate verifies that leaf is a device identity issued
+// by one of Apple's device certificate authorities, such as Apple iPhone
+// Device CA, and chains to a pinned Apple root. Other certificates under
+// Apple's roots, like developer certificates, are rejected.
+func VerifyAppleDeviceCertificate(leaf *x509.Certificate, candidates []*x509.Certificate, now time.Time) error {
+	chain, err := VerifyAppleCertificateChain(leaf, candidates, now)
+	if err != nil {
+		return err
+	}
+	if len(chain) < 3 || !strings.HasSuffix(chain[1].Subject.CommonName, "Device CA") {
+		return fmt.Errorf("certificate %q was not issued by an Apple device CA", leaf.Subject.CommonName)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+)
+
+const berMaxDepth = 64
+
+var errBERTruncated = errors.New("ber: truncated input")
+
+// berToDER re-encodes BER input (indefinite lengths, constructed OCTET
+// STRINGs) as DER so encoding/asn1 can parse it. DER input is returned
+// unchanged. CMS envelopes from Apple (provisioning profiles) and .p12 files
+// exported by keychains commonly use BER.
+func berToDER(data []byte) ([]byte, error) {
+	der, rest, err := berConvert(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("ber: %d trailing bytes", len(rest))
+	}
+	return der, nil
+}
+
+// berConvert converts a single TLV and returns its DER encoding and the
+// remaining input.
+func berConvert(data []byte, depth int) ([]byte, []byte, error) {
+	if depth > berMaxDepth {
+		return nil, nil, errors.New("ber: nesting too deep")
+	}
+	if len(data) < 2 {
+		return nil, nil, errBERTruncated
+	}
+
+	offset := 1
+	if data[0]&0x1f == 0x1f {
+		for {
+			if offset >= len(data) {
+				return nil, nil, errBERTruncated
+			}
+			b := data[offset]
+			offset++
+			if b&0x80 == 0 {
+				break
+			}
+		}
+	}
+	identifier := data[:offset]
+	constructed := data[0]&0x20 != 0
+
+	if offset >= len(data) {
+		return nil, nil, errBERTruncated
+	}
+	lengthByte := data[offset]
+	offset++
+
+	var children [][]byte
+	var content, rest []byte
+	switch {
+	case lengthByte == 0x80:
+		if !constructed {
+			return nil, nil, errors.New("ber: indefinite length on primitive value")
+		}
+		remaining := data[offset:]
+		for {
+			if len(remaining) < 2 {
+				return nil, nil, errBERTruncated
+			}
+			if remaining[0] == 0 && remaining[1] == 0 {
+				rest = remaining[2:]
+				break
+			}
+			child, next, err := berConvert(remaining, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			children = append(children, child)
+			remaining = next
+		}
+	default:
+		length := int(lengthByte)
+		if lengthByte&0x80 != 0 {
+			count := int(lengthByte & 0x7f)
+			if count > 4 || offset+count > len(data) {
+				return nil, nil, errors.New("ber: invalid length")
+			}
+			length = 0
+			for _, b := range data[offset : offset+count] {
+				length = length<<8 | int(b)
+			}
+			offset += count
+		}
+		if length < 0 || offset+length > len(data) {
+			return nil, nil, errBERTruncated
+		}
+		content = data[offset : offset+length]
+		rest = data[offset+length:]
+		if constructed {
+			for remaining := content; len(remaining) > 0; {
+				child, next, err := berConvert(remaining, depth+1)
+				if err != nil {
+					return nil, nil, err
+				}
+				children = append(children, child)
+				remaining = next
+			}
+		}
+	}
+
+	if !constructed {
+		return berEncode(identifier, content), rest, nil
+	}
+
+	// A constructed OCTET STRING is the concatenation of its segments.
+	if len(identifier) == 1 && identifier[0] == 0x24 {
+		var octets []byte
+		for _, child := range children {
+			if child[0] != 0x04 {
+				return nil, nil, errors.New("ber: invalid OCTET STRING segment")
+			}
+			_, segment, err := berContent(child)
+			if err != nil {
+				return nil, nil, err
+			}
+			octets = append(octets, segment...)
+		}
+		return berEncode([]byte{0x04}, octets), rest, nil
+	}
+
+	var joined []byte
+	for _, child := range children {
+		joined = append(joined, child...)
+	}
+	return berEncode(identifier, joined), rest, nil
+}
+
+// berContent splits a DER TLV into its header and content.
+func berContent(der []byte) ([]byte, []byte, error) {
+	offset := 1
+	if der[0]&0x1f == 0x1f {
+		for offset < len(der) && der[offset]&0x80 != 0 {
+			offset++
+		}
+		offset++
+	}
+	if offset >= len(der) {
+		return nil, nil, errBERTruncated
+	}
+	if der[offset]&0x80 != 0 {
+		offset += int(der[offset] & 0x7f)
+	}
+	offset++
+	if offset > len(der) {
+		return nil, nil, errBERTruncated
+	}
+	return der[:offset], der[offset:], nil
+}
+
+func berEncode(identifier, content []byte) []byte {
+	out := append([]byte(nil), identifier...)
+	length := len(content)
+	switch {
+	case length < 0x80:
+		out = append(out, byte(length))
+	default:
+		var encoded []byte
+		for l := length; l > 0; l >>= 8 {
+			encoded = append([]byte{byte(l)}, encoded...)
+		}
+		out = append(out, 0x80|byte(len(encoded)))
+		out = append(out, encoded...)
+	}
+	return append(out, content...)
+}
@@ -0,0 +1,524 @@
+package shared
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// Beta feedback export formats.
+const (
+	BetaFeedbackDigestMarkdown = "markdown"
+	BetaFeedbackDigestHTML     = "html"
+	BetaFeedbackIssuesGitHub   = "github"
+	BetaFeedbackIssuesJira     = "jira"
+	BetaFeedbackIssuesNone     = "none"
+)
+
+var unsafePathCharsRegex = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ValidateBetaFeedbackExportFormats checks the --digest and --issues values.
+func ValidateBetaFeedbackExportFormats(digest, issues string) error {
+	switch digest {
+	case BetaFeedbackDigestMarkdown, BetaFeedbackDigestHTML:
+	default:
+		return fmt.Errorf("--digest must be %s or %s", BetaFeedbackDigestMarkdown, BetaFeedbackDigestHTML)
+	}
+	switch issues {
+	case BetaFeedbackIssuesGitHub, BetaFeedbackIssuesJira, BetaFeedbackIssuesNone:
+	default:
+		return fmt.Errorf("--issues must be %s, %s or %s", BetaFeedbackIssuesGitHub, BetaFeedbackIssuesJira, BetaFeedbackIssuesNone)
+	}
+	return nil
+}
+
+// BetaFeedbackBuild is the build a submission was sent from.
+type BetaFeedbackBuild struct {
+	Number  string
+	Version string
+}
+
+// BetaFeedbackBuildResolver maps build IDs to build and version numbers,
+// fetching each pre-release version once.
+type BetaFeedbackBuildResolver struct {
+	client *asc.Client
+	builds map[string]BetaFeedbackBuild
+}
+
+// NewBetaFeedbackBuildResolver returns a resolver backed by client.
+func NewBetaFeedbackBuildResolver(client *asc.Client) *BetaFeedbackBuildResolver {
+	return &BetaFeedbackBuildResolver{client: client, builds: map[string]BetaFeedbackBuild{}}
+}
+
+// AddIncluded records builds from a list response's included resources.
+func (r *BetaFeedbackBuildResolver) AddIncluded(ctx context.Context, included json.RawMessage) error {
+	if len(included) == 0 {
+		return nil
+	}
+	var resources []struct {
+		Type       string              `json:"type"`
+		ID         string              `json:"id"`
+		Attributes asc.BuildAttributes `json:"attributes"`
+	}
+	if err := json.Unmarshal(included, &resources); err != nil {
+		return fmt.Errorf("parse included builds: %w", err)
+	}
+	for _, resource := range resources {
+		if resource.Type != string(asc.ResourceTypeBuilds) {
+			continue
+		}
+		if _, ok := r.builds[resource.ID]; ok {
+			continue
+		}
+		build := BetaFeedbackBuild{Number: resource.Attributes.Version}
+		if version, err := r.client.GetBuildPreReleaseVersion(ctx, resource.ID); err == nil {
+			build.Version = version.Data.Attributes.Version
+		}
+		r.builds[resource.ID] = build
+	}
+	return nil
+}
+
+// Apply fills the build fields of an item from its relationships.
+func (r *BetaFeedbackBuildResolver) Apply(item *asc.BetaFeedbackExportItem, relationships json.RawMessage) {
+	if len(relationships) == 0 {
+		return
+	}
+	var links struct {
+		Build struct {
+			Data *asc.ResourceData `json:"data"`
+		} `json:"build"`
+	}
+	if err := json.Unmarshal(relationships, &links); err != nil || links.Build.Data == nil {
+		return
+	}
+	item.BuildID = links.Build.Data.ID
+	build := r.builds[item.BuildID]
+	item.BuildNumber = build.Number
+	item.Version = build.Version
+}
+
+// BetaFeedbackItemDir returns the attachment directory of an item, relative
+// to the export directory: one directory per build, one per submission.
+func BetaFeedbackItemDir(item asc.BetaFeedbackExportItem) string {
+	build := "unknown-build"
+	switch {
+	case item.Version != "" && item.BuildNumber != "":
+		build = item.Version + "-" + item.BuildNumber
+	case item.BuildNumber != "":
+		build = item.BuildNumber
+	case item.BuildID != "":
+		build = item.BuildID
+	}
+	return path.Join(safePathSegment(build), safePathSegment(item.ID))
+}
+
+func safePathSegment(value string) string {
+	value = strings.Trim(unsafePathCharsRegex.ReplaceAllString(value, "_"), "._")
+	if value == "" {
+		return "_"
+	}
+	return value
+}
+
+// WriteBetaFeedbackAttachment writes an attachment below dir unless it was
+// already exported. It reports whether the file was written.
+func WriteBetaFeedbackAttachment(dir, relPath string, open func() (io.ReadCloser, error)) (bool, error) {
+	target := filepath.Join(dir, filepath.FromSlash(relPath))
+	if _, err := os.Lstat(target); err == nil {
+		return false, nil
+	}
+	reader, err := open()
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+	if _, err := WriteStreamToFile(target, reader); err != nil {
+		_ = os.Remove(target)
+		return false, err
+	}
+	return true, nil
+}
+
+// screenshotExtensions maps image content types to file extensions.
+// Screenshots of any other type are saved with unknownScreenshotExtension.
+var screenshotExtensions = []struct {
+	contentType string
+	ext         string
+}{
+	{"image/png", ".png"},
+	{"image/jpeg", ".jpg"},
+	{"image/heic", ".heic"},
+	{"image/webp", ".webp"},
+	{"image/gif", ".gif"},
+}
+
+const unknownScreenshotExtension = ".bin"
+
+// WriteBetaFeedbackScreenshot writes a screenshot below dir as relBase plus an
+// extension derived from its content type, sniffing the data when the server
+// does not name an image type. A screenshot already exported under relBase is
+// kept. It returns the relative path and whether the file was written.
+func WriteBetaFeedbackScreenshot(dir, relBase string, open func() (io.ReadCloser, string, error)) (string, bool, error) {
+	existing := make([]string, 0, len(screenshotExtensions)+1)
+	for _, known := range screenshotExtensions {
+		existing = append(existing, known.ext)
+	}
+	for _, ext := range append(existing, unknownScreenshotExtension) {
+		relPath := relBase + ext
+		if _, err := os.Lstat(filepath.Join(dir, filepath.FromSlash(relPath))); err == nil {
+			return relPath, false, nil
+		}
+	}
+	reader, contentType, err := open()
+	if err != nil {
+		return "", false, err
+	}
+	defer reader.Close()
+
+	buffered := bufio.NewReader(reader)
+	ext := screenshotExtension(contentType)
+	if ext == "" {
+		head, _ := buffered.Peek(512)
+		ext = screenshotExtension(http.DetectContentType(head))
+	}
+	if ext == "" {
+		ext = unknownScreenshotExtension
+	}
+	relPath := relBase + ext
+	target := filepath.Join(dir, filepath.FromSlash(relPath))
+	if _, err := WriteStreamToFile(target, buffered); err != nil {
+		_ = os.Remove(target)
+		return "", false, err
+	}
+	return relPath, true, nil
+}
+
+func screenshotExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	for _, known := range screenshotExtensions {
+		if known.contentType == mediaType {
+			return known.ext
+		}
+	}
+	return ""
+}
+
+// FinishBetaFeedbackExport summarizes builds and writes the digest, issue
+// file and manifest into the export directory.
+func FinishBetaFeedbackExport(result *asc.BetaFeedbackExportResult, digest, issues string) error {
+	sort.SliceStable(result.Items, func(i, j int) bool {
+		return result.Items[i].CreatedDate > result.Items[j].CreatedDate
+	})
+	result.Count = len(result.Items)
+	result.Builds = betaFeedbackBuilds(result.Items)
+
+	var (
+		digestName string
+		digestData []byte
+		err        error
+	)
+	if digest == BetaFeedbackDigestHTML {
+		digestName = "digest.html"
+		digestData, err = renderBetaFeedbackHTML(result)
+	} else {
+		digestName = "digest.md"
+		digestData = []byte(renderBetaFeedbackMarkdown(result))
+	}
+	if err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(filepath.Join(result.Dir, digestName), digestData, 0o644); err != nil {
+		return fmt.Errorf("failed to write digest: %w", err)
+	}
+	result.Digest = filepath.Join(result.Dir, digestName)
+
+	switch issues {
+	case BetaFeedbackIssuesGitHub:
+		data, err := renderBetaFeedbackGitHubIssues(result)
+		if err != nil {
+			return err
+		}
+		result.Issues = filepath.Join(result.Dir, "issues.json")
+		if err := WriteFileAtomic(result.Issues, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write issues: %w", err)
+		}
+	case BetaFeedbackIssuesJira:
+		data, err := renderBetaFeedbackJiraCSV(result)
+		if err != nil {
+			return err
+		}
+		result.Issues = filepath.Join(result.Dir, "issues.csv")
+		if err := WriteFileAtomic(result.Issues, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write issues: %w", err)
+		}
+	}
+
+	result.Manifest = filepath.Join(result.Dir, result.Kind+".json")
+	manifest, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(result.Manifest, append(manifest, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+func betaFeedbackBuilds(items []asc.BetaFeedbackExportItem) []asc.BetaFeedbackExportBuild {
+	var builds []asc.BetaFeedbackExportBuild
+	index := map[string]int{}
+	for _, item := range items {
+		i, ok := index[item.BuildID]
+		if !ok {
+			i = len(builds)
+			index[item.BuildID] = i
+			builds = append(builds, asc.BetaFeedbackExportBuild{
+				BuildID:     item.BuildID,
+				Version:     item.Version,
+				BuildNumber: item.BuildNumber,
+			})
+		}
+		builds[i].Count++
+	}
+	return builds
+}
+
+func betaFeedbackBuildLabel(version, number string) string {
+	switch {
+	case version != "" && number != "":
+		return version + " (" + number + ")"
+	case number != "":
+		return "build " + number
+	case version != "":
+		return version
+	default:
+		return "Unknown build"
+	}
+}
+
+func betaFeedbackKindLabel(kind string) string {
+	if kind == "crashes" {
+		return "crash"
+	}
+	return "feedback"
+}
+
+func isImageFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".heic", ".webp":
+		return true
+	}
+	return false
+}
+
+// betaFeedbackItemMarkdown renders one submission. With linkFiles, files are
+// linked relative to the export directory; issue bodies are read away from
+// the export, so they list the exported paths instead.
+func betaFeedbackItemMarkdown(item asc.BetaFeedbackExportItem, linkFiles bool) string {
+	var b strings.Builder
+	if comment := strings.TrimSpace(item.Comment); comment != "" {
+		for _, line := range strings.Split(comment, "\n") {
+			b.WriteString("> " + line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "- Submitted: %s\n", item.CreatedDate)
+	if item.Email != "" {
+		fmt.Fprintf(&b, "- Tester: %s\n", item.Email)
+	}
+	fmt.Fprintf(&b, "- Build: %s\n", betaFeedbackBuildLabel(item.Version, item.BuildNumber))
+	device := strings.TrimSpace(strings.Join([]string{item.DeviceModel, item.AppPlatform, item.OSVersion}, " "))
+	if device != "" {
+		fmt.Fprintf(&b, "- Device: %s\n", strings.Join(strings.Fields(device), " "))
+	}
+	fmt.Fprintf(&b, "- Submission: %s\n", item.ID)
+	for _, file := range item.Files {
+		if !linkFiles {
+			fmt.Fprintf(&b, "- Attachment: `%s` (in the exported directory)\n", file)
+			continue
+		}
+		if isImageFile(file) {
+			fmt.Fprintf(&b, "\n![%s](%s)\n", path.Base(file), file)
+		} else {
+			fmt.Fprintf(&b, "- Attachment: [%s](%s)\n", path.Base(file), file)
+		}
+	}
+	for _, message := range item.Errors {
+		fmt.Fprintf(&b, "- Error: %s\n", message)
+	}
+	return b.String()
+}
+
+func renderBetaFeedbackMarkdown(result *asc.BetaFeedbackExportResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# TestFlight %s digest\n\n", betaFeedbackKindLabel(result.Kind))
+	fmt.Fprintf(&b, "App %s, %d submissions since %s.\n", result.AppID, result.Count, result.Since)
+	for _, build := range result.Builds {
+		fmt.Fprintf(&b, "\n## %s\n", betaFeedbackBuildLabel(build.Version, build.BuildNumber))
+		for _, item := range result.Items {
+			if item.BuildID != build.BuildID {
+				continue
+			}
+			heading := item.CreatedDate
+			if item.Email != "" {
+				heading += " - " + item.Email
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n%s", heading, betaFeedbackItemMarkdown(item, true))
+		}
+	}
+	return b.String()
+}
+
+var betaFeedbackHTMLTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"buildLabel": betaFeedbackBuildLabel,
+	"isImage":    isImageFile,
+	"base":       path.Base,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>TestFlight {{.Label}} digest</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2em; }
+.item { border-top: 1px solid #ddd; padding: 1em 0; }
+.comment { white-space: pre-wrap; font-size: 1.1em; }
+img { max-height: 480px; margin: 0.5em 0.5em 0 0; border: 1px solid #ccc; }
+dt { font-weight: bold; float: left; width: 8em; }
+</style>
+</head>
+<body>
+<h1>TestFlight {{.Label}} digest</h1>
+<p>App {{.Result.AppID}}, {{.Result.Count}} submissions since {{.Result.Since}}.</p>
+{{range .Groups}}<h2>{{buildLabel .Build.Version .Build.BuildNumber}}</h2>
+{{range .Items}}<div class="item" id="{{.ID}}">
+{{if .Comment}}<p class="comment">{{.Comment}}</p>{{end}}
+<dl>
+<dt>Submitted</dt><dd>{{.CreatedDate}}</dd>
+{{if .Email}}<dt>Tester</dt><dd>{{.Email}}</dd>{{end}}
+<dt>Device</dt><dd>{{.DeviceModel}} {{.AppPlatform}} {{.OSVersion}}</dd>
+<dt>Submission</dt><dd>{{.ID}}</dd>
+</dl>
+{{range .Files}}{{if isImage .}}<a href="{{.}}"><img src="{{.}}" alt="{{base .}}"></a>{{else}}<p><a href="{{.}}">{{base .}}</a></p>{{end}}
+{{end}}{{range .Errors}}<p>Error: {{.}}</p>
+{{end}}</div>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func renderBetaFeedbackHTML(result *asc.BetaFeedbackExportResult) ([]byte, error) {
+	type group struct {
+		Build asc.BetaFeedbackExportBuild
+		Items []asc.BetaFeedbackExportItem
+	}
+	data := struct {
+		Label  string
+		Result *asc.BetaFeedbackExportResult
+		Groups []group
+	}{Label: betaFeedbackKindLabel(result.Kind), Result: result}
+	for _, build := range result.Builds {
+		entry := group{Build: build}
+		for _, item := range result.Items {
+			if item.BuildID == build.BuildID {
+				entry.Items = append(entry.Items, item)
+			}
+		}
+		data.Groups = append(data.Groups, entry)
+	}
+	var buf bytes.Buffer
+	if err := betaFeedbackHTMLTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func betaFeedbackIssueTitle(kind string, item asc.BetaFeedbackExportItem) string {
+	title := "TestFlight " + betaFeedbackKindLabel(kind) + " in " + betaFeedbackBuildLabel(item.Version, item.BuildNumber)
+	comment := strings.Join(strings.Fields(item.Comment), " ")
+	if comment == "" {
+		return title
+	}
+	if runes := []rune(comment); len(runes) > 80 {
+		comment = string(runes[:77]) + "..."
+	}
+	return title + ": " + comment
+}
+
+func renderBetaFeedbackGitHubIssues(result *asc.BetaFeedbackExportResult) ([]byte, error) {
+	type issue struct {
+		Title  string   `json:"title"`
+		Body   string   `json:"body"`
+		Labels []string `json:"labels"`
+	}
+	issues := make([]issue, 0, len(result.Items))
+	for _, item := range result.Items {
+		issues = append(issues, issue{
+			Title:  betaFeedbackIssueTitle(result.Kind, item),
+			Body:   betaFeedbackItemMarkdown(item, false),
+			Labels: []string{"testflight", betaFeedbackKindLabel(result.Kind)},
+		})
+	}
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func renderBetaFeedbackJiraCSV(result *asc.BetaFeedbackExportResult) ([]byte, error) {
+	issueType := "Task"
+	if result.Kind == "crashes" {
+		issueType = "Bug"
+	}
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"Summary", "Description", "Issue Type", "Labels", "Labels"}); err != nil {
+		return nil, err
+	}
+	for _, item := range result.Items {
+		record := []string{
+			betaFeedbackIssueTitle(result.Kind, item),
+			betaFeedbackItemMarkdown(item, false),
+			issueType,
+			"testflight",
+			betaFeedbackKindLabel(result.Kind),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BetaFeedbackExportFailure returns a reported error when attachments failed
+// to download, after the manifest and output have been written.
+func BetaFeedbackExportFailure(result *asc.BetaFeedbackExportResult) error {
+	if result.Failed == 0 {
+		return nil
+	}
+	return NewReportedError(errors.New(strconv.Itoa(result.Failed) + " attachments failed to download"))
+}
@@ -0,0 +1,60 @@
+package shared
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestBetaFeedbackItemDir(t *testing.T) {
+	tests := []struct {
+		item asc.BetaFeedbackExportItem
+		want string
+	}{
+		{asc.BetaFeedbackExportItem{ID: "fb-1", Version: "1.2", BuildNumber: "42"}, "1.2-42/fb-1"},
+		{asc.BetaFeedbackExportItem{ID: "fb-1", BuildID: "build-1"}, "build-1/fb-1"},
+		{asc.BetaFeedbackExportItem{ID: "../etc", BuildNumber: "4/2"}, "4_2/etc"},
+		{asc.BetaFeedbackExportItem{ID: "fb-1"}, "unknown-build/fb-1"},
+	}
+	for _, test := range tests {
+		if got := BetaFeedbackItemDir(test.item); got != test.want {
+			t.Fatalf("BetaFeedbackItemDir(%+v) = %q, want %q", test.item, got, test.want)
+		}
+	}
+}
+
+func TestWriteBetaFeedbackScreenshot(t *testing.T) {
+	dir := t.TempDir()
+	pngData := "\x89PNG\r\n\x1a\nrest"
+	open := func() (io.ReadCloser, string, error) {
+		return io.NopCloser(strings.NewReader(pngData)), "application/octet-stream", nil
+	}
+
+	relPath, written, err := WriteBetaFeedbackScreenshot(dir, "build/fb-1/screenshot-1", open)
+	if err != nil || !written || relPath != "build/fb-1/screenshot-1.png" {
+		t.Fatalf("WriteBetaFeedbackScreenshot() = %q, %t, %v", relPath, written, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "build", "fb-1", "screenshot-1.png"))
+	if err != nil || string(data) != pngData {
+		t.Fatalf("unexpected screenshot %q (%v)", data, err)
+	}
+
+	relPath, written, err = WriteBetaFeedbackScreenshot(dir, "build/fb-1/screenshot-1", func() (io.ReadCloser, string, error) {
+		t.Fatal("expected existing screenshot not to be downloaded again")
+		return nil, "", nil
+	})
+	if err != nil || written || relPath != "build/fb-1/screenshot-1.png" {
+		t.Fatalf("expected existing screenshot to be kept, got %q, %t, %v", relPath, written, err)
+	}
+
+	relPath, _, err = WriteBetaFeedbackScreenshot(dir, "build/fb-1/screenshot-2", func() (io.ReadCloser, string, error) {
+		return io.NopCloser(strings.NewReader("data")), "image/jpeg; charset=binary", nil
+	})
+	if err != nil || relPath != "build/fb-1/screenshot-2.jpg" {
+		t.Fatalf("expected jpeg extension, got %q (%v)", relPath, err)
+	}
+}
@@ -0,0 +1,318 @@
+package shared
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var (
+	oidCMSSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidCMSData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidCMSContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidCMSMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidCMSSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidRSAEncryption    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256  = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidSHA256           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+)
+
+// SignedData is a parsed CMS (PKCS#7) SignedData envelope with attached
+// content, such as a .mobileprovision file.
+type SignedData struct {
+	Content      []byte
+	Certificates []*x509.Certificate
+	signers      []cmsSignerInfo
+}
+
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo cmsEncapContentInfo
+	Certificates     asn1.RawValue   `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue   `asn1:"optional,tag:1"`
+	SignerInfos      []cmsSignerInfo `asn1:"set"`
+}
+
+type cmsEncapContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type cmsSignerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type cmsIssuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// ParseSignedData parses a BER or DER encoded CMS SignedData envelope.
+func ParseSignedData(data []byte) (*SignedData, error) {
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	var info cmsContentInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	if !info.ContentType.Equal(oidCMSSignedData) {
+		return nil, fmt.Errorf("cms: content type %s is not signedData", info.ContentType)
+	}
+	var signed cmsSignedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signed); err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	if len(signed.EncapContentInfo.Content) == 0 {
+		return nil, errors.New("cms: envelope has no attached content")
+	}
+
+	result := &SignedData{Content: signed.EncapContentInfo.Content, signers: signed.SignerInfos}
+	if len(signed.Certificates.Bytes) > 0 {
+		certs, err := x509.ParseCertificates(signed.Certificates.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cms: %w", err)
+		}
+		result.Certificates = certs
+	}
+	return result, nil
+}
+
+// Verify checks every signer's signature over the content and returns the
+// signing certificates. It does not verify the certificate chain.
+func (s *SignedData) Verify() ([]*x509.Certificate, error) {
+	if len(s.signers) == 0 {
+		return nil, errors.New("cms: no signers")
+	}
+	var signers []*x509.Certificate
+	for _, signer := range s.signers {
+		cert, err := s.signerCertificate(signer)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := cmsHash(signer.DigestAlgorithm.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		signed := s.Content
+		if len(signer.SignedAttrs.Bytes) > 0 {
+			// Signed attributes are signed as a SET OF, not as the
+			// implicitly tagged field they are encoded as.
+			signed = append([]byte{0x31}, signer.SignedAttrs.FullBytes[1:]...)
+			digest, err := cmsMessageDigest(signed)
+			if err != nil {
+				return nil, err
+			}
+			h := hash.New()
+			h.Write(s.Content)
+			if !bytes.Equal(h.Sum(nil), digest) {
+				return nil, errors.New("cms: content digest does not match")
+			}
+		}
+		h := hash.New()
+		h.Write(signed)
+		if err := cmsVerifySignature(cert.PublicKey, hash, h.Sum(nil), signer.Signature); err != nil {
+			return nil, err
+		}
+		signers = append(signers, cert)
+	}
+	return signers, nil
+}
+
+func (s *SignedData) signerCertificate(signer cmsSignerInfo) (*x509.Certificate, error) {
+	if signer.SID.Class == asn1.ClassContextSpecific && signer.SID.Tag == 0 {
+		for _, cert := range s.Certificates {
+			if bytes.Equal(cert.SubjectKeyId, signer.SID.Bytes) {
+				return cert, nil
+			}
+		}
+		return nil, errors.New("cms: signer certificate not found")
+	}
+	var sid cmsIssuerAndSerial
+	if _, err := asn1.Unmarshal(signer.SID.FullBytes, &sid); err != nil {
+		return nil, fmt.Errorf("cms: signer identifier: %w", err)
+	}
+	for _, cert := range s.Certificates {
+		if cert.SerialNumber.Cmp(sid.SerialNumber) == 0 && bytes.Equal(cert.RawIssuer, sid.Issuer.FullBytes) {
+			return cert, nil
+		}
+	}
+	return nil, errors.New("cms: signer certificate not found")
+}
+
+func cmsMessageDigest(signedAttrs []byte) ([]byte, error) {
+	var attrs []cmsAttribute
+	if _, err := asn1.UnmarshalWithParams(signedAttrs, &attrs, "set"); err != nil {
+		return nil, fmt.Errorf("cms: signed attributes: %w", err)
+	}
+	for _, attr := range attrs {
+		if attr.Type.Equal(oidCMSMessageDigest) {
+			var digest []byte
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &digest); err != nil {
+				return nil, fmt.Errorf("cms: message digest: %w", err)
+			}
+			return digest, nil
+		}
+	}
+	return nil, errors.New("cms: message digest attribute missing")
+}
+
+func cmsHash(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	switch {
+	case oid.Equal(oidSHA1):
+		return crypto.SHA1, nil
+	case oid.Equal(oidSHA256):
+		return crypto.SHA256, nil
+	case oid.Equal(oidSHA384):
+		return crypto.SHA384, nil
+	case oid.Equal(oidSHA512):
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("cms: unsupported digest algorithm %s", oid)
+	}
+}
+
+func cmsVerifySignature(publicKey any, hash crypto.Hash, digest, signature []byte) error {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+			return fmt.Errorf("cms: signature verification failed: %w", err)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("cms: signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("cms: unsupported signer key type %T", publicKey)
+	}
+}
+
+// SignData wraps content in a DER encoded CMS SignedData envelope, signed
+// with SHA-256 by key and carrying cert and any intermediates. This is the
+// format of a signed .mobileconfig.
+func SignData(content []byte, cert *x509.Certificate, key crypto.Signer, intermediates []*x509.Certificate) ([]byte, error) {
+	var signatureAlgorithm pkix.AlgorithmIdentifier
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}
+	case *ecdsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+	default:
+		return nil, fmt.Errorf("cms: unsupported signer key type %T", key.Public())
+	}
+
+	h := crypto.SHA256.New()
+	h.Write(content)
+	signingTime := time.Now().UTC()
+	attrs, err := cmsEncodeSignedAttributes(
+		cmsAttributeValue{oidCMSContentType, oidCMSData},
+		cmsAttributeValue{oidCMSSigningTime, signingTime},
+		cmsAttributeValue{oidCMSMessageDigest, h.Sum(nil)},
+	)
+	if err != nil {
+		return nil, err
+	}
+	h = crypto.SHA256.New()
+	h.Write(attrs)
+	signature, err := key.Sign(rand.Reader, h.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("cms: sign: %w", err)
+	}
+
+	sid, err := asn1.Marshal(cmsIssuerAndSerial{Issuer: asn1.RawValue{FullBytes: cert.RawIssuer}, SerialNumber: cert.SerialNumber})
+	if err != nil {
+		return nil, fmt.Errorf("cms: signer identifier: %w", err)
+	}
+	var rawCerts []byte
+	for _, c := range append([]*x509.Certificate{cert}, intermediates...) {
+		rawCerts = append(rawCerts, c.Raw...)
+	}
+	// Signed attributes are encoded as an implicitly tagged field.
+	signedAttrs := append([]byte{0xa0}, attrs[1:]...)
+	digestAlgorithm := pkix.AlgorithmIdentifier{Algorithm: oidSHA256}
+	signed, err := asn1.Marshal(cmsSignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlgorithm},
+		EncapContentInfo: cmsEncapContentInfo{ContentType: oidCMSData, Content: content},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: rawCerts},
+		SignerInfos: []cmsSignerInfo{{
+			Version:            1,
+			SID:                asn1.RawValue{FullBytes: sid},
+			DigestAlgorithm:    digestAlgorithm,
+			SignedAttrs:        asn1.RawValue{FullBytes: signedAttrs},
+			SignatureAlgorithm: signatureAlgorithm,
+			Signature:          signature,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	return asn1.Marshal(cmsContentInfo{
+		ContentType: oidCMSSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signed},
+	})
+}
+
+type cmsAttributeValue struct {
+	oid   asn1.ObjectIdentifier
+	value any
+}
+
+// cmsEncodeSignedAttributes encodes attributes as a DER SET OF, which sorts
+// its elements by their encoding.
+func cmsEncodeSignedAttributes(values ...cmsAttributeValue) ([]byte, error) {
+	encoded := make([][]byte, 0, len(values))
+	for _, attr := range values {
+		value, err := asn1.Marshal(attr.value)
+		if err != nil {
+			return nil, fmt.Errorf("cms: attribute %s: %w", attr.oid, err)
+		}
+		set := asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: value}
+		setBytes, err := asn1.Marshal(set)
+		if err != nil {
+			return nil, err
+		}
+		der, err := asn1.Marshal(cmsAttribute{Type: attr.oid, Values: asn1.RawValue{FullBytes: setBytes}})
+		if err != nil {
+			return nil, fmt.Errorf("cms: attribute %s: %w", attr.oid, err)
+		}
+		encoded = append(encoded, der)
+	}
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: bytes.Join(encoded, nil)})
+}
@@ -0,0 +1,303 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBERToDER(t *testing.T) {
+	// SEQUENCE (indefinite) { constructed OCTET STRING (indefinite) { "ab", "c" }, INTEGER 5 }
+	ber := []byte{0x30, 0x80, 0x24, 0x80, 0x04, 0x02, 'a', 'b', 0x04, 0x01, 'c', 0x00, 0x00, 0x02, 0x01, 0x05, 0x00, 0x00}
+	der, err := berToDER(ber)
+	if err != nil {
+		t.Fatalf("berToDER() error: %v", err)
+	}
+	want := []byte{0x30, 0x08, 0x04, 0x03, 'a', 'b', 'c', 0x02, 0x01, 0x05}
+	if !bytes.Equal(der, want) {
+		t.Fatalf("berToDER() = % x, want % x", der, want)
+	}
+
+	// DER input is returned unchanged.
+	if again, err := berToDER(want); err != nil || !bytes.Equal(again, want) {
+		t.Fatalf("expected DER to round-trip, got % x (%v)", again, err)
+	}
+	if _, err := berToDER([]byte{0x30, 0x80, 0x02, 0x01}); err == nil {
+		t.Fatal("expected truncated input to fail")
+	}
+}
+
+// testCertificateChain creates a root, an intermediate and a leaf.
+func testCertificateChain(t *testing.T) (root, intermediate, leaf *x509.Certificate, leafKey *rsa.PrivateKey) {
+	t.Helper()
+	return testCertificateChainIssuedBy(t, "Test Intermediate CA")
+}
+
+// testCertificateChainIssuedBy builds a root, an intermediate named
+// intermediateName and a leaf issued by it.
+func testCertificateChainIssuedBy(t *testing.T, intermediateName string) (root, intermediate, leaf *x509.Certificate, leafKey *rsa.PrivateKey) {
+	t.Helper()
+	issue := func(template, parent *x509.Certificate, key, parentKey *rsa.PrivateKey) *x509.Certificate {
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	newKey := func() *rsa.PrivateKey {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+	notBefore, notAfter := time.Now().Add(-time.Hour), time.Now().Add(24*time.Hour)
+	rootKey, intermediateKey := newKey(), newKey()
+	leafKey = newKey()
+	rootTemplate := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test Root CA"}, NotBefore: notBefore, NotAfter: notAfter, IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+	root = issue(rootTemplate, rootTemplate, rootKey, rootKey)
+	intermediateTemplate := &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: intermediateName}, NotBefore: notBefore, NotAfter: notAfter, IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+	intermediate = issue(intermediateTemplate, root, intermediateKey, rootKey)
+	leafTemplate := &x509.Certificate{SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "Test Profile Signing"}, NotBefore: notBefore, NotAfter: notAfter, KeyUsage: x509.KeyUsageDigitalSignature}
+	leaf = issue(leafTemplate, intermediate, leafKey, intermediateKey)
+	return root, intermediate, leaf, leafKey
+}
+
+func TestVerifyAppleCertificateChain(t *testing.T) {
+	root, intermediate, leaf, _ := testCertificateChain(t)
+	candidates := []*x509.Certificate{leaf, intermediate, root}
+
+	if _, err := VerifyAppleCertificateChain(leaf, candidates, time.Now()); err == nil || !strings.Contains(err.Error(), "no Apple root") {
+		t.Fatalf("expected untrusted root to be rejected, got %v", err)
+	}
+
+	_, fingerprint := CertificateFingerprints(root)
+	appleRootFingerprints[strings.ToLower(fingerprint)] = "Test Root CA"
+	t.Cleanup(func() { delete(appleRootFingerprints, strings.ToLower(fingerprint)) })
+
+	chain, err := VerifyAppleCertificateChain(leaf, candidates, time.Now())
+	if err != nil {
+		t.Fatalf("VerifyAppleCertificateChain() error: %v", err)
+	}
+	if len(chain) != 3 || chain[2] != root {
+		t.Fatalf("unexpected chain length %d", len(chain))
+	}
+	if _, err := VerifyAppleCertificateChain(leaf, []*x509.Certificate{leaf, root}, time.Now()); err == nil {
+		t.Fatal("expected missing intermediate to fail")
+	}
+}
+
+func TestVerifyAppleDeviceCertificate(t *testing.T) {
+	pin := func(root *x509.Certificate) {
+		_, fingerprint := CertificateFingerprints(root)
+		appleRootFingerprints[strings.ToLower(fingerprint)] = "Test Root CA"
+		t.Cleanup(func() { delete(appleRootFingerprints, strings.ToLower(fingerprint)) })
+	}
+
+	root, intermediate, leaf, _ := testCertificateChain(t)
+	pin(root)
+	if err := VerifyAppleDeviceCertificate(leaf, []*x509.Certificate{leaf, intermediate, root}, time.Now()); err == nil || !strings.Contains(err.Error(), "not issued by an Apple device CA") {
+		t.Fatalf("expected a non-device issuer to be rejected, got %v", err)
+	}
+
+	root, intermediate, leaf, _ = testCertificateChainIssuedBy(t, "Apple iPhone Device CA")
+	if err := VerifyAppleDeviceCertificate(leaf, []*x509.Certificate{leaf, intermediate, root}, time.Now()); err == nil {
+		t.Fatal("expected an unpinned root to be rejected")
+	}
+	pin(root)
+	if err := VerifyAppleDeviceCertificate(leaf, []*x509.Certificate{leaf, intermediate, root}, time.Now()); err != nil {
+		t.Fatalf("VerifyAppleDeviceCertificate() error: %v", err)
+	}
+}
+
+func TestParseSignedDataOpenSSL(t *testing.T) {
+	opensslPath, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not available")
+	}
+	root, intermediate, leaf, leafKey := testCertificateChain(t)
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(leafKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainPath := filepath.Join(dir, "chain.pem")
+	chainPEM := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw})...)
+	if err := os.WriteFile(chainPath, chainPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("<?xml version=\"1.0\"?><plist><dict/></plist>")
+	contentPath := filepath.Join(dir, "content.plist")
+	if err := os.WriteFile(contentPath, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// -stream produces indefinite-length BER, like Apple's profiles.
+	for _, mode := range []string{"der", "stream"} {
+		t.Run(mode, func(t *testing.T) {
+			outPath := filepath.Join(dir, mode+".p7s")
+			args := []string{"cms", "-sign", "-binary", "-nodetach", "-outform", "DER",
+				"-in", contentPath, "-signer", writePEM("leaf.pem", "CERTIFICATE", leaf.Raw), "-inkey", writePEM("leaf.key", "PRIVATE KEY", keyDER),
+				"-certfile", chainPath, "-out", outPath}
+			if mode == "stream" {
+				args = append(args, "-stream")
+			}
+			if out, err := exec.Command(opensslPath, args...).CombinedOutput(); err != nil {
+				t.Skipf("openssl cms -sign failed: %v\n%s", err, out)
+			}
+			data, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, err := ParseSignedData(data)
+			if err != nil {
+				t.Fatalf("ParseSignedData() error: %v", err)
+			}
+			if !bytes.Equal(signed.Content, content) {
+				t.Fatalf("unexpected content %q", signed.Content)
+			}
+			if len(signed.Certificates) != 3 {
+				t.Fatalf("expected 3 embedded certificates, got %d", len(signed.Certificates))
+			}
+			signers, err := signed.Verify()
+			if err != nil {
+				t.Fatalf("Verify() error: %v", err)
+			}
+			if len(signers) != 1 || !signers[0].Equal(leaf) {
+				t.Fatalf("unexpected signers: %v", signers)
+			}
+
+			signed.Content = []byte("tampered")
+			if _, err := signed.Verify(); err == nil {
+				t.Fatal("expected tampered content to fail verification")
+			}
+		})
+	}
+}
+
+func TestDecodePKCS12(t *testing.T) {
+	key, cert := testSigningCertificate(t)
+	data, err := EncodePKCS12(key, cert, "Example", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := DecodePKCS12(data, "s3cret")
+	if err != nil {
+		t.Fatalf("DecodePKCS12() error: %v", err)
+	}
+	if !contents.Certificate.Equal(cert) || !key.Equal(contents.PrivateKey) {
+		t.Fatal("decoded bundle does not match")
+	}
+	if _, err := DecodePKCS12(data, "wrong"); err != ErrPKCS12Password {
+		t.Fatalf("expected ErrPKCS12Password, got %v", err)
+	}
+}
+
+func TestDecodePKCS12OpenSSL(t *testing.T) {
+	opensslPath, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not available")
+	}
+	_, intermediate, leaf, leafKey := testCertificateChain(t)
+	dir := t.TempDir()
+	keyDER, err := x509.MarshalPKCS8PrivateKey(leafKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := append(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})...)
+	input = append(input, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw})...)
+	inPath := filepath.Join(dir, "in.pem")
+	if err := os.WriteFile(inPath, input, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The OpenSSL 3 defaults use PBES2 with AES-256-CBC and a SHA-256 MAC.
+	outPath := filepath.Join(dir, "out.p12")
+	cmd := exec.Command(opensslPath, "pkcs12", "-export", "-in", inPath, "-out", outPath, "-passout", "pass:s3cret")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("openssl pkcs12 -export failed: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := DecodePKCS12(data, "s3cret")
+	if err != nil {
+		t.Fatalf("DecodePKCS12() error: %v", err)
+	}
+	if !contents.Certificate.Equal(leaf) || !leafKey.Equal(contents.PrivateKey) {
+		t.Fatal("decoded bundle does not match")
+	}
+	if len(contents.CACerts) != 1 || !contents.CACerts[0].Equal(intermediate) {
+		t.Fatalf("expected intermediate CA certificate, got %d", len(contents.CACerts))
+	}
+}
+
+func TestSignDataRoundTrip(t *testing.T) {
+	root, intermediate, leaf, leafKey := testCertificateChain(t)
+	content := []byte("<?xml version=\"1.0\"?><plist><dict/></plist>")
+
+	data, err := SignData(content, leaf, leafKey, []*x509.Certificate{intermediate})
+	if err != nil {
+		t.Fatalf("SignData() error: %v", err)
+	}
+	signed, err := ParseSignedData(data)
+	if err != nil {
+		t.Fatalf("ParseSignedData() error: %v", err)
+	}
+	if !bytes.Equal(signed.Content, content) || len(signed.Certificates) != 2 {
+		t.Fatalf("unexpected envelope: content %q, %d certificates", signed.Content, len(signed.Certificates))
+	}
+	signers, err := signed.Verify()
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if len(signers) != 1 || !signers[0].Equal(leaf) {
+		t.Fatalf("unexpected signers: %v", signers)
+	}
+
+	opensslPath, err := exec.LookPath("openssl")
+	if err != nil {
+		return
+	}
+	dir := t.TempDir()
+	signedPath, rootPath, outPath := filepath.Join(dir, "signed.p7s"), filepath.Join(dir, "root.pem"), filepath.Join(dir, "out")
+	if err := os.WriteFile(signedPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rootPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(opensslPath, "cms", "-verify", "-binary", "-inform", "DER", "-in", signedPath, "-CAfile", rootPath, "-purpose", "any", "-out", outPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("openssl cms -verify failed: %v\n%s", err, out)
+	}
+	verified, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(verified, content) {
+		t.Fatalf("openssl returned %q", verified)
+	}
+}
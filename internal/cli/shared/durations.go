@@ -0,0 +1,55 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAgeDuration parses a relative duration flag value like 24h, 30d, 2w or
+// 3m (30-day months). flagName is used in the error message.
+func ParseAgeDuration(flagName, value string) (time.Duration, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	invalid := fmt.Errorf("%s must be a duration like 24h, 30d, 2w, or 3m", flagName)
+	if len(trimmed) < 2 {
+		return 0, invalid
+	}
+	count, err := strconv.Atoi(trimmed[:len(trimmed)-1])
+	if err != nil || count <= 0 {
+		return 0, invalid
+	}
+	day := 24 * time.Hour
+	switch trimmed[len(trimmed)-1] {
+	case 'h':
+		return time.Duration(count) * time.Hour, nil
+	case 'd':
+		return time.Duration(count) * day, nil
+	case 'w':
+		return time.Duration(count) * 7 * day, nil
+	case 'm':
+		return time.Duration(count) * 30 * day, nil
+	default:
+		return 0, invalid
+	}
+}
+
+// ParseTimeOrAge parses a date (YYYY-MM-DD), an RFC3339 timestamp, or a
+// duration accepted by ParseAgeDuration counted back from now.
+func ParseTimeOrAge(flagName, value string, now time.Time) (time.Time, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return time.Time{}, fmt.Errorf("%s is required", flagName)
+	}
+	if parsed, err := time.Parse("2006-01-02", trimmed); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, trimmed); err == nil {
+		return parsed, nil
+	}
+	duration, err := ParseAgeDuration(flagName, trimmed)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w, or a date (YYYY-MM-DD)", err)
+	}
+	return now.Add(-duration), nil
+}
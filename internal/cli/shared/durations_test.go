@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAgeDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := map[string]time.Duration{
+		"24h": 24 * time.Hour,
+		"7d":  7 * day,
+		"2W":  14 * day,
+		"3m":  90 * day,
+	}
+	for value, want := range tests {
+		got, err := ParseAgeDuration("--within", value)
+		if err != nil || got != want {
+			t.Fatalf("ParseAgeDuration(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "d", "0d", "-1d", "7y", "week"} {
+		if _, err := ParseAgeDuration("--within", value); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestParseTimeOrAge(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"7d", time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"1m", time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{"2026-01-02", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-02T10:00:00Z", time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		got, err := ParseTimeOrAge("--since", test.value, now)
+		if err != nil {
+			t.Fatalf("ParseTimeOrAge(%q) error: %v", test.value, err)
+		}
+		if !got.Equal(test.want) {
+			t.Fatalf("ParseTimeOrAge(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+	for _, value := range []string{"", "d", "0d", "7y", "yesterday"} {
+		if _, err := ParseTimeOrAge("--since", value, now); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
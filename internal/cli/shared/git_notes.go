@@ -0,0 +1,283 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// GitNotesMaxLength is the App Store Connect limit for "What to Test" and
+// "What's New" text.
+const GitNotesMaxLength = 4000
+
+// DefaultGitNotesTrailer is the commit trailer that overrides a commit subject.
+const DefaultGitNotesTrailer = "Release-Note"
+
+const defaultGitNotesTemplate = `{{range .Notes}}- {{.Text}}
+{{end}}`
+
+var conventionalCommitRegex = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// GitNote is one entry rendered into generated notes.
+type GitNote struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Subject   string
+	Type      string
+	Scope     string
+	Breaking  bool
+	Text      string
+}
+
+// GitNotesTemplateData is passed to notes templates.
+type GitNotesTemplateData struct {
+	Range string
+	Notes []GitNote
+}
+
+// GitNotesOptions controls how notes are collected and rendered.
+type GitNotesOptions struct {
+	Repo         string
+	Range        string
+	Paths        []string
+	Types        []string
+	Trailer      string
+	TrailersOnly bool
+	TemplatePath string
+	MaxLength    int
+}
+
+// GitNotes is the rendered result of GenerateGitNotes.
+type GitNotes struct {
+	Text      string
+	Notes     []GitNote
+	Commits   int
+	Truncated bool
+}
+
+// GitNotesFlags holds the shared flags that tune notes generated from git.
+type GitNotesFlags struct {
+	repo         *string
+	paths        *string
+	types        *string
+	trailer      *string
+	trailersOnly *bool
+	template     *string
+	maxLength    *int
+}
+
+// BindGitNotesFlags registers the flags that tune notes generated from git history.
+func BindGitNotesFlags(fs *flag.FlagSet) *GitNotesFlags {
+	return &GitNotesFlags{
+		repo:         fs.String("git-repo", ".", "Git repository used for notes generated from git"),
+		paths:        fs.String("git-path", "", "Only include commits touching these paths (comma-separated)"),
+		types:        fs.String("git-type", "", "Only include conventional commit types, e.g. feat,fix (comma-separated)"),
+		trailer:      fs.String("git-trailer", DefaultGitNotesTrailer, "Commit trailer whose value replaces the commit subject"),
+		trailersOnly: fs.Bool("git-trailers-only", false, "Only include commits that carry the --git-trailer trailer"),
+		template:     fs.String("notes-template", "", "Go text/template file for generated notes (data: .Range, .Notes)"),
+		maxLength:    fs.Int("notes-max-length", GitNotesMaxLength, "Maximum length of generated notes"),
+	}
+}
+
+// Options returns GitNotesOptions for the given revision range.
+func (f *GitNotesFlags) Options(revisionRange string) GitNotesOptions {
+	return GitNotesOptions{
+		Repo:         strings.TrimSpace(*f.repo),
+		Range:        strings.TrimSpace(revisionRange),
+		Paths:        SplitCSV(*f.paths),
+		Types:        SplitCSV(strings.ToLower(*f.types)),
+		Trailer:      strings.TrimSpace(*f.trailer),
+		TrailersOnly: *f.trailersOnly,
+		TemplatePath: strings.TrimSpace(*f.template),
+		MaxLength:    *f.maxLength,
+	}
+}
+
+// GenerateGitNotes collects commits in a revision range and renders them as notes.
+func GenerateGitNotes(ctx context.Context, opts GitNotesOptions) (*GitNotes, error) {
+	if opts.Range == "" {
+		return nil, fmt.Errorf("git range is required")
+	}
+	if strings.HasPrefix(opts.Range, "-") {
+		return nil, fmt.Errorf("invalid git range %q", opts.Range)
+	}
+	if opts.MaxLength <= 0 || opts.MaxLength > GitNotesMaxLength {
+		return nil, fmt.Errorf("--notes-max-length must be between 1 and %d", GitNotesMaxLength)
+	}
+
+	tmplText := defaultGitNotesTemplate
+	if opts.TemplatePath != "" {
+		data, err := os.ReadFile(opts.TemplatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notes template: %w", err)
+		}
+		tmplText = string(data)
+	}
+	tmpl, err := template.New("notes").Option("missingkey=error").Parse(tmplText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notes template: %w", err)
+	}
+
+	log, err := readGitCommits(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	notes := gitNotesFromCommits(log, opts)
+	if len(notes) == 0 {
+		return nil, fmt.Errorf("no commits in %s matched the git filters", opts.Range)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, GitNotesTemplateData{Range: opts.Range, Notes: notes}); err != nil {
+		return nil, fmt.Errorf("failed to render notes template: %w", err)
+	}
+	text, truncated := truncateGitNotes(strings.TrimSpace(buf.String()), opts.MaxLength)
+	if text == "" {
+		return nil, fmt.Errorf("notes template rendered empty text")
+	}
+	commits := map[string]struct{}{}
+	for _, note := range notes {
+		commits[note.Hash] = struct{}{}
+	}
+	return &GitNotes{Text: text, Notes: notes, Commits: len(commits), Truncated: truncated}, nil
+}
+
+type gitCommit struct {
+	hash    string
+	author  string
+	subject string
+	body    string
+}
+
+func readGitCommits(ctx context.Context, opts GitNotesOptions) ([]gitCommit, error) {
+	repo := opts.Repo
+	if repo == "" {
+		repo = "."
+	}
+	args := []string{"-C", repo, "log", "--no-merges", "--format=%H%x1f%an%x1f%s%x1f%b%x1e", opts.Range, "--"}
+	args = append(args, opts.Paths...)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("git log %s failed: %s", opts.Range, message)
+	}
+	return parseGitLog(string(out)), nil
+}
+
+func parseGitLog(output string) []gitCommit {
+	var commits []gitCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\r\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\x1f", 4)
+		if len(parts) < 3 {
+			continue
+		}
+		commit := gitCommit{hash: parts[0], author: parts[1], subject: strings.TrimSpace(parts[2])}
+		if len(parts) == 4 {
+			commit.body = parts[3]
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// gitNotesFromCommits applies the type and trailer rules to commits, newest first.
+func gitNotesFromCommits(commits []gitCommit, opts GitNotesOptions) []GitNote {
+	var notes []GitNote
+	for _, commit := range commits {
+		note := GitNote{
+			Hash:    commit.hash,
+			Author:  commit.author,
+			Subject: commit.subject,
+			Text:    commit.subject,
+		}
+		if len(commit.hash) >= 7 {
+			note.ShortHash = commit.hash[:7]
+		}
+		if match := conventionalCommitRegex.FindStringSubmatch(commit.subject); match != nil {
+			note.Type = strings.ToLower(match[1])
+			note.Scope = match[2]
+			note.Breaking = match[3] == "!"
+			note.Text = strings.TrimSpace(match[4])
+		}
+		if len(gitTrailerValues(commit.body, "BREAKING CHANGE")) > 0 {
+			note.Breaking = true
+		}
+		if len(opts.Types) > 0 && !slices.Contains(opts.Types, note.Type) {
+			continue
+		}
+
+		trailers := gitTrailerValues(commit.body, opts.Trailer)
+		if len(trailers) == 0 {
+			if !opts.TrailersOnly {
+				notes = append(notes, note)
+			}
+			continue
+		}
+		for _, value := range trailers {
+			if strings.EqualFold(value, "none") || value == "-" {
+				continue
+			}
+			entry := note
+			entry.Text = value
+			notes = append(notes, entry)
+		}
+	}
+	return notes
+}
+
+// gitTrailerValues returns the values of "Name: value" lines in a commit body.
+func gitTrailerValues(body, name string) []string {
+	if strings.TrimSpace(name) == "" {
+		return nil
+	}
+	prefix := strings.ToLower(name) + ":"
+	var values []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(strings.ToLower(line), prefix) {
+			continue
+		}
+		if value := strings.TrimSpace(line[len(prefix):]); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// truncateGitNotes drops whole trailing lines until text fits in maxLength
+// characters, cutting the first line only when it alone is too long.
+func truncateGitNotes(text string, maxLength int) (string, bool) {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text, false
+	}
+	lines := strings.Split(text, "\n")
+	for len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+		candidate := strings.TrimSpace(strings.Join(lines, "\n"))
+		if utf8.RuneCountInString(candidate) <= maxLength {
+			return candidate, true
+		}
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:maxLength])), true
+}
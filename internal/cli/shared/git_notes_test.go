@@ -0,0 +1,180 @@
+package shared
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func initGitNotesRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=tester@example.com",
+			"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=tester@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(path, message string) {
+		t.Helper()
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(message), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		run("add", path)
+		run("commit", "-q", "-m", message)
+	}
+
+	run("init", "-q")
+	commit("README.md", "Initial commit")
+	run("tag", "v1.0.0")
+	commit("App/Login.swift", "feat(login): add passkey sign-in")
+	commit("App/Cache.swift", "fix: clear cache on logout\n\nRelease-Note: Logging out now removes cached data")
+	commit("Docs/guide.md", "docs: update guide")
+	commit("App/Internal.swift", "chore: bump tooling\n\nRelease-Note: none")
+	return dir
+}
+
+func TestGenerateGitNotes(t *testing.T) {
+	repo := initGitNotesRepo(t)
+
+	notes, err := GenerateGitNotes(context.Background(), GitNotesOptions{
+		Repo:      repo,
+		Range:     "v1.0.0..HEAD",
+		Trailer:   DefaultGitNotesTrailer,
+		MaxLength: GitNotesMaxLength,
+	})
+	if err != nil {
+		t.Fatalf("GenerateGitNotes() error: %v", err)
+	}
+	want := "- update guide\n- Logging out now removes cached data\n- add passkey sign-in"
+	if notes.Text != want {
+		t.Fatalf("expected notes %q, got %q", want, notes.Text)
+	}
+	if notes.Commits != 3 {
+		t.Fatalf("expected 3 commits, got %d", notes.Commits)
+	}
+}
+
+func TestGenerateGitNotes_Filters(t *testing.T) {
+	repo := initGitNotesRepo(t)
+
+	tests := []struct {
+		name string
+		opts GitNotesOptions
+		want string
+	}{
+		{
+			name: "types",
+			opts: GitNotesOptions{Types: []string{"feat"}},
+			want: "- add passkey sign-in",
+		},
+		{
+			name: "paths",
+			opts: GitNotesOptions{Paths: []string{"Docs"}},
+			want: "- update guide",
+		},
+		{
+			name: "trailers only",
+			opts: GitNotesOptions{TrailersOnly: true},
+			want: "- Logging out now removes cached data",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := test.opts
+			opts.Repo = repo
+			opts.Range = "v1.0.0..HEAD"
+			opts.Trailer = DefaultGitNotesTrailer
+			opts.MaxLength = GitNotesMaxLength
+			notes, err := GenerateGitNotes(context.Background(), opts)
+			if err != nil {
+				t.Fatalf("GenerateGitNotes() error: %v", err)
+			}
+			if notes.Text != test.want {
+				t.Fatalf("expected %q, got %q", test.want, notes.Text)
+			}
+		})
+	}
+}
+
+func TestGenerateGitNotes_TemplateAndLimit(t *testing.T) {
+	repo := initGitNotesRepo(t)
+	templatePath := filepath.Join(t.TempDir(), "notes.tmpl")
+	tmpl := "Changes in {{.Range}}:\n{{range .Notes}}* {{if .Type}}[{{.Type}}] {{end}}{{.Text}}\n{{end}}"
+	if err := os.WriteFile(templatePath, []byte(tmpl), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	notes, err := GenerateGitNotes(context.Background(), GitNotesOptions{
+		Repo:         repo,
+		Range:        "v1.0.0..HEAD",
+		Trailer:      DefaultGitNotesTrailer,
+		TemplatePath: templatePath,
+		MaxLength:    60,
+	})
+	if err != nil {
+		t.Fatalf("GenerateGitNotes() error: %v", err)
+	}
+	want := "Changes in v1.0.0..HEAD:\n* [docs] update guide"
+	if notes.Text != want || !notes.Truncated {
+		t.Fatalf("expected truncated %q, got %q (truncated=%t)", want, notes.Text, notes.Truncated)
+	}
+}
+
+func TestGenerateGitNotes_Errors(t *testing.T) {
+	repo := initGitNotesRepo(t)
+
+	tests := []struct {
+		name string
+		opts GitNotesOptions
+		want string
+	}{
+		{name: "option-like range", opts: GitNotesOptions{Range: "--all", MaxLength: 10}, want: "invalid git range"},
+		{name: "max length", opts: GitNotesOptions{Range: "HEAD~1..HEAD", MaxLength: 5000}, want: "--notes-max-length"},
+		{name: "unknown revision", opts: GitNotesOptions{Range: "nope..HEAD", MaxLength: 10}, want: "git log nope..HEAD failed"},
+		{name: "no matches", opts: GitNotesOptions{Range: "v1.0.0..HEAD", Types: []string{"perf"}, MaxLength: 10}, want: "no commits"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := test.opts
+			opts.Repo = repo
+			_, err := GenerateGitNotes(context.Background(), opts)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestGitNotesFromCommits_ConventionalAndBreaking(t *testing.T) {
+	commits := []gitCommit{
+		{hash: "abcdef0123", subject: "feat(api)!: drop v1 endpoints"},
+		{hash: "123456789a", subject: "refactor: tidy", body: "BREAKING CHANGE: config moved\nRelease-Note: Settings were reset\nRelease-Note: Sign in again"},
+	}
+	got := gitNotesFromCommits(commits, GitNotesOptions{Trailer: DefaultGitNotesTrailer})
+	want := []GitNote{
+		{Hash: "abcdef0123", ShortHash: "abcdef0", Subject: "feat(api)!: drop v1 endpoints", Type: "feat", Scope: "api", Breaking: true, Text: "drop v1 endpoints"},
+		{Hash: "123456789a", ShortHash: "1234567", Subject: "refactor: tidy", Type: "refactor", Breaking: true, Text: "Settings were reset"},
+		{Hash: "123456789a", ShortHash: "1234567", Subject: "refactor: tidy", Type: "refactor", Breaking: true, Text: "Sign in again"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected notes:\n got: %+v\nwant: %+v", got, want)
+	}
+}
@@ -0,0 +1,662 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// Localization file formats accepted by localizations download/upload.
+const (
+	LocalizationFormatStrings   = "strings"
+	LocalizationFormatJSON      = "json"
+	LocalizationFormatXCStrings = "xcstrings"
+	LocalizationFormatXLIFF     = "xliff"
+)
+
+// Translation states shared by String Catalogs and XLIFF files.
+const (
+	TranslationStateNew         = "new"
+	TranslationStateTranslated  = "translated"
+	TranslationStateNeedsReview = "needs_review"
+)
+
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+// localizationKeyComments describe each metadata key for translators.
+var localizationKeyComments = map[string]string{
+	"description":       "App Store description (max 4000 characters)",
+	"keywords":          "App Store keywords, comma-separated (max 100 bytes)",
+	"marketingUrl":      "Marketing URL",
+	"promotionalText":   "Promotional text (max 170 characters)",
+	"supportUrl":        "Support URL",
+	"whatsNew":          "What's New in this version (max 4000 characters)",
+	"name":              "App name (max 30 characters)",
+	"subtitle":          "App subtitle (max 30 characters)",
+	"privacyPolicyUrl":  "Privacy policy URL",
+	"privacyChoicesUrl": "Privacy choices URL",
+	"privacyPolicyText": "Privacy policy text (tvOS)",
+}
+
+// localizationEntry is a metadata value together with its translation state.
+type localizationEntry struct {
+	Value string
+	State string
+}
+
+// localizationCatalog maps locale -> key -> entry.
+type localizationCatalog map[string]map[string]localizationEntry
+
+func (c localizationCatalog) set(locale, key string, entry localizationEntry) {
+	if c[locale] == nil {
+		c[locale] = make(map[string]localizationEntry)
+	}
+	c[locale][key] = entry
+}
+
+// ResolveLocalizationFormat validates a --format value. An empty value is
+// inferred from the path extension and falls back to .strings.
+func ResolveLocalizationFormat(format, path string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+	if normalized == "" {
+		switch strings.ToLower(filepath.Ext(strings.TrimSpace(path))) {
+		case ".xcstrings":
+			return LocalizationFormatXCStrings, nil
+		case ".xliff", ".xlf":
+			return LocalizationFormatXLIFF, nil
+		case ".json":
+			return LocalizationFormatJSON, nil
+		default:
+			return LocalizationFormatStrings, nil
+		}
+	}
+	switch normalized {
+	case LocalizationFormatStrings, LocalizationFormatJSON, LocalizationFormatXCStrings, LocalizationFormatXLIFF:
+		return normalized, nil
+	case "xlf":
+		return LocalizationFormatXLIFF, nil
+	default:
+		return "", fmt.Errorf("--format must be one of: %s, %s, %s, %s", LocalizationFormatStrings, LocalizationFormatJSON, LocalizationFormatXCStrings, LocalizationFormatXLIFF)
+	}
+}
+
+// WriteVersionLocalizations writes version localizations in the given format.
+// sourceLocale is the development language for String Catalogs and XLIFF.
+func WriteVersionLocalizations(outputPath, format, sourceLocale string, items []asc.Resource[asc.AppStoreVersionLocalizationAttributes]) ([]asc.LocalizationFileResult, error) {
+	if format == LocalizationFormatStrings {
+		return WriteVersionLocalizationStrings(outputPath, items)
+	}
+	return writeLocalizationFormat(outputPath, format, LocalizationTypeVersion, sourceLocale, nil, VersionLocalizationValues(items), versionLocalizationKeys)
+}
+
+// WriteAppInfoLocalizations writes app info localizations in the given format.
+func WriteAppInfoLocalizations(outputPath, format, sourceLocale string, items []asc.Resource[asc.AppInfoLocalizationAttributes]) ([]asc.LocalizationFileResult, error) {
+	if format == LocalizationFormatStrings {
+		return WriteAppInfoLocalizationStrings(outputPath, items)
+	}
+	return writeLocalizationFormat(outputPath, format, LocalizationTypeAppInfo, sourceLocale, nil, AppInfoLocalizationValues(items), appInfoLocalizationKeys)
+}
+
+// WriteLocalizationValues writes locale -> key -> value maps of the given
+// localization type (version or app-info) in the given format. sourceValues
+// are the source-locale strings XLIFF files use for <source>; when nil they
+// are taken from valuesByLocale.
+func WriteLocalizationValues(outputPath, format, locType, sourceLocale string, sourceValues map[string]string, valuesByLocale map[string]map[string]string) ([]asc.LocalizationFileResult, error) {
+	order := versionLocalizationKeys
+	if locType == LocalizationTypeAppInfo {
+		order = appInfoLocalizationKeys
+	}
+	if format == LocalizationFormatStrings {
+		return writeLocalizationStrings(outputPath, valuesByLocale, order)
+	}
+	return writeLocalizationFormat(outputPath, format, locType, sourceLocale, sourceValues, valuesByLocale, order)
+}
+
+func writeLocalizationFormat(outputPath, format, locType, sourceLocale string, sourceValues map[string]string, valuesByLocale map[string]map[string]string, order []string) ([]asc.LocalizationFileResult, error) {
+	if len(valuesByLocale) == 0 {
+		return nil, fmt.Errorf("no localizations returned")
+	}
+	locales := make([]string, 0, len(valuesByLocale))
+	for locale := range valuesByLocale {
+		if !isValidLocale(locale) {
+			return nil, fmt.Errorf("invalid locale code %q: must match pattern like 'en', 'en-US', or 'zh-Hans'", locale)
+		}
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	sourceLocale = resolveSourceLocale(sourceLocale, locales)
+	if sourceValues == nil {
+		sourceValues = valuesByLocale[sourceLocale]
+	}
+
+	switch format {
+	case LocalizationFormatJSON:
+		paths, err := resolveLocalizationOutputPaths(outputPath, locales, ".json")
+		if err != nil {
+			return nil, err
+		}
+		results := make([]asc.LocalizationFileResult, 0, len(locales))
+		for _, locale := range locales {
+			data, err := json.MarshalIndent(valuesByLocale[locale], "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			if err := writeNewLocalizationFile(paths[locale], append(data, '\n')); err != nil {
+				return nil, err
+			}
+			results = append(results, asc.LocalizationFileResult{Locale: locale, Path: paths[locale]})
+		}
+		return results, nil
+	case LocalizationFormatXCStrings:
+		path := localizationCatalogPath(outputPath, locType, ".xcstrings")
+		existing, err := readExistingCatalog(path, readXCStringsFile)
+		if err != nil {
+			return nil, err
+		}
+		merged := mergeLocalizationCatalog(existing, valuesByLocale)
+		data, err := encodeXCStrings(merged, sourceLocale)
+		if err != nil {
+			return nil, err
+		}
+		if err := WriteFileAtomic(path, data, 0o644); err != nil {
+			return nil, err
+		}
+		results := make([]asc.LocalizationFileResult, 0, len(locales))
+		for _, locale := range locales {
+			results = append(results, asc.LocalizationFileResult{Locale: locale, Path: path})
+		}
+		return results, nil
+	case LocalizationFormatXLIFF:
+		ext := ".xliff"
+		if strings.EqualFold(filepath.Ext(outputPath), ".xlf") {
+			ext = filepath.Ext(outputPath)
+		}
+		paths, err := resolveLocalizationOutputPaths(outputPath, locales, ext)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]asc.LocalizationFileResult, 0, len(locales))
+		for _, locale := range locales {
+			existing, err := readExistingCatalog(paths[locale], readXLIFFFile)
+			if err != nil {
+				return nil, err
+			}
+			merged := mergeLocalizationCatalog(existing, map[string]map[string]string{locale: valuesByLocale[locale]})
+			data, err := encodeXLIFF(locType, sourceLocale, locale, sourceValues, merged[locale], order)
+			if err != nil {
+				return nil, err
+			}
+			if err := WriteFileAtomic(paths[locale], data, 0o644); err != nil {
+				return nil, err
+			}
+			results = append(results, asc.LocalizationFileResult{Locale: locale, Path: paths[locale]})
+		}
+		return results, nil
+	default:
+		return nil, fmt.Errorf("unsupported localization format %q", format)
+	}
+}
+
+// resolveSourceLocale prefers the requested locale, then en-US, then the first locale.
+func resolveSourceLocale(requested string, locales []string) string {
+	requested = strings.TrimSpace(requested)
+	if requested != "" {
+		return requested
+	}
+	for _, locale := range locales {
+		if locale == "en-US" {
+			return locale
+		}
+	}
+	if len(locales) > 0 {
+		return locales[0]
+	}
+	return ""
+}
+
+// localizationCatalogPath returns path when it already names a catalog file,
+// otherwise "<path>/<type><ext>".
+func localizationCatalogPath(outputPath, locType, ext string) string {
+	if strings.TrimSpace(outputPath) == "" {
+		outputPath = "localizations"
+	}
+	if strings.HasSuffix(outputPath, ext) {
+		return outputPath
+	}
+	return filepath.Join(outputPath, locType+ext)
+}
+
+// mergeLocalizationCatalog applies downloaded values to an existing catalog.
+// Unchanged values keep their translation state; changed or new values are
+// marked translated. Keys and locales missing from values are preserved.
+func mergeLocalizationCatalog(existing localizationCatalog, valuesByLocale map[string]map[string]string) localizationCatalog {
+	merged := make(localizationCatalog, len(existing)+len(valuesByLocale))
+	for locale, entries := range existing {
+		for key, entry := range entries {
+			merged.set(locale, key, entry)
+		}
+	}
+	for locale, values := range valuesByLocale {
+		for key, value := range values {
+			entry := localizationEntry{Value: value, State: TranslationStateTranslated}
+			if previous, ok := existing[locale][key]; ok && previous.Value == value && previous.State != "" {
+				entry.State = previous.State
+			}
+			merged.set(locale, key, entry)
+		}
+	}
+	return merged
+}
+
+// ReadLocalizationValues reads metadata values in the given format. Entries that
+// are untranslated (state "new" or an empty value) are returned as skipped and
+// locales left without values are dropped.
+func ReadLocalizationValues(inputPath, format string, locales []string) (map[string]map[string]string, []asc.LocalizationSkippedValue, error) {
+	if format == LocalizationFormatStrings {
+		values, err := ReadLocalizationStrings(inputPath, locales)
+		return values, nil, err
+	}
+
+	catalog, err := readLocalizationCatalog(inputPath, format, locales)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := make(map[string]bool, len(locales))
+	for _, locale := range locales {
+		filter[locale] = true
+	}
+
+	values := make(map[string]map[string]string, len(catalog))
+	var skipped []asc.LocalizationSkippedValue
+	for locale, entries := range catalog {
+		if len(filter) > 0 && !filter[locale] {
+			continue
+		}
+		for key, entry := range entries {
+			if entry.State == TranslationStateNew || strings.TrimSpace(entry.Value) == "" {
+				state := entry.State
+				if state == "" {
+					state = TranslationStateNew
+				}
+				skipped = append(skipped, asc.LocalizationSkippedValue{Locale: locale, Key: key, State: state})
+				continue
+			}
+			if values[locale] == nil {
+				values[locale] = make(map[string]string)
+			}
+			values[locale][key] = entry.Value
+		}
+	}
+	sort.Slice(skipped, func(i, j int) bool {
+		if skipped[i].Locale != skipped[j].Locale {
+			return skipped[i].Locale < skipped[j].Locale
+		}
+		return skipped[i].Key < skipped[j].Key
+	})
+
+	if len(values) == 0 && len(skipped) == 0 {
+		return nil, nil, fmt.Errorf("no localizations found in %q", inputPath)
+	}
+	return values, skipped, nil
+}
+
+func readLocalizationCatalog(inputPath, format string, locales []string) (localizationCatalog, error) {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case LocalizationFormatXCStrings:
+		if info.IsDir() {
+			return nil, fmt.Errorf("%q is a directory; pass the .xcstrings file", inputPath)
+		}
+		return readXCStringsFile(inputPath)
+	case LocalizationFormatJSON, LocalizationFormatXLIFF:
+		readFile := func(path, locale string) (localizationCatalog, error) {
+			if format == LocalizationFormatJSON {
+				return readJSONLocalizationFile(path, locale)
+			}
+			return readXLIFFFile(path)
+		}
+		if !info.IsDir() {
+			locale := ""
+			if format == LocalizationFormatJSON {
+				if len(locales) > 1 {
+					return nil, fmt.Errorf("single file input only supports one locale")
+				}
+				if len(locales) == 1 {
+					locale = locales[0]
+				} else {
+					locale = strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
+				}
+			}
+			return readFile(inputPath, locale)
+		}
+
+		entries, err := os.ReadDir(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		catalog := make(localizationCatalog)
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() {
+				continue
+			}
+			if format == LocalizationFormatJSON && ext != ".json" {
+				continue
+			}
+			if format == LocalizationFormatXLIFF && ext != ".xliff" && ext != ".xlf" {
+				continue
+			}
+			parsed, err := readFile(filepath.Join(inputPath, entry.Name()), strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+			if err != nil {
+				return nil, err
+			}
+			for locale, values := range parsed {
+				if _, exists := catalog[locale]; exists {
+					return nil, fmt.Errorf("duplicate locale %q in %s", locale, inputPath)
+				}
+				catalog[locale] = values
+			}
+		}
+		if len(catalog) == 0 {
+			return nil, fmt.Errorf("no .%s files found in %q", format, inputPath)
+		}
+		return catalog, nil
+	default:
+		return nil, fmt.Errorf("unsupported localization format %q", format)
+	}
+}
+
+// readLocalizationFileBytes reads a regular file without following symlinks.
+func readLocalizationFileBytes(path string) ([]byte, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("refusing to read symlink %q", path)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("expected regular file: %q", path)
+	}
+	file, err := OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// readExistingCatalog reads path with read when it exists, for merging downloads.
+func readExistingCatalog(path string, read func(string) (localizationCatalog, error)) (localizationCatalog, error) {
+	if _, err := os.Lstat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return read(path)
+}
+
+func readJSONLocalizationFile(path, locale string) (localizationCatalog, error) {
+	if !isValidLocale(locale) {
+		return nil, fmt.Errorf("cannot infer locale from %q (use --locale)", path)
+	}
+	data, err := readLocalizationFileBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s (expected an object of string values): %w", path, err)
+	}
+	catalog := make(localizationCatalog)
+	for key, value := range values {
+		catalog.set(locale, key, localizationEntry{Value: value, State: TranslationStateTranslated})
+	}
+	return catalog, nil
+}
+
+// xcstringsFile is the subset of the Xcode String Catalog format used for metadata.
+// Plural and device variations are not supported.
+type xcstringsFile struct {
+	SourceLanguage string                     `json:"sourceLanguage"`
+	Strings        map[string]xcstringsString `json:"strings"`
+	Version        string                     `json:"version"`
+}
+
+type xcstringsString struct {
+	Comment         string                           `json:"comment,omitempty"`
+	ExtractionState string                           `json:"extractionState,omitempty"`
+	Localizations   map[string]xcstringsLocalization `json:"localizations,omitempty"`
+}
+
+type xcstringsLocalization struct {
+	StringUnit *xcstringsStringUnit `json:"stringUnit,omitempty"`
+}
+
+type xcstringsStringUnit struct {
+	State string `json:"state"`
+	Value string `json:"value"`
+}
+
+func readXCStringsFile(path string) (localizationCatalog, error) {
+	data, err := readLocalizationFileBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	var file xcstringsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid String Catalog %s: %w", path, err)
+	}
+	catalog := make(localizationCatalog)
+	for key, item := range file.Strings {
+		for locale, localization := range item.Localizations {
+			if localization.StringUnit == nil {
+				continue
+			}
+			state := localization.StringUnit.State
+			if state == "" && localization.StringUnit.Value != "" {
+				state = TranslationStateTranslated
+			}
+			catalog.set(locale, key, localizationEntry{Value: localization.StringUnit.Value, State: state})
+		}
+	}
+	return catalog, nil
+}
+
+func encodeXCStrings(catalog localizationCatalog, sourceLocale string) ([]byte, error) {
+	file := xcstringsFile{
+		SourceLanguage: sourceLocale,
+		Strings:        make(map[string]xcstringsString),
+		Version:        "1.0",
+	}
+	for locale, entries := range catalog {
+		for key, entry := range entries {
+			item, ok := file.Strings[key]
+			if !ok {
+				item = xcstringsString{
+					Comment:         localizationKeyComments[key],
+					ExtractionState: "manual",
+					Localizations:   make(map[string]xcstringsLocalization),
+				}
+			}
+			item.Localizations[locale] = xcstringsLocalization{
+				StringUnit: &xcstringsStringUnit{State: entry.State, Value: entry.Value},
+			}
+			file.Strings[key] = item
+		}
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string       `xml:"id,attr"`
+	Source string       `xml:"source"`
+	Target *xliffTarget `xml:"target"`
+	Note   string       `xml:"note,omitempty"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+func readXLIFFFile(path string) (localizationCatalog, error) {
+	data, err := readLocalizationFileBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc xliffDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid XLIFF %s: %w", path, err)
+	}
+	catalog := make(localizationCatalog)
+	for _, file := range doc.Files {
+		locale := strings.TrimSpace(file.TargetLanguage)
+		if locale == "" {
+			locale = strings.TrimSpace(file.SourceLanguage)
+		}
+		if locale == "" {
+			return nil, fmt.Errorf("XLIFF %s is missing target-language", path)
+		}
+		for _, unit := range file.Units {
+			entry := localizationEntry{State: TranslationStateNew}
+			if unit.Target != nil {
+				entry = localizationEntry{Value: unit.Target.Value, State: xliffStateToCatalog(unit.Target.State, unit.Target.Value)}
+			}
+			catalog.set(locale, unit.ID, entry)
+		}
+	}
+	return catalog, nil
+}
+
+func encodeXLIFF(locType, sourceLocale, targetLocale string, sourceValues map[string]string, entries map[string]localizationEntry, order []string) ([]byte, error) {
+	file := xliffFile{
+		Original:       locType,
+		SourceLanguage: sourceLocale,
+		TargetLanguage: targetLocale,
+		Datatype:       "plaintext",
+	}
+	for _, key := range orderedLocalizationKeys(entries, order) {
+		entry := entries[key]
+		source := sourceValues[key]
+		if source == "" {
+			source = entry.Value
+		}
+		file.Units = append(file.Units, xliffUnit{
+			ID:     key,
+			Source: source,
+			Target: &xliffTarget{State: catalogStateToXLIFF(entry.State), Value: entry.Value},
+			Note:   localizationKeyComments[key],
+		})
+	}
+	doc := xliffDocument{Xmlns: xliffNamespace, Version: "1.2", Files: []xliffFile{file}}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// orderedLocalizationKeys returns known keys in order followed by any extra keys sorted.
+func orderedLocalizationKeys(entries map[string]localizationEntry, order []string) []string {
+	keys := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(order))
+	for _, key := range order {
+		seen[key] = true
+		if _, ok := entries[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	var extra []string
+	for key := range entries {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	return append(keys, extra...)
+}
+
+// xliffStateToCatalog maps XLIFF 1.2 target states to String Catalog states.
+func xliffStateToCatalog(state, value string) string {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case "new", "needs-translation":
+		return TranslationStateNew
+	case "needs-review-translation", "needs-review-l10n", "needs-review-adaptation", "needs-adaptation", "needs-l10n":
+		return TranslationStateNeedsReview
+	case "translated", "final", "signed-off":
+		return TranslationStateTranslated
+	}
+	if strings.TrimSpace(value) == "" {
+		return TranslationStateNew
+	}
+	return TranslationStateTranslated
+}
+
+func catalogStateToXLIFF(state string) string {
+	switch state {
+	case TranslationStateNew:
+		return "new"
+	case TranslationStateNeedsReview:
+		return "needs-review-translation"
+	default:
+		return "translated"
+	}
+}
+
+func writeNewLocalizationFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := OpenNewFileNoFollow(path, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("output file already exists: %w", err)
+		}
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,260 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestResolveLocalizationFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		path   string
+		want   string
+	}{
+		{"", "localizations", LocalizationFormatStrings},
+		{"", "Metadata.xcstrings", LocalizationFormatXCStrings},
+		{"", "de-DE.xlf", LocalizationFormatXLIFF},
+		{"", "en-US.json", LocalizationFormatJSON},
+		{"XLIFF", "localizations", LocalizationFormatXLIFF},
+		{"strings", "Metadata.xcstrings", LocalizationFormatStrings},
+	}
+	for _, test := range tests {
+		got, err := ResolveLocalizationFormat(test.format, test.path)
+		if err != nil {
+			t.Fatalf("ResolveLocalizationFormat(%q, %q) error: %v", test.format, test.path, err)
+		}
+		if got != test.want {
+			t.Fatalf("ResolveLocalizationFormat(%q, %q) = %q, want %q", test.format, test.path, got, test.want)
+		}
+	}
+	if _, err := ResolveLocalizationFormat("yaml", ""); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func versionLocalizationItems(values map[string]asc.AppStoreVersionLocalizationAttributes) []asc.Resource[asc.AppStoreVersionLocalizationAttributes] {
+	items := make([]asc.Resource[asc.AppStoreVersionLocalizationAttributes], 0, len(values))
+	for locale, attrs := range values {
+		attrs.Locale = locale
+		items = append(items, asc.Resource[asc.AppStoreVersionLocalizationAttributes]{ID: "loc-" + locale, Attributes: attrs})
+	}
+	return items
+}
+
+func TestWriteVersionLocalizations_XCStringsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Metadata.xcstrings")
+	items := versionLocalizationItems(map[string]asc.AppStoreVersionLocalizationAttributes{
+		"en-US": {Description: "Hello", Keywords: "one,two", WhatsNew: "Fixes"},
+		"de-DE": {Description: "Hallo", Keywords: "eins,zwei"},
+	})
+
+	files, err := WriteVersionLocalizations(path, LocalizationFormatXCStrings, "", items)
+	if err != nil {
+		t.Fatalf("WriteVersionLocalizations() error: %v", err)
+	}
+	if len(files) != 2 || files[0].Path != path {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read catalog: %v", err)
+	}
+	if !strings.Contains(string(data), `"sourceLanguage": "en-US"`) {
+		t.Fatalf("expected en-US source language, got %s", data)
+	}
+
+	values, skipped, err := ReadLocalizationValues(path, LocalizationFormatXCStrings, nil)
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Fatalf("expected no skipped values, got %+v", skipped)
+	}
+	if values["de-DE"]["keywords"] != "eins,zwei" || values["en-US"]["whatsNew"] != "Fixes" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+}
+
+func TestWriteVersionLocalizations_XCStringsPreservesStates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Metadata.xcstrings")
+	existing := `{
+  "sourceLanguage": "en-US",
+  "strings": {
+    "description": {
+      "localizations": {
+        "de-DE": {"stringUnit": {"state": "needs_review", "value": "Hallo"}},
+        "fr-FR": {"stringUnit": {"state": "new", "value": ""}}
+      }
+    },
+    "keywords": {
+      "localizations": {
+        "de-DE": {"stringUnit": {"state": "needs_review", "value": "alt"}}
+      }
+    }
+  },
+  "version": "1.0"
+}`
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatalf("write catalog: %v", err)
+	}
+	items := versionLocalizationItems(map[string]asc.AppStoreVersionLocalizationAttributes{
+		"de-DE": {Description: "Hallo", Keywords: "neu"},
+	})
+	if _, err := WriteVersionLocalizations(path, LocalizationFormatXCStrings, "en-US", items); err != nil {
+		t.Fatalf("WriteVersionLocalizations() error: %v", err)
+	}
+
+	catalog, err := readXCStringsFile(path)
+	if err != nil {
+		t.Fatalf("readXCStringsFile() error: %v", err)
+	}
+	if got := catalog["de-DE"]["description"]; got.State != TranslationStateNeedsReview {
+		t.Fatalf("expected unchanged value to keep needs_review, got %+v", got)
+	}
+	if got := catalog["de-DE"]["keywords"]; got.State != TranslationStateTranslated || got.Value != "neu" {
+		t.Fatalf("expected changed value to be translated, got %+v", got)
+	}
+	if got, ok := catalog["fr-FR"]["description"]; !ok || got.State != TranslationStateNew {
+		t.Fatalf("expected fr-FR entry to be preserved, got %+v", got)
+	}
+
+	values, skipped, err := ReadLocalizationValues(path, LocalizationFormatXCStrings, nil)
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if _, ok := values["fr-FR"]; ok {
+		t.Fatalf("expected untranslated locale to be dropped, got %+v", values["fr-FR"])
+	}
+	if values["de-DE"]["description"] != "Hallo" {
+		t.Fatalf("expected needs_review value to be uploaded, got %+v", values["de-DE"])
+	}
+	if len(skipped) != 1 || skipped[0].Locale != "fr-FR" || skipped[0].State != TranslationStateNew {
+		t.Fatalf("unexpected skipped values: %+v", skipped)
+	}
+}
+
+func TestReadLocalizationValues_XLIFF(t *testing.T) {
+	dir := t.TempDir()
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="version" source-language="en-US" target-language="ja" datatype="plaintext">
+    <body>
+      <trans-unit id="description">
+        <source>Hello</source>
+        <target state="translated">こんにちは</target>
+      </trans-unit>
+      <trans-unit id="keywords">
+        <source>one,two</source>
+        <target state="needs-review-translation">いち,に</target>
+      </trans-unit>
+      <trans-unit id="whatsNew">
+        <source>Fixes</source>
+        <target state="new"></target>
+      </trans-unit>
+      <trans-unit id="promotionalText">
+        <source>Promo</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`
+	if err := os.WriteFile(filepath.Join(dir, "ja.xliff"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write xliff: %v", err)
+	}
+
+	values, skipped, err := ReadLocalizationValues(dir, LocalizationFormatXLIFF, nil)
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if values["ja"]["description"] != "こんにちは" || values["ja"]["keywords"] != "いち,に" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+	if len(values["ja"]) != 2 {
+		t.Fatalf("expected untranslated units to be skipped, got %+v", values["ja"])
+	}
+	if len(skipped) != 2 || skipped[0].Key != "promotionalText" || skipped[1].Key != "whatsNew" {
+		t.Fatalf("unexpected skipped values: %+v", skipped)
+	}
+}
+
+func TestWriteAppInfoLocalizations_XLIFF(t *testing.T) {
+	dir := t.TempDir()
+	items := []asc.Resource[asc.AppInfoLocalizationAttributes]{
+		{ID: "1", Attributes: asc.AppInfoLocalizationAttributes{Locale: "en-US", Name: "Demo", Subtitle: "Great"}},
+		{ID: "2", Attributes: asc.AppInfoLocalizationAttributes{Locale: "fr-FR", Name: "Démo"}},
+	}
+	files, err := WriteAppInfoLocalizations(dir, LocalizationFormatXLIFF, "", items)
+	if err != nil {
+		t.Fatalf("WriteAppInfoLocalizations() error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", files)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "fr-FR.xliff"))
+	if err != nil {
+		t.Fatalf("read xliff: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{`source-language="en-US"`, `target-language="fr-FR"`, `<source>Demo</source>`, `<target state="translated">Démo</target>`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in xliff, got %s", want, text)
+		}
+	}
+
+	values, _, err := ReadLocalizationValues(dir, LocalizationFormatXLIFF, []string{"fr-FR"})
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if len(values) != 1 || values["fr-FR"]["name"] != "Démo" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+}
+
+func TestWriteAppInfoLocalizations_XLFPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fr-FR.xlf")
+	items := []asc.Resource[asc.AppInfoLocalizationAttributes]{
+		{ID: "1", Attributes: asc.AppInfoLocalizationAttributes{Locale: "fr-FR", Name: "Démo"}},
+	}
+	files, err := WriteAppInfoLocalizations(path, LocalizationFormatXLIFF, "", items)
+	if err != nil {
+		t.Fatalf("WriteAppInfoLocalizations() error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != path {
+		t.Fatalf("expected a single file at %s, got %+v", path, files)
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		t.Fatalf("expected %s to be an XLIFF file: %v", path, err)
+	}
+
+	values, _, err := ReadLocalizationValues(path, LocalizationFormatXLIFF, nil)
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if values["fr-FR"]["name"] != "Démo" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+}
+
+func TestWriteVersionLocalizations_JSON(t *testing.T) {
+	dir := t.TempDir()
+	items := versionLocalizationItems(map[string]asc.AppStoreVersionLocalizationAttributes{
+		"en-US": {Description: "Hello"},
+	})
+	if _, err := WriteVersionLocalizations(dir, LocalizationFormatJSON, "", items); err != nil {
+		t.Fatalf("WriteVersionLocalizations() error: %v", err)
+	}
+	values, _, err := ReadLocalizationValues(filepath.Join(dir, "en-US.json"), LocalizationFormatJSON, nil)
+	if err != nil {
+		t.Fatalf("ReadLocalizationValues() error: %v", err)
+	}
+	if values["en-US"]["description"] != "Hello" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+	if _, err := WriteVersionLocalizations(dir, LocalizationFormatJSON, "", items); err == nil {
+		t.Fatal("expected error when JSON output already exists")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -39,6 +40,14 @@ var (
 	}
 )
 
+// LocalizationKeys returns the metadata keys for a localization type.
+func LocalizationKeys(locType string) []string {
+	if locType == LocalizationTypeAppInfo {
+		return slices.Clone(appInfoLocalizationKeys)
+	}
+	return slices.Clone(versionLocalizationKeys)
+}
+
 func NormalizeLocalizationType(value string) (string, error) {
 	normalized := strings.ToLower(strings.TrimSpace(value))
 	switch normalized {
@@ -84,7 +93,7 @@ func writeLocalizationStrings(outputPath string, valuesByLocale map[string]map[s
 	}
 	sort.Strings(locales)
 
-	paths, err := resolveLocalizationOutputPaths(outputPath, locales)
+	paths, err := resolveLocalizationOutputPaths(outputPath, locales, ".strings")
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +130,13 @@ func isValidLocale(locale string) bool {
 	return localeValidationRegex.MatchString(locale)
 }
 
-func resolveLocalizationOutputPaths(outputPath string, locales []string) (map[string]string, error) {
+func resolveLocalizationOutputPaths(outputPath string, locales []string, ext string) (map[string]string, error) {
 	if strings.TrimSpace(outputPath) == "" {
 		outputPath = "localizations"
 	}
 
 	result := make(map[string]string, len(locales))
-	if strings.HasSuffix(outputPath, ".strings") {
+	if strings.HasSuffix(outputPath, ext) {
 		if len(locales) != 1 {
 			return nil, fmt.Errorf("output path %q requires exactly one locale", outputPath)
 		}
@@ -147,11 +156,37 @@ func resolveLocalizationOutputPaths(outputPath string, locales []string) (map[st
 		if !isValidLocale(locale) {
 			return nil, fmt.Errorf("invalid locale code %q: must match pattern like 'en', 'en-US', or 'zh-Hans'", locale)
 		}
-		result[locale] = filepath.Join(outputPath, locale+".strings")
+		result[locale] = filepath.Join(outputPath, locale+ext)
 	}
 	return result, nil
 }
 
+// VersionLocalizationValues maps version localizations to locale -> key -> value.
+func VersionLocalizationValues(items []asc.Resource[asc.AppStoreVersionLocalizationAttributes]) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(items))
+	for _, item := range items {
+		locale := strings.TrimSpace(item.Attributes.Locale)
+		if locale == "" {
+			continue
+		}
+		values[locale] = mapVersionLocalizationStrings(item.Attributes)
+	}
+	return values
+}
+
+// AppInfoLocalizationValues maps app info localizations to locale -> key -> value.
+func AppInfoLocalizationValues(items []asc.Resource[asc.AppInfoLocalizationAttributes]) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(items))
+	for _, item := range items {
+		locale := strings.TrimSpace(item.Attributes.Locale)
+		if locale == "" {
+			continue
+		}
+		values[locale] = mapAppInfoLocalizationStrings(item.Attributes)
+	}
+	return values
+}
+
 func mapVersionLocalizationStrings(attrs asc.AppStoreVersionLocalizationAttributes) map[string]string {
 	values := make(map[string]string)
 	setIfNotEmpty(values, "description", attrs.Description)
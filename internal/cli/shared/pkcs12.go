@@ -0,0 +1,323 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"unicode/utf16"
+)
+
+// PKCS#12 is encoded with pbeWithSHAAnd3-KeyTripleDES-CBC and a SHA-1 MAC,
+// the combination macOS keychains and Xcode import reliably.
+const pkcs12Iterations = 2048
+
+var (
+	oidData                   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidPBEWithSHAAnd3KeyTDES  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPKCS8ShroudedKeyBag    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag                = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidX509CertificateForBag  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidSHA1                   = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	errPKCS12UnsupportedInput = errors.New("pkcs12: unsupported input")
+)
+
+type pkcs12PFX struct {
+	Version  int
+	AuthSafe pkcs12ContentInfo
+	MacData  pkcs12MacData `asn1:"optional"`
+}
+
+type pkcs12ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type pkcs12EncryptedData struct {
+	Version              int
+	EncryptedContentInfo pkcs12EncryptedContentInfo
+}
+
+type pkcs12EncryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkcs12AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type pkcs12AlgorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type pkcs12PBEParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type pkcs12MacData struct {
+	Mac        pkcs12DigestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type pkcs12DigestInfo struct {
+	Algorithm pkcs12AlgorithmIdentifier
+	Digest    []byte
+}
+
+type pkcs12SafeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue     `asn1:"tag:0,explicit"`
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+type pkcs12CertBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type pkcs12EncryptedPrivateKeyInfo struct {
+	AlgorithmIdentifier pkcs12AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// EncodePKCS12 bundles a private key and its certificate into a
+// password-protected PKCS#12 (.p12) file.
+func EncodePKCS12(privateKey any, certificate *x509.Certificate, friendlyName, password string) ([]byte, error) {
+	if privateKey == nil || certificate == nil {
+		return nil, errPKCS12UnsupportedInput
+	}
+	encodedPassword, err := pkcs12BMPString(password)
+	if err != nil {
+		return nil, err
+	}
+
+	localKeyID := sha1.Sum(certificate.Raw)
+	attributes, err := pkcs12BagAttributes(localKeyID[:], friendlyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Certificate bag, encrypted as a whole.
+	certBag, err := asn1.Marshal(pkcs12CertBag{ID: oidX509CertificateForBag, Data: certificate.Raw})
+	if err != nil {
+		return nil, err
+	}
+	certContents, err := asn1.Marshal([]pkcs12SafeBag{{
+		ID:         oidCertBag,
+		Value:      asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certBag},
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	certAlgorithm, encryptedCerts, err := pkcs12Encrypt(certContents, encodedPassword)
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, err := asn1.Marshal(pkcs12EncryptedData{
+		Version: 0,
+		EncryptedContentInfo: pkcs12EncryptedContentInfo{
+			ContentType:                oidData,
+			ContentEncryptionAlgorithm: certAlgorithm,
+			EncryptedContent:           encryptedCerts,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Shrouded key bag in plain data content.
+	keyInfo, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	keyAlgorithm, encryptedKey, err := pkcs12Encrypt(keyInfo, encodedPassword)
+	if err != nil {
+		return nil, err
+	}
+	shroudedKey, err := asn1.Marshal(pkcs12EncryptedPrivateKeyInfo{AlgorithmIdentifier: keyAlgorithm, EncryptedData: encryptedKey})
+	if err != nil {
+		return nil, err
+	}
+	keyContents, err := asn1.Marshal([]pkcs12SafeBag{{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: shroudedKey},
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	keyOctets, err := asn1.Marshal(keyContents)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatedSafe, err := asn1.Marshal([]pkcs12ContentInfo{
+		{ContentType: oidEncryptedData, Content: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: encryptedData}},
+		{ContentType: oidData, Content: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: keyOctets}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	macSalt := make([]byte, 8)
+	if _, err := rand.Read(macSalt); err != nil {
+		return nil, err
+	}
+	macKey := pkcs12DeriveKey(macSalt, encodedPassword, pkcs12Iterations, 3, sha1.Size)
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(authenticatedSafe)
+
+	authSafeOctets, err := asn1.Marshal(authenticatedSafe)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs12PFX{
+		Version: 3,
+		AuthSafe: pkcs12ContentInfo{
+			ContentType: oidData,
+			Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: authSafeOctets},
+		},
+		MacData: pkcs12MacData{
+			Mac: pkcs12DigestInfo{
+				Algorithm: pkcs12AlgorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+				Digest:    mac.Sum(nil),
+			},
+			MacSalt:    macSalt,
+			Iterations: pkcs12Iterations,
+		},
+	})
+}
+
+func pkcs12BagAttributes(localKeyID []byte, friendlyName string) ([]pkcs12Attribute, error) {
+	keyID, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, err
+	}
+	attributes := []pkcs12Attribute{{ID: oidLocalKeyID, Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: keyID}}}
+	if friendlyName != "" {
+		name, err := pkcs12BMPString(friendlyName)
+		if err != nil {
+			return nil, err
+		}
+		// Drop the terminating NUL that password encoding adds.
+		encoded, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagBMPString, Bytes: name[:len(name)-2]})
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, pkcs12Attribute{ID: oidFriendlyName, Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: encoded}})
+	}
+	return attributes, nil
+}
+
+// pkcs12BMPString encodes s as NUL-terminated UTF-16BE (RFC 7292, B.1).
+func pkcs12BMPString(s string) ([]byte, error) {
+	out := make([]byte, 0, 2*len(s)+2)
+	for _, r := range s {
+		if r > 0xffff {
+			return nil, errors.New("pkcs12: passwords and names must be in the Basic Multilingual Plane")
+		}
+		for _, unit := range utf16.Encode([]rune{r}) {
+			out = append(out, byte(unit>>8), byte(unit))
+		}
+	}
+	return append(out, 0, 0), nil
+}
+
+// pkcs12Encrypt encrypts data with pbeWithSHAAnd3-KeyTripleDES-CBC and a
+// random salt.
+func pkcs12Encrypt(data, password []byte) (pkcs12AlgorithmIdentifier, []byte, error) {
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return pkcs12AlgorithmIdentifier{}, nil, err
+	}
+	params, err := asn1.Marshal(pkcs12PBEParams{Salt: salt, Iterations: pkcs12Iterations})
+	if err != nil {
+		return pkcs12AlgorithmIdentifier{}, nil, err
+	}
+	key := pkcs12DeriveKey(salt, password, pkcs12Iterations, 1, 24)
+	iv := pkcs12DeriveKey(salt, password, pkcs12Iterations, 2, des.BlockSize)
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return pkcs12AlgorithmIdentifier{}, nil, err
+	}
+	padding := des.BlockSize - len(data)%des.BlockSize
+	encrypted := append(append([]byte(nil), data...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+	return pkcs12AlgorithmIdentifier{Algorithm: oidPBEWithSHAAnd3KeyTDES, Parameters: asn1.RawValue{FullBytes: params}}, encrypted, nil
+}
+
+// pkcs12DeriveKey implements the PKCS#12 key derivation function with SHA-1
+// (RFC 7292, Appendix B.2). id is 1 for keys, 2 for IVs and 3 for MAC keys.
+func pkcs12DeriveKey(salt, password []byte, iterations int, id byte, size int) []byte {
+	return pkcs12DeriveKeyHash(sha1.New, salt, password, iterations, id, size)
+}
+
+// pkcs12DeriveKeyHash is pkcs12DeriveKey with a caller-chosen hash, as used
+// by MACs with SHA-256.
+func pkcs12DeriveKeyHash(newHash func() hash.Hash, salt, password []byte, iterations int, id byte, size int) []byte {
+	u, v := newHash().Size(), newHash().BlockSize()
+
+	fill := func(src []byte) []byte {
+		if len(src) == 0 {
+			return nil
+		}
+		out := make([]byte, v*((len(src)+v-1)/v))
+		for i := range out {
+			out[i] = src[i%len(src)]
+		}
+		return out
+	}
+	diversifier := bytes.Repeat([]byte{id}, v)
+	input := append(fill(salt), fill(password)...)
+
+	one := big.NewInt(1)
+	out := make([]byte, 0, size+u)
+	for len(out) < size {
+		h := newHash()
+		h.Write(diversifier)
+		h.Write(input)
+		digest := h.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			h.Reset()
+			h.Write(digest)
+			digest = h.Sum(digest[:0])
+		}
+		out = append(out, digest...)
+		if len(out) >= size {
+			break
+		}
+
+		increment := new(big.Int).SetBytes(fill(digest)[:v])
+		increment.Add(increment, one)
+		for j := 0; j < len(input); j += v {
+			block := new(big.Int).SetBytes(input[j : j+v])
+			block.Add(block, increment)
+			sum := block.Bytes()
+			// Keep the low v bytes (addition modulo 2^(8v)).
+			if len(sum) > v {
+				sum = sum[len(sum)-v:]
+			}
+			clear(input[j : j+v])
+			copy(input[j+v-len(sum):j+v], sum)
+		}
+	}
+	return out[:size]
+}
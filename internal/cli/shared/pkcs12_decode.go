@@ -0,0 +1,310 @@
+package shared
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var (
+	oidKeyBag         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 1}
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC     = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+
+	// ErrPKCS12Password is returned when a .p12 MAC does not verify.
+	ErrPKCS12Password = errors.New("pkcs12: incorrect password")
+)
+
+type pkcs12PBES2Params struct {
+	KeyDerivationFunc pkcs12AlgorithmIdentifier
+	EncryptionScheme  pkcs12AlgorithmIdentifier
+}
+
+type pkcs12PBKDF2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                       `asn1:"optional"`
+	PRF        pkcs12AlgorithmIdentifier `asn1:"optional"`
+}
+
+// PKCS12Contents is the decoded content of a .p12 bundle.
+type PKCS12Contents struct {
+	PrivateKey  any
+	Certificate *x509.Certificate
+	CACerts     []*x509.Certificate
+}
+
+// DecodePKCS12 decodes a password-protected PKCS#12 bundle. It supports the
+// legacy pbeWithSHAAnd3-KeyTripleDES-CBC scheme and PBES2 (PBKDF2 with
+// AES-CBC or 3DES), with SHA-1 or SHA-256 MACs. Certificate is the one
+// matching the private key, or the first certificate when there is no key.
+func DecodePKCS12(data []byte, password string) (*PKCS12Contents, error) {
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	var pfx pkcs12PFX
+	if _, err := asn1.Unmarshal(der, &pfx); err != nil {
+		return nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	if pfx.Version != 3 {
+		return nil, fmt.Errorf("pkcs12: unsupported version %d", pfx.Version)
+	}
+	if !pfx.AuthSafe.ContentType.Equal(oidData) {
+		return nil, errors.New("pkcs12: only password-integrity bundles are supported")
+	}
+	var authSafe []byte
+	if _, err := asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		return nil, fmt.Errorf("pkcs12: %w", err)
+	}
+
+	bmpPassword, err := pkcs12BMPString(password)
+	if err != nil {
+		return nil, err
+	}
+	if len(pfx.MacData.Mac.Digest) > 0 {
+		if err := pkcs12VerifyMAC(pfx.MacData, authSafe, bmpPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	var contents []pkcs12ContentInfo
+	if _, err := asn1.Unmarshal(authSafe, &contents); err != nil {
+		return nil, fmt.Errorf("pkcs12: %w", err)
+	}
+
+	var (
+		keys  []any
+		certs []*x509.Certificate
+	)
+	for _, content := range contents {
+		var safeContents []byte
+		switch {
+		case content.ContentType.Equal(oidData):
+			if _, err := asn1.Unmarshal(content.Content.Bytes, &safeContents); err != nil {
+				return nil, fmt.Errorf("pkcs12: %w", err)
+			}
+		case content.ContentType.Equal(oidEncryptedData):
+			var encrypted pkcs12EncryptedData
+			if _, err := asn1.Unmarshal(content.Content.Bytes, &encrypted); err != nil {
+				return nil, fmt.Errorf("pkcs12: %w", err)
+			}
+			info := encrypted.EncryptedContentInfo
+			safeContents, err = pkcs12Decrypt(info.ContentEncryptionAlgorithm, info.EncryptedContent, password, bmpPassword)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("pkcs12: unsupported content type %s", content.ContentType)
+		}
+
+		var bags []pkcs12SafeBag
+		if _, err := asn1.Unmarshal(safeContents, &bags); err != nil {
+			return nil, fmt.Errorf("pkcs12: %w", err)
+		}
+		for _, bag := range bags {
+			switch {
+			case bag.ID.Equal(oidCertBag):
+				var certBag pkcs12CertBag
+				if _, err := asn1.Unmarshal(bag.Value.Bytes, &certBag); err != nil {
+					return nil, fmt.Errorf("pkcs12: %w", err)
+				}
+				if !certBag.ID.Equal(oidX509CertificateForBag) {
+					continue
+				}
+				cert, err := x509.ParseCertificate(certBag.Data)
+				if err != nil {
+					return nil, fmt.Errorf("pkcs12: %w", err)
+				}
+				certs = append(certs, cert)
+			case bag.ID.Equal(oidKeyBag):
+				key, err := x509.ParsePKCS8PrivateKey(bag.Value.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("pkcs12: %w", err)
+				}
+				keys = append(keys, key)
+			case bag.ID.Equal(oidPKCS8ShroudedKeyBag):
+				var shrouded pkcs12EncryptedPrivateKeyInfo
+				if _, err := asn1.Unmarshal(bag.Value.Bytes, &shrouded); err != nil {
+					return nil, fmt.Errorf("pkcs12: %w", err)
+				}
+				plain, err := pkcs12Decrypt(shrouded.AlgorithmIdentifier, shrouded.EncryptedData, password, bmpPassword)
+				if err != nil {
+					return nil, err
+				}
+				key, err := x509.ParsePKCS8PrivateKey(plain)
+				if err != nil {
+					return nil, fmt.Errorf("pkcs12: %w", err)
+				}
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("pkcs12: no certificates found")
+	}
+
+	result := &PKCS12Contents{}
+	if len(keys) > 0 {
+		result.PrivateKey = keys[0]
+		for _, cert := range certs {
+			if result.Certificate == nil && PrivateKeyMatchesCertificate(keys[0], cert) {
+				result.Certificate = cert
+				continue
+			}
+			result.CACerts = append(result.CACerts, cert)
+		}
+		if result.Certificate == nil {
+			return nil, errors.New("pkcs12: private key does not match any certificate")
+		}
+		return result, nil
+	}
+	result.Certificate = certs[0]
+	result.CACerts = certs[1:]
+	return result, nil
+}
+
+// PrivateKeyMatchesCertificate reports whether key is the private half of
+// the certificate's public key.
+func PrivateKeyMatchesCertificate(key any, cert *x509.Certificate) bool {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return false
+	}
+	public, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && public.Equal(cert.PublicKey)
+}
+
+func pkcs12VerifyMAC(macData pkcs12MacData, authSafe, password []byte) error {
+	var newHash func() hash.Hash
+	switch {
+	case macData.Mac.Algorithm.Algorithm.Equal(oidSHA1):
+		newHash = sha1.New
+	case macData.Mac.Algorithm.Algorithm.Equal(oidSHA256):
+		newHash = sha256.New
+	default:
+		return fmt.Errorf("pkcs12: unsupported MAC algorithm %s", macData.Mac.Algorithm.Algorithm)
+	}
+	key := pkcs12DeriveKeyHash(newHash, macData.MacSalt, password, macData.Iterations, 3, newHash().Size())
+	mac := hmac.New(newHash, key)
+	mac.Write(authSafe)
+	if !hmac.Equal(mac.Sum(nil), macData.Mac.Digest) {
+		return ErrPKCS12Password
+	}
+	return nil
+}
+
+// pkcs12Decrypt decrypts bag or content data. Legacy PKCS#12 PBE uses the
+// BMP-encoded password; PBES2 uses the UTF-8 password.
+func pkcs12Decrypt(algorithm pkcs12AlgorithmIdentifier, data []byte, password string, bmpPassword []byte) ([]byte, error) {
+	var (
+		block cipher.Block
+		iv    []byte
+		err   error
+	)
+	switch {
+	case algorithm.Algorithm.Equal(oidPBEWithSHAAnd3KeyTDES):
+		var params pkcs12PBEParams
+		if _, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("pkcs12: %w", err)
+		}
+		block, err = des.NewTripleDESCipher(pkcs12DeriveKey(params.Salt, bmpPassword, params.Iterations, 1, 24))
+		if err != nil {
+			return nil, err
+		}
+		iv = pkcs12DeriveKey(params.Salt, bmpPassword, params.Iterations, 2, des.BlockSize)
+	case algorithm.Algorithm.Equal(oidPBES2):
+		block, iv, err = pkcs12PBES2Cipher(algorithm.Parameters.FullBytes, password)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("pkcs12: unsupported encryption algorithm %s; re-export the .p12 with AES or 3DES", algorithm.Algorithm)
+	}
+
+	size := block.BlockSize()
+	if len(data) == 0 || len(data)%size != 0 {
+		return nil, errors.New("pkcs12: invalid encrypted data length")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > size || !bytes.Equal(plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, ErrPKCS12Password
+	}
+	return plain[:len(plain)-padding], nil
+}
+
+func pkcs12PBES2Cipher(parameters []byte, password string) (cipher.Block, []byte, error) {
+	var params pkcs12PBES2Params
+	if _, err := asn1.Unmarshal(parameters, &params); err != nil {
+		return nil, nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, nil, fmt.Errorf("pkcs12: unsupported key derivation %s", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pkcs12PBKDF2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	newHash := sha1.New
+	switch prf := kdf.PRF.Algorithm; {
+	case len(prf) == 0, prf.Equal(oidHMACWithSHA1):
+	case prf.Equal(oidHMACWithSHA256):
+		newHash = sha256.New
+	default:
+		return nil, nil, fmt.Errorf("pkcs12: unsupported PBKDF2 PRF %s", prf)
+	}
+
+	var keySize int
+	scheme := params.EncryptionScheme.Algorithm
+	switch {
+	case scheme.Equal(oidAES128CBC):
+		keySize = 16
+	case scheme.Equal(oidAES192CBC), scheme.Equal(oidDESEDE3CBC):
+		keySize = 24
+	case scheme.Equal(oidAES256CBC):
+		keySize = 32
+	default:
+		return nil, nil, fmt.Errorf("pkcs12: unsupported encryption scheme %s", scheme)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, nil, fmt.Errorf("pkcs12: %w", err)
+	}
+
+	key, err := pbkdf2.Key(newHash, password, kdf.Salt, kdf.Iterations, keySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pkcs12: %w", err)
+	}
+	var block cipher.Block
+	if scheme.Equal(oidDESEDE3CBC) {
+		block, err = des.NewTripleDESCipher(key)
+	} else {
+		block, err = aes.NewCipher(key)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, nil, errors.New("pkcs12: invalid IV length")
+	}
+	return block, iv, nil
+}
@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSigningCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := GenerateSigningKey(0)
+	if err != nil {
+		t.Fatalf("GenerateSigningKey() error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Example (TEAM123456)"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return key, cert
+}
+
+func TestEncodePKCS12RoundTrip(t *testing.T) {
+	key, cert := testSigningCertificate(t)
+	data, err := EncodePKCS12(key, cert, cert.Subject.CommonName, "s3cret")
+	if err != nil {
+		t.Fatalf("EncodePKCS12() error: %v", err)
+	}
+
+	var pfx pkcs12PFX
+	if rest, err := asn1.Unmarshal(data, &pfx); err != nil || len(rest) != 0 {
+		t.Fatalf("unmarshal PFX: %v (rest %d)", err, len(rest))
+	}
+	var authSafe []byte
+	if _, err := asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		t.Fatalf("unmarshal authSafe: %v", err)
+	}
+	password, _ := pkcs12BMPString("s3cret")
+	mac := hmac.New(sha1.New, pkcs12DeriveKey(pfx.MacData.MacSalt, password, pfx.MacData.Iterations, 3, sha1.Size))
+	mac.Write(authSafe)
+	if !hmac.Equal(mac.Sum(nil), pfx.MacData.Mac.Digest) {
+		t.Fatal("MAC does not verify")
+	}
+
+	var contents []pkcs12ContentInfo
+	if _, err := asn1.Unmarshal(authSafe, &contents); err != nil || len(contents) != 2 {
+		t.Fatalf("unmarshal contents: %v (%d)", err, len(contents))
+	}
+	var keyContents []byte
+	if _, err := asn1.Unmarshal(contents[1].Content.Bytes, &keyContents); err != nil {
+		t.Fatalf("unmarshal key contents: %v", err)
+	}
+	var bags []pkcs12SafeBag
+	if _, err := asn1.Unmarshal(keyContents, &bags); err != nil || len(bags) != 1 {
+		t.Fatalf("unmarshal key bags: %v", err)
+	}
+	var shrouded pkcs12EncryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(bags[0].Value.Bytes, &shrouded); err != nil {
+		t.Fatalf("unmarshal shrouded key: %v", err)
+	}
+	var params pkcs12PBEParams
+	if _, err := asn1.Unmarshal(shrouded.AlgorithmIdentifier.Parameters.FullBytes, &params); err != nil {
+		t.Fatalf("unmarshal PBE params: %v", err)
+	}
+	block, err := des.NewTripleDESCipher(pkcs12DeriveKey(params.Salt, password, params.Iterations, 1, 24))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := make([]byte, len(shrouded.EncryptedData))
+	cipher.NewCBCDecrypter(block, pkcs12DeriveKey(params.Salt, password, params.Iterations, 2, 8)).CryptBlocks(plain, shrouded.EncryptedData)
+	plain = plain[:len(plain)-int(plain[len(plain)-1])]
+	decoded, err := x509.ParsePKCS8PrivateKey(plain)
+	if err != nil {
+		t.Fatalf("parse decrypted key: %v", err)
+	}
+	if !key.Equal(decoded) {
+		t.Fatal("decrypted key does not match")
+	}
+}
+
+func TestEncodePKCS12OpenSSL(t *testing.T) {
+	opensslPath, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not available")
+	}
+	key, cert := testSigningCertificate(t)
+	data, err := EncodePKCS12(key, cert, cert.Subject.CommonName, "s3cret")
+	if err != nil {
+		t.Fatalf("EncodePKCS12() error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "cert.p12")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(opensslPath, "pkcs12", "-in", path, "-nodes", "-passin", "pass:s3cret").CombinedOutput()
+	if err != nil {
+		t.Fatalf("openssl pkcs12 failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{"friendlyName: Apple Distribution: Example (TEAM123456)", "BEGIN CERTIFICATE", "BEGIN PRIVATE KEY"} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("expected openssl output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if out, err := exec.Command(opensslPath, "pkcs12", "-in", path, "-nodes", "-passin", "pass:wrong").CombinedOutput(); err == nil {
+		t.Fatalf("expected wrong password to fail, got:\n%s", out)
+	}
+}
+
+func TestPKCS12DeriveKeyLength(t *testing.T) {
+	password, _ := pkcs12BMPString("password")
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	key := pkcs12DeriveKey(salt, password, 1, 1, 24)
+	if len(key) != 24 {
+		t.Fatalf("expected 24 byte key, got %d", len(key))
+	}
+	if bytes.Equal(key[:8], pkcs12DeriveKey(salt, password, 1, 2, 8)) {
+		t.Fatal("expected key and IV diversifiers to differ")
+	}
+}
+
+func TestCreateCertificateSigningRequest(t *testing.T) {
+	key, err := GenerateSigningKey(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := CreateCertificateSigningRequest(key, "asc", "ci@example.com")
+	if err != nil {
+		t.Fatalf("CreateCertificateSigningRequest() error: %v", err)
+	}
+	der, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		t.Fatalf("CSR signature: %v", err)
+	}
+	if csr.Subject.CommonName != "asc" || csr.EmailAddresses[0] != "ci@example.com" || !key.PublicKey.Equal(csr.PublicKey) {
+		t.Fatalf("unexpected CSR: %+v", csr.Subject)
+	}
+	if _, err := GenerateSigningKey(1024); err == nil {
+		t.Fatal("expected small key size to be rejected")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // WriteProfileFile writes provisioning profile data to disk securely.
@@ -26,3 +27,18 @@ func WriteProfileFile(path string, content []byte) error {
 	}
 	return file.Sync()
 }
+
+// ReplaceProfileFile atomically replaces the profile file at path.
+func ReplaceProfileFile(path string, content []byte) error {
+	return WriteFileAtomic(path, content, 0o644)
+}
+
+// ProfileFileName returns a .mobileprovision file name for a profile name,
+// using fallback (such as the profile ID) when the name is empty.
+func ProfileFileName(name, fallback string) string {
+	clean := strings.Trim(strings.NewReplacer("/", "_", "\\", "_").Replace(strings.TrimSpace(name)), ". ")
+	if clean == "" {
+		clean = fallback
+	}
+	return clean + ".mobileprovision"
+}
@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// ListAllDevices pages through every registered device matching opts.
+func ListAllDevices(ctx context.Context, client *asc.Client, opts ...asc.DevicesOption) ([]asc.Resource[asc.DeviceAttributes], error) {
+	var all []asc.Resource[asc.DeviceAttributes]
+	next := ""
+	for {
+		pageOpts := append(append([]asc.DevicesOption{}, opts...), asc.WithDevicesLimit(200), asc.WithDevicesNextURL(next))
+		resp, err := client.GetDevices(ctx, pageOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("fetch devices: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// ListProfileLinkageIDs pages through a profile relationship, such as
+// client.GetProfileCertificatesRelationships, and returns the linked IDs.
+func ListProfileLinkageIDs(ctx context.Context, profileID string, fetch func(context.Context, string, ...asc.LinkagesOption) (*asc.LinkagesResponse, error)) ([]string, error) {
+	var ids []string
+	next := ""
+	for {
+		resp, err := fetch(ctx, profileID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Data {
+			ids = append(ids, item.ID)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return ids, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// FindBundleID returns the bundle ID registered with exactly identifier.
+func FindBundleID(ctx context.Context, client *asc.Client, identifier string) (*asc.Resource[asc.BundleIDAttributes], error) {
+	resp, err := client.GetBundleIDs(ctx, asc.WithBundleIDsFilterIdentifier(identifier))
+	if err != nil {
+		return nil, fmt.Errorf("fetch bundle ID: %w", err)
+	}
+	for i := range resp.Data {
+		if resp.Data[i].Attributes.Identifier == identifier {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, fmt.Errorf("bundle ID not found: %s", identifier)
+}
+
+// ParseAPIDate parses the dates returned by the provisioning API, which use
+// either RFC 3339 or a numeric zone offset without a colon.
+func ParseAPIDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// ProfileBundleIDRelationship returns the bundle ID resource ID from a
+// profile's relationships, or "" when it is absent.
+func ProfileBundleIDRelationship(relationships json.RawMessage) string {
+	var parsed struct {
+		BundleID struct {
+			Data *asc.ResourceData `json:"data"`
+		} `json:"bundleId"`
+	}
+	if len(relationships) == 0 || json.Unmarshal(relationships, &parsed) != nil || parsed.BundleID.Data == nil {
+		return ""
+	}
+	return parsed.BundleID.Data.ID
+}
+
+// IncludedBundleIdentifiers maps bundle ID resource IDs to identifiers from
+// the included resources of a profiles response fetched with include=bundleId.
+func IncludedBundleIdentifiers(included json.RawMessage) (map[string]string, error) {
+	identifiers := map[string]string{}
+	if len(included) == 0 {
+		return identifiers, nil
+	}
+	var resources []asc.Resource[asc.BundleIDAttributes]
+	if err := json.Unmarshal(included, &resources); err != nil {
+		return nil, fmt.Errorf("parse included bundle IDs: %w", err)
+	}
+	for _, resource := range resources {
+		if resource.Type == asc.ResourceTypeBundleIds {
+			identifiers[resource.ID] = resource.Attributes.Identifier
+		}
+	}
+	return identifiers, nil
+}
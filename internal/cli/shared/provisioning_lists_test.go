@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseAPIDate(t *testing.T) {
+	for _, value := range []string{"2030-01-02T03:04:05Z", "2030-01-02T03:04:05.000+0000", "2030-01-02T03:04:05.000Z"} {
+		parsed, err := ParseAPIDate(value)
+		if err != nil {
+			t.Fatalf("ParseAPIDate(%q) error: %v", value, err)
+		}
+		if !parsed.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Fatalf("ParseAPIDate(%q) = %v", value, parsed)
+		}
+	}
+	if _, err := ParseAPIDate("soon"); err == nil {
+		t.Fatal("expected invalid date to fail")
+	}
+}
+
+func TestProfileBundleIdentifiers(t *testing.T) {
+	relationships := json.RawMessage(`{"bundleId":{"data":{"type":"bundleIds","id":"B1"}}}`)
+	if got := ProfileBundleIDRelationship(relationships); got != "B1" {
+		t.Fatalf("ProfileBundleIDRelationship() = %q, want B1", got)
+	}
+	if got := ProfileBundleIDRelationship(json.RawMessage(`{"bundleId":{}}`)); got != "" {
+		t.Fatalf("expected a missing bundle ID to be empty, got %q", got)
+	}
+
+	identifiers, err := IncludedBundleIdentifiers(json.RawMessage(`[
+		{"type":"bundleIds","id":"B1","attributes":{"identifier":"com.example.app"}},
+		{"type":"certificates","id":"C1","attributes":{}}
+	]`))
+	if err != nil || len(identifiers) != 1 || identifiers["B1"] != "com.example.app" {
+		t.Fatalf("IncludedBundleIdentifiers() = %v, %v", identifiers, err)
+	}
+}
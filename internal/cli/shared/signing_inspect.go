@@ -0,0 +1,278 @@
+package shared
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// Provisioning profile kinds reported by ParseProvisioningProfile.
+const (
+	ProfileKindDevelopment = "development"
+	ProfileKindAdHoc       = "ad-hoc"
+	ProfileKindAppStore    = "app-store"
+	ProfileKindEnterprise  = "enterprise"
+)
+
+// Certificate cross-check statuses.
+const (
+	CertificateStatusActive   = "ACTIVE"
+	CertificateStatusExpired  = "EXPIRED"
+	CertificateStatusNotFound = "NOT_FOUND"
+)
+
+const inspectLookupBatch = 50
+
+// provisioningProfilePlist is the plist embedded in a .mobileprovision file.
+type provisioningProfilePlist struct {
+	AppIDName             string         `plist:"AppIDName"`
+	CreationDate          time.Time      `plist:"CreationDate"`
+	ExpirationDate        time.Time      `plist:"ExpirationDate"`
+	Name                  string         `plist:"Name"`
+	Platform              []string       `plist:"Platform"`
+	ProvisionedDevices    []string       `plist:"ProvisionedDevices"`
+	ProvisionsAllDevices  bool           `plist:"ProvisionsAllDevices"`
+	TeamIdentifier        []string       `plist:"TeamIdentifier"`
+	TeamName              string         `plist:"TeamName"`
+	UUID                  string         `plist:"UUID"`
+	DeveloperCertificates [][]byte       `plist:"DeveloperCertificates"`
+	Entitlements          map[string]any `plist:"Entitlements"`
+}
+
+// ParseProvisioningProfile parses a .mobileprovision file: it verifies the
+// CMS signature and the chain to an Apple root, and decodes the embedded
+// plist. Signature and chain failures are reported on the result rather than
+// returned as errors.
+func ParseProvisioningProfile(data []byte, now time.Time) (*asc.InspectedProfile, error) {
+	signed, err := ParseSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	var content provisioningProfilePlist
+	if _, err := plist.Unmarshal(signed.Content, &content); err != nil {
+		return nil, fmt.Errorf("parse profile plist: %w", err)
+	}
+
+	profile := &asc.InspectedProfile{
+		Name:                 content.Name,
+		UUID:                 content.UUID,
+		AppIDName:            content.AppIDName,
+		TeamName:             content.TeamName,
+		Platforms:            content.Platform,
+		ExpirationDate:       content.ExpirationDate.UTC().Format(time.RFC3339),
+		Expired:              !content.ExpirationDate.After(now),
+		ProvisionsAllDevices: content.ProvisionsAllDevices,
+		Entitlements:         content.Entitlements,
+		Certificates:         []asc.InspectedCertificate{},
+	}
+	if !content.CreationDate.IsZero() {
+		profile.CreationDate = content.CreationDate.UTC().Format(time.RFC3339)
+	}
+	if len(content.TeamIdentifier) > 0 {
+		profile.TeamID = content.TeamIdentifier[0]
+	}
+	if appID, ok := content.Entitlements["application-identifier"].(string); ok {
+		profile.ApplicationID = appID
+	} else if appID, ok := content.Entitlements["com.apple.application-identifier"].(string); ok {
+		profile.ApplicationID = appID
+	}
+	if profile.ApplicationID != "" {
+		if _, bundleID, ok := strings.Cut(profile.ApplicationID, "."); ok {
+			profile.BundleID = bundleID
+		}
+	}
+	profile.Kind = provisioningProfileKind(content)
+
+	for _, der := range content.DeveloperCertificates {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse developer certificate: %w", err)
+		}
+		profile.Certificates = append(profile.Certificates, InspectCertificate(cert, now))
+	}
+	for _, udid := range content.ProvisionedDevices {
+		profile.Devices = append(profile.Devices, asc.InspectedProfileDevice{UDID: udid})
+	}
+
+	signers, err := signed.Verify()
+	if err != nil {
+		profile.ChainError = err.Error()
+		return profile, nil
+	}
+	profile.SignatureValid = true
+	profile.Signer = signers[0].Subject.CommonName
+	if _, err := VerifyAppleCertificateChain(signers[0], signed.Certificates, now); err != nil {
+		profile.ChainError = err.Error()
+	} else {
+		profile.ChainVerified = true
+	}
+	return profile, nil
+}
+
+func provisioningProfileKind(content provisioningProfilePlist) string {
+	getTaskAllow, _ := content.Entitlements["get-task-allow"].(bool)
+	switch {
+	case getTaskAllow:
+		return ProfileKindDevelopment
+	case content.ProvisionsAllDevices:
+		return ProfileKindEnterprise
+	case len(content.ProvisionedDevices) > 0:
+		return ProfileKindAdHoc
+	default:
+		return ProfileKindAppStore
+	}
+}
+
+// InspectCertificate summarizes a signing certificate. Apple signing
+// certificates carry the team ID in the subject's organizational unit.
+func InspectCertificate(cert *x509.Certificate, now time.Time) asc.InspectedCertificate {
+	sha1Sum, sha256Sum := CertificateFingerprints(cert)
+	inspected := asc.InspectedCertificate{
+		CommonName:   cert.Subject.CommonName,
+		Issuer:       cert.Issuer.CommonName,
+		SerialNumber: CertificateSerialNumber(cert),
+		SHA1:         sha1Sum,
+		SHA256:       sha256Sum,
+		NotBefore:    cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:     cert.NotAfter.UTC().Format(time.RFC3339),
+		Expired:      !cert.NotAfter.After(now),
+	}
+	if len(cert.Subject.OrganizationalUnit) > 0 {
+		inspected.TeamID = cert.Subject.OrganizationalUnit[0]
+	}
+	if len(cert.Subject.Organization) > 0 {
+		inspected.TeamName = cert.Subject.Organization[0]
+	}
+	return inspected
+}
+
+// CertificateSerialNumber formats a serial number the way the certificates
+// API reports it: uppercase hex without leading zeros.
+func CertificateSerialNumber(cert *x509.Certificate) string {
+	return strings.ToUpper(cert.SerialNumber.Text(16))
+}
+
+// CrossCheckCertificates looks up each certificate by serial number and
+// records whether App Store Connect still lists it.
+func CrossCheckCertificates(ctx context.Context, client *asc.Client, certs []asc.InspectedCertificate) error {
+	serials := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		serials = append(serials, cert.SerialNumber)
+	}
+	found := map[string]asc.Resource[asc.CertificateAttributes]{}
+	for start := 0; start < len(serials); start += inspectLookupBatch {
+		end := min(start+inspectLookupBatch, len(serials))
+		resp, err := client.GetCertificates(ctx,
+			asc.WithCertificatesFilterSerialNumbers(serials[start:end]),
+			asc.WithCertificatesLimit(200),
+		)
+		if err != nil {
+			return fmt.Errorf("fetch certificates: %w", err)
+		}
+		for _, item := range resp.Data {
+			found[normalizeSerialNumber(item.Attributes.SerialNumber)] = item
+		}
+	}
+
+	for i := range certs {
+		item, ok := found[normalizeSerialNumber(certs[i].SerialNumber)]
+		switch {
+		case !ok:
+			certs[i].APIStatus = CertificateStatusNotFound
+		case certs[i].Expired:
+			certs[i].APIStatus = CertificateStatusExpired
+		default:
+			certs[i].APIStatus = CertificateStatusActive
+		}
+		if ok {
+			certs[i].APIID = item.ID
+			certs[i].APIType = item.Attributes.CertificateType
+		}
+	}
+	return nil
+}
+
+// CrossCheckProfileDevices looks up the provisioned devices by UDID and
+// records their status (ENABLED, DISABLED or NOT_FOUND).
+func CrossCheckProfileDevices(ctx context.Context, client *asc.Client, devices []asc.InspectedProfileDevice) error {
+	found := map[string]asc.Resource[asc.DeviceAttributes]{}
+	for start := 0; start < len(devices); start += inspectLookupBatch {
+		end := min(start+inspectLookupBatch, len(devices))
+		udids := make([]string, 0, end-start)
+		for _, device := range devices[start:end] {
+			udids = append(udids, device.UDID)
+		}
+		resp, err := client.GetDevices(ctx,
+			asc.WithDevicesFilterUDIDs(udids),
+			asc.WithDevicesLimit(200),
+		)
+		if err != nil {
+			return fmt.Errorf("fetch devices: %w", err)
+		}
+		for _, item := range resp.Data {
+			found[strings.ToUpper(item.Attributes.UDID)] = item
+		}
+	}
+
+	for i := range devices {
+		item, ok := found[strings.ToUpper(devices[i].UDID)]
+		if !ok {
+			devices[i].APIStatus = CertificateStatusNotFound
+			continue
+		}
+		devices[i].APIID = item.ID
+		devices[i].Name = item.Attributes.Name
+		devices[i].APIStatus = string(item.Attributes.Status)
+	}
+	return nil
+}
+
+// ProfileProblems lists the reasons a profile cannot be used to sign.
+func ProfileProblems(profile *asc.InspectedProfile) []string {
+	var problems []string
+	if profile.Expired {
+		problems = append(problems, "profile expired on "+profile.ExpirationDate)
+	}
+	if !profile.SignatureValid {
+		problems = append(problems, "invalid profile signature")
+	} else if !profile.ChainVerified {
+		problems = append(problems, "signature chain not verified")
+	}
+	usable := 0
+	for _, cert := range profile.Certificates {
+		switch {
+		case cert.Expired:
+			problems = append(problems, fmt.Sprintf("certificate %s expired", cert.SerialNumber))
+		case cert.APIStatus == CertificateStatusNotFound:
+			problems = append(problems, fmt.Sprintf("certificate %s revoked or not found", cert.SerialNumber))
+		default:
+			usable++
+		}
+	}
+	if usable == 0 && len(profile.Certificates) > 0 {
+		problems = append(problems, "no usable certificates")
+	}
+	for _, device := range profile.Devices {
+		switch device.APIStatus {
+		case "", string(asc.DeviceStatusEnabled):
+		default:
+			problems = append(problems, fmt.Sprintf("device %s is %s", device.UDID, strings.ToLower(device.APIStatus)))
+		}
+	}
+	return problems
+}
+
+func normalizeSerialNumber(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	trimmed := strings.TrimLeft(value, "0")
+	if trimmed == "" && value != "" {
+		return "0"
+	}
+	return trimmed
+}
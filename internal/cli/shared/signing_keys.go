@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// DefaultSigningKeyBits is the RSA key size Apple expects for signing
+// certificate requests.
+const DefaultSigningKeyBits = 2048
+
+// GenerateSigningKey creates an RSA private key for a signing certificate.
+func GenerateSigningKey(bits int) (*rsa.PrivateKey, error) {
+	if bits == 0 {
+		bits = DefaultSigningKeyBits
+	}
+	if bits < 2048 {
+		return nil, fmt.Errorf("key size must be at least 2048 bits")
+	}
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+// CreateCertificateSigningRequest builds a CSR for key and returns it
+// base64-encoded (DER), ready for the certificates API.
+func CreateCertificateSigningRequest(key *rsa.PrivateKey, commonName, email string) (string, error) {
+	template := &x509.CertificateRequest{
+		Subject:            pkix.Name{CommonName: strings.TrimSpace(commonName)},
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+	if email = strings.TrimSpace(email); email != "" {
+		template.EmailAddresses = []string{email}
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", fmt.Errorf("create CSR: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// EncodePrivateKeyPEM encodes a private key as a PKCS#8 PEM block.
+func EncodePrivateKeyPEM(key any) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParseCertificateContent parses the base64 DER certificateContent returned
+// by the certificates API.
+func ParseCertificateContent(content string) (*x509.Certificate, error) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return nil, fmt.Errorf("certificate content is empty")
+	}
+	der, err := base64.StdEncoding.DecodeString(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("decode certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+	return cert, nil
+}
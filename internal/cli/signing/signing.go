@@ -19,11 +19,15 @@ func SigningCommand() *ffcli.Command {
 		LongHelp: `Manage signing assets for App Store Connect.
 
 Examples:
-  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --output ./signing`,
+  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --output ./signing
+  asc signing sync --repo ./certificates.git --bundle-id com.example.app --profile-type IOS_APP_STORE
+  asc signing audit --within 30d --output table`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			SigningFetchCommand(),
+			SigningSyncCommand(),
+			SigningAuditCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -0,0 +1,630 @@
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// Signing audit item kinds.
+const (
+	auditKindCertificate           = "certificate"
+	auditKindPassTypeIDCertificate = "passTypeIdCertificate"
+	auditKindMerchantIDCertificate = "merchantIdCertificate"
+	auditKindProfile               = "profile"
+	auditKindBundleID              = "bundleId"
+)
+
+// Signing audit issues.
+const (
+	auditIssueExpired            = "EXPIRED"
+	auditIssueExpiring           = "EXPIRING"
+	auditIssueInvalid            = "INVALID"
+	auditIssueUnknownExpiration  = "UNKNOWN_EXPIRATION"
+	auditIssueRevokedCertificate = "REVOKED_CERTIFICATE"
+	auditIssueDisabledDevice     = "DISABLED_DEVICE"
+	auditIssueMissingProfile     = "MISSING_APP_STORE_PROFILE"
+)
+
+// Slack rejects messages with more than 50 blocks and section text longer
+// than 3000 characters.
+const (
+	slackMaxBlocks      = 50
+	slackMaxSectionText = 3000
+)
+
+var appStoreProfileTypes = map[string]bool{
+	"IOS_APP_STORE":          true,
+	"TVOS_APP_STORE":         true,
+	"MAC_APP_STORE":          true,
+	"MAC_CATALYST_APP_STORE": true,
+}
+
+var passTypeCertificateTypes = map[string]bool{
+	"PASS_TYPE_ID":          true,
+	"PASS_TYPE_ID_WITH_NFC": true,
+}
+
+// SigningAuditCommand returns the signing audit subcommand.
+func SigningAuditCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+
+	within := fs.String("within", "30d", "Flag assets expiring within this window (e.g. 30d, 2w, 3m)")
+	slackBlocks := fs.String("slack-blocks", "", "Write a Slack Block Kit payload for asc notify slack --blocks-file")
+	strict := fs.Bool("strict", false, "Exit non-zero when any issue is found")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown, junit")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "audit",
+		ShortUsage: "asc signing audit [flags]",
+		ShortHelp:  "Report expired or expiring signing assets.",
+		LongHelp: `Report expired or expiring signing assets.
+
+Checks, across the whole team:
+  certificates, pass type ID and merchant ID certificates that are expired or expire within --within
+  profiles that are expired, expiring, invalid, or reference revoked certificates or disabled devices
+  bundle IDs without an active App Store profile
+  certificates and profiles whose expiration date cannot be read
+
+Use --output junit, or the root --report junit --report-file flags, for a CI
+summary with one test case per issue. --slack-blocks writes a Block Kit
+payload for asc notify slack --blocks-file.
+
+Examples:
+  asc signing audit
+  asc signing audit --within 60d --output table
+  asc signing audit --within 30d --slack-blocks audit-blocks.json --strict
+  asc notify slack --message "Signing audit" --blocks-file audit-blocks.json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			window, err := shared.ParseAgeDuration("--within", *within)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+			outputFormat := strings.ToLower(strings.TrimSpace(*output))
+			if outputFormat == "junit" && *pretty {
+				return fmt.Errorf("--pretty is only valid with JSON output")
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("signing audit: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			now := time.Now().UTC()
+			result, err := auditSigningAssets(requestCtx, client, now, now.Add(window))
+			if err != nil {
+				return fmt.Errorf("signing audit: %w", err)
+			}
+			result.Within = strings.TrimSpace(*within)
+
+			if path := strings.TrimSpace(*slackBlocks); path != "" {
+				payload, err := json.MarshalIndent(signingAuditSlackBlocks(result), "", "  ")
+				if err != nil {
+					return fmt.Errorf("signing audit: %w", err)
+				}
+				if err := shared.WriteFileAtomic(path, append(payload, '\n'), 0o644); err != nil {
+					return fmt.Errorf("signing audit: write slack blocks: %w", err)
+				}
+			}
+
+			report := signingAuditJUnitReport(result)
+			if shared.ReportFormat() == shared.ReportFormatJUnit {
+				if err := report.Write(shared.ReportFile()); err != nil {
+					return fmt.Errorf("signing audit: %w", err)
+				}
+			}
+			if outputFormat == "junit" {
+				if err := report.WriteTo(os.Stdout); err != nil {
+					return fmt.Errorf("signing audit: %w", err)
+				}
+			} else if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+
+			if *strict && len(result.Items) > 0 {
+				return shared.NewReportedError(fmt.Errorf("signing audit: %d issue(s) found", len(result.Items)))
+			}
+			return nil
+		},
+	}
+}
+
+// auditSigningAssets fetches the team's signing assets and flags those that
+// are expired, expire before cutoff, or cannot be used to sign.
+func auditSigningAssets(ctx context.Context, client *asc.Client, now, cutoff time.Time) (*asc.SigningAuditResult, error) {
+	result := &asc.SigningAuditResult{
+		Cutoff: cutoff.Format(time.RFC3339),
+		Items:  []asc.SigningAuditItem{},
+	}
+
+	certificates, err := listAllCertificates(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	certificatesByID := make(map[string]asc.Resource[asc.CertificateAttributes], len(certificates))
+	for _, cert := range certificates {
+		certificatesByID[cert.ID] = cert
+	}
+
+	// Pass type ID and merchant ID certificates are also listed under their
+	// identifiers; walk those to report which identifier each belongs to.
+	certificateIdentifiers := map[string]string{}
+	certificateKinds := map[string]string{}
+	passTypeIDs, err := listAllPassTypeIDs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, passTypeID := range passTypeIDs {
+		certs, err := listPassTypeIDCertificates(ctx, client, passTypeID.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range certs {
+			certificatesByID[cert.ID] = cert
+			certificateIdentifiers[cert.ID] = passTypeID.Attributes.Identifier
+			certificateKinds[cert.ID] = auditKindPassTypeIDCertificate
+		}
+	}
+	merchantIDs, err := listAllMerchantIDs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, merchantID := range merchantIDs {
+		certs, err := listMerchantIDCertificates(ctx, client, merchantID.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range certs {
+			certificatesByID[cert.ID] = cert
+			certificateIdentifiers[cert.ID] = merchantID.Attributes.Identifier
+			certificateKinds[cert.ID] = auditKindMerchantIDCertificate
+		}
+	}
+
+	certificateIDs := make([]string, 0, len(certificatesByID))
+	for id := range certificatesByID {
+		certificateIDs = append(certificateIDs, id)
+	}
+	sort.Strings(certificateIDs)
+	for _, id := range certificateIDs {
+		cert := certificatesByID[id]
+		kind := certificateKinds[id]
+		if kind == "" {
+			kind = auditCertificateKind(cert.Attributes.CertificateType)
+		}
+		switch kind {
+		case auditKindPassTypeIDCertificate:
+			result.Checked.PassTypeIDCertificates++
+		case auditKindMerchantIDCertificate:
+			result.Checked.MerchantIDCertificates++
+		default:
+			result.Checked.Certificates++
+		}
+		item := asc.SigningAuditItem{
+			Kind:           kind,
+			ID:             cert.ID,
+			Name:           certificateName(cert.Attributes),
+			Identifier:     certificateIdentifiers[id],
+			Type:           cert.Attributes.CertificateType,
+			ExpirationDate: cert.Attributes.ExpirationDate,
+		}
+		if auditExpiry(&item, now, cutoff) {
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	devices, err := shared.ListAllDevices(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	devicesByID := make(map[string]asc.DeviceAttributes, len(devices))
+	for _, device := range devices {
+		devicesByID[device.ID] = device.Attributes
+	}
+
+	profiles, err := listAllProfilesWithBundleIDs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	result.Checked.Profiles = len(profiles)
+	bundlesWithStoreProfile := map[string]bool{}
+	for _, profile := range profiles {
+		attrs := profile.Attributes
+		base := asc.SigningAuditItem{
+			Kind:           auditKindProfile,
+			ID:             profile.ID,
+			Name:           attrs.Name,
+			Identifier:     profile.bundleIdentifier,
+			Type:           attrs.ProfileType,
+			ExpirationDate: attrs.ExpirationDate,
+		}
+
+		expiry := base
+		if auditExpiry(&expiry, now, cutoff) {
+			result.Items = append(result.Items, expiry)
+			if expiry.Issue == auditIssueExpired {
+				continue
+			}
+		}
+		if attrs.ProfileState != "" && attrs.ProfileState != asc.ProfileStateActive {
+			invalid := base
+			invalid.Issue = auditIssueInvalid
+			invalid.Detail = "profile state " + string(attrs.ProfileState)
+			result.Items = append(result.Items, invalid)
+		} else if appStoreProfileTypes[attrs.ProfileType] {
+			bundlesWithStoreProfile[profile.bundleID] = true
+		}
+
+		certIDs, err := shared.ListProfileLinkageIDs(ctx, profile.ID, client.GetProfileCertificatesRelationships)
+		if err != nil {
+			return nil, fmt.Errorf("fetch profile certificates: %w", err)
+		}
+		var revoked []string
+		for _, id := range certIDs {
+			if _, ok := certificatesByID[id]; !ok {
+				revoked = append(revoked, id)
+			}
+		}
+		if len(revoked) > 0 {
+			item := base
+			item.Issue = auditIssueRevokedCertificate
+			item.Detail = "certificates " + strings.Join(revoked, ", ")
+			result.Items = append(result.Items, item)
+		}
+
+		if appStoreProfileTypes[attrs.ProfileType] || strings.HasSuffix(attrs.ProfileType, "_INHOUSE") {
+			continue
+		}
+		deviceIDs, err := shared.ListProfileLinkageIDs(ctx, profile.ID, client.GetProfileDevicesRelationships)
+		if err != nil {
+			return nil, fmt.Errorf("fetch profile devices: %w", err)
+		}
+		var disabled []string
+		for _, id := range deviceIDs {
+			device, ok := devicesByID[id]
+			if !ok {
+				continue
+			}
+			if device.Status != asc.DeviceStatusEnabled {
+				disabled = append(disabled, fmt.Sprintf("%s (%s)", device.Name, device.UDID))
+			}
+		}
+		if len(disabled) > 0 {
+			item := base
+			item.Issue = auditIssueDisabledDevice
+			item.Detail = "devices " + strings.Join(disabled, ", ")
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	bundleIDs, err := listAllBundleIDs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundleID := range bundleIDs {
+		// Wildcard app IDs cannot have App Store profiles.
+		if strings.Contains(bundleID.Attributes.Identifier, "*") {
+			continue
+		}
+		result.Checked.BundleIDs++
+		if bundlesWithStoreProfile[bundleID.ID] {
+			continue
+		}
+		result.Items = append(result.Items, asc.SigningAuditItem{
+			Kind:       auditKindBundleID,
+			ID:         bundleID.ID,
+			Name:       bundleID.Attributes.Name,
+			Identifier: bundleID.Attributes.Identifier,
+			Type:       string(bundleID.Attributes.Platform),
+			Issue:      auditIssueMissingProfile,
+			Detail:     "no active App Store profile",
+		})
+	}
+
+	return result, nil
+}
+
+// auditExpiry sets the expiry issue on item and reports whether it expired,
+// expires before cutoff, or has an expiration date that cannot be parsed.
+func auditExpiry(item *asc.SigningAuditItem, now, cutoff time.Time) bool {
+	if strings.TrimSpace(item.ExpirationDate) == "" {
+		return false
+	}
+	expires, err := shared.ParseAPIDate(item.ExpirationDate)
+	if err != nil {
+		item.Issue = auditIssueUnknownExpiration
+		item.Detail = "unparseable expiration date"
+		return true
+	}
+	days := int(math.Floor(expires.Sub(now).Hours() / 24))
+	switch {
+	case !expires.After(now):
+		item.Issue = auditIssueExpired
+	case expires.Before(cutoff):
+		item.Issue = auditIssueExpiring
+	default:
+		return false
+	}
+	item.DaysRemaining = &days
+	return true
+}
+
+func auditCertificateKind(certificateType string) string {
+	switch {
+	case passTypeCertificateTypes[certificateType]:
+		return auditKindPassTypeIDCertificate
+	case strings.HasPrefix(certificateType, "APPLE_PAY"):
+		return auditKindMerchantIDCertificate
+	default:
+		return auditKindCertificate
+	}
+}
+
+func certificateName(attrs asc.CertificateAttributes) string {
+	if strings.TrimSpace(attrs.DisplayName) != "" {
+		return attrs.DisplayName
+	}
+	return attrs.Name
+}
+
+func listAllCertificates(ctx context.Context, client *asc.Client) ([]asc.Resource[asc.CertificateAttributes], error) {
+	var all []asc.Resource[asc.CertificateAttributes]
+	next := ""
+	for {
+		resp, err := client.GetCertificates(ctx, asc.WithCertificatesLimit(200), asc.WithCertificatesNextURL(next))
+		if err != nil {
+			return nil, fmt.Errorf("fetch certificates: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func listAllPassTypeIDs(ctx context.Context, client *asc.Client) ([]asc.Resource[asc.PassTypeIDAttributes], error) {
+	var all []asc.Resource[asc.PassTypeIDAttributes]
+	next := ""
+	for {
+		resp, err := client.GetPassTypeIDs(ctx, asc.WithPassTypeIDsLimit(200), asc.WithPassTypeIDsNextURL(next))
+		if err != nil {
+			return nil, fmt.Errorf("fetch pass type IDs: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func listPassTypeIDCertificates(ctx context.Context, client *asc.Client, passTypeID string) ([]asc.Resource[asc.CertificateAttributes], error) {
+	var all []asc.Resource[asc.CertificateAttributes]
+	next := ""
+	for {
+		resp, err := client.GetPassTypeIDCertificates(ctx, passTypeID,
+			asc.WithPassTypeIDCertificatesLimit(200),
+			asc.WithPassTypeIDCertificatesNextURL(next),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetch pass type ID certificates: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func listAllMerchantIDs(ctx context.Context, client *asc.Client) ([]asc.Resource[asc.MerchantIDAttributes], error) {
+	var all []asc.Resource[asc.MerchantIDAttributes]
+	next := ""
+	for {
+		resp, err := client.GetMerchantIDs(ctx, asc.WithMerchantIDsLimit(200), asc.WithMerchantIDsNextURL(next))
+		if err != nil {
+			return nil, fmt.Errorf("fetch merchant IDs: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func listMerchantIDCertificates(ctx context.Context, client *asc.Client, merchantID string) ([]asc.Resource[asc.CertificateAttributes], error) {
+	var all []asc.Resource[asc.CertificateAttributes]
+	next := ""
+	for {
+		resp, err := client.GetMerchantIDCertificates(ctx, merchantID,
+			asc.WithMerchantIDCertificatesLimit(200),
+			asc.WithMerchantIDCertificatesNextURL(next),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetch merchant ID certificates: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func listAllBundleIDs(ctx context.Context, client *asc.Client) ([]asc.Resource[asc.BundleIDAttributes], error) {
+	var all []asc.Resource[asc.BundleIDAttributes]
+	next := ""
+	for {
+		resp, err := client.GetBundleIDs(ctx, asc.WithBundleIDsLimit(200), asc.WithBundleIDsNextURL(next))
+		if err != nil {
+			return nil, fmt.Errorf("fetch bundle IDs: %w", err)
+		}
+		all = append(all, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// auditProfile is a profile with its bundle ID resolved from the included
+// bundleId relationship.
+type auditProfile struct {
+	asc.Resource[asc.ProfileAttributes]
+	bundleID         string
+	bundleIdentifier string
+}
+
+func listAllProfilesWithBundleIDs(ctx context.Context, client *asc.Client) ([]auditProfile, error) {
+	var all []auditProfile
+	next := ""
+	for {
+		resp, err := client.GetProfiles(ctx,
+			asc.WithProfilesInclude([]string{"bundleId"}),
+			asc.WithProfilesLimit(200),
+			asc.WithProfilesNextURL(next),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetch profiles: %w", err)
+		}
+		identifiers, err := shared.IncludedBundleIdentifiers(resp.Included)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Data {
+			profile := auditProfile{Resource: item, bundleID: shared.ProfileBundleIDRelationship(item.Relationships)}
+			profile.bundleIdentifier = identifiers[profile.bundleID]
+			all = append(all, profile)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return all, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func signingAuditJUnitReport(result *asc.SigningAuditResult) *shared.JUnitReport {
+	report := &shared.JUnitReport{
+		Name:      "asc signing audit",
+		Timestamp: time.Now(),
+	}
+	for _, item := range result.Items {
+		name := item.Name
+		if item.Identifier != "" {
+			name = fmt.Sprintf("%s (%s)", name, item.Identifier)
+		}
+		report.Tests = append(report.Tests, shared.JUnitTestCase{
+			Name:      name,
+			Classname: "signing.audit." + item.Kind,
+			Failure:   item.Issue,
+			Message:   signingAuditSummary(item),
+		})
+	}
+	if len(report.Tests) == 0 {
+		report.Tests = append(report.Tests, shared.JUnitTestCase{
+			Name:      "no expired or expiring signing assets",
+			Classname: "signing.audit",
+		})
+	}
+	return report
+}
+
+func signingAuditSummary(item asc.SigningAuditItem) string {
+	parts := []string{item.Issue}
+	switch {
+	case item.DaysRemaining != nil && *item.DaysRemaining < 0:
+		parts = append(parts, fmt.Sprintf("expired %d day(s) ago", -*item.DaysRemaining))
+	case item.DaysRemaining != nil:
+		parts = append(parts, fmt.Sprintf("expires in %d day(s)", *item.DaysRemaining))
+	}
+	if item.ExpirationDate != "" {
+		parts = append(parts, item.ExpirationDate)
+	}
+	if item.Detail != "" {
+		parts = append(parts, item.Detail)
+	}
+	return strings.Join(parts, ": ")
+}
+
+// signingAuditSlackBlocks builds a Block Kit array with one section per issue
+// type, trimmed to Slack's block and text limits.
+func signingAuditSlackBlocks(result *asc.SigningAuditResult) []map[string]any {
+	text := func(kind, value string) map[string]any {
+		return map[string]any{"type": kind, "text": value}
+	}
+	title := fmt.Sprintf("Signing audit: %d issue(s) within %s", len(result.Items), result.Within)
+	if len(result.Items) == 0 {
+		title = fmt.Sprintf("Signing audit: no issues within %s", result.Within)
+	}
+	blocks := []map[string]any{
+		{"type": "header", "text": text("plain_text", title)},
+		{"type": "context", "elements": []map[string]any{text("mrkdwn", fmt.Sprintf(
+			"Checked %d certificates, %d pass type ID certificates, %d merchant ID certificates, %d profiles, %d bundle IDs",
+			result.Checked.Certificates, result.Checked.PassTypeIDCertificates, result.Checked.MerchantIDCertificates,
+			result.Checked.Profiles, result.Checked.BundleIDs,
+		))}},
+	}
+
+	var issues []string
+	byIssue := map[string][]string{}
+	for _, item := range result.Items {
+		if _, ok := byIssue[item.Issue]; !ok {
+			issues = append(issues, item.Issue)
+		}
+		line := fmt.Sprintf("• *%s* `%s`", slackEscape(item.Name), item.Kind)
+		if item.Identifier != "" {
+			line += " " + slackEscape(item.Identifier)
+		}
+		switch {
+		case item.DaysRemaining != nil && *item.DaysRemaining < 0:
+			line += fmt.Sprintf(" (expired %d day(s) ago)", -*item.DaysRemaining)
+		case item.DaysRemaining != nil:
+			line += fmt.Sprintf(" (expires in %d day(s))", *item.DaysRemaining)
+		case item.Detail != "":
+			line += " — " + slackEscape(item.Detail)
+		}
+		byIssue[item.Issue] = append(byIssue[item.Issue], line)
+	}
+
+	for _, issue := range issues {
+		if len(blocks) >= slackMaxBlocks {
+			break
+		}
+		lines := byIssue[issue]
+		body := fmt.Sprintf("*%s* (%d)", issue, len(lines))
+		for i, line := range lines {
+			more := fmt.Sprintf("\n…and %d more", len(lines)-i)
+			if len(body)+1+len(line)+len(more) > slackMaxSectionText {
+				body += more
+				break
+			}
+			body += "\n" + line
+		}
+		blocks = append(blocks, map[string]any{"type": "section", "text": text("mrkdwn", body)})
+	}
+	return blocks
+}
+
+func slackEscape(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(value)
+}
@@ -0,0 +1,71 @@
+package signing
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestAuditExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cutoff := now.Add(30 * 24 * time.Hour)
+	tests := []struct {
+		expires   string
+		wantIssue string
+		wantDays  int
+	}{
+		{expires: "2026-02-28T00:00:00.000+0000", wantIssue: auditIssueExpired, wantDays: -2},
+		{expires: "2026-03-11T12:00:00Z", wantIssue: auditIssueExpiring, wantDays: 10},
+		{expires: "2026-06-01T00:00:00Z"},
+		{expires: "next spring", wantIssue: auditIssueUnknownExpiration},
+		{expires: ""},
+	}
+	for _, test := range tests {
+		item := asc.SigningAuditItem{ExpirationDate: test.expires}
+		flagged := auditExpiry(&item, now, cutoff)
+		if flagged != (test.wantIssue != "") || item.Issue != test.wantIssue {
+			t.Fatalf("auditExpiry(%q) = %t %q, want %q", test.expires, flagged, item.Issue, test.wantIssue)
+		}
+		if flagged && item.DaysRemaining != nil && *item.DaysRemaining != test.wantDays {
+			t.Fatalf("auditExpiry(%q) days = %d, want %d", test.expires, *item.DaysRemaining, test.wantDays)
+		}
+	}
+}
+
+func TestSigningAuditSlackBlocksRespectLimits(t *testing.T) {
+	result := &asc.SigningAuditResult{Within: "30d"}
+	for i := 0; i < 500; i++ {
+		days := 5
+		result.Items = append(result.Items, asc.SigningAuditItem{
+			Kind:          auditKindProfile,
+			Name:          strings.Repeat("Profile <x> ", 4),
+			Issue:         auditIssueExpiring,
+			DaysRemaining: &days,
+		})
+	}
+	result.Items = append(result.Items, asc.SigningAuditItem{Kind: auditKindBundleID, Name: "App", Identifier: "com.example.app", Issue: auditIssueMissingProfile, Detail: "no active App Store profile"})
+
+	blocks := signingAuditSlackBlocks(result)
+	if len(blocks) != 4 {
+		t.Fatalf("expected header, context and two sections, got %d blocks", len(blocks))
+	}
+	payload, err := json.Marshal(blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(payload), "<x>") {
+		t.Fatal("expected names to be escaped for mrkdwn")
+	}
+	for _, block := range blocks[2:] {
+		body := block["text"].(map[string]any)["text"].(string)
+		if len(body) > slackMaxSectionText {
+			t.Fatalf("section text has %d characters", len(body))
+		}
+	}
+	if body := blocks[2]["text"].(map[string]any)["text"].(string); !strings.Contains(body, "more") {
+		t.Fatalf("expected truncated section, got %q", body[:80])
+	}
+}
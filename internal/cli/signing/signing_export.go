@@ -0,0 +1,223 @@
+package signing
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// fetchedProfile pairs a resolved profile with its decoded contents.
+type fetchedProfile struct {
+	asc.SigningFetchProfile
+	Content []byte
+}
+
+// signingExport holds what ExportOptions.plist and the xcconfig need.
+type signingExport struct {
+	Method             string
+	TeamID             string
+	SigningCertificate string
+	Profiles           []asc.SigningFetchProfile
+}
+
+// exportOptionsPlist is the subset of xcodebuild -exportArchive options that
+// signing fetch can derive.
+type exportOptionsPlist struct {
+	Method               string            `plist:"method"`
+	TeamID               string            `plist:"teamID"`
+	SigningStyle         string            `plist:"signingStyle"`
+	SigningCertificate   string            `plist:"signingCertificate"`
+	ProvisioningProfiles map[string]string `plist:"provisioningProfiles"`
+}
+
+// exportMethod maps a profile type to the xcodebuild export method.
+func exportMethod(profileType string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(profileType))
+	switch {
+	case strings.Contains(normalized, "DEVELOPMENT"):
+		return "development", nil
+	case strings.Contains(normalized, "ADHOC"):
+		return "ad-hoc", nil
+	case strings.Contains(normalized, "INHOUSE"):
+		return "enterprise", nil
+	case strings.Contains(normalized, "DIRECT"):
+		return "developer-id", nil
+	case strings.Contains(normalized, "STORE"):
+		return "app-store", nil
+	default:
+		return "", fmt.Errorf("unable to infer export method for profile type %s", profileType)
+	}
+}
+
+// buildSigningExport derives the team and signing certificate from the
+// fetched profiles and the certificates they were resolved against. The
+// signing certificate must be included in every profile.
+func buildSigningExport(profileType string, profiles []fetchedProfile, certs []asc.Resource[asc.CertificateAttributes]) (*signingExport, error) {
+	method, err := exportMethod(profileType)
+	if err != nil {
+		return nil, err
+	}
+	export := &signingExport{Method: method}
+
+	var profileCerts map[string]bool
+	for _, profile := range profiles {
+		inspected, err := shared.ParseProvisioningProfile(profile.Content, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("parse profile %s: %w", profile.ProfileID, err)
+		}
+		if inspected.TeamID == "" {
+			return nil, fmt.Errorf("profile %s has no team identifier", profile.ProfileID)
+		}
+		if export.TeamID == "" {
+			export.TeamID = inspected.TeamID
+		} else if export.TeamID != inspected.TeamID {
+			return nil, fmt.Errorf("profiles belong to different teams (%s, %s)", export.TeamID, inspected.TeamID)
+		}
+		// Every profile must contain the signing identity, so only
+		// certificates shared by all of them qualify.
+		included := map[string]bool{}
+		for _, cert := range inspected.Certificates {
+			if profileCerts == nil || profileCerts[cert.SHA1] {
+				included[cert.SHA1] = true
+			}
+		}
+		profileCerts = included
+
+		resolved := profile.SigningFetchProfile
+		if resolved.ProfileName == "" {
+			resolved.ProfileName = inspected.Name
+		}
+		if resolved.ProfileUUID == "" {
+			resolved.ProfileUUID = inspected.UUID
+		}
+		export.Profiles = append(export.Profiles, resolved)
+	}
+
+	names := map[string]bool{}
+	for _, cert := range certs {
+		der, err := decodeBase64Content("certificate", cert.Attributes.CertificateContent)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate %s: %w", cert.ID, err)
+		}
+		if sha1Sum, _ := shared.CertificateFingerprints(parsed); profileCerts[sha1Sum] {
+			names[parsed.Subject.CommonName] = true
+		}
+	}
+	signingCertificate, err := selectSigningCertificate(names)
+	if err != nil {
+		return nil, err
+	}
+	export.SigningCertificate = signingCertificate
+	return export, nil
+}
+
+// selectSigningCertificate picks the identity to sign with. When several
+// certificates qualify it falls back to the shared kind ("Apple
+// Distribution"), which Xcode resolves to any matching identity.
+func selectSigningCertificate(names map[string]bool) (string, error) {
+	if len(names) == 0 {
+		return "", fmt.Errorf("none of the fetched certificates are included in every profile")
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	if len(sorted) == 1 {
+		return sorted[0], nil
+	}
+	kind, _, _ := strings.Cut(sorted[0], ":")
+	for _, name := range sorted[1:] {
+		if other, _, _ := strings.Cut(name, ":"); other != kind {
+			return "", fmt.Errorf("profile includes certificates of different kinds (%s); use --certificate-type", strings.Join(sorted, ", "))
+		}
+	}
+	return strings.TrimSpace(kind), nil
+}
+
+// renderExportOptions renders ExportOptions.plist for xcodebuild -exportArchive.
+func renderExportOptions(export *signingExport) ([]byte, error) {
+	options := exportOptionsPlist{
+		Method:               export.Method,
+		TeamID:               export.TeamID,
+		SigningStyle:         "manual",
+		SigningCertificate:   export.SigningCertificate,
+		ProvisioningProfiles: map[string]string{},
+	}
+	for _, profile := range export.Profiles {
+		specifier := profile.ProfileUUID
+		if specifier == "" {
+			specifier = profile.ProfileName
+		}
+		options.ProvisioningProfiles[profile.BundleID] = specifier
+	}
+	data, err := plist.MarshalIndent(options, plist.XMLFormat, "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// renderSigningXcconfig renders build settings for manual signing. xcconfig
+// files cannot condition on the bundle ID, so each profile is stored in a
+// variable named after its bundle ID and PROVISIONING_PROFILE_SPECIFIER picks
+// the one matching the target being built.
+func renderSigningXcconfig(export *signingExport) []byte {
+	var b strings.Builder
+	b.WriteString("// Generated by asc signing fetch.\n")
+	b.WriteString("CODE_SIGN_STYLE = Manual\n")
+	fmt.Fprintf(&b, "DEVELOPMENT_TEAM = %s\n", export.TeamID)
+	fmt.Fprintf(&b, "CODE_SIGN_IDENTITY = %s\n", export.SigningCertificate)
+	for _, profile := range export.Profiles {
+		b.WriteString("\n")
+		if profile.ProfileUUID != "" {
+			fmt.Fprintf(&b, "// %s (%s)\n", profile.BundleID, profile.ProfileUUID)
+		} else {
+			fmt.Fprintf(&b, "// %s\n", profile.BundleID)
+		}
+		fmt.Fprintf(&b, "ASC_PROFILE_%s = %s\n", xcconfigIdentifier(profile.BundleID), profile.ProfileName)
+	}
+	b.WriteString("\nPROVISIONING_PROFILE_SPECIFIER = $(ASC_PROFILE_$(PRODUCT_BUNDLE_IDENTIFIER:c99extidentifier))\n")
+	return []byte(b.String())
+}
+
+// xcconfigIdentifier mirrors Xcode's c99extidentifier build setting modifier.
+func xcconfigIdentifier(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// profileMatchesBundleID reports whether a profile was issued for exactly the
+// given bundle ID. Content that cannot be parsed falls back to a substring
+// match.
+func profileMatchesBundleID(content []byte, bundleID string) bool {
+	inspected, err := shared.ParseProvisioningProfile(content, time.Now())
+	if err != nil || inspected.BundleID == "" {
+		return strings.Contains(string(content), bundleID)
+	}
+	return inspected.BundleID == bundleID
+}
@@ -0,0 +1,225 @@
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func testSigningCertificate(t *testing.T, commonName string, serial int64) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName, OrganizationalUnit: []string{"TEAM123"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func testProfileContent(t *testing.T, name, uuid, bundleID string, certs ...*x509.Certificate) []byte {
+	t.Helper()
+	developerCertificates := make([][]byte, 0, len(certs))
+	for _, cert := range certs {
+		developerCertificates = append(developerCertificates, cert.Raw)
+	}
+	content, err := plist.Marshal(map[string]any{
+		"Name":                  name,
+		"UUID":                  uuid,
+		"TeamIdentifier":        []string{"TEAM123"},
+		"ExpirationDate":        time.Now().Add(24 * time.Hour),
+		"DeveloperCertificates": developerCertificates,
+		"Entitlements": map[string]any{
+			"application-identifier": "TEAM123." + bundleID,
+		},
+	}, plist.XMLFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, key := testSigningCertificate(t, "Test Profile Signing", 99)
+	signed, err := shared.SignData(content, signer, key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func certificateResource(id string, cert *x509.Certificate) asc.Resource[asc.CertificateAttributes] {
+	return asc.Resource[asc.CertificateAttributes]{
+		ID: id,
+		Attributes: asc.CertificateAttributes{
+			CertificateContent: base64.StdEncoding.EncodeToString(cert.Raw),
+		},
+	}
+}
+
+func TestExportMethod(t *testing.T) {
+	tests := map[string]string{
+		"IOS_APP_STORE":           "app-store",
+		"IOS_APP_ADHOC":           "ad-hoc",
+		"IOS_APP_DEVELOPMENT":     "development",
+		"IOS_APP_INHOUSE":         "enterprise",
+		"TVOS_APP_STORE":          "app-store",
+		"MAC_APP_STORE":           "app-store",
+		"MAC_APP_DIRECT":          "developer-id",
+		"MAC_CATALYST_APP_DIRECT": "developer-id",
+	}
+	for profileType, want := range tests {
+		got, err := exportMethod(profileType)
+		if err != nil || got != want {
+			t.Fatalf("exportMethod(%s) = %q, %v; want %q", profileType, got, err, want)
+		}
+	}
+	if _, err := exportMethod("UNKNOWN"); err == nil {
+		t.Fatal("expected unknown profile type to fail")
+	}
+}
+
+func TestSelectSigningCertificate(t *testing.T) {
+	got, err := selectSigningCertificate(map[string]bool{"Apple Distribution: Example Inc (TEAM123)": true})
+	if err != nil || got != "Apple Distribution: Example Inc (TEAM123)" {
+		t.Fatalf("single certificate = %q, %v", got, err)
+	}
+	got, err = selectSigningCertificate(map[string]bool{
+		"Apple Distribution: Example Inc (TEAM123)":   true,
+		"Apple Distribution: Example Inc 2 (TEAM123)": true,
+	})
+	if err != nil || got != "Apple Distribution" {
+		t.Fatalf("multiple certificates = %q, %v", got, err)
+	}
+	if _, err := selectSigningCertificate(map[string]bool{
+		"Apple Distribution: Example Inc (TEAM123)":  true,
+		"iPhone Distribution: Example Inc (TEAM123)": true,
+	}); err == nil {
+		t.Fatal("expected mixed certificate kinds to fail")
+	}
+	if _, err := selectSigningCertificate(map[string]bool{}); err == nil {
+		t.Fatal("expected no certificates to fail")
+	}
+}
+
+func TestBuildSigningExport(t *testing.T) {
+	distribution, _ := testSigningCertificate(t, "Apple Distribution: Example Inc (TEAM123)", 1)
+	unrelated, _ := testSigningCertificate(t, "Apple Distribution: Example Inc (TEAM123) Old", 2)
+
+	profiles := []fetchedProfile{
+		{
+			SigningFetchProfile: asc.SigningFetchProfile{BundleID: "com.example.app", ProfileID: "P1", ProfileName: "App Store"},
+			Content:             testProfileContent(t, "App Store", "UUID-APP", "com.example.app", distribution),
+		},
+		{
+			SigningFetchProfile: asc.SigningFetchProfile{BundleID: "com.example.app.widget", ProfileID: "P2"},
+			Content:             testProfileContent(t, "Widget Store", "UUID-WIDGET", "com.example.app.widget", distribution),
+		},
+	}
+	certs := []asc.Resource[asc.CertificateAttributes]{
+		certificateResource("C1", distribution),
+		certificateResource("C2", unrelated),
+	}
+
+	export, err := buildSigningExport("IOS_APP_STORE", profiles, certs)
+	if err != nil {
+		t.Fatalf("buildSigningExport() error: %v", err)
+	}
+	if export.Method != "app-store" || export.TeamID != "TEAM123" || export.SigningCertificate != "Apple Distribution: Example Inc (TEAM123)" {
+		t.Fatalf("unexpected export %+v", export)
+	}
+	if export.Profiles[0].ProfileUUID != "UUID-APP" || export.Profiles[1].ProfileName != "Widget Store" || export.Profiles[1].ProfileUUID != "UUID-WIDGET" {
+		t.Fatalf("unexpected profiles %+v", export.Profiles)
+	}
+
+	data, err := renderExportOptions(export)
+	if err != nil {
+		t.Fatalf("renderExportOptions() error: %v", err)
+	}
+	var options exportOptionsPlist
+	if _, err := plist.Unmarshal(data, &options); err != nil {
+		t.Fatalf("parse export options: %v\n%s", err, data)
+	}
+	if options.Method != "app-store" || options.TeamID != "TEAM123" || options.SigningStyle != "manual" ||
+		options.SigningCertificate != "Apple Distribution: Example Inc (TEAM123)" ||
+		options.ProvisioningProfiles["com.example.app"] != "UUID-APP" ||
+		options.ProvisioningProfiles["com.example.app.widget"] != "UUID-WIDGET" {
+		t.Fatalf("unexpected export options %+v", options)
+	}
+
+	xcconfig := string(renderSigningXcconfig(export))
+	for _, want := range []string{
+		"CODE_SIGN_STYLE = Manual\n",
+		"DEVELOPMENT_TEAM = TEAM123\n",
+		"CODE_SIGN_IDENTITY = Apple Distribution: Example Inc (TEAM123)\n",
+		"// com.example.app (UUID-APP)\nASC_PROFILE_com_example_app = App Store\n",
+		"ASC_PROFILE_com_example_app_widget = Widget Store\n",
+		"PROVISIONING_PROFILE_SPECIFIER = $(ASC_PROFILE_$(PRODUCT_BUNDLE_IDENTIFIER:c99extidentifier))\n",
+	} {
+		if !strings.Contains(xcconfig, want) {
+			t.Fatalf("expected xcconfig to contain %q, got:\n%s", want, xcconfig)
+		}
+	}
+
+	if _, err := buildSigningExport("IOS_APP_STORE", profiles, certs[1:]); err == nil {
+		t.Fatal("expected certificates outside the profile to fail")
+	}
+
+	profiles[1].Content = testProfileContent(t, "Widget Store", "UUID-WIDGET", "com.example.app.widget", unrelated)
+	if _, err := buildSigningExport("IOS_APP_STORE", profiles, certs); err == nil {
+		t.Fatal("expected profiles without a shared certificate to fail")
+	}
+	profiles[0].Content = testProfileContent(t, "App Store", "UUID-APP", "com.example.app", distribution, unrelated)
+	export, err = buildSigningExport("IOS_APP_STORE", profiles, certs)
+	if err != nil || export.SigningCertificate != "Apple Distribution: Example Inc (TEAM123) Old" {
+		t.Fatalf("expected the certificate shared by both profiles, got %+v (%v)", export, err)
+	}
+}
+
+func TestProfileMatchesBundleID(t *testing.T) {
+	cert, _ := testSigningCertificate(t, "Apple Distribution: Example Inc (TEAM123)", 1)
+	widget := testProfileContent(t, "Widget", "UUID-WIDGET", "com.example.app.widget", cert)
+
+	if !profileMatchesBundleID(widget, "com.example.app.widget") {
+		t.Fatal("expected widget profile to match its bundle ID")
+	}
+	if profileMatchesBundleID(widget, "com.example.app") {
+		t.Fatal("expected widget profile not to match the app bundle ID")
+	}
+	if !profileMatchesBundleID([]byte("unparsed com.example.app"), "com.example.app") {
+		t.Fatal("expected unparsed content to fall back to a substring match")
+	}
+}
+
+func TestXcconfigIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"com.example.app":        "com_example_app",
+		"com.example.my-app.ext": "com_example_my_app_ext",
+		"1password.app":          "_1password_app",
+	}
+	for input, want := range tests {
+		if got := xcconfigIdentifier(input); got != want {
+			t.Fatalf("xcconfigIdentifier(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
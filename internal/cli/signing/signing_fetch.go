@@ -22,12 +22,14 @@ func SigningFetchCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
 
 	appID := fs.String("app", "", "App Store Connect app ID (optional, or ASC_APP_ID env)")
-	bundleID := fs.String("bundle-id", "", "Bundle identifier (e.g., com.example.app) - required")
+	bundleID := fs.String("bundle-id", "", "Bundle identifier(s), comma-separated (e.g., com.example.app,com.example.app.widget) - required")
 	profileType := fs.String("profile-type", "", "Profile type: IOS_APP_STORE, IOS_APP_DEVELOPMENT, MAC_APP_STORE, etc. (required)")
 	deviceIDs := fs.String("device", "", "Device ID(s), comma-separated (required for development profiles)")
 	certType := fs.String("certificate-type", "", "Certificate type filter (optional)")
 	outputPath := fs.String("output", "./signing", "Output directory for signing files")
 	createMissing := fs.Bool("create-missing", false, "Create missing profiles")
+	exportOptionsPath := fs.String("export-options", "", "Write ExportOptions.plist for xcodebuild -exportArchive to this path")
+	xcconfigPath := fs.String("xcconfig", "", "Write an xcconfig with manual signing build settings to this path")
 	format := fs.String("format", "json", "Output format for metadata: json (default), table, markdown")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 
@@ -41,17 +43,23 @@ This command resolves the bundle ID, finds matching certificates and profiles,
 and writes them to the output directory.
 
 With --create-missing, it will create a new profile if none exist for the
-specified configuration.
+specified configuration, named "<bundle ID> <profile type> <YYYYMMDD>".
+
+Pass several bundle IDs (the app first, then its extensions) to fetch a
+profile for each. --export-options and --xcconfig write the export method,
+team ID, signing certificate and the profile for every bundle ID, derived from
+the fetched profiles and certificates.
 
 Examples:
   asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --output ./signing
   asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_DEVELOPMENT --device "DEVICE1,DEVICE2"
-  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --create-missing`,
+  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --create-missing
+  asc signing fetch --bundle-id com.example.app,com.example.app.widget --profile-type IOS_APP_STORE --export-options ExportOptions.plist --xcconfig Signing.xcconfig`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			bundle := strings.TrimSpace(*bundleID)
-			if bundle == "" {
+			bundles := shared.SplitCSV(*bundleID)
+			if len(bundles) == 0 {
 				fmt.Fprintln(os.Stderr, "Error: --bundle-id is required")
 				return flag.ErrHelp
 			}
@@ -82,59 +90,78 @@ Examples:
 
 			resolvedAppID := shared.ResolveAppID(*appID)
 			if resolvedAppID != "" {
-				if err := validateBundleIDMatchesApp(requestCtx, client, resolvedAppID, bundle); err != nil {
+				if err := validateBundleIDMatchesApp(requestCtx, client, resolvedAppID, bundles[0]); err != nil {
 					return fmt.Errorf("signing fetch: %w", err)
 				}
 			}
 
 			result := &asc.SigningFetchResult{
-				BundleID:    bundle,
+				BundleID:    bundles[0],
 				ProfileType: profType,
 				OutputPath:  outputDir,
 			}
 
-			bundleIDResp, err := findBundleID(requestCtx, client, bundle)
-			if err != nil {
-				return fmt.Errorf("signing fetch: %w", err)
-			}
-			result.BundleIDResource = bundleIDResp.Data.ID
-
 			certs, err := findCertificates(requestCtx, client, profType, *certType)
 			if err != nil {
 				return fmt.Errorf("signing fetch: %w", err)
 			}
 			result.CertificateIDs = extractIDs(certs.Data)
 
-			profile, created, err := findOrCreateProfile(
-				requestCtx,
-				client,
-				bundleIDResp.Data.ID,
-				bundle,
-				profType,
-				result.CertificateIDs,
-				shared.SplitCSV(*deviceIDs),
-				*createMissing,
-			)
-			if err != nil {
-				return fmt.Errorf("signing fetch: %w", err)
-			}
-			result.ProfileID = profile.Data.ID
-			result.Created = created
-
 			if err := os.MkdirAll(outputDir, 0o755); err != nil {
 				return fmt.Errorf("signing fetch: create output dir: %w", err)
 			}
 
-			profileName := safeFileName(profile.Data.Attributes.Name, profile.Data.ID)
-			profilePath := filepath.Join(outputDir, profileName+".mobileprovision")
-			profileContent, err := decodeBase64Content("profile", profile.Data.Attributes.ProfileContent)
-			if err != nil {
-				return fmt.Errorf("signing fetch: decode profile: %w", err)
+			fetched := make([]fetchedProfile, 0, len(bundles))
+			for _, bundle := range bundles {
+				bundleIDResp, err := shared.FindBundleID(requestCtx, client, bundle)
+				if err != nil {
+					return fmt.Errorf("signing fetch: %w", err)
+				}
+
+				profile, created, err := findOrCreateProfile(
+					requestCtx,
+					client,
+					bundleIDResp.ID,
+					bundle,
+					profType,
+					result.CertificateIDs,
+					shared.SplitCSV(*deviceIDs),
+					*createMissing,
+				)
+				if err != nil {
+					return fmt.Errorf("signing fetch: %s: %w", bundle, err)
+				}
+
+				profileName := safeFileName(profile.Data.Attributes.Name, profile.Data.ID)
+				profilePath := filepath.Join(outputDir, profileName+".mobileprovision")
+				profileContent, err := decodeBase64Content("profile", profile.Data.Attributes.ProfileContent)
+				if err != nil {
+					return fmt.Errorf("signing fetch: decode profile: %w", err)
+				}
+				if err := shared.WriteProfileFile(profilePath, profileContent); err != nil {
+					return fmt.Errorf("signing fetch: write profile: %w", err)
+				}
+
+				fetched = append(fetched, fetchedProfile{
+					SigningFetchProfile: asc.SigningFetchProfile{
+						BundleID:         bundle,
+						BundleIDResource: bundleIDResp.ID,
+						ProfileID:        profile.Data.ID,
+						ProfileName:      profile.Data.Attributes.Name,
+						ProfileUUID:      profile.Data.Attributes.UUID,
+						ProfileFile:      profilePath,
+						Created:          created,
+					},
+					Content: profileContent,
+				})
 			}
-			if err := shared.WriteProfileFile(profilePath, profileContent); err != nil {
-				return fmt.Errorf("signing fetch: write profile: %w", err)
+			for _, profile := range fetched {
+				result.Profiles = append(result.Profiles, profile.SigningFetchProfile)
 			}
-			result.ProfileFile = profilePath
+			result.BundleIDResource = result.Profiles[0].BundleIDResource
+			result.ProfileID = result.Profiles[0].ProfileID
+			result.ProfileFile = result.Profiles[0].ProfileFile
+			result.Created = result.Profiles[0].Created
 
 			for _, cert := range certs.Data {
 				certName := safeFileName(cert.Attributes.SerialNumber, cert.ID)
@@ -149,6 +176,36 @@ Examples:
 				result.CertificateFiles = append(result.CertificateFiles, certPath)
 			}
 
+			exportOptionsFile := strings.TrimSpace(*exportOptionsPath)
+			xcconfigFile := strings.TrimSpace(*xcconfigPath)
+			if exportOptionsFile != "" || xcconfigFile != "" {
+				export, err := buildSigningExport(profType, fetched, certs.Data)
+				if err != nil {
+					return fmt.Errorf("signing fetch: %w", err)
+				}
+				result.Profiles = export.Profiles
+				result.Method = export.Method
+				result.TeamID = export.TeamID
+				result.SigningCertificate = export.SigningCertificate
+
+				if exportOptionsFile != "" {
+					data, err := renderExportOptions(export)
+					if err != nil {
+						return fmt.Errorf("signing fetch: render export options: %w", err)
+					}
+					if err := writeBinaryFile(exportOptionsFile, data); err != nil {
+						return fmt.Errorf("signing fetch: write export options: %w", err)
+					}
+					result.ExportOptionsFile = exportOptionsFile
+				}
+				if xcconfigFile != "" {
+					if err := writeBinaryFile(xcconfigFile, renderSigningXcconfig(export)); err != nil {
+						return fmt.Errorf("signing fetch: write xcconfig: %w", err)
+					}
+					result.XcconfigFile = xcconfigFile
+				}
+			}
+
 			return shared.PrintOutput(result, *format, *pretty)
 		},
 	}
@@ -165,17 +222,6 @@ func validateBundleIDMatchesApp(ctx context.Context, client *asc.Client, appID,
 	return nil
 }
 
-func findBundleID(ctx context.Context, client *asc.Client, identifier string) (*asc.BundleIDResponse, error) {
-	resp, err := client.GetBundleIDs(ctx, asc.WithBundleIDsFilterIdentifier(identifier))
-	if err != nil {
-		return nil, err
-	}
-	if len(resp.Data) == 0 {
-		return nil, fmt.Errorf("bundle ID not found: %s", identifier)
-	}
-	return &asc.BundleIDResponse{Data: resp.Data[0]}, nil
-}
-
 func findCertificates(ctx context.Context, client *asc.Client, profileType, certType string) (*asc.CertificatesResponse, error) {
 	certType = strings.TrimSpace(certType)
 	if certType == "" {
@@ -235,7 +281,7 @@ func findOrCreateProfile(ctx context.Context, client *asc.Client, bundleIDResour
 			if err != nil {
 				return nil, false, err
 			}
-			if strings.Contains(string(decoded), bundleIdentifier) {
+			if profileMatchesBundleID(decoded, bundleIdentifier) {
 				return &asc.ProfileResponse{Data: profile}, false, nil
 			}
 		}
@@ -249,18 +295,25 @@ func findOrCreateProfile(ctx context.Context, client *asc.Client, bundleIDResour
 	if !createMissing {
 		return nil, false, fmt.Errorf("no active profile found for bundle ID; use --create-missing to create one")
 	}
+	profile, err := createProfile(ctx, client, bundleIDResourceID, bundleIdentifier, profileType, certIDs, deviceIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	return profile, true, nil
+}
+
+// createProfile creates a profile named after the bundle ID, type and date.
+// Profile names are unique across the team, so the bundle ID keeps profiles
+// created together for an app and its extensions apart.
+func createProfile(ctx context.Context, client *asc.Client, bundleIDResourceID, bundleIdentifier, profileType string, certIDs, deviceIDs []string) (*asc.ProfileResponse, error) {
 	if len(certIDs) == 0 {
-		return nil, false, fmt.Errorf("no certificates available to create profile")
+		return nil, fmt.Errorf("no certificates available to create profile")
 	}
-	name := fmt.Sprintf("%s-%s", profileType, time.Now().Format("20060102"))
-	profile, err := client.CreateProfile(ctx, asc.ProfileCreateAttributes{
+	name := fmt.Sprintf("%s %s %s", bundleIdentifier, profileType, time.Now().Format("20060102"))
+	return client.CreateProfile(ctx, asc.ProfileCreateAttributes{
 		Name:        name,
 		ProfileType: profileType,
 	}, bundleIDResourceID, certIDs, deviceIDs)
-	if err != nil {
-		return nil, false, err
-	}
-	return profile, true, nil
 }
 
 func isDevelopmentProfile(profileType string) bool {
@@ -0,0 +1,151 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// signingRepo is a temporary checkout of the git repository backing
+// signing sync. Any git remote works, including a local bare repository.
+type signingRepo struct {
+	remote string
+	branch string
+	dir    string
+	kept   bool
+}
+
+// signingPushAttempts bounds how often a rejected push is rebased onto the
+// remote branch and retried.
+const signingPushAttempts = 3
+
+// cloneSigningRepo clones remote into a temporary directory and checks out
+// branch. A missing branch (or an empty repository) starts an orphan branch
+// unless readonly is set.
+func cloneSigningRepo(ctx context.Context, remote, branch string, readonly bool) (*signingRepo, error) {
+	dir, err := os.MkdirTemp("", "asc-signing-*")
+	if err != nil {
+		return nil, err
+	}
+	repo := &signingRepo{remote: remote, branch: branch, dir: dir}
+	if _, err := runGit(ctx, "", "clone", "--quiet", "--no-checkout", "--", remote, dir); err != nil {
+		repo.Close()
+		return nil, err
+	}
+
+	if _, err := repo.git(ctx, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err == nil {
+		if _, err := repo.git(ctx, "checkout", "--quiet", "-B", branch, "origin/"+branch); err != nil {
+			repo.Close()
+			return nil, err
+		}
+		return repo, nil
+	}
+	if readonly {
+		repo.Close()
+		return nil, fmt.Errorf("branch %s not found in %s", branch, remote)
+	}
+	if _, err := repo.git(ctx, "switch", "--quiet", "--orphan", branch); err != nil {
+		repo.Close()
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (r *signingRepo) git(ctx context.Context, args ...string) (string, error) {
+	return runGit(ctx, r.dir, args...)
+}
+
+// commitAndPush commits all changes and pushes them to the remote branch.
+// It returns the new commit hash, or "" when there was nothing to commit.
+func (r *signingRepo) commitAndPush(ctx context.Context, message string) (string, error) {
+	if _, err := r.git(ctx, "add", "--all"); err != nil {
+		return "", err
+	}
+	status, err := r.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(status) == "" {
+		return "", nil
+	}
+
+	if _, err := r.git(ctx, r.identityArgs(ctx, "commit", "--quiet", "-m", message)...); err != nil {
+		return "", err
+	}
+	if err := r.push(ctx); err != nil {
+		return "", err
+	}
+	commit, err := r.git(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// push pushes HEAD to the remote branch. A rejected push (another sync
+// pushed first) is rebased onto the remote branch and retried.
+func (r *signingRepo) push(ctx context.Context) error {
+	var err error
+	for attempt := 0; attempt < signingPushAttempts; attempt++ {
+		if _, err = r.git(ctx, "push", "--quiet", "origin", "HEAD:refs/heads/"+r.branch); err == nil {
+			return nil
+		}
+		if _, rebaseErr := r.git(ctx, r.identityArgs(ctx, "pull", "--quiet", "--rebase", "origin", r.branch)...); rebaseErr != nil {
+			_, _ = r.git(ctx, "rebase", "--abort")
+			return fmt.Errorf("%w; rebase onto %s failed: %v", err, r.branch, rebaseErr)
+		}
+	}
+	return err
+}
+
+// identityArgs prefixes args with a fallback committer identity when git has
+// none configured.
+func (r *signingRepo) identityArgs(ctx context.Context, args ...string) []string {
+	if email, _ := r.git(ctx, "config", "user.email"); strings.TrimSpace(email) == "" {
+		return append([]string{"-c", "user.name=asc", "-c", "user.email=asc@localhost"}, args...)
+	}
+	return args
+}
+
+// keep stops Close from removing the checkout, so commits that could not be
+// pushed can be recovered from it.
+func (r *signingRepo) keep() {
+	r.kept = true
+}
+
+// Close removes the temporary checkout unless keep was called.
+func (r *signingRepo) Close() {
+	if r.kept {
+		return
+	}
+	_ = os.RemoveAll(r.dir)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	name := "git"
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") {
+			name = "git " + arg
+			break
+		}
+	}
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("%s failed: %s", name, message)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,290 @@
+package signing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	signingStoreManifestFile = "manifest.json"
+	signingStoreVersion      = 1
+	signingStoreKDFAlgorithm = "pbkdf2-sha256"
+	signingStoreIterations   = 600000
+	signingStoreMagic        = "ASCSYNC1"
+	signingStoreVerifier     = "asc signing sync"
+)
+
+// signingStoreManifest is the plaintext index of a signing repository.
+// Certificates, keys and profiles are stored next to it, encrypted with a
+// key derived from the repository passphrase.
+type signingStoreManifest struct {
+	Version      int                       `json:"version"`
+	KDF          signingStoreKDF           `json:"kdf"`
+	Verifier     string                    `json:"verifier"`
+	Certificates []signingStoreCertificate `json:"certificates,omitempty"`
+	Profiles     []signingStoreProfile     `json:"profiles,omitempty"`
+}
+
+type signingStoreKDF struct {
+	Algorithm  string `json:"algorithm"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+}
+
+type signingStoreCertificate struct {
+	ID              string `json:"id"`
+	CertificateType string `json:"certificateType"`
+	Name            string `json:"name,omitempty"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	ExpirationDate  string `json:"expirationDate"`
+	CertificateFile string `json:"certificateFile"`
+	P12File         string `json:"p12File"`
+}
+
+type signingStoreProfile struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ProfileType    string `json:"profileType"`
+	BundleID       string `json:"bundleId"`
+	UUID           string `json:"uuid,omitempty"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+	CertificateID  string `json:"certificateId"`
+	File           string `json:"file"`
+}
+
+// signingStore reads and writes the encrypted contents of a checked-out
+// signing repository.
+type signingStore struct {
+	dir      string
+	manifest signingStoreManifest
+	aead     cipher.AEAD
+}
+
+// openSigningStore opens the store in dir. With create, an empty directory
+// is initialized with a fresh salt; otherwise a missing manifest is an error.
+func openSigningStore(dir, passphrase string, create bool) (*signingStore, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase is empty")
+	}
+	store := &signingStore{dir: dir}
+
+	data, err := os.ReadFile(filepath.Join(dir, signingStoreManifestFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if !create {
+			return nil, fmt.Errorf("signing repository is not initialized; run asc signing sync without --readonly first")
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		store.manifest = signingStoreManifest{
+			Version: signingStoreVersion,
+			KDF: signingStoreKDF{
+				Algorithm:  signingStoreKDFAlgorithm,
+				Iterations: signingStoreIterations,
+				Salt:       base64.StdEncoding.EncodeToString(salt),
+			},
+		}
+		if err := store.deriveKey(passphrase); err != nil {
+			return nil, err
+		}
+		verifier, err := store.encrypt(signingStoreManifestFile, []byte(signingStoreVerifier))
+		if err != nil {
+			return nil, err
+		}
+		store.manifest.Verifier = base64.StdEncoding.EncodeToString(verifier)
+		return store, nil
+	case err != nil:
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store.manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if store.manifest.Version != signingStoreVersion {
+		return nil, fmt.Errorf("unsupported signing repository version %d", store.manifest.Version)
+	}
+	if store.manifest.KDF.Algorithm != signingStoreKDFAlgorithm {
+		return nil, fmt.Errorf("unsupported key derivation %q", store.manifest.KDF.Algorithm)
+	}
+	if err := store.deriveKey(passphrase); err != nil {
+		return nil, err
+	}
+	verifier, err := base64.StdEncoding.DecodeString(store.manifest.Verifier)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if plain, err := store.decrypt(signingStoreManifestFile, verifier); err != nil || string(plain) != signingStoreVerifier {
+		return nil, fmt.Errorf("incorrect passphrase for signing repository")
+	}
+	return store, nil
+}
+
+func (s *signingStore) deriveKey(passphrase string) error {
+	salt, err := base64.StdEncoding.DecodeString(s.manifest.KDF.Salt)
+	if err != nil || len(salt) == 0 {
+		return fmt.Errorf("parse manifest: invalid salt")
+	}
+	if s.manifest.KDF.Iterations <= 0 {
+		return fmt.Errorf("parse manifest: invalid iteration count")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, s.manifest.KDF.Iterations, 32)
+	if err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	s.aead, err = cipher.NewGCM(block)
+	return err
+}
+
+// encrypt seals plaintext with AES-GCM. The store-relative name is bound as
+// additional data so encrypted files cannot be swapped for one another.
+func (s *signingStore) encrypt(name string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(signingStoreMagic), nonce...)
+	return s.aead.Seal(out, nonce, plaintext, []byte(name)), nil
+}
+
+func (s *signingStore) decrypt(name string, data []byte) ([]byte, error) {
+	header := len(signingStoreMagic) + s.aead.NonceSize()
+	if len(data) < header || string(data[:len(signingStoreMagic)]) != signingStoreMagic {
+		return nil, fmt.Errorf("%s is not an encrypted signing file", name)
+	}
+	plain, err := s.aead.Open(nil, data[len(signingStoreMagic):header], data[header:], []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", name, err)
+	}
+	return plain, nil
+}
+
+func (s *signingStore) readFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(name, data)
+}
+
+func (s *signingStore) writeFile(name string, plaintext []byte) error {
+	data, err := s.encrypt(name, plaintext)
+	if err != nil {
+		return err
+	}
+	target := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0o600)
+}
+
+func (s *signingStore) removeFile(name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *signingStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(name))
+}
+
+func (s *signingStore) save() error {
+	data, err := json.MarshalIndent(s.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, signingStoreManifestFile), append(data, '\n'), 0o644)
+}
+
+func (s *signingStore) certificate(certificateType string) *signingStoreCertificate {
+	for i := range s.manifest.Certificates {
+		if s.manifest.Certificates[i].CertificateType == certificateType {
+			return &s.manifest.Certificates[i]
+		}
+	}
+	return nil
+}
+
+// putCertificate stores the certificate and its .p12 bundle, replacing (and
+// deleting the files of) any previous certificate of the same type.
+func (s *signingStore) putCertificate(entry signingStoreCertificate, der, p12 []byte) error {
+	base := path.Join("certs", safeFileName(entry.CertificateType, "certificate"), safeFileName(entry.ID, "certificate"))
+	entry.CertificateFile = base + ".cer"
+	entry.P12File = base + ".p12"
+	if err := s.writeFile(entry.CertificateFile, der); err != nil {
+		return err
+	}
+	if err := s.writeFile(entry.P12File, p12); err != nil {
+		return err
+	}
+
+	if existing := s.certificate(entry.CertificateType); existing != nil {
+		if existing.ID != entry.ID {
+			if err := s.removeFile(existing.CertificateFile); err != nil {
+				return err
+			}
+			if err := s.removeFile(existing.P12File); err != nil {
+				return err
+			}
+		}
+		*existing = entry
+		return nil
+	}
+	s.manifest.Certificates = append(s.manifest.Certificates, entry)
+	return nil
+}
+
+func (s *signingStore) profile(profileType, bundleID string) *signingStoreProfile {
+	for i := range s.manifest.Profiles {
+		entry := &s.manifest.Profiles[i]
+		if entry.ProfileType == profileType && entry.BundleID == bundleID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// putProfile stores a provisioning profile, replacing any previous profile
+// for the same bundle ID and profile type.
+func (s *signingStore) putProfile(entry signingStoreProfile, content []byte) error {
+	entry.File = path.Join("profiles", safeFileName(entry.ProfileType, "profile"), safeFileName(entry.BundleID, entry.ID)+".mobileprovision")
+	if err := s.writeFile(entry.File, content); err != nil {
+		return err
+	}
+	if existing := s.profile(entry.ProfileType, entry.BundleID); existing != nil {
+		*existing = entry
+		return nil
+	}
+	s.manifest.Profiles = append(s.manifest.Profiles, entry)
+	return nil
+}
+
+// signingDateExpired reports whether value is missing, unparseable or not
+// after now.
+func signingDateExpired(value string, now time.Time) bool {
+	expires, err := shared.ParseAPIDate(value)
+	return err != nil || !expires.After(now)
+}
@@ -0,0 +1,414 @@
+package signing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const defaultSigningPassphraseEnv = "ASC_SIGNING_PASSPHRASE"
+
+// SigningSyncCommand returns the signing sync subcommand.
+func SigningSyncCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+
+	repoURL := fs.String("repo", "", "Git repository URL or local path storing encrypted signing files (required)")
+	branch := fs.String("branch", "main", "Git branch to use")
+	bundleID := fs.String("bundle-id", "", "Bundle identifier (e.g., com.example.app) - required")
+	profileType := fs.String("profile-type", "", "Profile type: IOS_APP_STORE, IOS_APP_DEVELOPMENT, MAC_APP_STORE, etc. (required)")
+	certType := fs.String("certificate-type", "", "Certificate type (default: inferred from --profile-type)")
+	deviceIDs := fs.String("device", "", "Device ID(s), comma-separated (required to create development profiles)")
+	passphraseEnv := fs.String("passphrase-env", defaultSigningPassphraseEnv, "Environment variable holding the repository passphrase")
+	readonly := fs.Bool("readonly", false, "Only decrypt and install; never create, renew or push")
+	outputPath := fs.String("output", "./signing", "Output directory for installed signing files")
+	format := fs.String("format", "json", "Output format for metadata: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "sync",
+		ShortUsage: "asc signing sync --repo <git url|path> [flags]",
+		ShortHelp:  "Sync certificates and profiles through an encrypted git repository.",
+		LongHelp: `Sync signing certificates and provisioning profiles through a git repository.
+
+Certificates are stored with their private keys as .p12 bundles, alongside
+provisioning profiles, encrypted with AES-256-GCM using a key derived from
+the passphrase in --passphrase-env. Any git remote works, including a local
+bare repository.
+
+Without --readonly, missing certificates and profiles are created, expired or
+revoked certificates are renewed, and changes are committed and pushed. A new
+certificate and its private key are pushed before any profile is changed; a
+push rejected because another sync got there first is rebased and retried.
+With --readonly, the repository is only decrypted; no App Store Connect
+credentials are needed.
+
+The installed .p12 uses the repository passphrase as its password.
+
+Examples:
+  asc signing sync --repo git@github.com:example/certificates.git --bundle-id com.example.app --profile-type IOS_APP_STORE
+  asc signing sync --repo ./certificates.git --bundle-id com.example.app --profile-type IOS_APP_STORE --readonly --output ./signing
+  asc signing sync --repo ./certificates.git --bundle-id com.example.app --profile-type IOS_APP_DEVELOPMENT --device "DEVICE1,DEVICE2"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			remote := strings.TrimSpace(*repoURL)
+			if remote == "" {
+				fmt.Fprintln(os.Stderr, "Error: --repo is required")
+				return flag.ErrHelp
+			}
+			bundle := strings.TrimSpace(*bundleID)
+			if bundle == "" {
+				fmt.Fprintln(os.Stderr, "Error: --bundle-id is required")
+				return flag.ErrHelp
+			}
+			profType := strings.ToUpper(strings.TrimSpace(*profileType))
+			if profType == "" {
+				fmt.Fprintln(os.Stderr, "Error: --profile-type is required")
+				return flag.ErrHelp
+			}
+			branchName := strings.TrimSpace(*branch)
+			if branchName == "" {
+				fmt.Fprintln(os.Stderr, "Error: --branch must not be empty")
+				return flag.ErrHelp
+			}
+			devices := shared.SplitCSV(*deviceIDs)
+			if !*readonly && isDevelopmentProfile(profType) && len(devices) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --device is required for development profiles")
+				return flag.ErrHelp
+			}
+
+			certificateType := strings.ToUpper(strings.TrimSpace(*certType))
+			if certificateType == "" {
+				inferred, err := inferCertificateType(profType)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				certificateType = inferred
+			}
+
+			envName := strings.TrimSpace(*passphraseEnv)
+			passphrase := os.Getenv(envName)
+			if envName == "" || passphrase == "" {
+				return fmt.Errorf("signing sync: passphrase environment variable %s is not set", envName)
+			}
+
+			outputDir := strings.TrimSpace(*outputPath)
+			if outputDir == "" {
+				outputDir = "./signing"
+			}
+
+			repo, err := cloneSigningRepo(ctx, remote, branchName, *readonly)
+			if err != nil {
+				return fmt.Errorf("signing sync: %w", err)
+			}
+			defer repo.Close()
+
+			store, err := openSigningStore(repo.dir, passphrase, !*readonly)
+			if err != nil {
+				return fmt.Errorf("signing sync: %w", err)
+			}
+
+			result := &asc.SigningSyncResult{
+				Repo:        remote,
+				Branch:      branchName,
+				BundleID:    bundle,
+				ProfileType: profType,
+				Readonly:    *readonly,
+				OutputPath:  outputDir,
+			}
+
+			var (
+				cert    *signingStoreCertificate
+				profile *signingStoreProfile
+			)
+			now := time.Now()
+			if *readonly {
+				cert, profile, err = readonlySigningAssets(store, certificateType, profType, bundle, now)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+			} else {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				defer cancel()
+
+				var change string
+				cert, change, err = syncSigningCertificate(requestCtx, client, store, certificateType, passphrase, now)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				if change != "" {
+					result.Changes = append(result.Changes, change)
+					// Push the new certificate and its private key before
+					// touching profiles: a later failure removes the checkout,
+					// and Apple cannot return the key.
+					if err := store.save(); err != nil {
+						repo.keep()
+						return fmt.Errorf("signing sync: certificate %s is saved in %s but the manifest could not be written: %w", cert.ID, repo.dir, err)
+					}
+					result.Commit, err = repo.commitAndPush(ctx, fmt.Sprintf("Add %s certificate %s", certificateType, cert.ID))
+					if err != nil {
+						repo.keep()
+						return fmt.Errorf("signing sync: certificate %s and its private key are saved in %s but could not be pushed: %w", cert.ID, repo.dir, err)
+					}
+					// A rebase may have brought in changes from another sync.
+					store, err = openSigningStore(repo.dir, passphrase, true)
+					if err != nil {
+						return fmt.Errorf("signing sync: %w", err)
+					}
+					cert = store.certificate(certificateType)
+				}
+				profile, change, err = syncSigningProfile(requestCtx, client, store, bundle, profType, cert, devices, now)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				if change != "" {
+					result.Changes = append(result.Changes, change)
+				}
+
+				if err := store.save(); err != nil {
+					return fmt.Errorf("signing sync: write manifest: %w", err)
+				}
+				message := fmt.Sprintf("Update signing files for %s (%s)", bundle, profType)
+				if len(result.Changes) > 0 {
+					message += "\n\n" + strings.Join(result.Changes, "\n")
+				}
+				commit, err := repo.commitAndPush(ctx, message)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				if commit != "" {
+					result.Commit = commit
+				}
+			}
+
+			result.CertificateID = cert.ID
+			result.ProfileID = profile.ID
+			if err := installSigningFiles(store, cert, profile, outputDir, result); err != nil {
+				return fmt.Errorf("signing sync: %w", err)
+			}
+
+			return shared.PrintOutput(result, *format, *pretty)
+		},
+	}
+}
+
+// readonlySigningAssets returns the stored certificate and profile without
+// contacting App Store Connect.
+func readonlySigningAssets(store *signingStore, certificateType, profileType, bundleID string, now time.Time) (*signingStoreCertificate, *signingStoreProfile, error) {
+	cert := store.certificate(certificateType)
+	if cert == nil {
+		return nil, nil, fmt.Errorf("no %s certificate in signing repository; run without --readonly to create one", certificateType)
+	}
+	if signingDateExpired(cert.ExpirationDate, now) {
+		return nil, nil, fmt.Errorf("certificate %s expired on %s; run without --readonly to renew it", cert.ID, cert.ExpirationDate)
+	}
+	profile := store.profile(profileType, bundleID)
+	if profile == nil {
+		return nil, nil, fmt.Errorf("no %s profile for %s in signing repository; run without --readonly to create one", profileType, bundleID)
+	}
+	if profile.CertificateID != cert.ID {
+		return nil, nil, fmt.Errorf("profile %s does not include certificate %s; run without --readonly to regenerate it", profile.ID, cert.ID)
+	}
+	if signingProfileExpired(profile, now) {
+		return nil, nil, fmt.Errorf("profile %s expired on %s; run without --readonly to renew it", profile.ID, profile.ExpirationDate)
+	}
+	return cert, profile, nil
+}
+
+// syncSigningCertificate returns the stored certificate of certificateType,
+// creating one with a generated key when it is missing, expired or revoked.
+func syncSigningCertificate(ctx context.Context, client *asc.Client, store *signingStore, certificateType, passphrase string, now time.Time) (*signingStoreCertificate, string, error) {
+	change := "created certificate"
+	if existing := store.certificate(certificateType); existing != nil {
+		change = "renewed expired certificate " + existing.ID
+		if !signingDateExpired(existing.ExpirationDate, now) {
+			_, err := client.GetCertificate(ctx, existing.ID)
+			if err == nil {
+				return existing, "", nil
+			}
+			if !asc.IsNotFound(err) {
+				return nil, "", fmt.Errorf("check certificate %s: %w", existing.ID, err)
+			}
+			change = "replaced revoked certificate " + existing.ID
+		}
+	}
+
+	key, err := shared.GenerateSigningKey(0)
+	if err != nil {
+		return nil, "", err
+	}
+	csr, err := shared.CreateCertificateSigningRequest(key, "asc", "")
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := client.CreateCertificate(ctx, csr, certificateType)
+	if err != nil {
+		return nil, "", fmt.Errorf("create certificate: %w", err)
+	}
+	content := resp.Data.Attributes.CertificateContent
+	if strings.TrimSpace(content) == "" {
+		fetched, err := client.GetCertificate(ctx, resp.Data.ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("certificate %s created but download failed: %w", resp.Data.ID, err)
+		}
+		content = fetched.Data.Attributes.CertificateContent
+	}
+	cert, err := shared.ParseCertificateContent(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("certificate %s created but %w", resp.Data.ID, err)
+	}
+	p12, err := shared.EncodePKCS12(key, cert, cert.Subject.CommonName, passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entry := signingStoreCertificate{
+		ID:              resp.Data.ID,
+		CertificateType: certificateType,
+		Name:            resp.Data.Attributes.Name,
+		SerialNumber:    resp.Data.Attributes.SerialNumber,
+		ExpirationDate:  cert.NotAfter.UTC().Format(time.RFC3339),
+	}
+	if err := store.putCertificate(entry, cert.Raw, p12); err != nil {
+		return nil, "", fmt.Errorf("store certificate: %w", err)
+	}
+	return store.certificate(certificateType), fmt.Sprintf("%s %s", change, resp.Data.ID), nil
+}
+
+// syncSigningProfile returns the stored profile for bundleID, finding or
+// creating one signed with cert when it is missing, stale or inactive.
+func syncSigningProfile(ctx context.Context, client *asc.Client, store *signingStore, bundleID, profileType string, cert *signingStoreCertificate, deviceIDs []string, now time.Time) (*signingStoreProfile, string, error) {
+	existing := store.profile(profileType, bundleID)
+	if existing != nil && existing.CertificateID == cert.ID && !signingProfileExpired(existing, now) {
+		resp, err := client.GetProfile(ctx, existing.ID)
+		if err == nil && resp.Data.Attributes.ProfileState == asc.ProfileStateActive {
+			return existing, "", nil
+		}
+		if err != nil && !asc.IsNotFound(err) {
+			return nil, "", fmt.Errorf("check profile %s: %w", existing.ID, err)
+		}
+	}
+
+	bundleIDResp, err := shared.FindBundleID(ctx, client, bundleID)
+	if err != nil {
+		return nil, "", err
+	}
+	certIDs := []string{cert.ID}
+	profile, created, err := findOrCreateProfile(ctx, client, bundleIDResp.ID, bundleID, profileType, certIDs, deviceIDs, true)
+	if err != nil {
+		return nil, "", err
+	}
+	if !created {
+		included, err := profileIncludesCertificate(ctx, client, profile.Data.ID, cert.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		if !included {
+			profile, err = createProfile(ctx, client, bundleIDResp.ID, bundleID, profileType, certIDs, deviceIDs)
+			if err != nil {
+				return nil, "", err
+			}
+			created = true
+		}
+	}
+
+	content, err := decodeBase64Content("profile", profile.Data.Attributes.ProfileContent)
+	if err != nil {
+		return nil, "", err
+	}
+	expiration := profile.Data.Attributes.ExpirationDate
+	if parsed, err := shared.ParseAPIDate(expiration); err == nil {
+		expiration = parsed.UTC().Format(time.RFC3339)
+	}
+	entry := signingStoreProfile{
+		ID:             profile.Data.ID,
+		Name:           profile.Data.Attributes.Name,
+		ProfileType:    profileType,
+		BundleID:       bundleID,
+		UUID:           profile.Data.Attributes.UUID,
+		ExpirationDate: expiration,
+		CertificateID:  cert.ID,
+	}
+	if err := store.putProfile(entry, content); err != nil {
+		return nil, "", fmt.Errorf("store profile: %w", err)
+	}
+
+	change := "stored profile " + profile.Data.ID
+	if created {
+		change = "created profile " + profile.Data.ID
+	}
+	return store.profile(profileType, bundleID), change, nil
+}
+
+// signingProfileExpired reports whether a stored profile has expired. Profiles
+// stored without an expiration date are treated as current.
+func signingProfileExpired(profile *signingStoreProfile, now time.Time) bool {
+	return profile.ExpirationDate != "" && signingDateExpired(profile.ExpirationDate, now)
+}
+
+func profileIncludesCertificate(ctx context.Context, client *asc.Client, profileID, certificateID string) (bool, error) {
+	next := ""
+	for {
+		resp, err := client.GetProfileCertificatesRelationships(ctx, profileID,
+			asc.WithLinkagesLimit(200),
+			asc.WithLinkagesNextURL(next),
+		)
+		if err != nil {
+			return false, fmt.Errorf("fetch profile certificates: %w", err)
+		}
+		for _, item := range resp.Data {
+			if item.ID == certificateID {
+				return true, nil
+			}
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return false, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// installSigningFiles decrypts the certificate, .p12 and profile into
+// outputDir, replacing files from earlier runs.
+func installSigningFiles(store *signingStore, cert *signingStoreCertificate, profile *signingStoreProfile, outputDir string, result *asc.SigningSyncResult) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+
+	files := []struct {
+		source string
+		target *string
+		name   string
+		perm   os.FileMode
+	}{
+		{cert.CertificateFile, &result.CertificateFile, safeFileName(cert.ID, "certificate") + ".cer", 0o644},
+		{cert.P12File, &result.P12File, safeFileName(cert.ID, "certificate") + ".p12", 0o600},
+		{profile.File, &result.ProfileFile, safeFileName(profile.Name, profile.ID) + ".mobileprovision", 0o644},
+	}
+	for _, file := range files {
+		data, err := store.readFile(file.source)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(outputDir, file.name)
+		if err := shared.WriteFileAtomic(target, data, file.perm); err != nil {
+			return fmt.Errorf("write %s: %w", target, err)
+		}
+		*file.target = target
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigningSyncValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing repo",
+			args:    []string{"--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"},
+			wantErr: "Error: --repo is required",
+		},
+		{
+			name:    "missing bundle-id",
+			args:    []string{"--repo", "./certs.git", "--profile-type", "IOS_APP_STORE"},
+			wantErr: "Error: --bundle-id is required",
+		},
+		{
+			name:    "missing profile-type",
+			args:    []string{"--repo", "./certs.git", "--bundle-id", "com.example.app"},
+			wantErr: "Error: --profile-type is required",
+		},
+		{
+			name:    "missing device for development profile",
+			args:    []string{"--repo", "./certs.git", "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_DEVELOPMENT"},
+			wantErr: "Error: --device is required for development profiles",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := SigningSyncCommand()
+			cmd.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := cmd.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := cmd.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestSigningStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store, err := openSigningStore(dir, "passphrase", true)
+	if err != nil {
+		t.Fatalf("openSigningStore() error: %v", err)
+	}
+	if err := store.putProfile(signingStoreProfile{ID: "P1", ProfileType: "IOS_APP_STORE", BundleID: "com.example.app"}, []byte("profile")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := openSigningStore(dir, "other", false); err == nil || !strings.Contains(err.Error(), "incorrect passphrase") {
+		t.Fatalf("expected incorrect passphrase error, got %v", err)
+	}
+	reopened, err := openSigningStore(dir, "passphrase", false)
+	if err != nil {
+		t.Fatalf("reopen error: %v", err)
+	}
+	entry := reopened.profile("IOS_APP_STORE", "com.example.app")
+	if entry == nil {
+		t.Fatal("expected stored profile")
+	}
+	data, err := reopened.readFile(entry.File)
+	if err != nil || string(data) != "profile" {
+		t.Fatalf("readFile() = %q, %v", data, err)
+	}
+
+	encrypted, err := reopened.encrypt("a.mobileprovision", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.decrypt("b.mobileprovision", encrypted); err == nil {
+		t.Fatal("expected decrypt under a different name to fail")
+	}
+}
+
+func TestOpenSigningStoreRequiresInitializedRepo(t *testing.T) {
+	if _, err := openSigningStore(t.TempDir(), "passphrase", false); err == nil || !strings.Contains(err.Error(), "not initialized") {
+		t.Fatalf("expected not initialized error, got %v", err)
+	}
+}
+
+func TestSigningDateExpired(t *testing.T) {
+	if !signingDateExpired("", time.Now()) || signingDateExpired("2999-01-01T00:00:00Z", time.Now()) {
+		t.Fatal("unexpected signingDateExpired result")
+	}
+	if signingDateExpired("2999-01-01T00:00:00.000+0000", time.Now()) {
+		t.Fatal("expected numeric zone offset to parse")
+	}
+}
+
+func TestSigningRepoPushRebasesOnRejection(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	ctx := context.Background()
+	remote := filepath.Join(t.TempDir(), "certificates.git")
+	if _, err := runGit(ctx, "", "init", "--quiet", "--bare", remote); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := cloneSigningRepo(ctx, remote, "main", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	second, err := cloneSigningRepo(ctx, remote, "main", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	commit := func(repo *signingRepo, name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo.dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.commitAndPush(ctx, "Add "+name); err != nil {
+			t.Fatalf("commitAndPush(%s) error: %v", name, err)
+		}
+	}
+	commit(first, "base")
+	if _, err := second.git(ctx, "pull", "--quiet", "origin", "main"); err != nil {
+		t.Fatal(err)
+	}
+	commit(first, "one")
+	commit(second, "two")
+
+	out, err := runGit(ctx, "", "--git-dir", remote, "log", "--format=%s", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(strings.ReplaceAll(out, "Add ", "")); strings.Join(got, ",") != "two,one,base" {
+		t.Fatalf("unexpected remote history %q", out)
+	}
+}
@@ -60,3 +60,69 @@ func findBetaTesterIDByEmail(ctx context.Context, client *asc.Client, appID, ema
 
 	return testers.Data[0].ID, nil
 }
+
+func fetchAllBetaGroups(ctx context.Context, client *asc.Client, appID string) ([]asc.Resource[asc.BetaGroupAttributes], error) {
+	first, err := client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta groups: %w", err)
+	}
+	all, err := asc.PaginateAll(ctx, first, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta groups: %w", err)
+	}
+	return all.(*asc.BetaGroupsResponse).Data, nil
+}
+
+func fetchAllBetaGroupTesters(ctx context.Context, client *asc.Client, groupID string) ([]asc.Resource[asc.BetaTesterAttributes], error) {
+	first, err := client.GetBetaGroupTesters(ctx, groupID, asc.WithBetaGroupTestersLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch testers for group %s: %w", groupID, err)
+	}
+	all, err := asc.PaginateAll(ctx, first, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetBetaGroupTesters(ctx, groupID, asc.WithBetaGroupTestersNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch testers for group %s: %w", groupID, err)
+	}
+	return all.(*asc.BetaTestersResponse).Data, nil
+}
+
+func fetchAllAppBetaTesters(ctx context.Context, client *asc.Client, appID string) ([]asc.Resource[asc.BetaTesterAttributes], error) {
+	first, err := client.GetBetaTesters(ctx, appID, asc.WithBetaTestersLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta testers: %w", err)
+	}
+	all, err := asc.PaginateAll(ctx, first, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetBetaTesters(ctx, appID, asc.WithBetaTestersNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta testers: %w", err)
+	}
+	return all.(*asc.BetaTestersResponse).Data, nil
+}
+
+// matchBetaGroup finds a group by ID or unique case-insensitive name.
+func matchBetaGroup(groups []asc.Resource[asc.BetaGroupAttributes], value string) (asc.Resource[asc.BetaGroupAttributes], error) {
+	value = strings.TrimSpace(value)
+	for _, group := range groups {
+		if group.ID == value {
+			return group, nil
+		}
+	}
+	var matches []asc.Resource[asc.BetaGroupAttributes]
+	for _, group := range groups {
+		if strings.EqualFold(strings.TrimSpace(group.Attributes.Name), value) {
+			matches = append(matches, group)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return asc.Resource[asc.BetaGroupAttributes]{}, fmt.Errorf("beta group %q not found", value)
+	case 1:
+		return matches[0], nil
+	default:
+		return asc.Resource[asc.BetaGroupAttributes]{}, fmt.Errorf("multiple beta groups named %q; use group ID", value)
+	}
+}
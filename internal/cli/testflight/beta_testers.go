@@ -35,7 +35,11 @@ Examples:
   asc testflight beta-testers remove-builds --id "TESTER_ID" --build "BUILD_ID" --confirm
   asc testflight beta-testers remove-apps --id "TESTER_ID" --app "APP_ID" --confirm
   asc testflight beta-testers invite --app "APP_ID" --email "tester@example.com"
-  asc testflight beta-testers invite --app "APP_ID" --email "tester@example.com" --group "Beta"`,
+  asc testflight beta-testers invite --app "APP_ID" --email "tester@example.com" --group "Beta"
+  asc testflight beta-testers engagement --app "APP_ID" --since 90d
+  asc testflight beta-testers prune --app "APP_ID" --group "External" --since 90d --dry-run
+  asc testflight beta-testers import --app "APP_ID" --file testers.csv --group "External" --dry-run
+  asc testflight beta-testers export --app "APP_ID" --group "External" --file testers.csv`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -53,6 +57,10 @@ Examples:
 			BetaTestersBetaGroupsCommand(),
 			BetaTestersBuildsCommand(),
 			BetaTestersMetricsCommand(),
+			BetaTestersEngagementCommand(),
+			BetaTestersPruneCommand(),
+			BetaTestersImportCommand(),
+			BetaTestersExportCommand(),
 			BetaTestersInviteCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
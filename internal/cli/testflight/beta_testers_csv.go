@@ -0,0 +1,470 @@
+package testflight
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	betaTesterImportCreated     = "created"
+	betaTesterImportAdded       = "added"
+	betaTesterImportWouldCreate = "would-create"
+	betaTesterImportWouldAdd    = "would-add"
+	betaTesterImportExists      = "exists"
+	betaTesterImportDuplicate   = "duplicate"
+	betaTesterImportInvalid     = "invalid"
+	betaTesterImportFailed      = "failed"
+)
+
+// betaTesterCSVHeader matches the tester CSV used by App Store Connect.
+var betaTesterCSVHeader = []string{"First Name", "Last Name", "Email"}
+
+type betaTesterCSVRow struct {
+	line      int
+	firstName string
+	lastName  string
+	email     string
+}
+
+// BetaTestersImportCommand returns the beta-testers import subcommand.
+func BetaTestersImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	file := fs.String("file", "", "CSV file with first name, last name and email columns")
+	groups := fs.String("group", "", "Beta groups to add testers to (names or IDs, comma-separated)")
+	invite := fs.Bool("invite", false, "Send a TestFlight invitation to created and added testers")
+	resultsPath := fs.String("results", "", "Write a per-row result CSV to this new file")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without creating or adding testers")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "asc testflight beta-testers import --app \"APP_ID\" --file testers.csv --group GROUP [flags]",
+		ShortHelp:  "Import beta testers from a CSV file.",
+		LongHelp: `Import beta testers from a CSV file.
+
+Accepts the CSV format App Store Connect uses for tester import and export:
+first name, last name and email, with or without a header row. With a header,
+columns are matched by name and extra columns are ignored.
+
+Emails are deduplicated within the file and against the app's existing
+testers. New testers are created in the --group groups; existing testers are
+added to the groups they are missing from in batches. Each row gets a status:
+created, added, exists, duplicate, invalid or failed (would-create and
+would-add with --dry-run).
+
+Examples:
+  asc testflight beta-testers import --app "APP_ID" --file testers.csv --group "External" --dry-run
+  asc testflight beta-testers import --app "APP_ID" --file testers.csv --group "External" --invite --results results.csv`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			groupValues := shared.SplitCSV(*groups)
+			if len(groupValues) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --group is required")
+				return flag.ErrHelp
+			}
+			resultsValue := strings.TrimSpace(*resultsPath)
+			if resultsValue != "" {
+				if _, err := os.Lstat(resultsValue); err == nil {
+					return fmt.Errorf("beta-testers import: output file already exists: %s", resultsValue)
+				}
+			}
+
+			rows, err := readBetaTesterCSV(fileValue)
+			if err != nil {
+				return fmt.Errorf("beta-testers import: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("beta-testers import: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			groupList, err := fetchAllBetaGroups(requestCtx, client, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("beta-testers import: %w", err)
+			}
+			result := &asc.BetaTesterImportResult{
+				AppID:       resolvedAppID,
+				File:        fileValue,
+				DryRun:      *dryRun,
+				ResultsFile: resultsValue,
+			}
+			var groupIDs []string
+			for _, value := range groupValues {
+				group, err := matchBetaGroup(groupList, value)
+				if err != nil {
+					return fmt.Errorf("beta-testers import: %w", err)
+				}
+				groupIDs = append(groupIDs, group.ID)
+				result.Groups = append(result.Groups, group.Attributes.Name)
+			}
+
+			existing, err := fetchAllAppBetaTesters(requestCtx, client, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("beta-testers import: %w", err)
+			}
+			testersByEmail := make(map[string]string, len(existing))
+			for _, tester := range existing {
+				testersByEmail[strings.ToLower(strings.TrimSpace(tester.Attributes.Email))] = tester.ID
+			}
+			members := make(map[string]map[string]bool, len(groupIDs))
+			for _, groupID := range groupIDs {
+				testers, err := fetchAllBetaGroupTesters(requestCtx, client, groupID)
+				if err != nil {
+					return fmt.Errorf("beta-testers import: %w", err)
+				}
+				members[groupID] = make(map[string]bool, len(testers))
+				for _, tester := range testers {
+					members[groupID][tester.ID] = true
+				}
+			}
+
+			importBetaTesterRows(requestCtx, client, result, rows, groupIDs, testersByEmail, members, *invite)
+
+			if resultsValue != "" {
+				if err := writeBetaTesterImportResults(resultsValue, result.Rows); err != nil {
+					return fmt.Errorf("beta-testers import: %w", err)
+				}
+			}
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if result.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("beta-testers import: %d rows failed", result.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+// BetaTestersExportCommand returns the beta-testers export subcommand.
+func BetaTestersExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	group := fs.String("group", "", "Beta group name or ID (default: all testers for the app)")
+	file := fs.String("file", "", "Write testers to this new CSV file")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc testflight beta-testers export --app \"APP_ID\" --file testers.csv [--group GROUP]",
+		ShortHelp:  "Export beta testers to a CSV file.",
+		LongHelp: `Export beta testers to a CSV file.
+
+Writes First Name, Last Name and Email columns, the format accepted by
+beta-testers import and the App Store Connect tester import.
+
+Examples:
+  asc testflight beta-testers export --app "APP_ID" --group "External" --file external.csv
+  asc testflight beta-testers export --app "APP_ID" --file testers.csv`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			if _, err := os.Lstat(fileValue); err == nil {
+				return fmt.Errorf("beta-testers export: output file already exists: %s", fileValue)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("beta-testers export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			result := &asc.BetaTesterExportResult{AppID: resolvedAppID, File: fileValue}
+			var testers []asc.Resource[asc.BetaTesterAttributes]
+			if groupValue := strings.TrimSpace(*group); groupValue != "" {
+				groupList, err := fetchAllBetaGroups(requestCtx, client, resolvedAppID)
+				if err != nil {
+					return fmt.Errorf("beta-testers export: %w", err)
+				}
+				matched, err := matchBetaGroup(groupList, groupValue)
+				if err != nil {
+					return fmt.Errorf("beta-testers export: %w", err)
+				}
+				result.Group = matched.Attributes.Name
+				testers, err = fetchAllBetaGroupTesters(requestCtx, client, matched.ID)
+				if err != nil {
+					return fmt.Errorf("beta-testers export: %w", err)
+				}
+			} else {
+				testers, err = fetchAllAppBetaTesters(requestCtx, client, resolvedAppID)
+				if err != nil {
+					return fmt.Errorf("beta-testers export: %w", err)
+				}
+			}
+
+			if err := writeBetaTesterCSV(fileValue, testers); err != nil {
+				return fmt.Errorf("beta-testers export: %w", err)
+			}
+			result.Testers = len(testers)
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+// readBetaTesterCSV parses a tester CSV with an optional header row.
+func readBetaTesterCSV(path string) ([]betaTesterCSVRow, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	firstCol, lastCol, emailCol := 0, 1, 2
+	var rows []betaTesterCSVRow
+	for record := 0; ; {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		record++
+		if record == 1 && len(fields) > 0 {
+			fields[0] = strings.TrimPrefix(fields[0], "\ufeff")
+			if first, last, email, ok := betaTesterCSVColumns(fields); ok {
+				firstCol, lastCol, emailCol = first, last, email
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := betaTesterCSVRow{
+			line:      line,
+			firstName: csvField(fields, firstCol),
+			lastName:  csvField(fields, lastCol),
+			email:     csvField(fields, emailCol),
+		}
+		// A single-column file is a plain list of emails.
+		if len(fields) == 1 && strings.Contains(fields[0], "@") {
+			row.firstName, row.email = "", strings.TrimSpace(fields[0])
+		}
+		if row.firstName == "" && row.lastName == "" && row.email == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no testers found in %s", path)
+	}
+	return rows, nil
+}
+
+// betaTesterCSVColumns detects a header row and returns the column indexes.
+func betaTesterCSVColumns(fields []string) (int, int, int, bool) {
+	first, last, email := -1, -1, -1
+	for i, field := range fields {
+		switch strings.ToLower(strings.Join(strings.Fields(field), " ")) {
+		case "first name", "firstname", "first":
+			first = i
+		case "last name", "lastname", "last":
+			last = i
+		case "email", "email address", "e-mail":
+			email = i
+		}
+	}
+	return first, last, email, email >= 0
+}
+
+func csvField(fields []string, index int) string {
+	if index < 0 || index >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[index])
+}
+
+func importBetaTesterRows(
+	ctx context.Context,
+	client *asc.Client,
+	result *asc.BetaTesterImportResult,
+	rows []betaTesterCSVRow,
+	groupIDs []string,
+	testersByEmail map[string]string,
+	members map[string]map[string]bool,
+	invite bool,
+) {
+	seen := map[string]bool{}
+	pending := map[string][]int{}
+	for _, row := range rows {
+		entry := asc.BetaTesterImportRow{
+			Row:       row.line,
+			FirstName: row.firstName,
+			LastName:  row.lastName,
+			Email:     row.email,
+		}
+		key := strings.ToLower(row.email)
+		address, err := mail.ParseAddress(row.email)
+		switch {
+		case err != nil || address.Address != row.email:
+			entry.Status = betaTesterImportInvalid
+			entry.Error = "invalid email address"
+			result.Invalid++
+		case seen[key]:
+			entry.Status = betaTesterImportDuplicate
+			result.Duplicates++
+		case testersByEmail[key] != "":
+			entry.TesterID = testersByEmail[key]
+			entry.Status = betaTesterImportExists
+			for _, groupID := range groupIDs {
+				if !members[groupID][entry.TesterID] {
+					entry.Status = betaTesterImportWouldAdd
+					pending[groupID] = append(pending[groupID], len(result.Rows))
+				}
+			}
+		case result.DryRun:
+			entry.Status = betaTesterImportWouldCreate
+		default:
+			created, err := client.CreateBetaTester(ctx, row.email, row.firstName, row.lastName, groupIDs)
+			if err != nil {
+				entry.Status = betaTesterImportFailed
+				entry.Error = err.Error()
+			} else {
+				entry.TesterID = created.Data.ID
+				entry.Status = betaTesterImportCreated
+			}
+		}
+		seen[key] = true
+		result.Rows = append(result.Rows, entry)
+	}
+
+	if !result.DryRun {
+		for _, groupID := range groupIDs {
+			indexes := pending[groupID]
+			for start := 0; start < len(indexes); start += betaTesterBatchSize {
+				batch := indexes[start:min(start+betaTesterBatchSize, len(indexes))]
+				testerIDs := make([]string, 0, len(batch))
+				for _, index := range batch {
+					testerIDs = append(testerIDs, result.Rows[index].TesterID)
+				}
+				err := client.AddBetaTestersToGroup(ctx, groupID, testerIDs)
+				for _, index := range batch {
+					entry := &result.Rows[index]
+					if err != nil {
+						entry.Status = betaTesterImportFailed
+						entry.Error = err.Error()
+					} else if entry.Status != betaTesterImportFailed {
+						entry.Status = betaTesterImportAdded
+					}
+				}
+			}
+		}
+	}
+
+	for i := range result.Rows {
+		entry := &result.Rows[i]
+		if invite && !result.DryRun && (entry.Status == betaTesterImportCreated || entry.Status == betaTesterImportAdded) {
+			if _, err := client.CreateBetaTesterInvitation(ctx, result.AppID, entry.TesterID); err != nil {
+				entry.Status = betaTesterImportFailed
+				entry.Error = "invite: " + err.Error()
+			} else {
+				entry.Invited = true
+				result.Invited++
+			}
+		}
+		switch entry.Status {
+		case betaTesterImportCreated, betaTesterImportWouldCreate:
+			result.Created++
+		case betaTesterImportAdded, betaTesterImportWouldAdd:
+			result.Added++
+		case betaTesterImportExists:
+			result.Existing++
+		case betaTesterImportFailed:
+			result.Failed++
+		}
+	}
+	result.Total = len(result.Rows)
+}
+
+func writeBetaTesterImportResults(path string, rows []asc.BetaTesterImportRow) error {
+	records := [][]string{{"Row", "First Name", "Last Name", "Email", "Tester ID", "Status", "Invited", "Error"}}
+	for _, row := range rows {
+		records = append(records, []string{
+			strconv.Itoa(row.Row),
+			row.FirstName,
+			row.LastName,
+			row.Email,
+			row.TesterID,
+			row.Status,
+			strconv.FormatBool(row.Invited),
+			row.Error,
+		})
+	}
+	return writeCSVFile(path, records)
+}
+
+// writeBetaTesterCSV writes testers sorted by email in the import format.
+func writeBetaTesterCSV(path string, testers []asc.Resource[asc.BetaTesterAttributes]) error {
+	sorted := append([]asc.Resource[asc.BetaTesterAttributes](nil), testers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Attributes.Email) < strings.ToLower(sorted[j].Attributes.Email)
+	})
+	records := [][]string{betaTesterCSVHeader}
+	for _, tester := range sorted {
+		records = append(records, []string{tester.Attributes.FirstName, tester.Attributes.LastName, tester.Attributes.Email})
+	}
+	return writeCSVFile(path, records)
+}
+
+// writeCSVFile writes records to a new file, refusing to replace an existing one.
+func writeCSVFile(path string, records [][]string) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	if _, err := shared.WriteStreamToFile(path, &buf); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
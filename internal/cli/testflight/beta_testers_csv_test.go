@@ -0,0 +1,65 @@
+package testflight
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadBetaTesterCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []betaTesterCSVRow
+	}{
+		{
+			name:    "positional",
+			content: "Ada,Lovelace,ada@example.com\n\nAlan,Turing,alan@example.com\n",
+			want: []betaTesterCSVRow{
+				{line: 1, firstName: "Ada", lastName: "Lovelace", email: "ada@example.com"},
+				{line: 3, firstName: "Alan", lastName: "Turing", email: "alan@example.com"},
+			},
+		},
+		{
+			name:    "header with extra columns",
+			content: "\ufeffEmail,First Name,Last Name,Status\nada@example.com,Ada,Lovelace,Accepted\n",
+			want: []betaTesterCSVRow{
+				{line: 2, firstName: "Ada", lastName: "Lovelace", email: "ada@example.com"},
+			},
+		},
+		{
+			name:    "emails only",
+			content: "ada@example.com\nalan@example.com\n",
+			want: []betaTesterCSVRow{
+				{line: 1, email: "ada@example.com"},
+				{line: 2, email: "alan@example.com"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "testers.csv")
+			if err := os.WriteFile(path, []byte(test.content), 0o600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			got, err := readBetaTesterCSV(path)
+			if err != nil {
+				t.Fatalf("readBetaTesterCSV() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("unexpected rows:\n got: %+v\nwant: %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadBetaTesterCSV_Empty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testers.csv")
+	if err := os.WriteFile(path, []byte("First Name,Last Name,Email\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := readBetaTesterCSV(path); err == nil {
+		t.Fatal("expected error for file without testers")
+	}
+}
@@ -0,0 +1,519 @@
+package testflight
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// betaTesterBatchSize caps tester IDs per beta group relationship request.
+const betaTesterBatchSize = 100
+
+var betaTesterEngagementSorts = []string{"sessions", "crashes", "feedback"}
+
+type betaTesterUsageItem struct {
+	DataPoints []struct {
+		Values struct {
+			CrashCount    int `json:"crashCount"`
+			SessionCount  int `json:"sessionCount"`
+			FeedbackCount int `json:"feedbackCount"`
+		} `json:"values"`
+	} `json:"dataPoints"`
+	Dimensions struct {
+		BetaTesters struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"betaTesters"`
+	} `json:"dimensions"`
+}
+
+type betaTesterUsageTotals struct {
+	sessions int
+	crashes  int
+	feedback int
+}
+
+// betaTesterEngagementData joins testers with their groups and usage metrics.
+type betaTesterEngagementData struct {
+	groups     map[string]asc.Resource[asc.BetaGroupAttributes]
+	groupOrder []string
+	testers    map[string]asc.Resource[asc.BetaTesterAttributes]
+	membership map[string][]string
+	usage      map[string]betaTesterUsageTotals
+	// usageRows counts usage rows; matchedUsageRows those for a loaded tester.
+	usageRows        int
+	matchedUsageRows int
+}
+
+// BetaTestersEngagementCommand returns the beta-testers engagement subcommand.
+func BetaTestersEngagementCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("engagement", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	since := fs.String("since", "90d", "Usage window: 7d, 30d, 90d, 365d")
+	groups := fs.String("group", "", "Only include testers in these beta groups (names or IDs, comma-separated)")
+	sortBy := fs.String("sort", "sessions", "Rank by: "+strings.Join(betaTesterEngagementSorts, ", "))
+	inactiveOnly := fs.Bool("inactive", false, "Only include testers with no sessions in the window")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "engagement",
+		ShortUsage: "asc testflight beta-testers engagement --app \"APP_ID\" [flags]",
+		ShortHelp:  "Rank beta testers by sessions, crashes and feedback.",
+		LongHelp: `Rank beta testers by sessions, crashes and feedback.
+
+Joins the app's beta testers, their beta groups and beta tester usage metrics
+for the --since window into one ranked report. App Store Connect reports usage
+for fixed windows only, so --since must be 7d, 30d, 90d or 365d.
+
+Examples:
+  asc testflight beta-testers engagement --app "APP_ID" --since 90d
+  asc testflight beta-testers engagement --app "APP_ID" --since 30d --group "External" --output table
+  asc testflight beta-testers engagement --app "APP_ID" --since 90d --sort feedback
+  asc testflight beta-testers engagement --app "APP_ID" --since 90d --inactive`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			period, err := normalizeBetaTesterUsageWindow(*since)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+				return flag.ErrHelp
+			}
+			sortValue := strings.ToLower(strings.TrimSpace(*sortBy))
+			if !slices.Contains(betaTesterEngagementSorts, sortValue) {
+				fmt.Fprintf(os.Stderr, "Error: --sort must be one of: %s\n", strings.Join(betaTesterEngagementSorts, ", "))
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("beta-testers engagement: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			data, err := loadBetaTesterEngagement(requestCtx, client, resolvedAppID, period, shared.SplitCSV(*groups))
+			if err != nil {
+				return fmt.Errorf("beta-testers engagement: %w", err)
+			}
+
+			result := data.report(resolvedAppID, period, sortValue)
+			if *inactiveOnly {
+				inactive := make([]asc.BetaTesterEngagement, 0, result.Inactive)
+				for _, tester := range result.Testers {
+					if tester.Sessions == 0 {
+						inactive = append(inactive, tester)
+					}
+				}
+				result.Testers = inactive
+			}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+// BetaTestersPruneCommand returns the beta-testers prune subcommand.
+func BetaTestersPruneCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	groups := fs.String("group", "", "Beta groups to prune (names or IDs, comma-separated)")
+	since := fs.String("since", "", "Remove testers with no sessions in this window: 7d, 30d, 90d, 365d")
+	csvPath := fs.String("csv", "", "Write the selected testers to a new CSV file")
+	dryRun := fs.Bool("dry-run", false, "Report testers that would be removed without removing")
+	confirm := fs.Bool("confirm", false, "Confirm removal (required unless --dry-run)")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "prune",
+		ShortUsage: "asc testflight beta-testers prune --app \"APP_ID\" --group GROUP --since 90d [--dry-run | --confirm]",
+		ShortHelp:  "Remove inactive testers from beta groups.",
+		LongHelp: `Remove inactive testers from beta groups.
+
+Removes testers with no sessions in the --since window from each --group.
+Testers stay in the app's other groups. Nothing is removed when usage is
+reported but none of it belongs to a tester in the selected groups. Use --csv to keep a record of who was
+removed (First Name, Last Name, Email, then tester and group details).
+
+Examples:
+  asc testflight beta-testers prune --app "APP_ID" --group "External" --since 90d --dry-run
+  asc testflight beta-testers prune --app "APP_ID" --group "External" --since 90d --confirm --csv removed.csv`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintf(os.Stderr, "Error: --app is required (or set ASC_APP_ID)\n\n")
+				return flag.ErrHelp
+			}
+			groupValues := shared.SplitCSV(*groups)
+			if len(groupValues) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --group is required")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*since) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --since is required")
+				return flag.ErrHelp
+			}
+			period, err := normalizeBetaTesterUsageWindow(*since)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+				return flag.ErrHelp
+			}
+			if !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required to remove testers")
+				return flag.ErrHelp
+			}
+			csvValue := strings.TrimSpace(*csvPath)
+			if csvValue != "" {
+				if _, err := os.Lstat(csvValue); err == nil {
+					return fmt.Errorf("beta-testers prune: output file already exists: %s", csvValue)
+				}
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("beta-testers prune: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			data, err := loadBetaTesterEngagement(requestCtx, client, resolvedAppID, period, groupValues)
+			if err != nil {
+				return fmt.Errorf("beta-testers prune: %w", err)
+			}
+			// Usage that matches nobody means the tester IDs could not be
+			// joined, and every tester would look inactive.
+			if data.usageRows > 0 && data.matchedUsageRows == 0 {
+				return fmt.Errorf("beta-testers prune: none of the %d usage rows match a tester in the selected groups; refusing to prune", data.usageRows)
+			}
+
+			result := &asc.BetaTesterPruneResult{
+				AppID:     resolvedAppID,
+				Period:    period,
+				DryRun:    *dryRun,
+				Evaluated: len(data.testers),
+				CSVPath:   csvValue,
+				Testers:   data.pruneCandidates(),
+			}
+			for _, groupID := range data.groupOrder {
+				result.Groups = append(result.Groups, data.groups[groupID].Attributes.Name)
+			}
+			result.Selected = len(result.Testers)
+
+			if !*dryRun {
+				removeBetaTesterPruneEntries(requestCtx, client, result)
+			}
+
+			if csvValue != "" {
+				if err := writeBetaTesterPruneCSV(csvValue, result.Testers, *dryRun); err != nil {
+					return fmt.Errorf("beta-testers prune: %w", err)
+				}
+			}
+
+			if err := shared.PrintOutput(result, *output, *pretty); err != nil {
+				return err
+			}
+			if result.FailedCount > 0 {
+				return shared.NewReportedError(fmt.Errorf("beta-testers prune: %d testers failed to be removed", result.FailedCount))
+			}
+			return nil
+		},
+	}
+}
+
+// normalizeBetaTesterUsageWindow maps "90d" style windows onto usage metric periods.
+func normalizeBetaTesterUsageWindow(value string) (string, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value != "" && !strings.HasPrefix(value, "P") {
+		value = "P" + value
+	}
+	if _, ok := betaTesterUsagePeriods[value]; !ok {
+		return "", fmt.Errorf("--since must be one of: 7d, 30d, 90d, 365d")
+	}
+	return value, nil
+}
+
+// loadBetaTesterEngagement fetches groups, group membership, testers and usage
+// metrics. With groupFilter set, only testers in those groups are included.
+func loadBetaTesterEngagement(ctx context.Context, client *asc.Client, appID, period string, groupFilter []string) (*betaTesterEngagementData, error) {
+	data := &betaTesterEngagementData{
+		groups:     map[string]asc.Resource[asc.BetaGroupAttributes]{},
+		testers:    map[string]asc.Resource[asc.BetaTesterAttributes]{},
+		membership: map[string][]string{},
+		usage:      map[string]betaTesterUsageTotals{},
+	}
+
+	groupList, err := fetchAllBetaGroups(ctx, client, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupFilter) > 0 {
+		for _, value := range groupFilter {
+			group, err := matchBetaGroup(groupList, value)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := data.groups[group.ID]; !ok {
+				data.groups[group.ID] = group
+				data.groupOrder = append(data.groupOrder, group.ID)
+			}
+		}
+	} else {
+		for _, group := range groupList {
+			data.groups[group.ID] = group
+			data.groupOrder = append(data.groupOrder, group.ID)
+		}
+	}
+
+	for _, groupID := range data.groupOrder {
+		members, err := fetchAllBetaGroupTesters(ctx, client, groupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, tester := range members {
+			data.testers[tester.ID] = tester
+			data.membership[tester.ID] = append(data.membership[tester.ID], groupID)
+		}
+	}
+
+	// Testers invited to individual builds belong to no group.
+	if len(groupFilter) == 0 {
+		testers, err := fetchAllAppBetaTesters(ctx, client, appID)
+		if err != nil {
+			return nil, err
+		}
+		for _, tester := range testers {
+			data.testers[tester.ID] = tester
+		}
+	}
+
+	firstUsage, err := client.GetAppBetaTesterUsagesMetrics(ctx, appID,
+		asc.WithBetaTesterUsagesPeriod(period),
+		asc.WithBetaTesterUsagesGroupBy("betaTesters"),
+		asc.WithBetaTesterUsagesLimit(200),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta tester usages: %w", err)
+	}
+	usages, err := paginateBetaTesterUsages(ctx, client, appID, firstUsage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch beta tester usages: %w", err)
+	}
+	for _, raw := range usages.Data {
+		testerID, totals, err := parseBetaTesterUsageItem(raw)
+		if err != nil {
+			return nil, err
+		}
+		data.usageRows++
+		if _, ok := data.testers[testerID]; ok {
+			data.matchedUsageRows++
+		}
+		current := data.usage[testerID]
+		current.sessions += totals.sessions
+		current.crashes += totals.crashes
+		current.feedback += totals.feedback
+		data.usage[testerID] = current
+	}
+
+	return data, nil
+}
+
+func parseBetaTesterUsageItem(raw json.RawMessage) (string, betaTesterUsageTotals, error) {
+	var item betaTesterUsageItem
+	var totals betaTesterUsageTotals
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return "", totals, fmt.Errorf("parse beta tester usage: %w", err)
+	}
+	for _, point := range item.DataPoints {
+		totals.sessions += point.Values.SessionCount
+		totals.crashes += point.Values.CrashCount
+		totals.feedback += point.Values.FeedbackCount
+	}
+
+	// The tester dimension is the tester ID, or a resource identifier in
+	// some responses.
+	dimension := item.Dimensions.BetaTesters.Data
+	var testerID string
+	if err := json.Unmarshal(dimension, &testerID); err != nil {
+		var identifier asc.ResourceData
+		if err := json.Unmarshal(dimension, &identifier); err == nil {
+			testerID = identifier.ID
+		}
+	}
+	testerID = strings.TrimSpace(testerID)
+	if testerID == "" {
+		return "", totals, fmt.Errorf("parse beta tester usage: unrecognized betaTesters dimension %s", string(dimension))
+	}
+	return testerID, totals, nil
+}
+
+func (d *betaTesterEngagementData) report(appID, period, sortBy string) *asc.BetaTesterEngagementResult {
+	result := &asc.BetaTesterEngagementResult{
+		AppID:   appID,
+		Period:  period,
+		Testers: make([]asc.BetaTesterEngagement, 0, len(d.testers)),
+	}
+	for _, groupID := range d.groupOrder {
+		result.Groups = append(result.Groups, d.groups[groupID].Attributes.Name)
+	}
+	for id, tester := range d.testers {
+		usage := d.usage[id]
+		entry := asc.BetaTesterEngagement{
+			ID:         id,
+			Email:      tester.Attributes.Email,
+			FirstName:  tester.Attributes.FirstName,
+			LastName:   tester.Attributes.LastName,
+			State:      string(tester.Attributes.State),
+			InviteType: string(tester.Attributes.InviteType),
+			Sessions:   usage.sessions,
+			Crashes:    usage.crashes,
+			Feedback:   usage.feedback,
+		}
+		for _, groupID := range d.membership[id] {
+			entry.Groups = append(entry.Groups, d.groups[groupID].Attributes.Name)
+		}
+		if entry.Sessions > 0 {
+			result.Active++
+		} else {
+			result.Inactive++
+		}
+		result.Testers = append(result.Testers, entry)
+	}
+	result.Total = len(result.Testers)
+
+	metric := func(entry asc.BetaTesterEngagement) int {
+		switch sortBy {
+		case "crashes":
+			return entry.Crashes
+		case "feedback":
+			return entry.Feedback
+		default:
+			return entry.Sessions
+		}
+	}
+	sort.Slice(result.Testers, func(i, j int) bool {
+		a, b := result.Testers[i], result.Testers[j]
+		if metric(a) != metric(b) {
+			return metric(a) > metric(b)
+		}
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		if a.Feedback != b.Feedback {
+			return a.Feedback > b.Feedback
+		}
+		if a.Email != b.Email {
+			return a.Email < b.Email
+		}
+		return a.ID < b.ID
+	})
+	for i := range result.Testers {
+		result.Testers[i].Rank = i + 1
+	}
+	return result
+}
+
+// pruneCandidates returns one entry per inactive tester and group, in group
+// order then email order.
+func (d *betaTesterEngagementData) pruneCandidates() []asc.BetaTesterPruneEntry {
+	entries := make([]asc.BetaTesterPruneEntry, 0)
+	for _, groupID := range d.groupOrder {
+		var groupEntries []asc.BetaTesterPruneEntry
+		for testerID, groupIDs := range d.membership {
+			if !slices.Contains(groupIDs, groupID) || d.usage[testerID].sessions > 0 {
+				continue
+			}
+			tester := d.testers[testerID]
+			groupEntries = append(groupEntries, asc.BetaTesterPruneEntry{
+				TesterID:  testerID,
+				Email:     tester.Attributes.Email,
+				FirstName: tester.Attributes.FirstName,
+				LastName:  tester.Attributes.LastName,
+				State:     string(tester.Attributes.State),
+				GroupID:   groupID,
+				Group:     d.groups[groupID].Attributes.Name,
+			})
+		}
+		sort.Slice(groupEntries, func(i, j int) bool {
+			if groupEntries[i].Email != groupEntries[j].Email {
+				return groupEntries[i].Email < groupEntries[j].Email
+			}
+			return groupEntries[i].TesterID < groupEntries[j].TesterID
+		})
+		entries = append(entries, groupEntries...)
+	}
+	return entries
+}
+
+// removeBetaTesterPruneEntries removes selected testers from their groups in
+// batches, recording the outcome on each entry.
+func removeBetaTesterPruneEntries(ctx context.Context, client *asc.Client, result *asc.BetaTesterPruneResult) {
+	for start := 0; start < len(result.Testers); {
+		groupID := result.Testers[start].GroupID
+		end := start
+		for end < len(result.Testers) && end-start < betaTesterBatchSize && result.Testers[end].GroupID == groupID {
+			end++
+		}
+		batch := result.Testers[start:end]
+		testerIDs := make([]string, 0, len(batch))
+		for _, entry := range batch {
+			testerIDs = append(testerIDs, entry.TesterID)
+		}
+		err := client.RemoveBetaTestersFromGroup(ctx, groupID, testerIDs)
+		for i := range batch {
+			if err != nil {
+				batch[i].Error = err.Error()
+				result.FailedCount++
+				continue
+			}
+			batch[i].Removed = true
+			result.RemovedCount++
+		}
+		start = end
+	}
+}
+
+func writeBetaTesterPruneCSV(path string, entries []asc.BetaTesterPruneEntry, dryRun bool) error {
+	records := [][]string{append(append([]string(nil), betaTesterCSVHeader...), "Tester ID", "Group", "Group ID", "Sessions", "Status")}
+	for _, entry := range entries {
+		status := "removed"
+		switch {
+		case dryRun:
+			status = "would-remove"
+		case entry.Error != "":
+			status = "failed"
+		}
+		records = append(records, []string{
+			entry.FirstName,
+			entry.LastName,
+			entry.Email,
+			entry.TesterID,
+			entry.Group,
+			entry.GroupID,
+			strconv.Itoa(entry.Sessions),
+			status,
+		})
+	}
+	return writeCSVFile(path, records)
+}
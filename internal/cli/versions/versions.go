@@ -34,6 +34,8 @@ func VersionsCommand() *ffcli.Command {
 			VersionsReleaseCommand(),
 			PhasedReleaseCommand(),
 			VersionsPromotionsCommand(),
+			VersionsDiffCommand(),
+			VersionsSnapshotCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
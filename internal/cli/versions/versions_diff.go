@@ -0,0 +1,450 @@
+package versions
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	versionDiffSectionVersion              = "version"
+	versionDiffSectionLocalizations        = "localizations"
+	versionDiffSectionAppInfoLocalizations = "app-info-localizations"
+	versionDiffSectionCategories           = "categories"
+	versionDiffSectionAgeRating            = "age-rating"
+	versionDiffSectionScreenshots          = "screenshots"
+	versionDiffSectionPricing              = "pricing"
+
+	versionDiffAdded     = "added"
+	versionDiffRemoved   = "removed"
+	versionDiffChanged   = "changed"
+	versionDiffReordered = "reordered"
+)
+
+var versionDiffSections = []string{
+	versionDiffSectionVersion,
+	versionDiffSectionLocalizations,
+	versionDiffSectionAppInfoLocalizations,
+	versionDiffSectionCategories,
+	versionDiffSectionAgeRating,
+	versionDiffSectionScreenshots,
+	versionDiffSectionPricing,
+}
+
+var versionStringPattern = regexp.MustCompile(`^\d+(\.\d+){0,3}$`)
+
+// VersionsDiffCommand returns the versions diff subcommand.
+func VersionsDiffCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("versions diff", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
+	from := fs.String("from", "", "Base version: version string, version ID, or snapshot file (required)")
+	to := fs.String("to", "", "Target version: version string, version ID, or snapshot file (required)")
+	platform := fs.String("platform", "IOS", "Platform: IOS, MAC_OS, TV_OS, VISION_OS")
+	sections := fs.String("sections", "", "Sections to compare (comma-separated): "+strings.Join(versionDiffSections, ", "))
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "diff",
+		ShortUsage: "asc versions diff --from <version> --to <version> [flags]",
+		ShortHelp:  "Compare the App Store state of two versions.",
+		LongHelp: `Compare the App Store state of two versions.
+
+Compares version localizations, app info localizations, categories, the age
+rating declaration, screenshots (by checksum and display order) and pricing.
+--from and --to accept a version string (resolved with --app and --platform),
+a version ID, or a snapshot file written by "asc versions snapshot".
+
+Categories, app info localizations and pricing belong to the app. For live
+versions they are read from the app info matching each version's state; use
+snapshots to compare against their historical values.
+
+Examples:
+  asc versions diff --app "123456789" --from "4.2" --to "4.3"
+  asc versions diff --app "123456789" --from "VERSION_ID_A" --to "VERSION_ID_B" --output table
+  asc versions diff --app "123456789" --from "./snapshots/4.2.json" --to "4.3" --output markdown
+  asc versions diff --app "123456789" --from "4.2" --to "4.3" --sections localizations,screenshots`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fromValue := strings.TrimSpace(*from)
+			toValue := strings.TrimSpace(*to)
+			if fromValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --from is required")
+				return flag.ErrHelp
+			}
+			if toValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --to is required")
+				return flag.ErrHelp
+			}
+			selected, err := normalizeVersionDiffSections(*sections)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return flag.ErrHelp
+			}
+			normalizedPlatform, err := shared.NormalizeAppStoreVersionPlatform(*platform)
+			if err != nil {
+				return fmt.Errorf("versions diff: %w", err)
+			}
+
+			resolvedAppID := shared.ResolveAppID(*appID)
+			fromIsFile := isSnapshotFile(fromValue)
+			toIsFile := isSnapshotFile(toValue)
+			if resolvedAppID == "" && (!fromIsFile || !toIsFile) {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+
+			var client *asc.Client
+			if !fromIsFile || !toIsFile {
+				client, err = shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("versions diff: %w", err)
+				}
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			fromSnapshot, fromSource, err := loadVersionDiffSide(requestCtx, client, resolvedAppID, normalizedPlatform, fromValue, fromIsFile)
+			if err != nil {
+				return fmt.Errorf("versions diff: --from: %w", err)
+			}
+			toSnapshot, toSource, err := loadVersionDiffSide(requestCtx, client, resolvedAppID, normalizedPlatform, toValue, toIsFile)
+			if err != nil {
+				return fmt.Errorf("versions diff: --to: %w", err)
+			}
+
+			changes := diffVersionSnapshots(fromSnapshot, toSnapshot, selected)
+			result := &asc.VersionDiffResult{
+				From:      versionDiffSide(fromSnapshot, fromSource),
+				To:        versionDiffSide(toSnapshot, toSource),
+				Sections:  selected,
+				Changes:   changes,
+				Identical: len(changes) == 0,
+			}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+func normalizeVersionDiffSections(value string) ([]string, error) {
+	requested := shared.SplitCSV(value)
+	if len(requested) == 0 {
+		return slices.Clone(versionDiffSections), nil
+	}
+	selected := make([]string, 0, len(requested))
+	for _, section := range versionDiffSections {
+		if slices.Contains(requested, section) {
+			selected = append(selected, section)
+		}
+	}
+	for _, section := range requested {
+		if !slices.Contains(versionDiffSections, section) {
+			return nil, fmt.Errorf("--sections must be one of: %s", strings.Join(versionDiffSections, ", "))
+		}
+	}
+	return selected, nil
+}
+
+func isSnapshotFile(value string) bool {
+	info, err := os.Stat(value)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// loadVersionDiffSide returns the snapshot for one side of a diff, reading it
+// from disk or capturing it live.
+func loadVersionDiffSide(ctx context.Context, client *asc.Client, appID, platform, value string, isFile bool) (*asc.VersionSnapshot, string, error) {
+	if isFile {
+		snapshot, err := readVersionSnapshot(value)
+		if err != nil {
+			return nil, "", err
+		}
+		return snapshot, value, nil
+	}
+
+	versionID := value
+	if versionStringPattern.MatchString(value) {
+		resolved, err := shared.ResolveAppStoreVersionID(ctx, client, appID, value, platform)
+		if err != nil {
+			return nil, "", err
+		}
+		versionID = resolved
+	}
+	snapshot, err := captureVersionSnapshot(ctx, client, appID, versionID)
+	if err != nil {
+		return nil, "", err
+	}
+	return snapshot, "live", nil
+}
+
+func versionDiffSide(snapshot *asc.VersionSnapshot, source string) asc.VersionDiffSide {
+	side := asc.VersionDiffSide{
+		Source:        source,
+		VersionID:     snapshot.VersionID,
+		VersionString: snapshot.VersionString,
+		Platform:      snapshot.Platform,
+	}
+	if source != "live" {
+		side.CapturedAt = snapshot.CapturedAt
+	}
+	return side
+}
+
+// diffVersionSnapshots returns every difference between two snapshots for the
+// selected sections, in section order.
+func diffVersionSnapshots(from, to *asc.VersionSnapshot, sections []string) []asc.VersionDiffChange {
+	changes := []asc.VersionDiffChange{}
+	for _, section := range sections {
+		switch section {
+		case versionDiffSectionVersion:
+			changes = append(changes, diffFieldValues(section, "", from.Version, to.Version)...)
+		case versionDiffSectionLocalizations:
+			changes = append(changes, diffLocaleValues(section, from.Localizations, to.Localizations)...)
+		case versionDiffSectionAppInfoLocalizations:
+			changes = append(changes, diffLocaleValues(section, from.AppInfoLocalizations, to.AppInfoLocalizations)...)
+		case versionDiffSectionCategories:
+			changes = append(changes, diffFieldValues(section, "", from.Categories, to.Categories)...)
+		case versionDiffSectionAgeRating:
+			changes = append(changes, diffFieldValues(section, "", from.AgeRating, to.AgeRating)...)
+		case versionDiffSectionScreenshots:
+			changes = append(changes, diffScreenshots(from.Screenshots, to.Screenshots)...)
+		case versionDiffSectionPricing:
+			changes = append(changes, diffPricing(from.Pricing, to.Pricing)...)
+		}
+	}
+	return changes
+}
+
+func diffLocaleValues(section string, from, to map[string]map[string]string) []asc.VersionDiffChange {
+	changes := []asc.VersionDiffChange{}
+	for _, locale := range unionKeys(from, to) {
+		changes = append(changes, diffFieldValues(section, locale, from[locale], to[locale])...)
+	}
+	return changes
+}
+
+func diffFieldValues(section, locale string, from, to map[string]string) []asc.VersionDiffChange {
+	changes := []asc.VersionDiffChange{}
+	for _, field := range unionKeys(from, to) {
+		fromValue, toValue := from[field], to[field]
+		if fromValue == toValue {
+			continue
+		}
+		changes = append(changes, asc.VersionDiffChange{
+			Section: section,
+			Locale:  locale,
+			Field:   field,
+			Action:  diffAction(fromValue != "", toValue != ""),
+			From:    fromValue,
+			To:      toValue,
+		})
+	}
+	return changes
+}
+
+func diffScreenshots(from, to map[string]map[string][]asc.VersionSnapshotScreenshot) []asc.VersionDiffChange {
+	changes := []asc.VersionDiffChange{}
+	for _, locale := range unionKeys(from, to) {
+		fromSets, toSets := from[locale], to[locale]
+		for _, displayType := range unionKeys(fromSets, toSets) {
+			fromShots, fromOK := fromSets[displayType]
+			toShots, toOK := toSets[displayType]
+			if !fromOK || !toOK {
+				change := asc.VersionDiffChange{
+					Section: versionDiffSectionScreenshots,
+					Locale:  locale,
+					Field:   displayType,
+					Action:  diffAction(fromOK, toOK),
+				}
+				if fromOK {
+					change.From = screenshotNames(fromShots)
+				}
+				if toOK {
+					change.To = screenshotNames(toShots)
+				}
+				changes = append(changes, change)
+				continue
+			}
+			changes = append(changes, diffScreenshotSet(locale, displayType, fromShots, toShots)...)
+		}
+	}
+	return changes
+}
+
+func diffScreenshotSet(locale, displayType string, from, to []asc.VersionSnapshotScreenshot) []asc.VersionDiffChange {
+	fromKeys := screenshotKeys(from)
+	toKeys := screenshotKeys(to)
+	if slices.Equal(fromKeys, toKeys) {
+		return nil
+	}
+	sortedFrom, sortedTo := slices.Clone(fromKeys), slices.Clone(toKeys)
+	sort.Strings(sortedFrom)
+	sort.Strings(sortedTo)
+	if slices.Equal(sortedFrom, sortedTo) {
+		return []asc.VersionDiffChange{{
+			Section: versionDiffSectionScreenshots,
+			Locale:  locale,
+			Field:   displayType,
+			Action:  versionDiffReordered,
+			From:    screenshotNames(from),
+			To:      screenshotNames(to),
+		}}
+	}
+
+	changes := []asc.VersionDiffChange{}
+	for i := 0; i < max(len(from), len(to)); i++ {
+		var fromShot, toShot *asc.VersionSnapshotScreenshot
+		if i < len(from) {
+			fromShot = &from[i]
+		}
+		if i < len(to) {
+			toShot = &to[i]
+		}
+		if fromShot != nil && toShot != nil && fromKeys[i] == toKeys[i] {
+			continue
+		}
+		change := asc.VersionDiffChange{
+			Section: versionDiffSectionScreenshots,
+			Locale:  locale,
+			Field:   fmt.Sprintf("%s #%d", displayType, i+1),
+			Action:  diffAction(fromShot != nil, toShot != nil),
+		}
+		if fromShot != nil {
+			change.From = screenshotLabel(*fromShot)
+		}
+		if toShot != nil {
+			change.To = screenshotLabel(*toShot)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// screenshotKeys identifies screenshots by checksum, falling back to name and
+// size when App Store Connect did not report a checksum.
+func screenshotKeys(items []asc.VersionSnapshotScreenshot) []string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Checksum != "" {
+			keys = append(keys, item.Checksum)
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("%s:%d", item.FileName, item.FileSize))
+	}
+	return keys
+}
+
+func screenshotNames(items []asc.VersionSnapshotScreenshot) string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.FileName)
+	}
+	return strings.Join(names, ", ")
+}
+
+func screenshotLabel(item asc.VersionSnapshotScreenshot) string {
+	if item.Checksum == "" {
+		return item.FileName
+	}
+	return fmt.Sprintf("%s (%s)", item.FileName, item.Checksum)
+}
+
+func diffPricing(from, to *asc.VersionSnapshotPricing) []asc.VersionDiffChange {
+	if from == nil {
+		from = &asc.VersionSnapshotPricing{}
+	}
+	if to == nil {
+		to = &asc.VersionSnapshotPricing{}
+	}
+	changes := diffFieldValues(versionDiffSectionPricing, "",
+		map[string]string{"baseTerritory": from.BaseTerritory},
+		map[string]string{"baseTerritory": to.BaseTerritory},
+	)
+	fromPrices, toPrices := map[string]string{}, map[string]string{}
+	for _, price := range from.ManualPrices {
+		fromPrices[priceKey(price)] = priceLabel(price)
+	}
+	for _, price := range to.ManualPrices {
+		toPrices[priceKey(price)] = priceLabel(price)
+	}
+	for _, key := range unionKeys(fromPrices, toPrices) {
+		fromValue, toValue := fromPrices[key], toPrices[key]
+		if fromValue == toValue {
+			continue
+		}
+		changes = append(changes, asc.VersionDiffChange{
+			Section: versionDiffSectionPricing,
+			Field:   "manualPrice " + key,
+			Action:  diffAction(fromValue != "", toValue != ""),
+			From:    fromValue,
+			To:      toValue,
+		})
+	}
+	return changes
+}
+
+// priceKey identifies a manual price by territory and start date. Price IDs
+// change whenever a schedule is replaced, so they are only used when the
+// territory is unknown.
+func priceKey(price asc.VersionSnapshotPrice) string {
+	if price.Territory == "" {
+		return price.ID
+	}
+	start := price.StartDate
+	if start == "" {
+		start = "(always)"
+	}
+	return price.Territory + " " + start
+}
+
+func priceLabel(price asc.VersionSnapshotPrice) string {
+	start, end := price.StartDate, price.EndDate
+	if start == "" {
+		start = "(always)"
+	}
+	if end == "" {
+		end = "(open)"
+	}
+	label := start + " - " + end
+	if price.CustomerPrice == "" {
+		return label
+	}
+	return strings.TrimSpace(price.CustomerPrice+" "+price.Currency) + ", " + label
+}
+
+func diffAction(hasFrom, hasTo bool) string {
+	switch {
+	case !hasFrom:
+		return versionDiffAdded
+	case !hasTo:
+		return versionDiffRemoved
+	default:
+		return versionDiffChanged
+	}
+}
+
+func unionKeys[V any](from, to map[string]V) []string {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
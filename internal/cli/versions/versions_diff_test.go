@@ -0,0 +1,133 @@
+package versions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestDiffVersionSnapshots(t *testing.T) {
+	from := &asc.VersionSnapshot{
+		Localizations: map[string]map[string]string{
+			"en-US": {"description": "Hello", "keywords": "one,two"},
+			"de-DE": {"description": "Hallo"},
+		},
+		Categories: map[string]string{"primaryCategory": "GAMES", "primarySubcategoryOne": "GAMES_PUZZLE"},
+		Screenshots: map[string]map[string][]asc.VersionSnapshotScreenshot{
+			"en-US": {
+				"APP_IPHONE_65": {
+					{FileName: "1.png", Checksum: "c1"},
+					{FileName: "2.png", Checksum: "c2"},
+				},
+				"APP_IPAD_PRO_129": {{FileName: "ipad.png", Checksum: "i1"}},
+			},
+		},
+		Pricing: &asc.VersionSnapshotPricing{
+			BaseTerritory: "USA",
+			ManualPrices: []asc.VersionSnapshotPrice{
+				{ID: "price-1", StartDate: "2025-01-01"},
+				{ID: "price-usa-1", Territory: "USA", CustomerPrice: "0.99", Currency: "USD"},
+			},
+		},
+	}
+	to := &asc.VersionSnapshot{
+		Localizations: map[string]map[string]string{
+			"en-US": {"description": "Hello", "keywords": "one,three"},
+			"fr-FR": {"description": "Bonjour"},
+		},
+		Categories: map[string]string{"primaryCategory": "GAMES", "primarySubcategoryOne": "GAMES_BOARD", "secondaryCategory": "PUZZLE"},
+		Screenshots: map[string]map[string][]asc.VersionSnapshotScreenshot{
+			"en-US": {
+				"APP_IPHONE_65": {
+					{FileName: "1.png", Checksum: "c1"},
+					{FileName: "2-new.png", Checksum: "c3"},
+					{FileName: "3.png", Checksum: "c4"},
+				},
+			},
+		},
+		Pricing: &asc.VersionSnapshotPricing{
+			BaseTerritory: "USA",
+			ManualPrices: []asc.VersionSnapshotPrice{
+				{ID: "price-2", StartDate: "2026-01-01"},
+				{ID: "price-usa-2", Territory: "USA", CustomerPrice: "1.99", Currency: "USD"},
+			},
+		},
+	}
+
+	got := diffVersionSnapshots(from, to, versionDiffSections)
+	want := []asc.VersionDiffChange{
+		{Section: "localizations", Locale: "de-DE", Field: "description", Action: "removed", From: "Hallo"},
+		{Section: "localizations", Locale: "en-US", Field: "keywords", Action: "changed", From: "one,two", To: "one,three"},
+		{Section: "localizations", Locale: "fr-FR", Field: "description", Action: "added", To: "Bonjour"},
+		{Section: "categories", Field: "primarySubcategoryOne", Action: "changed", From: "GAMES_PUZZLE", To: "GAMES_BOARD"},
+		{Section: "categories", Field: "secondaryCategory", Action: "added", To: "PUZZLE"},
+		{Section: "screenshots", Locale: "en-US", Field: "APP_IPAD_PRO_129", Action: "removed", From: "ipad.png"},
+		{Section: "screenshots", Locale: "en-US", Field: "APP_IPHONE_65 #2", Action: "changed", From: "2.png (c2)", To: "2-new.png (c3)"},
+		{Section: "screenshots", Locale: "en-US", Field: "APP_IPHONE_65 #3", Action: "added", To: "3.png (c4)"},
+		{Section: "pricing", Field: "manualPrice USA (always)", Action: "changed", From: "0.99 USD, (always) - (open)", To: "1.99 USD, (always) - (open)"},
+		{Section: "pricing", Field: "manualPrice price-1", Action: "removed", From: "2025-01-01 - (open)"},
+		{Section: "pricing", Field: "manualPrice price-2", Action: "added", To: "2026-01-01 - (open)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected changes:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestDiffVersionSnapshots_SectionFilter(t *testing.T) {
+	from := &asc.VersionSnapshot{
+		Version:   map[string]string{"copyright": "2025"},
+		AgeRating: map[string]string{"gambling": "false"},
+	}
+	to := &asc.VersionSnapshot{
+		Version:   map[string]string{"copyright": "2026"},
+		AgeRating: map[string]string{"gambling": "true"},
+	}
+
+	got := diffVersionSnapshots(from, to, []string{versionDiffSectionAgeRating})
+	if len(got) != 1 || got[0].Section != versionDiffSectionAgeRating {
+		t.Fatalf("expected only age rating changes, got %+v", got)
+	}
+}
+
+func TestNormalizeVersionDiffSections(t *testing.T) {
+	got, err := normalizeVersionDiffSections("screenshots,localizations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"localizations", "screenshots"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, err := normalizeVersionDiffSections("builds"); err == nil {
+		t.Fatal("expected error for unknown section")
+	}
+}
+
+func TestSelectSnapshotAppInfoID(t *testing.T) {
+	infos := &asc.AppInfosResponse{
+		Data: []asc.Resource[asc.AppInfoAttributes]{
+			{ID: "live", Attributes: asc.AppInfoAttributes{"state": "READY_FOR_DISTRIBUTION"}},
+			{ID: "edit", Attributes: asc.AppInfoAttributes{"state": "PREPARE_FOR_SUBMISSION"}},
+		},
+	}
+	if got := selectSnapshotAppInfoID(infos, "READY_FOR_SALE"); got != "live" {
+		t.Fatalf("expected live app info for released version, got %q", got)
+	}
+	if got := selectSnapshotAppInfoID(infos, "PREPARE_FOR_SUBMISSION"); got != "edit" {
+		t.Fatalf("expected editable app info for in-flight version, got %q", got)
+	}
+}
+
+func TestSnapshotPriceIncludes(t *testing.T) {
+	included := []byte(`[
+		{"type":"appPricePoints","id":"pp-1","attributes":{"customerPrice":"0.99","proceeds":"0.7"}},
+		{"type":"territories","id":"USA","attributes":{"currency":"USD"}}
+	]`)
+	pricePoints, currencies, err := snapshotPriceIncludes(included)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pricePoints["pp-1"] != "0.99" || currencies["USA"] != "USD" {
+		t.Fatalf("unexpected includes %v %v", pricePoints, currencies)
+	}
+}
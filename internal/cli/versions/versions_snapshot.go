@@ -0,0 +1,507 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// VersionsSnapshotCommand returns the versions snapshot subcommand.
+func VersionsSnapshotCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("versions snapshot", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
+	version := fs.String("version", "", "App Store version string")
+	versionID := fs.String("version-id", "", "App Store version ID")
+	platform := fs.String("platform", "IOS", "Platform: IOS, MAC_OS, TV_OS, VISION_OS")
+	path := fs.String("path", "", "Snapshot file to write (required)")
+	overwrite := fs.Bool("overwrite", false, "Replace an existing snapshot file")
+	output := fs.String("output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown")
+	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
+
+	return &ffcli.Command{
+		Name:       "snapshot",
+		ShortUsage: "asc versions snapshot [flags]",
+		ShortHelp:  "Save the full App Store state of a version to a local file.",
+		LongHelp: `Save the full App Store state of a version to a local file.
+
+A snapshot records version and app info localizations, categories, the age
+rating declaration, screenshots (display order and checksums) and the app
+price schedule. Pass the snapshot file to "asc versions diff" to compare
+against a version even after App Store Connect no longer serves its data.
+
+Categories, app info localizations and pricing belong to the app, so they
+reflect the state at the time the snapshot was taken.
+
+Examples:
+  asc versions snapshot --app "123456789" --version "4.2" --path "./snapshots/4.2.json"
+  asc versions snapshot --version-id "VERSION_ID" --app "123456789" --path "./snapshots/4.2.json" --overwrite`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			trimmedPath := strings.TrimSpace(*path)
+			if trimmedPath == "" {
+				fmt.Fprintln(os.Stderr, "Error: --path is required")
+				return flag.ErrHelp
+			}
+			trimmedVersion := strings.TrimSpace(*version)
+			trimmedVersionID := strings.TrimSpace(*versionID)
+			if trimmedVersion == "" && trimmedVersionID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --version or --version-id is required")
+				return flag.ErrHelp
+			}
+			if trimmedVersion != "" && trimmedVersionID != "" {
+				fmt.Fprintln(os.Stderr, "Error: --version and --version-id are mutually exclusive")
+				return flag.ErrHelp
+			}
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			normalizedPlatform, err := shared.NormalizeAppStoreVersionPlatform(*platform)
+			if err != nil {
+				return fmt.Errorf("versions snapshot: %w", err)
+			}
+			if !*overwrite {
+				if _, err := os.Lstat(trimmedPath); err == nil {
+					return fmt.Errorf("versions snapshot: %s already exists (use --overwrite)", trimmedPath)
+				}
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("versions snapshot: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			if trimmedVersionID == "" {
+				trimmedVersionID, err = shared.ResolveAppStoreVersionID(requestCtx, client, resolvedAppID, trimmedVersion, normalizedPlatform)
+				if err != nil {
+					return fmt.Errorf("versions snapshot: %w", err)
+				}
+			}
+
+			snapshot, err := captureVersionSnapshot(requestCtx, client, resolvedAppID, trimmedVersionID)
+			if err != nil {
+				return fmt.Errorf("versions snapshot: %w", err)
+			}
+			if err := writeVersionSnapshot(trimmedPath, snapshot); err != nil {
+				return fmt.Errorf("versions snapshot: %w", err)
+			}
+
+			screenshotSets := 0
+			for _, sets := range snapshot.Screenshots {
+				screenshotSets += len(sets)
+			}
+			result := &asc.VersionSnapshotResult{
+				Path:                 trimmedPath,
+				AppID:                snapshot.AppID,
+				VersionID:            snapshot.VersionID,
+				VersionString:        snapshot.VersionString,
+				Platform:             snapshot.Platform,
+				CapturedAt:           snapshot.CapturedAt,
+				Localizations:        len(snapshot.Localizations),
+				AppInfoLocalizations: len(snapshot.AppInfoLocalizations),
+				ScreenshotSets:       screenshotSets,
+			}
+			return shared.PrintOutput(result, *output, *pretty)
+		},
+	}
+}
+
+// captureVersionSnapshot fetches everything a version diff compares.
+func captureVersionSnapshot(ctx context.Context, client *asc.Client, appID, versionID string) (*asc.VersionSnapshot, error) {
+	versionResp, err := client.GetAppStoreVersion(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	attrs := versionResp.Data.Attributes
+	snapshot := &asc.VersionSnapshot{
+		SchemaVersion: asc.VersionSnapshotSchemaVersion,
+		CapturedAt:    time.Now().UTC().Format(time.RFC3339),
+		AppID:         appID,
+		VersionID:     versionResp.Data.ID,
+		VersionString: attrs.VersionString,
+		Platform:      string(attrs.Platform),
+		State:         shared.ResolveAppStoreVersionState(attrs),
+		Version:       map[string]string{},
+	}
+	if attrs.Copyright != "" {
+		snapshot.Version["copyright"] = attrs.Copyright
+	}
+
+	localizations, err := fetchSnapshotVersionLocalizations(ctx, client, versionID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Localizations = shared.VersionLocalizationValues(localizations)
+	snapshot.Screenshots = map[string]map[string][]asc.VersionSnapshotScreenshot{}
+	for _, localization := range localizations {
+		sets, err := fetchSnapshotScreenshots(ctx, client, localization.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(sets) > 0 {
+			snapshot.Screenshots[localization.Attributes.Locale] = sets
+		}
+	}
+
+	ageRating, err := client.GetAgeRatingDeclarationForAppStoreVersion(ctx, versionID)
+	if err != nil && !asc.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to fetch age rating declaration: %w", err)
+	}
+	if ageRating != nil {
+		snapshot.AgeRating, err = ageRatingValues(ageRating.Data.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	appInfos, err := client.GetAppInfos(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app infos: %w", err)
+	}
+	if appInfoID := selectSnapshotAppInfoID(appInfos, snapshot.State); appInfoID != "" {
+		snapshot.AppInfoID = appInfoID
+		if err := captureAppInfoState(ctx, client, appInfoID, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	pricing, err := fetchSnapshotPricing(ctx, client, appID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Pricing = pricing
+
+	return snapshot, nil
+}
+
+func captureAppInfoState(ctx context.Context, client *asc.Client, appInfoID string, snapshot *asc.VersionSnapshot) error {
+	opts := []asc.AppInfoLocalizationsOption{asc.WithAppInfoLocalizationsLimit(200)}
+	firstPage, err := client.GetAppInfoLocalizations(ctx, appInfoID, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to fetch app info localizations: %w", err)
+	}
+	resp, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppInfoLocalizations(ctx, appInfoID, asc.WithAppInfoLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return err
+	}
+	aggregated, ok := resp.(*asc.AppInfoLocalizationsResponse)
+	if !ok {
+		return fmt.Errorf("unexpected pagination response type")
+	}
+	snapshot.AppInfoLocalizations = shared.AppInfoLocalizationValues(aggregated.Data)
+
+	snapshot.Categories = map[string]string{}
+	categories := []struct {
+		field string
+		fetch func(context.Context, string) (*asc.AppCategoryResponse, error)
+	}{
+		{"primaryCategory", client.GetAppInfoPrimaryCategory},
+		{"primarySubcategoryOne", client.GetAppInfoPrimarySubcategoryOne},
+		{"primarySubcategoryTwo", client.GetAppInfoPrimarySubcategoryTwo},
+		{"secondaryCategory", client.GetAppInfoSecondaryCategory},
+		{"secondarySubcategoryOne", client.GetAppInfoSecondarySubcategoryOne},
+		{"secondarySubcategoryTwo", client.GetAppInfoSecondarySubcategoryTwo},
+	}
+	for _, category := range categories {
+		resp, err := category.fetch(ctx, appInfoID)
+		if err != nil && !asc.IsNotFound(err) {
+			return fmt.Errorf("failed to fetch %s: %w", category.field, err)
+		}
+		if resp != nil && resp.Data.ID != "" {
+			snapshot.Categories[category.field] = resp.Data.ID
+		}
+	}
+	return nil
+}
+
+func fetchSnapshotVersionLocalizations(ctx context.Context, client *asc.Client, versionID string) ([]asc.Resource[asc.AppStoreVersionLocalizationAttributes], error) {
+	firstPage, err := client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch version localizations: %w", err)
+	}
+	resp, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregated, ok := resp.(*asc.AppStoreVersionLocalizationsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+	return aggregated.Data, nil
+}
+
+// fetchSnapshotScreenshots returns display type -> screenshots in display order.
+func fetchSnapshotScreenshots(ctx context.Context, client *asc.Client, localizationID string) (map[string][]asc.VersionSnapshotScreenshot, error) {
+	sets, err := client.GetAppScreenshotSets(ctx, localizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch screenshot sets: %w", err)
+	}
+	result := map[string][]asc.VersionSnapshotScreenshot{}
+	for _, set := range sets.Data {
+		screenshots, err := client.GetAppScreenshots(ctx, set.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch screenshots: %w", err)
+		}
+		items := make([]asc.VersionSnapshotScreenshot, 0, len(screenshots.Data))
+		for _, screenshot := range screenshots.Data {
+			item := asc.VersionSnapshotScreenshot{
+				FileName: screenshot.Attributes.FileName,
+				Checksum: screenshot.Attributes.SourceFileChecksum,
+				FileSize: screenshot.Attributes.FileSize,
+			}
+			if screenshot.Attributes.ImageAsset != nil {
+				item.Width = screenshot.Attributes.ImageAsset.Width
+				item.Height = screenshot.Attributes.ImageAsset.Height
+			}
+			items = append(items, item)
+		}
+		result[set.Attributes.ScreenshotDisplayType] = items
+	}
+	return result, nil
+}
+
+func fetchSnapshotPricing(ctx context.Context, client *asc.Client, appID string) (*asc.VersionSnapshotPricing, error) {
+	schedule, err := client.GetAppPriceSchedule(ctx, appID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch price schedule: %w", err)
+	}
+	pricing := &asc.VersionSnapshotPricing{}
+	territory, err := client.GetAppPriceScheduleBaseTerritory(ctx, schedule.Data.ID)
+	if err != nil && !asc.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to fetch base territory: %w", err)
+	}
+	if territory != nil {
+		pricing.BaseTerritory = territory.Data.ID
+	}
+	prices, err := fetchSnapshotManualPrices(ctx, client, schedule.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+	pricing.ManualPrices = prices
+	return pricing, nil
+}
+
+// fetchSnapshotManualPrices lists the manual prices of a schedule with their
+// territory and customer price resolved from the included price points.
+func fetchSnapshotManualPrices(ctx context.Context, client *asc.Client, scheduleID string) ([]asc.VersionSnapshotPrice, error) {
+	var prices []asc.VersionSnapshotPrice
+	next := ""
+	for {
+		opts := []asc.AppPricesOption{
+			asc.WithAppPricesInclude([]string{"appPricePoint", "territory"}),
+			asc.WithAppPricesLimit(200),
+		}
+		if next != "" {
+			opts = []asc.AppPricesOption{asc.WithAppPricesNextURL(next)}
+		}
+		resp, err := client.GetAppPriceScheduleManualPrices(ctx, scheduleID, opts...)
+		if err != nil {
+			if asc.IsNotFound(err) {
+				break
+			}
+			return nil, fmt.Errorf("failed to fetch manual prices: %w", err)
+		}
+		pricePoints, currencies, err := snapshotPriceIncludes(resp.Included)
+		if err != nil {
+			return nil, err
+		}
+		for _, price := range resp.Data {
+			var relationships struct {
+				AppPricePoint struct {
+					Data *asc.ResourceData `json:"data"`
+				} `json:"appPricePoint"`
+				Territory struct {
+					Data *asc.ResourceData `json:"data"`
+				} `json:"territory"`
+			}
+			if len(price.Relationships) > 0 {
+				if err := json.Unmarshal(price.Relationships, &relationships); err != nil {
+					return nil, fmt.Errorf("failed to parse manual price %s: %w", price.ID, err)
+				}
+			}
+			item := asc.VersionSnapshotPrice{
+				ID:        price.ID,
+				StartDate: price.Attributes.StartDate,
+				EndDate:   price.Attributes.EndDate,
+				Manual:    price.Attributes.Manual,
+			}
+			if relationships.Territory.Data != nil {
+				item.Territory = relationships.Territory.Data.ID
+				item.Currency = currencies[item.Territory]
+			}
+			if relationships.AppPricePoint.Data != nil {
+				item.CustomerPrice = pricePoints[relationships.AppPricePoint.Data.ID]
+			}
+			prices = append(prices, item)
+		}
+		if resp.Links.Next == "" {
+			break
+		}
+		next = resp.Links.Next
+	}
+	sort.SliceStable(prices, func(i, j int) bool {
+		if prices[i].Territory != prices[j].Territory {
+			return prices[i].Territory < prices[j].Territory
+		}
+		return prices[i].StartDate < prices[j].StartDate
+	})
+	return prices, nil
+}
+
+// snapshotPriceIncludes returns price point ID -> customer price and
+// territory ID -> currency from an included array.
+func snapshotPriceIncludes(included json.RawMessage) (map[string]string, map[string]string, error) {
+	pricePoints, currencies := map[string]string{}, map[string]string{}
+	if len(included) == 0 {
+		return pricePoints, currencies, nil
+	}
+	var resources []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			CustomerPrice string `json:"customerPrice"`
+			Currency      string `json:"currency"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(included, &resources); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manual price includes: %w", err)
+	}
+	for _, resource := range resources {
+		switch resource.Type {
+		case string(asc.ResourceTypeAppPricePoints):
+			pricePoints[resource.ID] = resource.Attributes.CustomerPrice
+		case string(asc.ResourceTypeTerritories):
+			currencies[resource.ID] = resource.Attributes.Currency
+		}
+	}
+	return pricePoints, currencies, nil
+}
+
+// ageRatingValues flattens an age rating declaration into field -> value.
+func ageRatingValues(attrs asc.AgeRatingDeclarationAttributes) (map[string]string, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		values[key] = fmt.Sprint(value)
+	}
+	return values, nil
+}
+
+// selectSnapshotAppInfoID picks the app info that matches a version: the
+// editable app info for versions still in flight, the live one otherwise.
+func selectSnapshotAppInfoID(appInfos *asc.AppInfosResponse, versionState string) string {
+	if appInfos == nil || len(appInfos.Data) == 0 {
+		return ""
+	}
+	if len(appInfos.Data) == 1 {
+		return appInfos.Data[0].ID
+	}
+	wantLive := isReleasedVersionState(versionState)
+	for _, info := range appInfos.Data {
+		state := appInfoState(info.Attributes)
+		if isReleasedVersionState(state) == wantLive {
+			return info.ID
+		}
+	}
+	return appInfos.Data[0].ID
+}
+
+func appInfoState(attrs asc.AppInfoAttributes) string {
+	for _, key := range []string{"state", "appStoreState"} {
+		if value, ok := attrs[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.ToUpper(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
+
+func isReleasedVersionState(state string) bool {
+	switch strings.ToUpper(strings.TrimSpace(state)) {
+	case "READY_FOR_SALE", "READY_FOR_DISTRIBUTION", "REPLACED_WITH_NEW_VERSION",
+		"REMOVED_FROM_SALE", "DEVELOPER_REMOVED_FROM_SALE", "PROCESSING_FOR_DISTRIBUTION":
+		return true
+	default:
+		return false
+	}
+}
+
+// readVersionSnapshot loads a snapshot written by "asc versions snapshot".
+func readVersionSnapshot(path string) (*asc.VersionSnapshot, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snapshot asc.VersionSnapshot
+	if err := json.NewDecoder(file).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if snapshot.SchemaVersion == 0 || snapshot.SchemaVersion > asc.VersionSnapshotSchemaVersion {
+		return nil, fmt.Errorf("unsupported snapshot schema version %d in %s", snapshot.SchemaVersion, path)
+	}
+	return &snapshot, nil
+}
+
+func writeVersionSnapshot(path string, snapshot *asc.VersionSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(dir, ".asc-snapshot-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}